@@ -1,7 +1,9 @@
 package anomaly
 
 import (
+	"errors"
 	"math"
+	"math/big"
 	"testing"
 	"time"
 )
@@ -123,6 +125,142 @@ func TestAnomalyDetector_InsufficientData(t *testing.T) {
 	}
 }
 
+func TestAnomalyDetector_MinSamplesWarmUp(t *testing.T) {
+	detector := NewDetector(10, 2.0)
+	detector.MinSamples = 4
+
+	for i, value := range []float64{10, 10, 50} {
+		detection, err := detector.ProcessDataDetailed(DataPoint{Timestamp: int64(i + 1), Value: value})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !detection.WarmingUp || detection.IsAnomaly {
+			t.Errorf("Point %d: expected an unscored warm-up decision, got %+v", i+1, detection)
+		}
+	}
+
+	detection, err := detector.ProcessDataDetailed(DataPoint{Timestamp: 4, Value: 10})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if detection.WarmingUp {
+		t.Errorf("Expected the detector to be warm after %d points, got %+v", detector.MinSamples, detection)
+	}
+}
+
+// TestAnomalyDetector_LargeValuePrecision tests that variance stays accurate for
+// large, tightly clustered values where sumOfSquares/n - mean^2 cancels to zero
+func TestAnomalyDetector_LargeValuePrecision(t *testing.T) {
+	windowSize := 100
+	detector := NewDetector(windowSize, 3.0)
+
+	// Feed twice the window so the sliding eviction path is exercised
+	values := make([]float64, 0, 2*windowSize)
+	for i := 0; i < 2*windowSize; i++ {
+		value := 1e9 + float64(i%7)*0.01
+		values = append(values, value)
+		if _, _, err := detector.ProcessData(DataPoint{Timestamp: int64(1609459200 + i), Value: value}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// High-precision two-pass reference over the final window
+	window := values[len(values)-windowSize:]
+	prec := uint(256)
+	sum := new(big.Float).SetPrec(prec)
+	for _, v := range window {
+		sum.Add(sum, new(big.Float).SetPrec(prec).SetFloat64(v))
+	}
+	refMean := new(big.Float).SetPrec(prec).Quo(sum, big.NewFloat(float64(windowSize)))
+	sq := new(big.Float).SetPrec(prec)
+	for _, v := range window {
+		d := new(big.Float).SetPrec(prec).Sub(new(big.Float).SetFloat64(v), refMean)
+		sq.Add(sq, new(big.Float).SetPrec(prec).Mul(d, d))
+	}
+	refVariance, _ := new(big.Float).SetPrec(prec).Quo(sq, big.NewFloat(float64(windowSize))).Float64()
+	refStdDev := math.Sqrt(refVariance)
+	refMeanF, _ := refMean.Float64()
+
+	count, mean, stdDev := detector.GetStats()
+	if count != windowSize {
+		t.Errorf("Expected count %d, got %d", windowSize, count)
+	}
+	if math.Abs(mean-refMeanF)/refMeanF > 1e-12 {
+		t.Errorf("Expected mean %.9f, got %.9f", refMeanF, mean)
+	}
+	if stdDev == 0 {
+		t.Fatal("Expected non-zero stddev for varying large values")
+	}
+	if relErr := math.Abs(stdDev-refStdDev) / refStdDev; relErr > 1e-6 {
+		t.Errorf("Expected stddev %.9f, got %.9f (relative error %.3g)", refStdDev, stdDev, relErr)
+	}
+
+	// An in-range value must not be flagged with a bogus z-score
+	isAnomaly, zScore, _ := detector.ProcessData(DataPoint{Timestamp: 1609459500, Value: 1e9 + 0.03})
+	if isAnomaly {
+		t.Errorf("Expected no anomaly for in-range value, got z-score: %.3f", zScore)
+	}
+}
+
+// TestAnomalyDetector_ProcessBatch tests batch results match sequential processing
+func TestAnomalyDetector_ProcessBatch(t *testing.T) {
+	points := make([]DataPoint, 0, 60)
+	for i := 0; i < 60; i++ {
+		value := 10.0 + float64(i%5)
+		if i == 55 {
+			value = 100.0
+		}
+		points = append(points, DataPoint{Timestamp: int64(1609459200 + i), Value: value})
+	}
+
+	sequential := NewDetector(50, 2.0)
+	batch := NewDetector(50, 2.0)
+
+	detections, err := batch.ProcessBatch(points)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(detections) != len(points) {
+		t.Fatalf("Expected %d detections, got %d", len(points), len(detections))
+	}
+
+	for i, dp := range points {
+		isAnomaly, zScore, _ := sequential.ProcessData(dp)
+		if detections[i].IsAnomaly != isAnomaly || detections[i].ZScore != zScore {
+			t.Errorf("Point %d: expected (%t, %.3f), got (%t, %.3f)",
+				i, isAnomaly, zScore, detections[i].IsAnomaly, detections[i].ZScore)
+		}
+	}
+
+	if !detections[55].IsAnomaly {
+		t.Error("Expected spike in batch to be detected")
+	}
+}
+
+// TestAnomalyDetector_ProcessBatchPartial tests a failing point stops the batch
+func TestAnomalyDetector_ProcessBatchPartial(t *testing.T) {
+	detector := NewDetector(50, 2.0)
+	detector.OrderPolicy = OrderReject
+
+	points := []DataPoint{
+		{Timestamp: 1609459200, Value: 1.0},
+		{Timestamp: 1609459201, Value: 2.0},
+		{Timestamp: 1609459100, Value: 3.0},
+		{Timestamp: 1609459202, Value: 4.0},
+	}
+
+	detections, err := detector.ProcessBatch(points)
+	if !errors.Is(err, ErrOutOfOrder) {
+		t.Fatalf("Expected ErrOutOfOrder, got %v", err)
+	}
+	if len(detections) != 2 {
+		t.Errorf("Expected 2 detections before the failing point, got %d", len(detections))
+	}
+	if count, _, _ := detector.GetStats(); count != 2 {
+		t.Errorf("Expected points after the failure to be skipped, got count %d", count)
+	}
+}
+
 // TestAnomalyDetector_ThreadSafety tests concurrent access
 func TestAnomalyDetector_ThreadSafety(t *testing.T) {
 	detector := NewDetector(100, 2.0)
@@ -291,4 +429,35 @@ func BenchmarkAnomalyDetector_GetStats(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		detector.GetStats()
 	}
-}
\ No newline at end of file
+}
+func TestAnomalyDetector_WindowFullSince(t *testing.T) {
+	detector := NewDetector(5, 3.0)
+
+	for i := 0; i < 4; i++ {
+		detector.ProcessData(DataPoint{Timestamp: int64(i + 1), Value: float64(10 + i%2)})
+		if _, full := detector.WindowFullSince(); full {
+			t.Fatalf("Expected the window not full after %d points", i+1)
+		}
+	}
+
+	before := time.Now()
+	detector.ProcessData(DataPoint{Timestamp: 5, Value: 10})
+	since, full := detector.WindowFullSince()
+	if !full || since.Before(before) || since.After(time.Now()) {
+		t.Fatalf("Expected the window full from the fifth point, got %v, %v", since, full)
+	}
+
+	// Sliding the window keeps the first time it filled
+	time.Sleep(time.Millisecond)
+	for i := 0; i < 5; i++ {
+		detector.ProcessData(DataPoint{Timestamp: int64(6 + i), Value: 11})
+	}
+	if again, full := detector.WindowFullSince(); !full || !again.Equal(since) {
+		t.Errorf("Expected the timestamp to stay %v, got %v, %v", since, again, full)
+	}
+
+	detector.Reset()
+	if _, full := detector.WindowFullSince(); full {
+		t.Error("Expected Reset to clear the window-full timestamp")
+	}
+}
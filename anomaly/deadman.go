@@ -0,0 +1,197 @@
+package anomaly
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSeriesFreshness is how long an expected series may stay silent by default.
+const DefaultSeriesFreshness = 5 * time.Minute
+
+// MissingSeries is an expected series that has not reported within its
+// freshness window.
+type MissingSeries struct {
+	SeriesID  string        `json:"series_id"`
+	LastSeen  time.Time     `json:"last_seen,omitempty"` // Zero if the series never reported
+	Freshness time.Duration `json:"freshness"`
+}
+
+// watchedSeries is the dead-man's switch of one expected series.
+type watchedSeries struct {
+	freshness time.Duration
+	lastSeen  time.Time
+	missing   bool // Alerted and not yet recovered
+}
+
+// SeriesWatchdog is a dead-man's switch per series: every expected series
+// must report within its freshness window, and one that falls silent is
+// alerted once until it reports again. A series that never reports is
+// measured from when the watchdog was created.
+type SeriesWatchdog struct {
+	mu      sync.Mutex
+	series  map[string]*watchedSeries
+	started time.Time
+	now     func() time.Time
+	stop    chan struct{}
+}
+
+// ParseExpectedSeries parses a comma-separated list of series IDs, each
+// optionally with its own freshness window such as "cpu,reactor_temp:30s".
+// Series without one get freshness.
+func ParseExpectedSeries(s string, freshness time.Duration) (map[string]time.Duration, error) {
+	expected := make(map[string]time.Duration)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		seriesID, window, hasWindow := strings.Cut(entry, ":")
+		seriesID = strings.TrimSpace(seriesID)
+		if seriesID == "" {
+			return nil, fmt.Errorf("invalid expected series %q: missing series ID", entry)
+		}
+		d := freshness
+		if hasWindow {
+			var err error
+			d, err = time.ParseDuration(strings.TrimSpace(window))
+			if err != nil {
+				return nil, fmt.Errorf("invalid freshness for series %q: %w", seriesID, err)
+			}
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("freshness for series %q must be positive", seriesID)
+		}
+		expected[seriesID] = d
+	}
+	return expected, nil
+}
+
+// NewSeriesWatchdog creates a watchdog for the expected series and their
+// freshness windows.
+func NewSeriesWatchdog(expected map[string]time.Duration) *SeriesWatchdog {
+	w := &SeriesWatchdog{
+		series: make(map[string]*watchedSeries, len(expected)),
+		now:    time.Now,
+		stop:   make(chan struct{}),
+	}
+	for seriesID, freshness := range expected {
+		w.series[seriesID] = &watchedSeries{freshness: freshness}
+	}
+	w.started = w.now()
+	return w
+}
+
+// Seen records a report from seriesID. It returns true if the series was
+// missing and has now recovered. Unexpected series are ignored.
+func (w *SeriesWatchdog) Seen(seriesID string) (recovered bool) {
+	if w == nil {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	s, ok := w.series[seriesID]
+	if !ok {
+		return false
+	}
+	s.lastSeen = w.now()
+	recovered, s.missing = s.missing, false
+	return recovered
+}
+
+// Check returns the expected series that have gone silent since the last
+// check. A series stays out of later results until it reports again.
+func (w *SeriesWatchdog) Check() []MissingSeries {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var missing []MissingSeries
+	for seriesID, s := range w.series {
+		if s.missing || !w.silent(s) {
+			continue
+		}
+		s.missing = true
+		missing = append(missing, MissingSeries{SeriesID: seriesID, LastSeen: s.lastSeen, Freshness: s.freshness})
+	}
+	sortMissing(missing)
+	return missing
+}
+
+// Missing returns every expected series currently past its freshness window.
+func (w *SeriesWatchdog) Missing() []MissingSeries {
+	if w == nil {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	missing := make([]MissingSeries, 0)
+	for seriesID, s := range w.series {
+		if w.silent(s) {
+			missing = append(missing, MissingSeries{SeriesID: seriesID, LastSeen: s.lastSeen, Freshness: s.freshness})
+		}
+	}
+	sortMissing(missing)
+	return missing
+}
+
+// silent reports whether s is past its freshness window. Must be called with w.mu held.
+func (w *SeriesWatchdog) silent(s *watchedSeries) bool {
+	last := s.lastSeen
+	if last.IsZero() {
+		last = w.started
+	}
+	return w.now().Sub(last) > s.freshness
+}
+
+// Start checks the expected series every half of the shortest freshness
+// window, calling alert for each series that goes silent, until Stop.
+func (w *SeriesWatchdog) Start(alert func(MissingSeries)) {
+	interval := time.Duration(0)
+	for _, s := range w.series {
+		if interval == 0 || s.freshness/2 < interval {
+			interval = s.freshness / 2
+		}
+	}
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				for _, m := range w.Check() {
+					alert(m)
+				}
+			case <-w.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the checks started by Start.
+func (w *SeriesWatchdog) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	select {
+	case <-w.stop:
+	default:
+		close(w.stop)
+	}
+}
+
+// sortMissing orders missing series by ID.
+func sortMissing(missing []MissingSeries) {
+	sort.Slice(missing, func(i, j int) bool { return missing[i].SeriesID < missing[j].SeriesID })
+}
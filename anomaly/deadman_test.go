@@ -0,0 +1,81 @@
+package anomaly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeriesWatchdog_AlertsOnlySilentSeries(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	watchdog := NewSeriesWatchdog(map[string]time.Duration{"cpu": time.Minute, "mem": time.Minute})
+	watchdog.now = func() time.Time { return now }
+	watchdog.started = now
+
+	watchdog.Seen("cpu")
+	watchdog.Seen("mem")
+
+	// Both series report within their freshness window
+	now = now.Add(50 * time.Second)
+	watchdog.Seen("cpu")
+	if missing := watchdog.Check(); len(missing) != 0 {
+		t.Fatalf("Expected no missing series, got %+v", missing)
+	}
+
+	// mem goes silent while cpu keeps reporting
+	now = now.Add(50 * time.Second)
+	watchdog.Seen("cpu")
+	missing := watchdog.Check()
+	if len(missing) != 1 || missing[0].SeriesID != "mem" {
+		t.Fatalf("Expected only mem to be missing, got %+v", missing)
+	}
+	if want := now.Add(-100 * time.Second); !missing[0].LastSeen.Equal(want) {
+		t.Errorf("Expected mem last seen at %v, got %v", want, missing[0].LastSeen)
+	}
+
+	// The alert fires once per outage
+	if again := watchdog.Check(); len(again) != 0 {
+		t.Errorf("Expected no repeated alert, got %+v", again)
+	}
+	if current := watchdog.Missing(); len(current) != 1 || current[0].SeriesID != "mem" {
+		t.Errorf("Expected mem reported as missing, got %+v", current)
+	}
+
+	if !watchdog.Seen("mem") {
+		t.Error("Expected mem to recover when it reports again")
+	}
+	if watchdog.Seen("disk") {
+		t.Error("Expected an unexpected series to be ignored")
+	}
+	if current := watchdog.Missing(); len(current) != 0 {
+		t.Errorf("Expected no missing series after recovery, got %+v", current)
+	}
+}
+
+func TestSeriesWatchdog_NeverReportedSeries(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	watchdog := NewSeriesWatchdog(map[string]time.Duration{"cpu": time.Minute})
+	watchdog.now = func() time.Time { return now }
+	watchdog.started = now
+
+	now = now.Add(2 * time.Minute)
+	missing := watchdog.Check()
+	if len(missing) != 1 || !missing[0].LastSeen.IsZero() {
+		t.Errorf("Expected a never-reported series to be missing, got %+v", missing)
+	}
+}
+
+func TestParseExpectedSeries(t *testing.T) {
+	expected, err := ParseExpectedSeries("cpu, reactor_temp:30s", time.Minute)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected["cpu"] != time.Minute || expected["reactor_temp"] != 30*time.Second || len(expected) != 2 {
+		t.Errorf("Unexpected expected series %v", expected)
+	}
+
+	for _, invalid := range []string{":30s", "cpu:soon", "cpu:-1s"} {
+		if _, err := ParseExpectedSeries(invalid, time.Minute); err == nil {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package anomaly
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DecisionRecord is one scored data point in a series' recent-decision ring.
+type DecisionRecord struct {
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+	ZScore    float64 `json:"z_score"`
+	IsAnomaly bool    `json:"is_anomaly"`
+	Severity  string  `json:"severity"`
+	WarmingUp bool    `json:"warming_up,omitempty"`
+}
+
+// decisionRing holds the newest decisions of one series.
+type decisionRing struct {
+	key     string
+	records []DecisionRecord // Circular; next is the slot written next
+	next    int
+	full    bool
+}
+
+// DecisionLog keeps the last decisions of each series, normal points
+// included, for debugging a flapping series. Idle series are evicted in
+// least-recently-used order once maxSeries is reached.
+type DecisionLog struct {
+	mu        sync.Mutex
+	perSeries int
+	maxSeries int
+	series    map[string]*list.Element
+	lru       *list.List // Front is the most recently recorded series
+}
+
+// NewDecisionLog creates a log keeping perSeries decisions for up to
+// maxSeries series. A non-positive maxSeries leaves the series unbounded.
+func NewDecisionLog(perSeries, maxSeries int) *DecisionLog {
+	return &DecisionLog{
+		perSeries: perSeries,
+		maxSeries: maxSeries,
+		series:    make(map[string]*list.Element),
+		lru:       list.New(),
+	}
+}
+
+// Record appends the decision on dp to its series' ring.
+func (l *DecisionLog) Record(dp DataPoint, detection Detection) {
+	if l == nil || l.perSeries <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var ring *decisionRing
+	if elem, exists := l.series[dp.SeriesID]; exists {
+		l.lru.MoveToFront(elem)
+		ring = elem.Value.(*decisionRing)
+	} else {
+		ring = &decisionRing{key: dp.SeriesID, records: make([]DecisionRecord, l.perSeries)}
+		l.series[dp.SeriesID] = l.lru.PushFront(ring)
+		for l.maxSeries > 0 && l.lru.Len() > l.maxSeries {
+			oldest := l.lru.Back()
+			l.lru.Remove(oldest)
+			delete(l.series, oldest.Value.(*decisionRing).key)
+		}
+	}
+
+	ring.records[ring.next] = DecisionRecord{
+		Timestamp: dp.Timestamp,
+		Value:     dp.Value,
+		ZScore:    detection.ZScore,
+		IsAnomaly: detection.IsAnomaly,
+		Severity:  detection.Severity,
+		WarmingUp: detection.WarmingUp,
+	}
+	ring.next = (ring.next + 1) % len(ring.records)
+	if ring.next == 0 {
+		ring.full = true
+	}
+}
+
+// Recent returns up to limit of the series' decisions, newest first, and
+// whether the series has any. A non-positive limit returns all retained.
+func (l *DecisionLog) Recent(seriesID string, limit int) ([]DecisionRecord, bool) {
+	if l == nil {
+		return nil, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, exists := l.series[seriesID]
+	if !exists {
+		return nil, false
+	}
+	ring := elem.Value.(*decisionRing)
+
+	count := ring.next
+	if ring.full {
+		count = len(ring.records)
+	}
+	if limit > 0 && limit < count {
+		count = limit
+	}
+
+	recent := make([]DecisionRecord, 0, count)
+	for i := 1; i <= count; i++ {
+		idx := (ring.next - i + len(ring.records)) % len(ring.records)
+		recent = append(recent, ring.records[idx])
+	}
+	return recent, true
+}
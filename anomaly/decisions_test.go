@@ -0,0 +1,47 @@
+package anomaly
+
+import "testing"
+
+func TestDecisionLog_NewestFirstAndBounded(t *testing.T) {
+	decisions := NewDecisionLog(3, 10)
+	for i := 1; i <= 5; i++ {
+		decisions.Record(DataPoint{SeriesID: "cpu", Timestamp: int64(i), Value: float64(i * 10)},
+			Detection{ZScore: float64(i), IsAnomaly: i == 5, Severity: SeverityNone})
+	}
+
+	recent, ok := decisions.Recent("cpu", 0)
+	if !ok || len(recent) != 3 {
+		t.Fatalf("Expected the last 3 decisions, got %+v", recent)
+	}
+	for i, want := range []int64{5, 4, 3} {
+		if recent[i].Timestamp != want {
+			t.Errorf("Expected decision %d at timestamp %d, got %d", i, want, recent[i].Timestamp)
+		}
+	}
+	if !recent[0].IsAnomaly || recent[0].Value != 50 || recent[0].ZScore != 5 {
+		t.Errorf("Unexpected newest decision %+v", recent[0])
+	}
+
+	if limited, _ := decisions.Recent("cpu", 2); len(limited) != 2 || limited[1].Timestamp != 4 {
+		t.Errorf("Expected the limit to keep the newest 2, got %+v", limited)
+	}
+	if _, ok := decisions.Recent("mem", 0); ok {
+		t.Error("Expected an unseen series to report no decisions")
+	}
+}
+
+func TestDecisionLog_EvictsLeastRecentSeries(t *testing.T) {
+	decisions := NewDecisionLog(2, 2)
+	for _, id := range []string{"a", "b", "a", "c"} {
+		decisions.Record(DataPoint{SeriesID: id, Timestamp: 1}, Detection{})
+	}
+
+	if _, ok := decisions.Recent("b", 0); ok {
+		t.Error("Expected the least recently recorded series to be evicted")
+	}
+	for _, id := range []string{"a", "c"} {
+		if _, ok := decisions.Recent(id, 0); !ok {
+			t.Errorf("Expected series %s to be kept", id)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package anomaly
+
+// Severity bands reported in Detection.Severity.
+const (
+	SeverityNone     = "none"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Default severity band multiples of the active threshold.
+const (
+	DefaultWarningMultiplier  = 1.0
+	DefaultCriticalMultiplier = 2.0
+)
+
+// Detection is the detailed result of scoring a single data point.
+type Detection struct {
+	IsAnomaly  bool    `json:"is_anomaly"`
+	ZScore     float64 `json:"z_score"`
+	Direction  int     `json:"direction"` // -1 below the mean, 0 at the mean, +1 above
+	Severity   string  `json:"severity"`
+	OutOfOrder bool    `json:"out_of_order,omitempty"` // Accepted under OrderFlag or OrderReorder
+	WarmingUp  bool    `json:"warming_up,omitempty"`   // Too few points in the window to score
+}
+
+// direction returns the sign of a deviation from the mean.
+func direction(deviation float64) int {
+	switch {
+	case deviation > 0:
+		return 1
+	case deviation < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// severity maps a score to a severity band using multiples of threshold.
+func (ad *AnomalyDetector) severity(score float64, threshold float64) string {
+	warning := ad.WarningMultiplier
+	if warning <= 0 {
+		warning = DefaultWarningMultiplier
+	}
+	critical := ad.CriticalMultiplier
+	if critical <= 0 {
+		critical = DefaultCriticalMultiplier
+	}
+
+	switch {
+	case score > threshold*critical:
+		return SeverityCritical
+	case score > threshold*warning:
+		return SeverityWarning
+	default:
+		return SeverityNone
+	}
+}
@@ -0,0 +1,168 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+)
+
+// newStableDetector returns a detector whose window alternates 9 and 11 (mean 10, stddev 1)
+func newStableDetector(threshold float64) *AnomalyDetector {
+	detector := NewDetector(1000, threshold)
+	for i := 0; i < 100; i++ {
+		detector.ProcessData(DataPoint{Timestamp: int64(1609459200 + i), Value: 9.0 + float64(i%2)*2})
+	}
+	return detector
+}
+
+// TestProcessDataDetailed_Direction tests the sign of the deviation is reported
+func TestProcessDataDetailed_Direction(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     float64
+		direction int
+	}{
+		{"Spike above", 20.0, 1},
+		{"Drop below", 0.0, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := newStableDetector(2.0)
+			detection, err := detector.ProcessDataDetailed(DataPoint{Timestamp: 1609459400, Value: tt.value})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !detection.IsAnomaly {
+				t.Errorf("Expected anomaly, got z-score: %.3f", detection.ZScore)
+			}
+			if detection.Direction != tt.direction {
+				t.Errorf("Expected direction %d, got %d", tt.direction, detection.Direction)
+			}
+			if detection.ZScore < 0 {
+				t.Errorf("Expected absolute z-score, got %.3f", detection.ZScore)
+			}
+		})
+	}
+}
+
+// TestProcessDataDetailed_Severity tests severity bands derived from threshold multiples
+func TestProcessDataDetailed_Severity(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		severity string
+	}{
+		{"Within threshold", 10.5, SeverityNone},
+		{"Warning band", 13.0, SeverityWarning},
+		{"Critical band", 17.0, SeverityCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := newStableDetector(2.0)
+			detector.CriticalMultiplier = 2.5 // critical above z=5
+
+			detection, err := detector.ProcessDataDetailed(DataPoint{Timestamp: 1609459400, Value: tt.value})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if detection.Severity != tt.severity {
+				t.Errorf("Expected severity %s, got %s (z-score %.3f)", tt.severity, detection.Severity, detection.ZScore)
+			}
+		})
+	}
+}
+
+// TestProcessDataDetailed_ZeroVariance tests direction and severity with a constant window
+func TestProcessDataDetailed_ZeroVariance(t *testing.T) {
+	detector := NewDetector(10, 2.0)
+	for i := 0; i < 5; i++ {
+		detector.ProcessData(DataPoint{Timestamp: int64(1609459200 + i), Value: 5.0})
+	}
+
+	detection, err := detector.ProcessDataDetailed(DataPoint{Timestamp: 1609459300, Value: 5.0})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if detection.IsAnomaly || detection.Direction != 0 || detection.Severity != SeverityNone {
+		t.Errorf("Expected no anomaly at the mean, got %+v", detection)
+	}
+}
+
+// TestProcessDataDetailed_MatchesProcessData tests both APIs score identically
+func TestProcessDataDetailed_MatchesProcessData(t *testing.T) {
+	legacy := newStableDetector(2.0)
+	detailed := newStableDetector(2.0)
+
+	for _, value := range []float64{10.0, 14.0, 3.0} {
+		dp := DataPoint{Timestamp: 1609459400, Value: value}
+		isAnomaly, zScore, _ := legacy.ProcessData(dp)
+		detection, _ := detailed.ProcessDataDetailed(dp)
+
+		if detection.IsAnomaly != isAnomaly || math.Abs(detection.ZScore-zScore) > 1e-12 {
+			t.Errorf("Value %.1f: expected (%t, %.3f), got %+v", value, isAnomaly, zScore, detection)
+		}
+	}
+}
+
+// TestEffectiveStdDev_MinStdDevFloor tests the floor applies to scoring but not GetStats
+func TestEffectiveStdDev_MinStdDevFloor(t *testing.T) {
+	detector := NewDetector(100, 3.0)
+	detector.MinStdDev = 2.0
+
+	// Alternating 9/11 gives a raw stddev of 1
+	for i := 0; i < 50; i++ {
+		detector.ProcessData(DataPoint{Timestamp: int64(1609459200 + i), Value: 9.0 + float64(i%2)*2})
+	}
+
+	_, _, rawStdDev := detector.GetStats()
+	if math.Abs(rawStdDev-1.0) > 1e-9 {
+		t.Errorf("Expected raw stddev 1.0 from GetStats, got %.6f", rawStdDev)
+	}
+	if effective := detector.EffectiveStdDev(); effective != 2.0 {
+		t.Errorf("Expected effective stddev at the 2.0 floor, got %.6f", effective)
+	}
+
+	// 16 is 6 raw sigma away but only ~3 floored sigma, so it stays under threshold 3.5
+	detector.Threshold = 3.5
+	detection, _ := detector.ProcessDataDetailed(DataPoint{Timestamp: 1609459300, Value: 16.0})
+	if detection.IsAnomaly {
+		t.Errorf("Expected floored stddev to suppress the anomaly, got z-score %.3f", detection.ZScore)
+	}
+
+	// Without a floor the effective value tracks the raw stddev
+	detector.MinStdDev = 0
+	_, _, rawStdDev = detector.GetStats()
+	if effective := detector.EffectiveStdDev(); effective != rawStdDev {
+		t.Errorf("Expected effective stddev %.6f without floor, got %.6f", rawStdDev, effective)
+	}
+}
+
+// TestVerifyDeterminism_ReplaysAgainstCheckpoint tests a point replayed on the
+// state it was scored against reproduces its detection, and a differing one is flagged
+func TestVerifyDeterminism_ReplaysAgainstCheckpoint(t *testing.T) {
+	detector := newStableDetector(2.0)
+	dp := DataPoint{Timestamp: 1609459300, Value: 20.0}
+
+	detection, checkpoint, err := detector.ProcessDataCheckpointed(dp)
+	if err != nil {
+		t.Fatalf("ProcessDataCheckpointed failed: %v", err)
+	}
+	// Later points must not affect the checkpoint
+	detector.ProcessData(DataPoint{Timestamp: 1609459301, Value: 50.0})
+
+	explanation, err := checkpoint.VerifyDeterminism(dp, detection)
+	if err != nil {
+		t.Fatalf("Expected the replay to match, got %v", err)
+	}
+	if explanation.Baseline != 10.0 {
+		t.Errorf("Expected the replay explained against the checkpointed baseline 10, got %v", explanation.Baseline)
+	}
+
+	_, checkpoint, _ = newStableDetector(2.0).ProcessDataCheckpointed(dp)
+	tampered := detection
+	tampered.ZScore++
+	if _, err := checkpoint.VerifyDeterminism(dp, tampered); err == nil {
+		t.Error("Expected a differing detection to be reported as a violation")
+	}
+}
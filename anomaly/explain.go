@@ -0,0 +1,115 @@
+package anomaly
+
+import "math"
+
+// Explanation is the scoring breakdown behind a Detection, with enough of the
+// window state for the decision to be reproduced by hand.
+//
+// In ModeZScore the point is anomalous when |RawZScore| > Threshold, where
+// RawZScore = (value - Mean) / EffectiveStdDev over the window including the point.
+// In ModePercentChange it is anomalous when |value - Baseline| / |Baseline| * 100
+// exceeds Threshold, Baseline being the window mean before the point was added.
+// In ModeMAD it is anomalous when |RawZScore| > Threshold, where
+// RawZScore = (value - Median) / EffectiveStdDev and EffectiveStdDev is
+// max(1.4826 * MAD, MinStdDev) over the window including the point.
+// In ModeSeasonal it is anomalous when |RawZScore| > Threshold, where
+// RawZScore = (value - Baseline) / EffectiveStdDev, Baseline and
+// EffectiveStdDev being those of the point's SeasonBucket before it was added.
+type Explanation struct {
+	Mode            Mode    `json:"mode"`
+	WindowCount     int     `json:"window_count"`
+	Mean            float64 `json:"mean"`
+	Variance        float64 `json:"variance"` // Population variance
+	StdDev          float64 `json:"std_dev"`
+	MinStdDev       float64 `json:"min_std_dev"`
+	EffectiveStdDev float64 `json:"effective_std_dev"` // max(StdDev, MinStdDev)
+	RawZScore       float64 `json:"raw_z_score"`       // Signed; Detection.ZScore is its magnitude in ModeZScore
+	Baseline        float64 `json:"baseline"`
+	Median          float64 `json:"median,omitempty"`        // ModeMAD only
+	MAD             float64 `json:"mad,omitempty"`           // ModeMAD only
+	SeasonBucket    int     `json:"season_bucket,omitempty"` // ModeSeasonal only
+	Threshold       float64 `json:"threshold"`
+	Direction       int     `json:"direction"`
+	IsAnomaly       bool    `json:"is_anomaly"`
+	Severity        string  `json:"severity"`
+}
+
+// ProcessDataExplained is ProcessDataDetailed also returning the scoring breakdown.
+// It costs a few extra reads of the window statistics, so the plain variants
+// remain the default path.
+func (ad *AnomalyDetector) ProcessDataExplained(dp DataPoint) (Detection, Explanation, error) {
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+
+	baseline := ad.mean
+	detection, err := ad.process(dp)
+	if err != nil {
+		return detection, Explanation{}, err
+	}
+	return detection, ad.explain(dp.Value, baseline, detection), nil
+}
+
+// ProcessDataExplained is ProcessDataDetailed also returning the scoring breakdown.
+func (md *MultiDetector) ProcessDataExplained(key string, dp DataPoint) (Detection, Explanation, error) {
+	return md.acquire(key).ProcessDataExplained(dp)
+}
+
+// explain describes how detection was reached for value. Must be called with
+// ad.mu held, immediately after process.
+func (ad *AnomalyDetector) explain(value float64, baseline float64, detection Detection) Explanation {
+	mode := ad.Mode
+	if mode == "" {
+		mode = ModeZScore
+	}
+	threshold := ad.Threshold
+	if mode == ModePercentChange {
+		threshold = ad.PercentThreshold
+	}
+
+	variance := ad.variance()
+	stdDev := ad.effectiveStdDev()
+	center := ad.mean
+
+	var median, mad float64
+	if mode == ModeMAD && len(ad.dataWindow) > 0 {
+		median, mad = ad.medianAndMAD()
+		stdDev = ad.robustStdDev(mad)
+		center = median
+	}
+
+	scored := len(ad.dataWindow) >= 2
+	var seasonBucket int
+	if mode == ModeSeasonal {
+		seasonBucket = ad.lastSeason.bucket
+		center, stdDev, baseline = ad.lastSeason.mean, ad.lastSeason.stdDev, ad.lastSeason.mean
+		scored = ad.lastSeason.count >= 2
+	}
+
+	rawZScore := 0.0
+	if scored {
+		if stdDev > 0 {
+			rawZScore = (value - center) / stdDev
+		} else if value != center {
+			rawZScore = float64(direction(value-center)) * math.MaxFloat64
+		}
+	}
+
+	return Explanation{
+		Mode:            mode,
+		WindowCount:     len(ad.dataWindow),
+		Mean:            ad.mean,
+		Variance:        variance,
+		StdDev:          math.Sqrt(variance),
+		MinStdDev:       ad.MinStdDev,
+		EffectiveStdDev: stdDev,
+		RawZScore:       rawZScore,
+		Baseline:        baseline,
+		Median:          median,
+		MAD:             mad,
+		SeasonBucket:    seasonBucket,
+		Threshold:       threshold,
+		Direction:       detection.Direction,
+		IsAnomaly:       detection.IsAnomaly,
+		Severity:        detection.Severity,
+	}
+}
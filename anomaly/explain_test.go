@@ -0,0 +1,61 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+)
+
+// TestProcessDataExplained_ReconstructsZScore tests that the breakdown reproduces the decision exactly
+func TestProcessDataExplained_ReconstructsZScore(t *testing.T) {
+	detector := NewDetector(20, 2.5)
+	detector.MinStdDev = 0.5
+	values := []float64{10, 11, 10.5, 9.8, 10.2, 10.1, 9.9, 10.4, 14, 6}
+
+	for i, value := range values {
+		detection, explanation, err := detector.ProcessDataExplained(DataPoint{Timestamp: int64(1609459200 + i), Value: value})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if explanation.Mode != ModeZScore || explanation.WindowCount != i+1 || explanation.Threshold != 2.5 {
+			t.Errorf("Point %d: unexpected explanation %+v", i, explanation)
+		}
+		if i == 0 {
+			continue // A single point is never scored
+		}
+
+		effective := math.Max(math.Sqrt(explanation.Variance), explanation.MinStdDev)
+		if explanation.StdDev != math.Sqrt(explanation.Variance) || explanation.EffectiveStdDev != effective {
+			t.Errorf("Point %d: std dev %f / effective %f inconsistent with variance %f", i, explanation.StdDev, explanation.EffectiveStdDev, explanation.Variance)
+		}
+		rawZScore := (value - explanation.Mean) / explanation.EffectiveStdDev
+		if explanation.RawZScore != rawZScore || detection.ZScore != math.Abs(rawZScore) {
+			t.Errorf("Point %d: z-score %f does not reconstruct from breakdown (%f)", i, detection.ZScore, rawZScore)
+		}
+		if detection.IsAnomaly != (math.Abs(rawZScore) > explanation.Threshold) || explanation.IsAnomaly != detection.IsAnomaly {
+			t.Errorf("Point %d: decision %t does not follow from |%f| > %f", i, detection.IsAnomaly, rawZScore, explanation.Threshold)
+		}
+		if explanation.Direction != detection.Direction || explanation.Severity != detection.Severity {
+			t.Errorf("Point %d: direction/severity mismatch %+v vs %+v", i, explanation, detection)
+		}
+	}
+}
+
+// TestProcessDataExplained_PercentChangeBaseline tests that percent-change scoring explains against the prior mean
+func TestProcessDataExplained_PercentChangeBaseline(t *testing.T) {
+	detector := NewPercentChangeDetector(20, 25.0)
+	for i := 0; i < 10; i++ {
+		detector.ProcessData(DataPoint{Timestamp: int64(1609459200 + i), Value: 100.0})
+	}
+
+	detection, explanation, err := detector.ProcessDataExplained(DataPoint{Timestamp: 1609459300, Value: 140.0})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if explanation.Mode != ModePercentChange || explanation.Baseline != 100.0 || explanation.Threshold != 25.0 {
+		t.Errorf("Unexpected explanation %+v", explanation)
+	}
+	score := math.Abs((140.0-explanation.Baseline)/explanation.Baseline) * 100
+	if detection.ZScore != score || !detection.IsAnomaly {
+		t.Errorf("Expected score %f from the baseline to be anomalous, got %f (%t)", score, detection.ZScore, detection.IsAnomaly)
+	}
+}
@@ -0,0 +1,57 @@
+package anomaly
+
+// PartialStats are a node's window statistics in mergeable form. An
+// aggregator combines them across nodes with MergePartialStats to compute a
+// global baseline without the raw data. They are exchanged as the count, mean
+// and M2 rather than the count, sum and sum of squares: for large, tightly
+// clustered values the variance recovered from a sum of squares cancels to
+// noise. Sum and SumOfSquares derive that form for consumers that need it.
+type PartialStats struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	M2    float64 `json:"m2"` // Sum of squared deviations from Mean
+}
+
+// PartialStats returns the count, mean and sum of squared deviations of the
+// current window, read from the detector's running Welford state in O(1).
+func (ad *AnomalyDetector) PartialStats() PartialStats {
+	ad.mu.RLock()
+	defer ad.mu.RUnlock()
+
+	if len(ad.dataWindow) == 0 {
+		return PartialStats{}
+	}
+	return PartialStats{Count: len(ad.dataWindow), Mean: ad.mean, M2: ad.m2}
+}
+
+// Sum returns the sum of the partial's values.
+func (p PartialStats) Sum() float64 {
+	return p.Mean * float64(p.Count)
+}
+
+// SumOfSquares returns the sum of the squares of the partial's values.
+func (p PartialStats) SumOfSquares() float64 {
+	return p.M2 + p.Mean*p.Mean*float64(p.Count)
+}
+
+// MergePartialStats combines partial statistics into the count, mean and
+// population variance of their union, the same statistics GetStats reports
+// for a single window. It uses Chan et al.'s pairwise update, which, unlike
+// merging sums of squares, stays accurate for large, tightly clustered values.
+func MergePartialStats(partials ...PartialStats) (count int, mean, variance float64) {
+	var m2 float64
+	for _, p := range partials {
+		if p.Count == 0 {
+			continue
+		}
+		n := count + p.Count
+		delta := p.Mean - mean
+		mean += delta * float64(p.Count) / float64(n)
+		m2 += p.M2 + delta*delta*float64(count)*float64(p.Count)/float64(n)
+		count = n
+	}
+	if count == 0 || m2 <= 0 {
+		return count, mean, 0
+	}
+	return count, mean, m2 / float64(count)
+}
@@ -0,0 +1,83 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMergePartialStats_MatchesSingleDetector(t *testing.T) {
+	data := []float64{10, 12, 9.5, 11, 13, 8, 10.5, 12.5, 9, 11.5, 14, 7.5}
+
+	whole := NewDetector(100, 3.0)
+	left := NewDetector(100, 3.0)
+	right := NewDetector(100, 3.0)
+	for i, v := range data {
+		whole.ProcessData(DataPoint{Timestamp: int64(i + 1), Value: v})
+		if i < 5 {
+			left.ProcessData(DataPoint{Timestamp: int64(i + 1), Value: v})
+		} else {
+			right.ProcessData(DataPoint{Timestamp: int64(i + 1), Value: v})
+		}
+	}
+
+	count, mean, variance := MergePartialStats(left.PartialStats(), right.PartialStats())
+
+	wantCount, wantMean, wantStdDev := whole.GetStats()
+	if count != wantCount {
+		t.Errorf("Expected merged count %d, got %d", wantCount, count)
+	}
+	if math.Abs(mean-wantMean) > 1e-9 {
+		t.Errorf("Expected merged mean %v, got %v", wantMean, mean)
+	}
+	if math.Abs(math.Sqrt(variance)-wantStdDev) > 1e-9 {
+		t.Errorf("Expected merged std dev %v, got %v", wantStdDev, math.Sqrt(variance))
+	}
+}
+
+func TestMergePartialStats_LargeMagnitudeValues(t *testing.T) {
+	// Unit spread around 1e9: a sum of squares near 1e19 would cancel to noise
+	nodes := []*AnomalyDetector{NewDetector(100, 3.0), NewDetector(100, 3.0), NewDetector(100, 3.0)}
+	var all []float64
+	for i := 0; i < 30; i++ {
+		v := 1e9 + float64(i%5) - 2
+		nodes[i%len(nodes)].ProcessData(DataPoint{Timestamp: int64(i + 1), Value: v})
+		all = append(all, v)
+	}
+
+	var partials []PartialStats
+	for _, ad := range nodes {
+		partials = append(partials, ad.PartialStats())
+	}
+	count, mean, variance := MergePartialStats(partials...)
+
+	// Offsets -2..2 in equal numbers: mean 1e9, population variance 2
+	if count != len(all) {
+		t.Errorf("Expected merged count %d, got %d", len(all), count)
+	}
+	if math.Abs(mean-1e9) > 1e-6 {
+		t.Errorf("Expected merged mean 1e9, got %v", mean)
+	}
+	if math.Abs(variance-2) > 1e-6 {
+		t.Errorf("Expected merged variance 2, got %v", variance)
+	}
+}
+
+func TestMergePartialStats_Empty(t *testing.T) {
+	if count, mean, variance := MergePartialStats(); count != 0 || mean != 0 || variance != 0 {
+		t.Errorf("Expected zero statistics for no partials, got %d, %v, %v", count, mean, variance)
+	}
+	if stats := NewDetector(10, 3.0).PartialStats(); stats.Count != 0 {
+		t.Errorf("Expected an empty detector to report no points, got %d", stats.Count)
+	}
+	if count, mean, variance := MergePartialStats(PartialStats{}, PartialStats{Count: 2, Mean: 5, M2: 2}); count != 2 || mean != 5 || variance != 1 {
+		t.Errorf("Expected an empty partial ignored, got %d, %v, %v", count, mean, variance)
+	}
+}
+
+func TestPartialStats_SumAndSumOfSquares(t *testing.T) {
+	// The partial of {4, 6}
+	stats := PartialStats{Count: 2, Mean: 5, M2: 2}
+	if stats.Sum() != 10 || stats.SumOfSquares() != 52 {
+		t.Errorf("Expected sum 10 and sum of squares 52, got %v, %v", stats.Sum(), stats.SumOfSquares())
+	}
+}
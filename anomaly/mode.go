@@ -0,0 +1,241 @@
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Mode selects the scoring algorithm an AnomalyDetector applies to each point.
+type Mode string
+
+const (
+	// ModeZScore flags points whose z-score against the window exceeds Threshold.
+	ModeZScore Mode = "zscore"
+	// ModePercentChange flags points whose percentage change from the window
+	// mean exceeds PercentThreshold, independent of variance.
+	ModePercentChange Mode = "percent_change"
+	// ModeMAD flags points whose robust z-score, the distance from the window
+	// median in units of the scaled median absolute deviation, exceeds
+	// Threshold. Outliers already in the window barely move it.
+	ModeMAD Mode = "mad"
+	// ModeSeasonal flags points whose z-score against the baseline of their
+	// seasonal bucket exceeds Threshold. The bucket is the point's phase in a
+	// cycle of SeasonPeriod seconds, e.g. its hour of day, so recurring peaks
+	// are scored against the same phase of earlier cycles.
+	ModeSeasonal Mode = "seasonal"
+)
+
+// ParamPercentThreshold is the ModePercentChange threshold parameter, in percent.
+const ParamPercentThreshold = "percent_threshold"
+
+// ParamSeasonPeriod is the ModeSeasonal cycle length parameter, in seconds.
+const ParamSeasonPeriod = "season_period"
+
+// ParamSeasonBuckets is the ModeSeasonal buckets-per-cycle parameter;
+// DefaultSeasonBuckets when not set.
+const ParamSeasonBuckets = "season_buckets"
+
+// DefaultSeasonBuckets splits a daily cycle into hours of the day.
+const DefaultSeasonBuckets = 24
+
+// modeParams lists the parameters each mode accepts through ApplyMode.
+var modeParams = map[Mode][]string{
+	ModeZScore:        nil,
+	ModePercentChange: {ParamPercentThreshold},
+	ModeMAD:           nil,
+	ModeSeasonal:      {ParamSeasonPeriod, ParamSeasonBuckets},
+}
+
+// ParseMode validates a mode name. An empty name selects ModeZScore.
+func ParseMode(name string) (Mode, error) {
+	mode := Mode(name)
+	if mode == "" {
+		return ModeZScore, nil
+	}
+	if _, exists := modeParams[mode]; !exists {
+		return "", fmt.Errorf("unknown detection mode %q", name)
+	}
+	return mode, nil
+}
+
+// ValidateMode checks that mode exists and that params are exactly what it
+// needs, returning the parsed mode.
+func ValidateMode(mode Mode, params map[string]float64) (Mode, error) {
+	mode, err := ParseMode(string(mode))
+	if err != nil {
+		return "", err
+	}
+	for name := range params {
+		if !acceptsParam(mode, name) {
+			return "", fmt.Errorf("detection mode %q does not accept parameter %q", mode, name)
+		}
+	}
+	if mode == ModePercentChange && params[ParamPercentThreshold] <= 0 {
+		return "", fmt.Errorf("detection mode %q requires a positive %s", mode, ParamPercentThreshold)
+	}
+	if mode == ModeSeasonal {
+		if err := validateSeasonParams(params); err != nil {
+			return "", fmt.Errorf("detection mode %q: %w", mode, err)
+		}
+	}
+	return mode, nil
+}
+
+// ModeParams returns the names of the parameters mode accepts.
+func ModeParams(mode Mode) []string {
+	return append([]string(nil), modeParams[mode]...)
+}
+
+// ApplyMode switches ad to mode, taking mode-specific settings from params.
+// Parameters the mode does not accept are rejected.
+func (ad *AnomalyDetector) ApplyMode(mode Mode, params map[string]float64) error {
+	mode, err := ValidateMode(mode, params)
+	if err != nil {
+		return err
+	}
+
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+
+	if mode == ModePercentChange {
+		ad.PercentThreshold = params[ParamPercentThreshold]
+	}
+	if mode == ModeSeasonal {
+		ad.SeasonPeriod, ad.SeasonBuckets = seasonParams(params)
+		ad.seasons = nil // Baselines of another cycle do not apply
+	}
+	ad.Mode = mode
+	return nil
+}
+
+// ParseSeriesModes parses a comma-separated list of series:mode pairs such as
+// "cpu:mad,requests:percent_change".
+func ParseSeriesModes(s string) (map[string]Mode, error) {
+	modes := make(map[string]Mode)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		seriesID, name, ok := strings.Cut(pair, ":")
+		seriesID, name = strings.TrimSpace(seriesID), strings.TrimSpace(name)
+		if !ok || seriesID == "" || name == "" {
+			return nil, fmt.Errorf("invalid series mode %q: expected series:mode", pair)
+		}
+		mode, err := ParseMode(name)
+		if err != nil {
+			return nil, err
+		}
+		modes[seriesID] = mode
+	}
+	return modes, nil
+}
+
+// acceptsParam reports whether mode takes the named parameter.
+func acceptsParam(mode Mode, name string) bool {
+	for _, param := range modeParams[mode] {
+		if param == name {
+			return true
+		}
+	}
+	return false
+}
+
+// minBaseline is the smallest baseline magnitude treated as non-zero when
+// computing percentage change.
+const minBaseline = 1e-9
+
+// NewPercentChangeDetector initializes an AnomalyDetector in percent-change mode.
+// percentThreshold is expressed in percent (e.g., 25 for 25%).
+func NewPercentChangeDetector(windowSize int, percentThreshold float64) *AnomalyDetector {
+	ad := NewDetector(windowSize, 0)
+	ad.Mode = ModePercentChange
+	ad.PercentThreshold = percentThreshold
+	return ad
+}
+
+// percentChange returns the absolute percentage change of value from baseline.
+// A zero baseline yields 0 for a zero value and +Inf otherwise.
+func percentChange(value float64, baseline float64) float64 {
+	if math.Abs(baseline) < minBaseline {
+		if math.Abs(value) < minBaseline {
+			return 0.0
+		}
+		return math.Inf(1)
+	}
+	return math.Abs((value-baseline)/baseline) * 100
+}
+
+// scorePercentChange scores value against the mean of the window before it was added.
+func (ad *AnomalyDetector) scorePercentChange(detection Detection, value float64, baseline float64) Detection {
+	detection.Direction = direction(value - baseline)
+
+	score := percentChange(value, baseline)
+	if math.IsInf(score, 1) {
+		detection.IsAnomaly = true
+		detection.ZScore = math.MaxFloat64
+		detection.Severity = SeverityCritical
+		return detection
+	}
+
+	detection.ZScore = score
+	detection.IsAnomaly = score > ad.PercentThreshold
+	detection.Severity = ad.severity(score, ad.PercentThreshold)
+	return detection
+}
+
+// madScale converts a median absolute deviation into an estimate of the
+// standard deviation of normally distributed data.
+const madScale = 1.4826
+
+// medianAndMAD returns the median of the window and the median absolute
+// deviation from it. It sorts a copy of the window, so it is O(n log n).
+// Must be called with ad.mu held.
+func (ad *AnomalyDetector) medianAndMAD() (median float64, mad float64) {
+	values := append([]float64(nil), ad.dataWindow...)
+	sort.Float64s(values)
+	median = sortedMedian(values)
+
+	for i, v := range values {
+		values[i] = math.Abs(v - median)
+	}
+	sort.Float64s(values)
+	return median, sortedMedian(values)
+}
+
+// sortedMedian returns the median of a non-empty sorted slice.
+func sortedMedian(sorted []float64) float64 {
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// robustStdDev returns the standard deviation estimated from mad, floored at MinStdDev.
+func (ad *AnomalyDetector) robustStdDev(mad float64) float64 {
+	return math.Max(madScale*mad, ad.MinStdDev)
+}
+
+// scoreMAD scores value by its robust z-score over the window including it.
+func (ad *AnomalyDetector) scoreMAD(detection Detection, value float64) Detection {
+	median, mad := ad.medianAndMAD()
+	stdDev := ad.robustStdDev(mad)
+	detection.Direction = direction(value - median)
+
+	if stdDev == 0 {
+		if value != median {
+			detection.IsAnomaly = true
+			detection.ZScore = math.MaxFloat64
+			detection.Severity = SeverityCritical
+		}
+		return detection
+	}
+
+	detection.ZScore = math.Abs((value - median) / stdDev)
+	detection.IsAnomaly = detection.ZScore > ad.Threshold
+	detection.Severity = ad.severity(detection.ZScore, ad.Threshold)
+	return detection
+}
@@ -0,0 +1,140 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPercentChange_Threshold tests flagging by percentage change from the window mean
+func TestPercentChange_Threshold(t *testing.T) {
+	cases := []struct {
+		threshold float64
+		expected  bool
+	}{
+		{25.0, true},
+		{50.0, false},
+	}
+
+	for _, tc := range cases {
+		detector := NewPercentChangeDetector(20, tc.threshold)
+		for i := 0; i < 10; i++ {
+			detector.ProcessData(DataPoint{Timestamp: int64(1609459200 + i), Value: 100.0})
+		}
+
+		// 140 is 40% above the baseline mean of 100
+		isAnomaly, score, err := detector.ProcessData(DataPoint{Timestamp: 1609459300, Value: 140.0})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if isAnomaly != tc.expected {
+			t.Errorf("Threshold %.0f%%: expected anomaly %t, got %t (score %.2f)", tc.threshold, tc.expected, isAnomaly, score)
+		}
+		if score < 39.99 || score > 40.01 {
+			t.Errorf("Expected percent change 40, got %.3f", score)
+		}
+	}
+}
+
+// TestPercentChange_ZeroBaseline tests that a zero baseline does not divide by zero
+func TestPercentChange_ZeroBaseline(t *testing.T) {
+	detector := NewPercentChangeDetector(10, 25.0)
+	for i := 0; i < 5; i++ {
+		detector.ProcessData(DataPoint{Timestamp: int64(1609459200 + i), Value: 0.0})
+	}
+
+	isAnomaly, score, err := detector.ProcessData(DataPoint{Timestamp: 1609459300, Value: 0.0})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if isAnomaly || score != 0 {
+		t.Errorf("Expected no change for zero on zero baseline, got anomaly=%t score=%.3f", isAnomaly, score)
+	}
+
+	isAnomaly, _, err = detector.ProcessData(DataPoint{Timestamp: 1609459301, Value: 5.0})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !isAnomaly {
+		t.Error("Expected non-zero value on zero baseline to be flagged")
+	}
+}
+
+// TestApplyMode tests switching modes with mode-specific parameters
+func TestApplyMode(t *testing.T) {
+	detector := NewDetector(20, 3.0)
+	if err := detector.ApplyMode(ModePercentChange, map[string]float64{ParamPercentThreshold: 25}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if detector.Mode != ModePercentChange || detector.PercentThreshold != 25 {
+		t.Errorf("Expected percent-change mode at 25%%, got %q at %.1f", detector.Mode, detector.PercentThreshold)
+	}
+
+	if err := detector.ApplyMode("", nil); err != nil || detector.Mode != ModeZScore {
+		t.Errorf("Expected empty mode to select zscore, got %q (%v)", detector.Mode, err)
+	}
+
+	if err := detector.ApplyMode(ModePercentChange, nil); err == nil {
+		t.Error("Expected error for percent-change mode without a threshold")
+	}
+	if err := detector.ApplyMode(ModeZScore, map[string]float64{ParamPercentThreshold: 25}); err == nil {
+		t.Error("Expected error for a parameter the mode does not accept")
+	}
+	if _, err := ParseMode("ewma"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
+
+// TestMAD_RobustToOutliersInWindow tests that outliers inflating the standard
+// deviation hide a shift from ModeZScore but not from ModeMAD
+func TestMAD_RobustToOutliersInWindow(t *testing.T) {
+	zscore := NewDetector(50, 3.5)
+	mad := NewDetector(50, 3.5)
+	if err := mad.ApplyMode(ModeMAD, nil); err != nil {
+		t.Fatalf("Failed to apply mode: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		value := 10.0 + float64(i%2)
+		if i >= 18 {
+			value = 100
+		}
+		zscore.ProcessData(DataPoint{Timestamp: int64(i + 1), Value: value})
+		mad.ProcessData(DataPoint{Timestamp: int64(i + 1), Value: value})
+	}
+
+	if isAnomaly, _, _ := zscore.ProcessData(DataPoint{Timestamp: 21, Value: 20}); isAnomaly {
+		t.Error("Expected the outliers to mask the shift in zscore mode")
+	}
+	detection, err := mad.ProcessDataDetailed(DataPoint{Timestamp: 21, Value: 20})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Median 11 and MAD 1 over the window including the point
+	if want := 9 / madScale; !detection.IsAnomaly || math.Abs(detection.ZScore-want) > 1e-9 || detection.Direction != 1 {
+		t.Errorf("Expected an anomaly with robust z-score %.3f, got %+v", want, detection)
+	}
+}
+
+// TestValidateMode tests mode and parameter validation
+func TestValidateMode(t *testing.T) {
+	if _, err := ValidateMode(ModeMAD, nil); err != nil {
+		t.Errorf("Expected mad without parameters to be valid, got %v", err)
+	}
+	if _, err := ValidateMode("ewma", nil); err == nil {
+		t.Error("Expected an unknown mode to be rejected")
+	}
+	if _, err := ValidateMode(ModeMAD, map[string]float64{ParamPercentThreshold: 10}); err == nil {
+		t.Error("Expected mad to reject percent_threshold")
+	}
+	if _, err := ValidateMode(ModePercentChange, nil); err == nil {
+		t.Error("Expected percent_change to require percent_threshold")
+	}
+
+	modes, err := ParseSeriesModes("cpu:mad, requests:percent_change")
+	if err != nil || modes["cpu"] != ModeMAD || modes["requests"] != ModePercentChange {
+		t.Errorf("Unexpected series modes %v, %v", modes, err)
+	}
+	if _, err := ParseSeriesModes("cpu"); err == nil {
+		t.Error("Expected a pair without a mode to be rejected")
+	}
+}
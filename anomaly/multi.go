@@ -0,0 +1,186 @@
+package anomaly
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// MultiDetector routes data points to independent per-series AnomalyDetectors
+// that share the same WindowSize and Threshold.
+// Idle series are evicted in least-recently-used order once MaxSeries is reached.
+type MultiDetector struct {
+	mu                 sync.Mutex
+	WindowSize         int
+	Threshold          float64
+	MaxSeries          int
+	OrderPolicy        OrderPolicy // Applied to each series' detector on creation
+	ReorderBuffer      int
+	WarningMultiplier  float64
+	CriticalMultiplier float64
+	MinStdDev          float64
+	MinSamples         int
+	Mode               Mode // Scoring mode applied to each series' detector on creation
+	PercentThreshold   float64
+	SeasonPeriod       int64
+	SeasonBuckets      int
+	seriesModes        map[string]seriesMode // Per-series overrides of Mode
+	series             map[string]*list.Element
+	lru                *list.List // front = most recently used
+}
+
+// seriesMode is a detection mode set for a single series.
+type seriesMode struct {
+	mode   Mode
+	params map[string]float64
+}
+
+// seriesEntry is the LRU payload for a single series.
+type seriesEntry struct {
+	key      string
+	detector *AnomalyDetector
+	lastSeen time.Time
+}
+
+// NewMultiDetector initializes a new MultiDetector.
+func NewMultiDetector(windowSize int, threshold float64, maxSeries int) *MultiDetector {
+	if maxSeries <= 0 {
+		maxSeries = 1000 // Default to 1k series
+	}
+
+	return &MultiDetector{
+		WindowSize: windowSize,
+		Threshold:  threshold,
+		MaxSeries:  maxSeries,
+		series:     make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// SetSeriesMode switches the series to mode for subsequent points, keeping its
+// window. The mode also applies if the series is evicted and later returns.
+func (md *MultiDetector) SetSeriesMode(key string, mode Mode, params map[string]float64) error {
+	mode, err := ValidateMode(mode, params)
+	if err != nil {
+		return err
+	}
+
+	copied := make(map[string]float64, len(params))
+	for name, value := range params {
+		copied[name] = value
+	}
+
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	if md.seriesModes == nil {
+		md.seriesModes = make(map[string]seriesMode)
+	}
+	md.seriesModes[key] = seriesMode{mode: mode, params: copied}
+	if elem, exists := md.series[key]; exists {
+		return elem.Value.(*seriesEntry).detector.ApplyMode(mode, copied)
+	}
+	return nil
+}
+
+// SeriesMode returns the mode scoring the series, which is Mode unless it was
+// set with SetSeriesMode.
+func (md *MultiDetector) SeriesMode(key string) Mode {
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	if override, exists := md.seriesModes[key]; exists {
+		return override.mode
+	}
+	if md.Mode == "" {
+		return ModeZScore
+	}
+	return md.Mode
+}
+
+// ProcessData ingests a data point into the detector for the given series key,
+// creating the detector on first use.
+func (md *MultiDetector) ProcessData(key string, dp DataPoint) (isAnomaly bool, zScore float64, err error) {
+	return md.acquire(key).ProcessData(dp)
+}
+
+// ProcessDataDetailed is ProcessData returning the detailed Detection.
+func (md *MultiDetector) ProcessDataDetailed(key string, dp DataPoint) (Detection, error) {
+	return md.acquire(key).ProcessDataDetailed(dp)
+}
+
+// acquire returns the detector for key, creating it and evicting the least
+// recently used series if the series limit is exceeded.
+func (md *MultiDetector) acquire(key string) *AnomalyDetector {
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	if elem, exists := md.series[key]; exists {
+		entry := elem.Value.(*seriesEntry)
+		entry.lastSeen = time.Now()
+		md.lru.MoveToFront(elem)
+		return entry.detector
+	}
+
+	entry := &seriesEntry{
+		key:      key,
+		detector: NewDetector(md.WindowSize, md.Threshold),
+		lastSeen: time.Now(),
+	}
+	entry.detector.OrderPolicy = md.OrderPolicy
+	entry.detector.ReorderBuffer = md.ReorderBuffer
+	entry.detector.WarningMultiplier = md.WarningMultiplier
+	entry.detector.CriticalMultiplier = md.CriticalMultiplier
+	entry.detector.MinStdDev = md.MinStdDev
+	entry.detector.MinSamples = md.MinSamples
+	entry.detector.Mode = md.Mode
+	entry.detector.PercentThreshold = md.PercentThreshold
+	entry.detector.SeasonPeriod = md.SeasonPeriod
+	entry.detector.SeasonBuckets = md.SeasonBuckets
+	if override, exists := md.seriesModes[key]; exists {
+		entry.detector.ApplyMode(override.mode, override.params) // Validated by SetSeriesMode
+	}
+	md.series[key] = md.lru.PushFront(entry)
+
+	for md.lru.Len() > md.MaxSeries {
+		oldest := md.lru.Back()
+		md.lru.Remove(oldest)
+		delete(md.series, oldest.Value.(*seriesEntry).key)
+	}
+
+	return entry.detector
+}
+
+// GetStats returns window statistics for a single series.
+// ok is false if the series is unknown or has been evicted.
+func (md *MultiDetector) GetStats(key string) (count int, mean float64, stdDev float64, ok bool) {
+	md.mu.Lock()
+	elem, exists := md.series[key]
+	md.mu.Unlock()
+
+	if !exists {
+		return 0, 0.0, 0.0, false
+	}
+
+	count, mean, stdDev = elem.Value.(*seriesEntry).detector.GetStats()
+	return count, mean, stdDev, true
+}
+
+// SeriesCount returns the number of series currently tracked.
+func (md *MultiDetector) SeriesCount() int {
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	return md.lru.Len()
+}
+
+// Remove discards the detector for a series.
+func (md *MultiDetector) Remove(key string) {
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	if elem, exists := md.series[key]; exists {
+		md.lru.Remove(elem)
+		delete(md.series, key)
+	}
+}
@@ -0,0 +1,111 @@
+package anomaly
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestMultiDetector_IsolatesSeries tests that series do not share a window
+func TestMultiDetector_IsolatesSeries(t *testing.T) {
+	md := NewMultiDetector(50, 2.0, 10)
+
+	// Series A is stable around 10, series B around 1000
+	for i := 0; i < 30; i++ {
+		md.ProcessData("a", DataPoint{Timestamp: int64(1609459200 + i), Value: 10.0 + float64(i%3)})
+		md.ProcessData("b", DataPoint{Timestamp: int64(1609459200 + i), Value: 1000.0 + float64(i%3)})
+	}
+
+	// A value normal for B must be anomalous for A
+	isAnomaly, zScore, err := md.ProcessData("a", DataPoint{Timestamp: 1609459300, Value: 1000.0})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !isAnomaly {
+		t.Errorf("Expected anomaly for series a, got z-score: %.3f", zScore)
+	}
+
+	isAnomaly, zScore, err = md.ProcessData("b", DataPoint{Timestamp: 1609459300, Value: 1001.0})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if isAnomaly {
+		t.Errorf("Expected no anomaly for series b, got z-score: %.3f", zScore)
+	}
+
+	count, mean, _, ok := md.GetStats("b")
+	if !ok {
+		t.Fatal("Expected stats for series b")
+	}
+	if count != 31 {
+		t.Errorf("Expected 31 points in series b, got %d", count)
+	}
+	if mean < 1000.0 || mean > 1002.0 {
+		t.Errorf("Expected series b mean near 1001, got %.3f", mean)
+	}
+}
+
+// TestMultiDetector_LRUEviction tests that idle series are evicted first
+func TestMultiDetector_LRUEviction(t *testing.T) {
+	md := NewMultiDetector(10, 2.0, 3)
+
+	for i := 0; i < 3; i++ {
+		md.ProcessData(fmt.Sprintf("series-%d", i), DataPoint{Timestamp: 1609459200, Value: 1.0})
+	}
+
+	// Touch series-0 so series-1 becomes the least recently used
+	md.ProcessData("series-0", DataPoint{Timestamp: 1609459201, Value: 1.0})
+	md.ProcessData("series-3", DataPoint{Timestamp: 1609459202, Value: 1.0})
+
+	if md.SeriesCount() != 3 {
+		t.Errorf("Expected 3 tracked series, got %d", md.SeriesCount())
+	}
+
+	if _, _, _, ok := md.GetStats("series-1"); ok {
+		t.Error("Expected series-1 to be evicted")
+	}
+
+	for _, key := range []string{"series-0", "series-2", "series-3"} {
+		if _, _, _, ok := md.GetStats(key); !ok {
+			t.Errorf("Expected %s to be retained", key)
+		}
+	}
+}
+
+// TestMultiDetector_UnknownSeries tests stats for a series that was never seen
+func TestMultiDetector_UnknownSeries(t *testing.T) {
+	md := NewMultiDetector(10, 2.0, 0)
+
+	if md.MaxSeries <= 0 {
+		t.Errorf("Expected a positive default max series, got %d", md.MaxSeries)
+	}
+
+	if count, _, _, ok := md.GetStats("missing"); ok || count != 0 {
+		t.Errorf("Expected no stats for unknown series, got count=%d ok=%t", count, ok)
+	}
+}
+
+// TestMultiDetector_SeriesModeSurvivesEviction tests that a per-series mode
+// applies only to its series, including after the series is evicted and returns
+func TestMultiDetector_SeriesModeSurvivesEviction(t *testing.T) {
+	md := NewMultiDetector(10, 2.0, 1)
+	md.ProcessData("cpu", DataPoint{Timestamp: 1609459200, Value: 1.0})
+
+	if err := md.SetSeriesMode("cpu", ModeMAD, nil); err != nil {
+		t.Fatalf("Failed to set series mode: %v", err)
+	}
+	if err := md.SetSeriesMode("cpu", "ewma", nil); err == nil {
+		t.Error("Expected an unknown mode to be rejected")
+	}
+	if mode := md.SeriesMode("cpu"); mode != ModeMAD {
+		t.Errorf("Expected cpu in mad mode, got %s", mode)
+	}
+
+	// Evict cpu; the mode returns with it, while other series keep the default
+	md.ProcessData("mem", DataPoint{Timestamp: 1609459201, Value: 1.0})
+	if mode := md.acquire("mem").Mode; mode != "" {
+		t.Errorf("Expected mem in the default mode, got %s", mode)
+	}
+	if mode := md.acquire("cpu").Mode; mode != ModeMAD {
+		t.Errorf("Expected the recreated cpu detector in mad mode, got %s", mode)
+	}
+}
@@ -0,0 +1,82 @@
+package anomaly
+
+import (
+	"sync"
+)
+
+// MultiWindowDetector scores each point against a short and a long window.
+// The short window catches fast spikes; comparing its mean against the long
+// window's mean catches slow drifts that a single window absorbs.
+type MultiWindowDetector struct {
+	mu                sync.Mutex
+	Short             *AnomalyDetector
+	Long              *AnomalyDetector
+	DivergencePercent float64 // Flag when the short mean deviates from the long mean by more than this (e.g., 5 for 5%)
+}
+
+// WindowDetection holds the result and statistics of a single window.
+type WindowDetection struct {
+	Detection
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+}
+
+// MultiWindowDetection is the combined result of a MultiWindowDetector.
+type MultiWindowDetection struct {
+	IsAnomaly     bool            `json:"is_anomaly"`
+	Diverged      bool            `json:"diverged"`
+	DivergencePct float64         `json:"divergence_pct"`
+	Short         WindowDetection `json:"short"`
+	Long          WindowDetection `json:"long"`
+}
+
+// NewMultiWindowDetector initializes a new MultiWindowDetector sharing one z-score threshold.
+func NewMultiWindowDetector(shortSize int, longSize int, threshold float64, divergencePercent float64) *MultiWindowDetector {
+	return &MultiWindowDetector{
+		Short:             NewDetector(shortSize, threshold),
+		Long:              NewDetector(longSize, threshold),
+		DivergencePercent: divergencePercent,
+	}
+}
+
+// ProcessData ingests a point into both windows. The point is anomalous if
+// either window's z-score is breached or the window means diverge.
+func (mw *MultiWindowDetector) ProcessData(dp DataPoint) (MultiWindowDetection, error) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	var result MultiWindowDetection
+
+	short, err := mw.Short.ProcessDataDetailed(dp)
+	if err != nil {
+		return result, err
+	}
+	long, err := mw.Long.ProcessDataDetailed(dp)
+	if err != nil {
+		return result, err
+	}
+
+	result.Short = windowDetection(mw.Short, short)
+	result.Long = windowDetection(mw.Long, long)
+
+	// Only compare means once the long window has more history than the short one
+	if result.Long.Count > result.Short.Count {
+		result.DivergencePct = percentChange(result.Short.Mean, result.Long.Mean)
+		result.Diverged = result.DivergencePct > mw.DivergencePercent
+	}
+
+	result.IsAnomaly = short.IsAnomaly || long.IsAnomaly || result.Diverged
+	return result, nil
+}
+
+// windowDetection attaches the window statistics to a detection.
+func windowDetection(ad *AnomalyDetector, detection Detection) WindowDetection {
+	count, mean, stdDev := ad.GetStats()
+	return WindowDetection{
+		Detection: detection,
+		Count:     count,
+		Mean:      mean,
+		StdDev:    stdDev,
+	}
+}
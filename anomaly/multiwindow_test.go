@@ -0,0 +1,70 @@
+package anomaly
+
+import (
+	"testing"
+)
+
+// TestMultiWindowDetector_CatchesSlowDrift tests that mean divergence flags a
+// gradual drift that a single window's z-score never breaches
+func TestMultiWindowDetector_CatchesSlowDrift(t *testing.T) {
+	single := NewDetector(200, 3.0)
+	mw := NewMultiWindowDetector(10, 200, 3.0, 3.0)
+
+	singleFlagged := false
+	diverged := false
+
+	// 200 noisy but stable points, then a slow upward drift of 0.05 per point
+	for i := 0; i < 400; i++ {
+		value := 100.0 + float64(i%5)*2.0
+		if i >= 200 {
+			value += float64(i-200) * 0.05
+		}
+		dp := DataPoint{Timestamp: int64(1609459200 + i), Value: value}
+
+		isAnomaly, _, err := single.ProcessData(dp)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		singleFlagged = singleFlagged || isAnomaly
+
+		result, err := mw.ProcessData(dp)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Diverged {
+			diverged = true
+			if !result.IsAnomaly {
+				t.Error("Expected divergence to mark the point anomalous")
+			}
+			if result.Short.Mean <= result.Long.Mean {
+				t.Errorf("Expected short mean above long mean during upward drift, got %.3f <= %.3f",
+					result.Short.Mean, result.Long.Mean)
+			}
+		}
+	}
+
+	if singleFlagged {
+		t.Error("Expected single window to miss the slow drift")
+	}
+	if !diverged {
+		t.Error("Expected short/long divergence to catch the slow drift")
+	}
+}
+
+// TestMultiWindowDetector_StableSeries tests no divergence for a stable series
+func TestMultiWindowDetector_StableSeries(t *testing.T) {
+	mw := NewMultiWindowDetector(10, 100, 3.0, 3.0)
+
+	for i := 0; i < 300; i++ {
+		result, err := mw.ProcessData(DataPoint{Timestamp: int64(1609459200 + i), Value: 50.0 + float64(i%3)})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.IsAnomaly {
+			t.Fatalf("Point %d: unexpected anomaly %+v", i, result)
+		}
+		if result.Long.Count > 100 || result.Short.Count > 10 {
+			t.Fatalf("Window sizes exceeded: short=%d long=%d", result.Short.Count, result.Long.Count)
+		}
+	}
+}
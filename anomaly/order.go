@@ -0,0 +1,97 @@
+package anomaly
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// OrderPolicy controls how a detector handles points whose timestamp is
+// earlier than the last accepted point of the same series.
+type OrderPolicy string
+
+const (
+	// OrderFlag accepts out-of-order points and counts them (default).
+	OrderFlag OrderPolicy = "flag"
+	// OrderReject refuses out-of-order points with ErrOutOfOrder.
+	OrderReject OrderPolicy = "reject"
+	// OrderReorder inserts late points at their timestamp position if they fall
+	// within the last ReorderBuffer accepted points, and rejects them otherwise.
+	OrderReorder OrderPolicy = "reorder"
+)
+
+// DefaultReorderBuffer is the reorder tolerance used when ReorderBuffer is unset.
+const DefaultReorderBuffer = 8
+
+// ErrOutOfOrder is returned when a point is older than the series allows.
+var ErrOutOfOrder = errors.New("out-of-order timestamp")
+
+// ParseOrderPolicy validates an order policy name. An empty name selects OrderFlag.
+func ParseOrderPolicy(name string) (OrderPolicy, error) {
+	switch policy := OrderPolicy(name); policy {
+	case "":
+		return OrderFlag, nil
+	case OrderFlag, OrderReject, OrderReorder:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown order policy %q", name)
+	}
+}
+
+// checkOrder applies OrderPolicy to ts and returns how many already-windowed
+// points must stay after the new one (non-zero only when reordering), and
+// whether the point arrived late. Must be called with ad.mu held.
+func (ad *AnomalyDetector) checkOrder(ts int64) (after int, late bool, err error) {
+	if ts >= ad.lastTimestamp {
+		ad.lastTimestamp = ts
+		ad.trackRecent(ts, 0)
+		return 0, false, nil
+	}
+
+	ad.outOfOrder++
+
+	switch ad.OrderPolicy {
+	case OrderReject:
+		return 0, true, fmt.Errorf("%w: %d precedes last accepted %d", ErrOutOfOrder, ts, ad.lastTimestamp)
+	case OrderReorder:
+		if len(ad.recent) == 0 || ts < ad.recent[0] {
+			return 0, true, fmt.Errorf("%w: %d is beyond the reorder buffer", ErrOutOfOrder, ts)
+		}
+		after = len(ad.recent) - sort.Search(len(ad.recent), func(i int) bool { return ad.recent[i] > ts })
+		ad.trackRecent(ts, after)
+		return after, true, nil
+	default:
+		return 0, true, nil
+	}
+}
+
+// trackRecent records ts in the sorted buffer of recent timestamps used by
+// OrderReorder, keeping at most ReorderBuffer entries.
+func (ad *AnomalyDetector) trackRecent(ts int64, after int) {
+	if ad.OrderPolicy != OrderReorder {
+		return
+	}
+
+	limit := ad.ReorderBuffer
+	if limit <= 0 {
+		limit = DefaultReorderBuffer
+	}
+
+	pos := len(ad.recent) - after
+	ad.recent = append(ad.recent, 0)
+	copy(ad.recent[pos+1:], ad.recent[pos:])
+	ad.recent[pos] = ts
+
+	if len(ad.recent) > limit {
+		ad.recent = ad.recent[len(ad.recent)-limit:]
+	}
+}
+
+// OutOfOrderCount returns the number of out-of-order points seen, whether
+// they were flagged, reordered or rejected.
+func (ad *AnomalyDetector) OutOfOrderCount() int64 {
+	ad.mu.RLock()
+	defer ad.mu.RUnlock()
+
+	return ad.outOfOrder
+}
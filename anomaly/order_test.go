@@ -0,0 +1,103 @@
+package anomaly
+
+import (
+	"errors"
+	"testing"
+)
+
+// feedInOrder loads a detector with points at timestamps 100..100+n-1
+func feedInOrder(t *testing.T, detector *AnomalyDetector, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if _, _, err := detector.ProcessData(DataPoint{Timestamp: int64(100 + i), Value: 10.0}); err != nil {
+			t.Fatalf("Unexpected error for in-order point: %v", err)
+		}
+	}
+}
+
+// TestOrderPolicy_Reject tests that late points are refused
+func TestOrderPolicy_Reject(t *testing.T) {
+	detector := NewDetector(50, 2.0)
+	detector.OrderPolicy = OrderReject
+	feedInOrder(t, detector, 10)
+
+	_, _, err := detector.ProcessData(DataPoint{Timestamp: 105, Value: 10.0})
+	if !errors.Is(err, ErrOutOfOrder) {
+		t.Fatalf("Expected ErrOutOfOrder, got %v", err)
+	}
+
+	if count, _, _ := detector.GetStats(); count != 10 {
+		t.Errorf("Expected rejected point to stay out of the window, got count %d", count)
+	}
+	if detector.OutOfOrderCount() != 1 {
+		t.Errorf("Expected 1 out-of-order point, got %d", detector.OutOfOrderCount())
+	}
+}
+
+// TestOrderPolicy_Flag tests that late points are accepted and counted
+func TestOrderPolicy_Flag(t *testing.T) {
+	detector := NewDetector(50, 2.0)
+	feedInOrder(t, detector, 10)
+
+	if _, _, err := detector.ProcessData(DataPoint{Timestamp: 105, Value: 10.0}); err != nil {
+		t.Fatalf("Expected late point to be accepted, got %v", err)
+	}
+
+	if count, _, _ := detector.GetStats(); count != 11 {
+		t.Errorf("Expected flagged point in the window, got count %d", count)
+	}
+	if detector.OutOfOrderCount() != 1 {
+		t.Errorf("Expected 1 out-of-order point, got %d", detector.OutOfOrderCount())
+	}
+
+	// The last accepted timestamp must not move backwards
+	if _, _, err := detector.ProcessData(DataPoint{Timestamp: 107, Value: 10.0}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if detector.OutOfOrderCount() != 2 {
+		t.Errorf("Expected 2 out-of-order points, got %d", detector.OutOfOrderCount())
+	}
+}
+
+// TestOrderPolicy_Reorder tests that late points within the buffer are slotted by timestamp
+func TestOrderPolicy_Reorder(t *testing.T) {
+	detector := NewDetector(5, 2.0)
+	detector.OrderPolicy = OrderReorder
+	detector.ReorderBuffer = 3
+
+	// Window holds timestamps 100..104 with values equal to their offset
+	for i := 0; i < 5; i++ {
+		detector.ProcessData(DataPoint{Timestamp: int64(100 + i), Value: float64(i)})
+	}
+
+	// 103.5 is within the last 3 points: it lands before 104
+	if _, _, err := detector.ProcessData(DataPoint{Timestamp: 103, Value: 3.5}); err != nil {
+		t.Fatalf("Expected point within buffer to be reordered, got %v", err)
+	}
+
+	// The next in-order point evicts the oldest by timestamp, not the late arrival
+	detector.ProcessData(DataPoint{Timestamp: 105, Value: 5.0})
+
+	expected := []float64{2.0, 3.0, 3.5, 4.0, 5.0}
+	for i, v := range expected {
+		if detector.dataWindow[i] != v {
+			t.Fatalf("Expected window %v, got %v", expected, detector.dataWindow)
+		}
+	}
+
+	// A point older than the buffer is rejected
+	_, _, err := detector.ProcessData(DataPoint{Timestamp: 101, Value: 1.0})
+	if !errors.Is(err, ErrOutOfOrder) {
+		t.Errorf("Expected ErrOutOfOrder beyond the buffer, got %v", err)
+	}
+}
+
+// TestParseOrderPolicy tests policy name validation
+func TestParseOrderPolicy(t *testing.T) {
+	if policy, err := ParseOrderPolicy(""); err != nil || policy != OrderFlag {
+		t.Errorf("Expected empty policy to default to flag, got %q (%v)", policy, err)
+	}
+	if _, err := ParseOrderPolicy("drop"); err == nil {
+		t.Error("Expected error for unknown policy")
+	}
+}
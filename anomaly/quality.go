@@ -0,0 +1,187 @@
+package anomaly
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// FeedbackOutcome is the ground truth a client reports for one decision.
+type FeedbackOutcome string
+
+const (
+	TruePositive  FeedbackOutcome = "true_positive"  // Flagged, and was an anomaly
+	FalsePositive FeedbackOutcome = "false_positive" // Flagged, but was normal
+	TrueNegative  FeedbackOutcome = "true_negative"  // Not flagged, and was normal
+	FalseNegative FeedbackOutcome = "false_negative" // Not flagged, but was an anomaly
+)
+
+// Valid reports whether outcome is one of the four feedback outcomes.
+func (outcome FeedbackOutcome) Valid() bool {
+	switch outcome {
+	case TruePositive, FalsePositive, TrueNegative, FalseNegative:
+		return true
+	}
+	return false
+}
+
+// QualityReport summarizes the feedback in the window. A ratio whose
+// denominator is zero is reported as 0.
+type QualityReport struct {
+	Window            int     `json:"window"`   // Most feedback entries considered
+	Feedback          int     `json:"feedback"` // Entries currently in the window
+	TruePositives     int     `json:"true_positives"`
+	FalsePositives    int     `json:"false_positives"`
+	TrueNegatives     int     `json:"true_negatives"`
+	FalseNegatives    int     `json:"false_negatives"`
+	Precision         float64 `json:"precision"`           // TP / (TP + FP)
+	Recall            float64 `json:"recall"`              // TP / (TP + FN)
+	FalsePositiveRate float64 `json:"false_positive_rate"` // FP / (FP + TN)
+}
+
+// Anomaly reports whether the decision was really an anomaly.
+func (outcome FeedbackOutcome) Anomaly() bool {
+	return outcome == TruePositive || outcome == FalseNegative
+}
+
+// LabeledDecision is a decision's z-score with its ground truth, for
+// replaying the feedback against another threshold.
+type LabeledDecision struct {
+	ZScore  float64
+	Anomaly bool
+}
+
+// feedbackEntry is the latest outcome reported for one decision.
+type feedbackEntry struct {
+	decisionID string
+	outcome    FeedbackOutcome
+	seq        int64   // Sequence number of the feedback
+	zScore     float64 // The decision's z-score, when scored
+	scored     bool
+}
+
+// QualityTracker aggregates client feedback on decisions into detection
+// quality metrics over the last window decisions with feedback. Feedback on
+// a decision already in the window replaces its earlier outcome.
+type QualityTracker struct {
+	mu        sync.Mutex
+	window    int
+	decisions map[string]*list.Element
+	order     *list.List // Front is the most recent feedback
+	seq       int64      // Sequence number of the latest feedback
+}
+
+// NewQualityTracker creates a tracker over the last window decisions with feedback.
+func NewQualityTracker(window int) *QualityTracker {
+	return &QualityTracker{
+		window:    window,
+		decisions: make(map[string]*list.Element),
+		order:     list.New(),
+	}
+}
+
+// Record stores the outcome reported for decisionID.
+func (qt *QualityTracker) Record(decisionID string, outcome FeedbackOutcome) error {
+	return qt.record(&feedbackEntry{decisionID: decisionID, outcome: outcome})
+}
+
+// RecordScored is Record also keeping the decision's z-score, so the
+// feedback can be replayed by Labeled.
+func (qt *QualityTracker) RecordScored(decisionID string, outcome FeedbackOutcome, zScore float64) error {
+	return qt.record(&feedbackEntry{decisionID: decisionID, outcome: outcome, zScore: zScore, scored: true})
+}
+
+// record stores entry, replacing earlier feedback on its decision.
+func (qt *QualityTracker) record(entry *feedbackEntry) error {
+	decisionID, outcome := entry.decisionID, entry.outcome
+	if decisionID == "" {
+		return fmt.Errorf("decision ID must be set")
+	}
+	if !outcome.Valid() {
+		return fmt.Errorf("unsupported feedback outcome %q (expected true_positive, false_positive, true_negative or false_negative)", outcome)
+	}
+
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	qt.seq++
+	entry.seq = qt.seq
+	if elem, exists := qt.decisions[decisionID]; exists {
+		elem.Value = entry
+		qt.order.MoveToFront(elem)
+		return nil
+	}
+	qt.decisions[decisionID] = qt.order.PushFront(entry)
+	for qt.order.Len() > qt.window {
+		oldest := qt.order.Back()
+		qt.order.Remove(oldest)
+		delete(qt.decisions, oldest.Value.(*feedbackEntry).decisionID)
+	}
+	return nil
+}
+
+// Report computes the quality metrics over the feedback in the window.
+func (qt *QualityTracker) Report() QualityReport {
+	return qt.ReportSince(0)
+}
+
+// ReportSince is Report over the feedback in the window received after the
+// sequence number seq, as returned by Seq.
+func (qt *QualityTracker) ReportSince(seq int64) QualityReport {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	report := QualityReport{Window: qt.window}
+	for elem := qt.order.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*feedbackEntry)
+		if entry.seq <= seq {
+			break // The order is newest first
+		}
+		report.Feedback++
+		switch entry.outcome {
+		case TruePositive:
+			report.TruePositives++
+		case FalsePositive:
+			report.FalsePositives++
+		case TrueNegative:
+			report.TrueNegatives++
+		case FalseNegative:
+			report.FalseNegatives++
+		}
+	}
+
+	report.Precision = ratio(report.TruePositives, report.TruePositives+report.FalsePositives)
+	report.Recall = ratio(report.TruePositives, report.TruePositives+report.FalseNegatives)
+	report.FalsePositiveRate = ratio(report.FalsePositives, report.FalsePositives+report.TrueNegatives)
+	return report
+}
+
+// Seq returns the sequence number of the latest feedback.
+func (qt *QualityTracker) Seq() int64 {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	return qt.seq
+}
+
+// Labeled returns the scored feedback in the window, oldest first.
+func (qt *QualityTracker) Labeled() []LabeledDecision {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	var labeled []LabeledDecision
+	for elem := qt.order.Back(); elem != nil; elem = elem.Prev() {
+		if entry := elem.Value.(*feedbackEntry); entry.scored {
+			labeled = append(labeled, LabeledDecision{ZScore: entry.zScore, Anomaly: entry.outcome.Anomaly()})
+		}
+	}
+	return labeled
+}
+
+// ratio returns n / d, or 0 when d is zero.
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
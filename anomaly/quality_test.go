@@ -0,0 +1,64 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQualityTracker_Report(t *testing.T) {
+	qt := NewQualityTracker(10)
+	feedback := map[string]FeedbackOutcome{
+		"TS-1": TruePositive,
+		"TS-2": TruePositive,
+		"TS-3": TruePositive,
+		"TS-4": FalsePositive,
+		"TS-5": FalseNegative,
+		"TS-6": TrueNegative,
+		"TS-7": TrueNegative,
+		"TS-8": TrueNegative,
+	}
+	for decisionID, outcome := range feedback {
+		if err := qt.Record(decisionID, outcome); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	report := qt.Report()
+	if report.TruePositives != 3 || report.FalsePositives != 1 || report.TrueNegatives != 3 || report.FalseNegatives != 1 {
+		t.Fatalf("Unexpected counts %+v", report)
+	}
+	if math.Abs(report.Precision-0.75) > 1e-9 || math.Abs(report.Recall-0.75) > 1e-9 || math.Abs(report.FalsePositiveRate-0.25) > 1e-9 {
+		t.Errorf("Expected precision 0.75, recall 0.75 and FPR 0.25, got %+v", report)
+	}
+
+	// Corrected feedback replaces the earlier outcome
+	qt.Record("TS-4", TruePositive)
+	if report := qt.Report(); report.Feedback != 8 || report.Precision != 1 || report.FalsePositiveRate != 0 {
+		t.Errorf("Expected the correction to replace the false positive, got %+v", report)
+	}
+}
+
+func TestQualityTracker_Window(t *testing.T) {
+	qt := NewQualityTracker(2)
+	qt.Record("TS-1", FalsePositive)
+	qt.Record("TS-2", TruePositive)
+	qt.Record("TS-3", TruePositive)
+
+	report := qt.Report()
+	if report.Feedback != 2 || report.FalsePositives != 0 || report.Precision != 1 {
+		t.Errorf("Expected the oldest feedback to leave the window, got %+v", report)
+	}
+	if report := NewQualityTracker(5).Report(); report.Precision != 0 || report.Recall != 0 {
+		t.Errorf("Expected zero ratios without feedback, got %+v", report)
+	}
+}
+
+func TestQualityTracker_RejectsInvalidFeedback(t *testing.T) {
+	qt := NewQualityTracker(10)
+	if err := qt.Record("TS-1", "maybe"); err == nil {
+		t.Error("Expected an unknown outcome to be rejected")
+	}
+	if err := qt.Record("", TruePositive); err == nil {
+		t.Error("Expected a missing decision ID to be rejected")
+	}
+}
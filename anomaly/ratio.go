@@ -0,0 +1,134 @@
+package anomaly
+
+import (
+	"errors"
+	"sync"
+)
+
+// DefaultMaxPending bounds how many unmatched timestamps a RatioDetector buffers per side.
+const DefaultMaxPending = 64
+
+// ErrZeroDenominator is returned when the denominator series is zero at an aligned timestamp.
+var ErrZeroDenominator = errors.New("ratio denominator is zero")
+
+// RatioDetector detects anomalies in the ratio of two series
+// (e.g., error-rate = errors/requests). Points from either series are
+// buffered by timestamp until the other series reports the same timestamp;
+// the ratio is then scored by a dedicated AnomalyDetector.
+// Unmatched points older than an aligned pair are dropped.
+type RatioDetector struct {
+	mu               sync.Mutex
+	Numerator        string // Series ID of the numerator
+	Denominator      string // Series ID of the denominator
+	MaxPending       int
+	Detector         *AnomalyDetector
+	numerators       map[int64]float64
+	denominators     map[int64]float64
+	dropped          int64
+	zeroDenominators int64
+}
+
+// RatioDetection is the result of scoring an aligned ratio.
+type RatioDetection struct {
+	Detection
+	Timestamp int64   `json:"timestamp"`
+	Ratio     float64 `json:"ratio"`
+}
+
+// NewRatioDetector initializes a new RatioDetector for numerator/denominator.
+func NewRatioDetector(numerator string, denominator string, windowSize int, threshold float64) *RatioDetector {
+	return &RatioDetector{
+		Numerator:    numerator,
+		Denominator:  denominator,
+		MaxPending:   DefaultMaxPending,
+		Detector:     NewDetector(windowSize, threshold),
+		numerators:   make(map[int64]float64),
+		denominators: make(map[int64]float64),
+	}
+}
+
+// Matches reports whether the series ID is a source of this ratio.
+func (rd *RatioDetector) Matches(seriesID string) bool {
+	return seriesID == rd.Numerator || seriesID == rd.Denominator
+}
+
+// ProcessData ingests a point from either source series, identified by dp.SeriesID.
+// ready is true when the point completed an aligned pair and the ratio was scored.
+// A zero denominator discards the pair and returns ErrZeroDenominator.
+func (rd *RatioDetector) ProcessData(dp DataPoint) (result RatioDetection, ready bool, err error) {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+
+	var own, other map[int64]float64
+	switch dp.SeriesID {
+	case rd.Numerator:
+		own, other = rd.numerators, rd.denominators
+	case rd.Denominator:
+		own, other = rd.denominators, rd.numerators
+	default:
+		return result, false, nil
+	}
+
+	counterpart, aligned := other[dp.Timestamp]
+	if !aligned {
+		own[dp.Timestamp] = dp.Value
+		rd.trimPending(own)
+		return result, false, nil
+	}
+	delete(other, dp.Timestamp)
+
+	numerator, denominator := dp.Value, counterpart
+	if dp.SeriesID == rd.Denominator {
+		numerator, denominator = counterpart, dp.Value
+	}
+
+	// Anything older than an aligned pair will never be matched
+	rd.dropBefore(rd.numerators, dp.Timestamp)
+	rd.dropBefore(rd.denominators, dp.Timestamp)
+
+	if denominator == 0 {
+		rd.zeroDenominators++
+		return result, false, ErrZeroDenominator
+	}
+
+	ratio := numerator / denominator
+	detection, err := rd.Detector.ProcessDataDetailed(DataPoint{Timestamp: dp.Timestamp, Value: ratio})
+	if err != nil {
+		return result, false, err
+	}
+
+	return RatioDetection{Detection: detection, Timestamp: dp.Timestamp, Ratio: ratio}, true, nil
+}
+
+// trimPending drops the oldest buffered timestamps beyond MaxPending.
+func (rd *RatioDetector) trimPending(pending map[int64]float64) {
+	for len(pending) > rd.MaxPending {
+		oldest := int64(0)
+		first := true
+		for ts := range pending {
+			if first || ts < oldest {
+				oldest, first = ts, false
+			}
+		}
+		delete(pending, oldest)
+		rd.dropped++
+	}
+}
+
+// dropBefore discards buffered points older than ts.
+func (rd *RatioDetector) dropBefore(pending map[int64]float64, ts int64) {
+	for pts := range pending {
+		if pts < ts {
+			delete(pending, pts)
+			rd.dropped++
+		}
+	}
+}
+
+// GetStats returns counters for misaligned and zero-denominator points.
+func (rd *RatioDetector) GetStats() (dropped int64, zeroDenominators int64, pending int) {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+
+	return rd.dropped, rd.zeroDenominators, len(rd.numerators) + len(rd.denominators)
+}
@@ -0,0 +1,119 @@
+package anomaly
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestRatioDetector_DetectsRatioAnomaly tests that a ratio spike is flagged
+// while neither source series is anomalous on its own
+func TestRatioDetector_DetectsRatioAnomaly(t *testing.T) {
+	rd := NewRatioDetector("errors", "requests", 50, 3.0)
+	errorsOnly := NewDetector(50, 3.0)
+	requestsOnly := NewDetector(50, 3.0)
+
+	// Traffic and errors vary together, keeping the error rate near 1%
+	for i := 0; i < 40; i++ {
+		ts := int64(1609459200 + i)
+		requests := 1000.0 + float64(i%5)*100.0
+		errs := requests * (0.01 + float64(i%3)*0.0005)
+
+		for _, dp := range []DataPoint{
+			{Timestamp: ts, Value: errs, SeriesID: "errors"},
+			{Timestamp: ts, Value: requests, SeriesID: "requests"},
+		} {
+			if _, _, err := rd.ProcessData(dp); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		errorsOnly.ProcessData(DataPoint{Timestamp: ts, Value: errs})
+		requestsOnly.ProcessData(DataPoint{Timestamp: ts, Value: requests})
+	}
+
+	// Low traffic with ordinary error count: neither source is unusual, the rate is
+	ts := int64(1609459300)
+	errs, requests := 12.0, 800.0
+
+	if isAnomaly, zScore, _ := errorsOnly.ProcessData(DataPoint{Timestamp: ts, Value: errs}); isAnomaly {
+		t.Errorf("Expected numerator alone to be normal, got z-score: %.3f", zScore)
+	}
+	if isAnomaly, zScore, _ := requestsOnly.ProcessData(DataPoint{Timestamp: ts, Value: requests}); isAnomaly {
+		t.Errorf("Expected denominator alone to be normal, got z-score: %.3f", zScore)
+	}
+
+	// Denominator arrives first; the numerator completes the pair
+	if _, ready, err := rd.ProcessData(DataPoint{Timestamp: ts, Value: requests, SeriesID: "requests"}); ready || err != nil {
+		t.Fatalf("Expected half a pair to be buffered, got ready=%v err=%v", ready, err)
+	}
+	result, ready, err := rd.ProcessData(DataPoint{Timestamp: ts, Value: errs, SeriesID: "errors"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !ready {
+		t.Fatal("Expected aligned pair to be scored")
+	}
+	if result.Ratio != errs/requests {
+		t.Errorf("Expected ratio %.4f, got %.4f", errs/requests, result.Ratio)
+	}
+	if !result.IsAnomaly || result.Direction != 1 {
+		t.Errorf("Expected upward ratio anomaly, got %+v", result)
+	}
+}
+
+// TestRatioDetector_ZeroDenominator tests division-by-zero handling
+func TestRatioDetector_ZeroDenominator(t *testing.T) {
+	rd := NewRatioDetector("errors", "requests", 10, 2.0)
+
+	rd.ProcessData(DataPoint{Timestamp: 1, Value: 5.0, SeriesID: "errors"})
+	_, ready, err := rd.ProcessData(DataPoint{Timestamp: 1, Value: 0.0, SeriesID: "requests"})
+	if !errors.Is(err, ErrZeroDenominator) {
+		t.Errorf("Expected ErrZeroDenominator, got %v", err)
+	}
+	if ready {
+		t.Error("Expected zero-denominator pair not to be scored")
+	}
+
+	if count, _, _ := rd.Detector.GetStats(); count != 0 {
+		t.Errorf("Expected no ratio recorded, got %d", count)
+	}
+	if _, zeros, pending := rd.GetStats(); zeros != 1 || pending != 0 {
+		t.Errorf("Expected 1 zero denominator and nothing pending, got %d/%d", zeros, pending)
+	}
+}
+
+// TestRatioDetector_MisalignedTimestamps tests that unmatched points are dropped
+func TestRatioDetector_MisalignedTimestamps(t *testing.T) {
+	rd := NewRatioDetector("errors", "requests", 10, 2.0)
+
+	// Numerator at t=1 never gets a denominator; denominator at t=2 never gets a numerator
+	rd.ProcessData(DataPoint{Timestamp: 1, Value: 1.0, SeriesID: "errors"})
+	rd.ProcessData(DataPoint{Timestamp: 2, Value: 100.0, SeriesID: "requests"})
+	rd.ProcessData(DataPoint{Timestamp: 3, Value: 2.0, SeriesID: "errors"})
+
+	result, ready, err := rd.ProcessData(DataPoint{Timestamp: 3, Value: 200.0, SeriesID: "requests"})
+	if err != nil || !ready {
+		t.Fatalf("Expected aligned pair at t=3, got ready=%v err=%v", ready, err)
+	}
+	if result.Ratio != 0.01 {
+		t.Errorf("Expected ratio 0.01, got %.4f", result.Ratio)
+	}
+
+	dropped, _, pending := rd.GetStats()
+	if dropped != 2 || pending != 0 {
+		t.Errorf("Expected 2 dropped and 0 pending, got %d/%d", dropped, pending)
+	}
+
+	// Unrelated series are ignored
+	if _, ready, err := rd.ProcessData(DataPoint{Timestamp: 4, Value: 1.0, SeriesID: "latency"}); ready || err != nil {
+		t.Errorf("Expected unrelated series to be ignored, got ready=%v err=%v", ready, err)
+	}
+
+	// Pending buffer is bounded
+	rd.MaxPending = 4
+	for i := 0; i < 10; i++ {
+		rd.ProcessData(DataPoint{Timestamp: int64(10 + i), Value: 1.0, SeriesID: "errors"})
+	}
+	if _, _, pending := rd.GetStats(); pending != 4 {
+		t.Errorf("Expected pending bounded to 4, got %d", pending)
+	}
+}
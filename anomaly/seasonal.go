@@ -0,0 +1,113 @@
+package anomaly
+
+import (
+	"fmt"
+	"math"
+)
+
+// seasonBaseline is the bucket baseline a ModeSeasonal point was scored against.
+type seasonBaseline struct {
+	bucket int
+	count  int
+	mean   float64
+	stdDev float64 // After the MinStdDev floor
+}
+
+// validateSeasonParams checks the ModeSeasonal parameters: a positive whole
+// period and, when set, a whole number of buckets between 1 and the period.
+func validateSeasonParams(params map[string]float64) error {
+	period := params[ParamSeasonPeriod]
+	if period < 1 || period != math.Trunc(period) {
+		return fmt.Errorf("%s must be a positive whole number of seconds", ParamSeasonPeriod)
+	}
+	if buckets, exists := params[ParamSeasonBuckets]; exists {
+		if buckets < 1 || buckets > period || buckets != math.Trunc(buckets) {
+			return fmt.Errorf("%s must be a whole number between 1 and %s", ParamSeasonBuckets, ParamSeasonPeriod)
+		}
+	}
+	return nil
+}
+
+// seasonParams returns the period and bucket count of validated ModeSeasonal params.
+func seasonParams(params map[string]float64) (period int64, buckets int) {
+	buckets = DefaultSeasonBuckets
+	if b, exists := params[ParamSeasonBuckets]; exists {
+		buckets = int(b)
+	}
+	return int64(params[ParamSeasonPeriod]), buckets
+}
+
+// seasonBucket returns the bucket of timestamp: its phase in the cycle,
+// scaled to SeasonBuckets. Must be called with ad.mu held.
+func (ad *AnomalyDetector) seasonBucket(timestamp int64) int {
+	phase := timestamp % ad.SeasonPeriod
+	if phase < 0 {
+		phase += ad.SeasonPeriod
+	}
+	return int(phase * int64(ad.SeasonBuckets) / ad.SeasonPeriod)
+}
+
+// seasonDepth is how many recent values each bucket keeps: the window spread
+// over the buckets, and at least two so a bucket has a spread.
+func (ad *AnomalyDetector) seasonDepth() int {
+	return max(ad.WindowSize/ad.SeasonBuckets, 2)
+}
+
+// scoreSeasonal scores the point against the mean and standard deviation of
+// its bucket before the point is added, then adds it. A bucket needs two
+// values for a baseline, so until then its points are warm-up, as are all
+// points while the window holds fewer than MinSamples. Must be called with
+// ad.mu held, after the point was added to the window.
+func (ad *AnomalyDetector) scoreSeasonal(detection Detection, dp DataPoint) Detection {
+	if ad.SeasonPeriod <= 0 || ad.SeasonBuckets <= 0 {
+		// Mode was set directly rather than through ApplyMode; use hours of the day
+		ad.SeasonPeriod, ad.SeasonBuckets = 86400, DefaultSeasonBuckets
+	}
+	if len(ad.seasons) != ad.SeasonBuckets {
+		ad.seasons = make([][]float64, ad.SeasonBuckets)
+	}
+
+	bucket := ad.seasonBucket(dp.Timestamp)
+	values := ad.seasons[bucket]
+	baseline := seasonBaseline{bucket: bucket, count: len(values)}
+	defer func() {
+		if len(values) >= ad.seasonDepth() {
+			values = values[1:]
+		}
+		ad.seasons[bucket] = append(values, dp.Value)
+	}()
+
+	if len(values) > 0 {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		baseline.mean = sum / float64(len(values))
+		var m2 float64
+		for _, v := range values {
+			m2 += (v - baseline.mean) * (v - baseline.mean)
+		}
+		baseline.stdDev = math.Max(math.Sqrt(m2/float64(len(values))), ad.MinStdDev)
+	}
+	ad.lastSeason = baseline
+
+	if len(values) < 2 || len(ad.dataWindow) < ad.MinSamples {
+		detection.WarmingUp = true
+		return detection
+	}
+
+	detection.Direction = direction(dp.Value - baseline.mean)
+	if baseline.stdDev == 0 {
+		if dp.Value != baseline.mean {
+			detection.IsAnomaly = true
+			detection.ZScore = math.MaxFloat64
+			detection.Severity = SeverityCritical
+		}
+		return detection
+	}
+
+	detection.ZScore = math.Abs((dp.Value - baseline.mean) / baseline.stdDev)
+	detection.IsAnomaly = detection.ZScore > ad.Threshold
+	detection.Severity = ad.severity(detection.ZScore, ad.Threshold)
+	return detection
+}
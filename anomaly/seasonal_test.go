@@ -0,0 +1,95 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+)
+
+// TestSeasonal_FlagsOffPatternSpikeOnly feeds two daily cycles of a sinusoid
+// sampled every 10 minutes, with one spike at the second cycle's trough.
+func TestSeasonal_FlagsOffPatternSpikeOnly(t *testing.T) {
+	const (
+		day   = 86400
+		step  = 600
+		start = 1609459200 // Midnight UTC
+	)
+	seasonal := NewDetector(500, 3.5)
+	if err := seasonal.ApplyMode(ModeSeasonal, map[string]float64{ParamSeasonPeriod: day}); err != nil {
+		t.Fatalf("Failed to apply seasonal mode: %v", err)
+	}
+	plain := NewDetector(500, 3.5)
+
+	spikeAt := int64(start + day + 3*day/4) // 18:00 of the second day, when the signal bottoms out at 50
+	var spike, plainSpike Detection
+	for ts := int64(start); ts < start+2*day; ts += step {
+		value := 100 + 50*math.Sin(2*math.Pi*float64(ts-start)/day)
+		if ts == spikeAt {
+			value += 60 // Well within the signal's range, but not at this hour
+		}
+
+		detection, err := seasonal.ProcessDataDetailed(DataPoint{Timestamp: ts, Value: value})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		plainDetection, _ := plain.ProcessDataDetailed(DataPoint{Timestamp: ts, Value: value})
+
+		switch {
+		case ts == spikeAt:
+			spike, plainSpike = detection, plainDetection
+		case ts >= start+day && detection.IsAnomaly:
+			t.Errorf("Expected the seasonal pattern not flagged, got z-score %.2f for %.1f at %d", detection.ZScore, value, ts)
+		}
+	}
+
+	if !spike.IsAnomaly || spike.Direction != 1 {
+		t.Errorf("Expected the off-pattern spike flagged upwards, got %+v", spike)
+	}
+	if plainSpike.IsAnomaly {
+		t.Errorf("Expected a non-seasonal z-score to miss the spike, got %+v", plainSpike)
+	}
+}
+
+func TestSeasonal_BucketsByPhase(t *testing.T) {
+	detector := NewDetector(100, 3.5)
+	if err := detector.ApplyMode(ModeSeasonal, map[string]float64{ParamSeasonPeriod: 60, ParamSeasonBuckets: 2}); err != nil {
+		t.Fatalf("Failed to apply seasonal mode: %v", err)
+	}
+
+	// The first half of each minute reads 10, the second half 1000
+	for minute := int64(0); minute < 5; minute++ {
+		for _, offset := range []int64{0, 10, 30, 40} {
+			value := 10.0
+			if offset >= 30 {
+				value = 1000
+			}
+			ts := 1609459200 + minute*60 + offset
+			if isAnomaly, _, _ := detector.ProcessData(DataPoint{Timestamp: ts, Value: value}); isAnomaly {
+				t.Fatalf("Expected %.0f at second %d of the minute to match its phase", value, offset)
+			}
+		}
+	}
+
+	detection, explanation, err := detector.ProcessDataExplained(DataPoint{Timestamp: 1609459200 + 300 + 45, Value: 10})
+	if err != nil || !detection.IsAnomaly || detection.Direction != -1 {
+		t.Errorf("Expected 10 flagged low in the second half of the minute, got %+v, %v", detection, err)
+	}
+	if explanation.Mode != ModeSeasonal || explanation.SeasonBucket != 1 || explanation.Baseline != 1000 {
+		t.Errorf("Expected the explanation to show bucket 1 with baseline 1000, got %+v", explanation)
+	}
+}
+
+func TestValidateMode_Seasonal(t *testing.T) {
+	if _, err := ValidateMode(ModeSeasonal, map[string]float64{ParamSeasonPeriod: 86400}); err != nil {
+		t.Errorf("Expected seasonal with a period to be valid, got %v", err)
+	}
+	for _, params := range []map[string]float64{
+		nil,
+		{ParamSeasonPeriod: 0.5},
+		{ParamSeasonPeriod: 86400, ParamSeasonBuckets: 0},
+		{ParamSeasonPeriod: 60, ParamSeasonBuckets: 120},
+	} {
+		if _, err := ValidateMode(ModeSeasonal, params); err == nil {
+			t.Errorf("Expected seasonal params %v to be rejected", params)
+		}
+	}
+}
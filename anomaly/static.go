@@ -0,0 +1,36 @@
+package anomaly
+
+// StaticThreshold flags values outside a fixed [Min, Max] range.
+// It keeps no window, so it serves as a degraded fallback when the
+// statistical detector fails or its window is corrupt.
+type StaticThreshold struct {
+	Min float64
+	Max float64
+}
+
+// NewStaticThreshold initializes a new StaticThreshold.
+func NewStaticThreshold(min float64, max float64) *StaticThreshold {
+	return &StaticThreshold{
+		Min: min,
+		Max: max,
+	}
+}
+
+// Evaluate scores a data point against the static bounds.
+// ZScore is always 0 since no statistics are available.
+func (st *StaticThreshold) Evaluate(dp DataPoint) Detection {
+	detection := Detection{Severity: SeverityNone}
+
+	switch {
+	case dp.Value > st.Max:
+		detection.IsAnomaly = true
+		detection.Direction = 1
+		detection.Severity = SeverityWarning
+	case dp.Value < st.Min:
+		detection.IsAnomaly = true
+		detection.Direction = -1
+		detection.Severity = SeverityWarning
+	}
+
+	return detection
+}
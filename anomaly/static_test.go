@@ -0,0 +1,31 @@
+package anomaly
+
+import (
+	"testing"
+)
+
+// TestStaticThreshold_Evaluate tests static bound checks
+func TestStaticThreshold_Evaluate(t *testing.T) {
+	st := NewStaticThreshold(0.0, 100.0)
+
+	tests := []struct {
+		name      string
+		value     float64
+		isAnomaly bool
+		direction int
+	}{
+		{"Within bounds", 50.0, false, 0},
+		{"At upper bound", 100.0, false, 0},
+		{"Above max", 150.0, true, 1},
+		{"Below min", -1.0, true, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detection := st.Evaluate(DataPoint{Timestamp: 1609459200, Value: tt.value})
+			if detection.IsAnomaly != tt.isAnomaly || detection.Direction != tt.direction {
+				t.Errorf("Expected (%t, %d), got %+v", tt.isAnomaly, tt.direction, detection)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package anomaly
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// DefaultAnomalyHistorySize is the number of recent anomalies kept by default.
+const DefaultAnomalyHistorySize = 100
+
+// AnomalyRecord is one anomalous data point in the recent-anomaly ring.
+type AnomalyRecord struct {
+	SeriesID  string  `json:"series_id,omitempty"`
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+	ZScore    float64 `json:"z_score"`
+	Direction int     `json:"direction"`
+	Severity  string  `json:"severity"`
+	DedupKey  string  `json:"dedup_key,omitempty"` // Key of the episode the anomaly belongs to
+}
+
+// Episode is a run of consecutive anomalies on one series. It is open until
+// the series reports a normal point, which signals its recovery.
+type Episode struct {
+	SeriesID       string  `json:"series_id,omitempty"`
+	StartTimestamp int64   `json:"start_timestamp"`
+	LastTimestamp  int64   `json:"last_timestamp"`
+	Count          int     `json:"count"`
+	PeakZScore     float64 `json:"peak_z_score"`
+	Severity       string  `json:"severity"`  // Highest severity seen during the episode
+	DedupKey       string  `json:"dedup_key"` // Shared by every alert of the episode, so downstream alerting groups them
+}
+
+// AnomalyStore keeps the most recent anomalies and the open episode of each
+// series. With a state file it is saved after every change and reloaded on
+// startup, so a restart neither re-alerts on an ongoing episode nor drops its
+// recovery.
+type AnomalyStore struct {
+	mu       sync.Mutex
+	capacity int
+	recent   []AnomalyRecord // Oldest first, at most capacity entries
+	episodes map[string]*Episode
+	path     string // Empty keeps the store in memory only
+	prefix   string // Prepended to episode dedup keys
+}
+
+// anomalyStoreState is the on-disk form of an AnomalyStore.
+type anomalyStoreState struct {
+	Recent   []AnomalyRecord `json:"recent"`
+	Episodes []Episode       `json:"episodes"`
+}
+
+// NewAnomalyStore creates a store keeping up to capacity recent anomalies.
+// If path is set, state previously saved there is restored; a missing file
+// is not an error.
+func NewAnomalyStore(capacity int, path string) (*AnomalyStore, error) {
+	if capacity <= 0 {
+		capacity = DefaultAnomalyHistorySize
+	}
+	s := &AnomalyStore{
+		capacity: capacity,
+		episodes: make(map[string]*Episode),
+		path:     path,
+	}
+	if path != "" {
+		if err := s.load(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// SetDedupKeyPrefix sets the prefix of the dedup keys of new episodes, such
+// as a deployment name, so keys from separate deployments cannot collide.
+func (s *AnomalyStore) SetDedupKeyPrefix(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prefix = prefix
+}
+
+// DedupKey returns the dedup key of the open episode of seriesID and whether
+// one is open.
+func (s *AnomalyStore) DedupKey(seriesID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	episode, open := s.episodes[seriesID]
+	if !open {
+		return "", false
+	}
+	return episode.DedupKey, true
+}
+
+// Record tracks the outcome of scoring dp. An anomaly is added to the recent
+// list and opens or extends the episode of its series. A normal point closes
+// the open episode, which is returned so the caller can signal recovery;
+// otherwise the returned episode is nil.
+func (s *AnomalyStore) Record(dp DataPoint, detection Detection) *Episode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	episode, open := s.episodes[dp.SeriesID]
+	if !detection.IsAnomaly {
+		if !open {
+			return nil
+		}
+		delete(s.episodes, dp.SeriesID)
+		s.save()
+		return episode
+	}
+
+	if !open {
+		episode = &Episode{SeriesID: dp.SeriesID, StartTimestamp: dp.Timestamp}
+		s.episodes[dp.SeriesID] = episode
+	}
+	if episode.DedupKey == "" { // Also covers episodes restored from state saved without keys
+		episode.DedupKey = dedupKey(s.prefix, episode.SeriesID, episode.StartTimestamp)
+	}
+
+	s.recent = append(s.recent, AnomalyRecord{
+		SeriesID:  dp.SeriesID,
+		Timestamp: dp.Timestamp,
+		Value:     dp.Value,
+		ZScore:    detection.ZScore,
+		Direction: detection.Direction,
+		Severity:  detection.Severity,
+		DedupKey:  episode.DedupKey,
+	})
+	if len(s.recent) > s.capacity {
+		s.recent = s.recent[len(s.recent)-s.capacity:]
+	}
+
+	episode.LastTimestamp = dp.Timestamp
+	episode.Count++
+	if detection.ZScore > episode.PeakZScore {
+		episode.PeakZScore = detection.ZScore
+	}
+	if severityRank(detection.Severity) > severityRank(episode.Severity) {
+		episode.Severity = detection.Severity
+	}
+	s.save()
+	return nil
+}
+
+// Recent returns up to limit of the most recent anomalies, oldest first.
+// A limit of zero or less returns all of them.
+func (s *AnomalyStore) Recent(limit int) []AnomalyRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if limit <= 0 || limit > len(s.recent) {
+		limit = len(s.recent)
+	}
+	return append([]AnomalyRecord(nil), s.recent[len(s.recent)-limit:]...)
+}
+
+// OpenEpisodes returns the episodes still in progress, ordered by series ID.
+func (s *AnomalyStore) OpenEpisodes() []Episode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.openEpisodes()
+}
+
+func (s *AnomalyStore) openEpisodes() []Episode {
+	episodes := make([]Episode, 0, len(s.episodes))
+	for _, episode := range s.episodes {
+		episodes = append(episodes, *episode)
+	}
+	sort.Slice(episodes, func(i, j int) bool {
+		return episodes[i].SeriesID < episodes[j].SeriesID
+	})
+	return episodes
+}
+
+// save writes the store to its state file, replacing the previous state
+// atomically. Failures are logged; detection carries on in memory.
+// Must be called with s.mu held.
+func (s *AnomalyStore) save() {
+	if s.path == "" {
+		return
+	}
+	data, err := json.Marshal(anomalyStoreState{Recent: s.recent, Episodes: s.openEpisodes()})
+	if err != nil {
+		log.Printf("AnomalyStore: Error encoding state: %v", err)
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		log.Printf("AnomalyStore: Error creating state file: %v", err)
+		return
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		log.Printf("AnomalyStore: Error writing state file: %v", err)
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		log.Printf("AnomalyStore: Error writing state file: %v", err)
+		return
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		log.Printf("AnomalyStore: Error replacing state file: %v", err)
+	}
+}
+
+// load restores the state saved in the state file, keeping the most recent
+// capacity anomalies. A missing file is not an error.
+func (s *AnomalyStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read anomaly state: %w", err)
+	}
+
+	var state anomalyStoreState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to decode anomaly state: %w", err)
+	}
+
+	if len(state.Recent) > s.capacity {
+		state.Recent = state.Recent[len(state.Recent)-s.capacity:]
+	}
+	s.recent = state.Recent
+	for i := range state.Episodes {
+		episode := state.Episodes[i]
+		s.episodes[episode.SeriesID] = &episode
+	}
+	return nil
+}
+
+// dedupKey fingerprints the episode of seriesID starting at startTimestamp.
+// It is derived rather than random, so it is the same after a restart.
+func dedupKey(prefix, seriesID string, startTimestamp int64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", seriesID, startTimestamp)))
+	key := hex.EncodeToString(sum[:8])
+	if prefix == "" {
+		return key
+	}
+	return prefix + "-" + key
+}
+
+// severityRank orders severity bands from none to critical.
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityWarning:
+		return 1
+	case SeverityCritical:
+		return 2
+	default:
+		return 0
+	}
+}
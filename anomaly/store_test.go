@@ -0,0 +1,114 @@
+package anomaly
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnomalyStore_RestoresStateFromDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "anomalies.json")
+	store, err := NewAnomalyStore(3, path)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	warning := Detection{IsAnomaly: true, ZScore: 3.5, Direction: 1, Severity: SeverityWarning}
+	critical := Detection{IsAnomaly: true, ZScore: 7.0, Direction: 1, Severity: SeverityCritical}
+	store.Record(DataPoint{SeriesID: "cpu", Timestamp: 1, Value: 90}, warning)
+	store.Record(DataPoint{SeriesID: "cpu", Timestamp: 2, Value: 99}, critical)
+	store.Record(DataPoint{SeriesID: "mem", Timestamp: 3, Value: 80}, warning)
+	store.Record(DataPoint{SeriesID: "mem", Timestamp: 4, Value: 40}, Detection{Severity: SeverityNone})
+	store.Record(DataPoint{SeriesID: "disk", Timestamp: 5, Value: 95}, warning)
+
+	restored, err := NewAnomalyStore(3, path)
+	if err != nil {
+		t.Fatalf("Failed to reload store: %v", err)
+	}
+
+	recent := restored.Recent(0)
+	if len(recent) != 3 {
+		t.Fatalf("Expected 3 recent anomalies, got %d", len(recent))
+	}
+	for i, want := range []int64{2, 3, 5} {
+		if recent[i].Timestamp != want {
+			t.Errorf("Expected recent[%d] at timestamp %d, got %d", i, want, recent[i].Timestamp)
+		}
+	}
+
+	episodes := restored.OpenEpisodes()
+	if len(episodes) != 2 {
+		t.Fatalf("Expected open episodes for cpu and disk, got %+v", episodes)
+	}
+	cpu := episodes[0]
+	if cpu.SeriesID != "cpu" || cpu.StartTimestamp != 1 || cpu.LastTimestamp != 2 || cpu.Count != 2 ||
+		cpu.PeakZScore != 7.0 || cpu.Severity != SeverityCritical {
+		t.Errorf("Unexpected cpu episode: %+v", cpu)
+	}
+	if episodes[1].SeriesID != "disk" {
+		t.Errorf("Expected disk episode, got %+v", episodes[1])
+	}
+
+	// The restored episode still reports its recovery
+	recovered := restored.Record(DataPoint{SeriesID: "cpu", Timestamp: 6, Value: 50}, Detection{Severity: SeverityNone})
+	if recovered == nil || recovered.StartTimestamp != 1 || recovered.Count != 2 {
+		t.Errorf("Expected the restored cpu episode to recover, got %+v", recovered)
+	}
+}
+
+func TestAnomalyStore_InMemoryWithoutPath(t *testing.T) {
+	store, err := NewAnomalyStore(0, "")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	if store.capacity != DefaultAnomalyHistorySize {
+		t.Errorf("Expected default capacity %d, got %d", DefaultAnomalyHistorySize, store.capacity)
+	}
+
+	if recovered := store.Record(DataPoint{Timestamp: 1, Value: 1}, Detection{}); recovered != nil {
+		t.Errorf("Expected no recovery without an open episode, got %+v", recovered)
+	}
+	store.Record(DataPoint{Timestamp: 2, Value: 100}, Detection{IsAnomaly: true, ZScore: 4})
+	if got := store.Recent(10); len(got) != 1 || got[0].Timestamp != 2 {
+		t.Errorf("Expected one recent anomaly, got %+v", got)
+	}
+}
+
+func TestAnomalyStore_DedupKeyPerEpisode(t *testing.T) {
+	store, err := NewAnomalyStore(10, "")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	store.SetDedupKeyPrefix("prod")
+	anomalous := Detection{IsAnomaly: true, ZScore: 4, Severity: SeverityCritical}
+
+	store.Record(DataPoint{SeriesID: "cpu", Timestamp: 1, Value: 100}, anomalous)
+	first, open := store.DedupKey("cpu")
+	if !open || !strings.HasPrefix(first, "prod-") {
+		t.Fatalf("Expected a prefixed key for the open episode, got %q", first)
+	}
+	store.Record(DataPoint{SeriesID: "cpu", Timestamp: 2, Value: 110}, anomalous)
+	store.Record(DataPoint{SeriesID: "mem", Timestamp: 2, Value: 90}, anomalous)
+
+	// Every alert of the episode shares its key; another series has its own
+	for _, record := range store.Recent(0) {
+		if record.SeriesID == "cpu" && record.DedupKey != first {
+			t.Errorf("Expected cpu alert at %d to share key %q, got %q", record.Timestamp, first, record.DedupKey)
+		}
+		if record.SeriesID == "mem" && record.DedupKey == first {
+			t.Error("Expected another series to get its own key")
+		}
+	}
+
+	// Recovery ends the episode; the next one gets a new key
+	if episode := store.Record(DataPoint{SeriesID: "cpu", Timestamp: 3, Value: 10}, Detection{}); episode == nil || episode.DedupKey != first {
+		t.Fatalf("Expected the recovered episode to carry key %q, got %+v", first, episode)
+	}
+	if _, open := store.DedupKey("cpu"); open {
+		t.Error("Expected no open episode after recovery")
+	}
+	store.Record(DataPoint{SeriesID: "cpu", Timestamp: 4, Value: 120}, anomalous)
+	if second, _ := store.DedupKey("cpu"); second == "" || second == first {
+		t.Errorf("Expected a new key for the new episode, got %q (previous %q)", second, first)
+	}
+}
@@ -0,0 +1,163 @@
+package anomaly
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrNoStandby is returned when priming or promoting without a staged standby.
+var ErrNoStandby = errors.New("no standby detector staged")
+
+// DetectorSwap holds the live detector behind an atomic pointer so a
+// recalibrated standby can be staged, primed, and promoted without a gap.
+// Requests that acquired the previous detector finish on it; Promote waits
+// for them to drain before returning.
+type DetectorSwap struct {
+	mu      sync.Mutex // Serializes Stage, Prime, and Promote
+	live    atomic.Pointer[detectorSlot]
+	standby *AnomalyDetector
+}
+
+// detectorSlot pairs a detector with the number of requests still using it.
+type detectorSlot struct {
+	detector *AnomalyDetector
+	inflight atomic.Int64
+}
+
+// NewDetectorSwap initializes a new DetectorSwap serving live.
+func NewDetectorSwap(live *AnomalyDetector) *DetectorSwap {
+	ds := &DetectorSwap{}
+	ds.live.Store(&detectorSlot{detector: live})
+	return ds
+}
+
+// Live returns the detector currently serving requests.
+func (ds *DetectorSwap) Live() *AnomalyDetector {
+	return ds.live.Load().detector
+}
+
+// Acquire returns the live detector and a release func the caller must invoke
+// when done with it. A promotion in between does not affect the caller.
+func (ds *DetectorSwap) Acquire() (*AnomalyDetector, func()) {
+	for {
+		slot := ds.live.Load()
+		slot.inflight.Add(1)
+
+		// Re-check so Promote cannot miss an acquisition that raced the swap
+		if ds.live.Load() == slot {
+			return slot.detector, func() { slot.inflight.Add(-1) }
+		}
+		slot.inflight.Add(-1)
+	}
+}
+
+// Stage sets the standby detector, replacing any previously staged one.
+func (ds *DetectorSwap) Stage(standby *AnomalyDetector) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.standby = standby
+}
+
+// Standby returns the staged detector, or nil if none is staged.
+func (ds *DetectorSwap) Standby() *AnomalyDetector {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	return ds.standby
+}
+
+// Prime feeds historical points to the standby detector in order.
+// It returns the number of points accepted before any error.
+func (ds *DetectorSwap) Prime(points []DataPoint) (int, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.standby == nil {
+		return 0, ErrNoStandby
+	}
+
+	detections, err := ds.standby.ProcessBatch(points)
+	return len(detections), err
+}
+
+// PrimeFromLive seeds the standby detector with the live detector's current window.
+// It returns the number of values seeded.
+func (ds *DetectorSwap) PrimeFromLive() (int, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.standby == nil {
+		return 0, ErrNoStandby
+	}
+
+	values, lastTimestamp := ds.Live().Snapshot()
+	return ds.standby.Seed(values, lastTimestamp), nil
+}
+
+// Promote atomically swaps the standby in as the live detector and waits up
+// to drainTimeout for requests still using the previous detector to finish.
+// drained is false if the timeout elapsed first; the swap takes effect regardless.
+func (ds *DetectorSwap) Promote(drainTimeout time.Duration) (previous *AnomalyDetector, drained bool, err error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.standby == nil {
+		return nil, false, ErrNoStandby
+	}
+
+	old := ds.live.Swap(&detectorSlot{detector: ds.standby})
+	ds.standby = nil
+
+	deadline := time.Now().Add(drainTimeout)
+	for old.inflight.Load() > 0 {
+		if time.Now().After(deadline) {
+			return old.detector, false, nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	return old.detector, true, nil
+}
+
+// Snapshot returns a copy of the window values and the latest accepted timestamp.
+func (ad *AnomalyDetector) Snapshot() (values []float64, lastTimestamp int64) {
+	ad.mu.RLock()
+	defer ad.mu.RUnlock()
+
+	values = make([]float64, len(ad.dataWindow))
+	copy(values, ad.dataWindow)
+	return values, ad.lastTimestamp
+}
+
+// Seed replaces the window with the newest WindowSize values and rebuilds the
+// statistics. Subsequent points must be newer than lastTimestamp.
+// It returns the number of values kept.
+func (ad *AnomalyDetector) Seed(values []float64, lastTimestamp int64) int {
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+
+	if len(values) > ad.WindowSize {
+		values = values[len(values)-ad.WindowSize:]
+	}
+
+	ad.dataWindow = make([]float64, 0, ad.WindowSize)
+	ad.mean = 0.0
+	ad.m2 = 0.0
+	ad.evictions = 0
+	ad.lastTimestamp = lastTimestamp
+	ad.recent = nil
+
+	// recompute treats its argument as the point about to join the window
+	if n := len(values); n > 0 {
+		ad.dataWindow = append(ad.dataWindow, values[:n-1]...)
+		ad.recompute(values[n-1])
+		ad.dataWindow = append(ad.dataWindow, values[n-1])
+	}
+	ad.windowFull = time.Time{}
+	ad.markWindowFull()
+
+	return len(values)
+}
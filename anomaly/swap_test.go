@@ -0,0 +1,121 @@
+package anomaly
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestDetectorSwap_PromoteStandby tests staging, priming, and promoting a standby detector
+func TestDetectorSwap_PromoteStandby(t *testing.T) {
+	live := NewDetector(50, 3.0)
+	for i := 0; i < 30; i++ {
+		live.ProcessData(DataPoint{Timestamp: int64(1609459200 + i), Value: 100.0 + float64(i%5)})
+	}
+
+	ds := NewDetectorSwap(live)
+	if _, _, err := ds.Promote(time.Second); !errors.Is(err, ErrNoStandby) {
+		t.Errorf("Expected ErrNoStandby, got %v", err)
+	}
+
+	ds.Stage(NewDetector(50, 1.0))
+	seeded, err := ds.PrimeFromLive()
+	if err != nil {
+		t.Fatalf("Unexpected error priming standby: %v", err)
+	}
+	if seeded != 30 {
+		t.Errorf("Expected 30 seeded values, got %d", seeded)
+	}
+
+	count, mean, stdDev := ds.Standby().GetStats()
+	liveCount, liveMean, liveStdDev := live.GetStats()
+	if count != liveCount || math.Abs(mean-liveMean) > 1e-9 || math.Abs(stdDev-liveStdDev) > 1e-9 {
+		t.Errorf("Expected standby stats to match live: got %d/%.4f/%.4f, want %d/%.4f/%.4f",
+			count, mean, stdDev, liveCount, liveMean, liveStdDev)
+	}
+
+	previous, drained, err := ds.Promote(time.Second)
+	if err != nil {
+		t.Fatalf("Unexpected error promoting: %v", err)
+	}
+	if previous != live || !drained {
+		t.Errorf("Expected previous live detector drained, got drained=%v", drained)
+	}
+	if ds.Live().Threshold != 1.0 {
+		t.Errorf("Expected promoted detector with threshold 1.0, got %.1f", ds.Live().Threshold)
+	}
+	if ds.Standby() != nil {
+		t.Error("Expected standby to be cleared after promotion")
+	}
+
+	// Seeded ordering carries over: a timestamp before the live detector's latest is late
+	if _, _, err := ds.Live().ProcessData(DataPoint{Timestamp: 1609459228, Value: 100.0}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ds.Live().OutOfOrderCount() != 1 {
+		t.Errorf("Expected replayed timestamp flagged out of order, got %d", ds.Live().OutOfOrderCount())
+	}
+}
+
+// TestDetectorSwap_DrainsInFlight tests that Promote waits for requests on the previous detector
+func TestDetectorSwap_DrainsInFlight(t *testing.T) {
+	live := NewDetector(10, 3.0)
+	ds := NewDetectorSwap(live)
+
+	acquired, release := ds.Acquire()
+	if acquired != live {
+		t.Fatal("Expected Acquire to return the live detector")
+	}
+
+	ds.Stage(NewDetector(10, 2.0))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		time.Sleep(20 * time.Millisecond)
+		// The in-flight request completes on the detector it acquired
+		if _, _, err := acquired.ProcessData(DataPoint{Timestamp: 1, Value: 1.0}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		release()
+	}()
+
+	start := time.Now()
+	_, drained, err := ds.Promote(time.Second)
+	wg.Wait()
+	if err != nil || !drained {
+		t.Fatalf("Expected drained promotion, got drained=%v err=%v", drained, err)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Error("Expected Promote to wait for the in-flight request")
+	}
+	if count, _, _ := live.GetStats(); count != 1 {
+		t.Errorf("Expected in-flight point recorded on previous detector, got %d", count)
+	}
+
+	// A request that never releases does not block promotion forever
+	_, _ = ds.Acquire()
+	ds.Stage(NewDetector(10, 3.0))
+	if _, drained, _ := ds.Promote(10 * time.Millisecond); drained {
+		t.Error("Expected promotion to report an undrained previous detector")
+	}
+}
+
+// TestAnomalyDetector_SeedTrimsToWindow tests that Seed keeps only the newest WindowSize values
+func TestAnomalyDetector_SeedTrimsToWindow(t *testing.T) {
+	ad := NewDetector(3, 2.0)
+	if kept := ad.Seed([]float64{1, 2, 3, 4, 5}, 100); kept != 3 {
+		t.Errorf("Expected 3 values kept, got %d", kept)
+	}
+
+	values, lastTimestamp := ad.Snapshot()
+	if len(values) != 3 || values[0] != 3 || values[2] != 5 || lastTimestamp != 100 {
+		t.Errorf("Unexpected snapshot %v at %d", values, lastTimestamp)
+	}
+	if _, mean, _ := ad.GetStats(); mean != 4 {
+		t.Errorf("Expected mean 4, got %.3f", mean)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// apiKeyAuth checks bearer tokens against the configured API keys. Keys are
+// kept and compared as SHA-256 digests, so every comparison takes the same
+// time whatever the length of the key or how much of it matches.
+type apiKeyAuth struct {
+	digests [][sha256.Size]byte
+}
+
+// newAPIKeyAuth parses a comma-separated list of API keys. It returns nil,
+// disabling authentication, when the list holds no keys.
+func newAPIKeyAuth(keys string) *apiKeyAuth {
+	auth := &apiKeyAuth{}
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			auth.digests = append(auth.digests, sha256.Sum256([]byte(key)))
+		}
+	}
+	if len(auth.digests) == 0 {
+		return nil
+	}
+	return auth
+}
+
+// Valid reports whether token is one of the API keys. Every key is compared,
+// so the time taken does not reveal which one matched.
+func (k *apiKeyAuth) Valid(token string) bool {
+	digest := sha256.Sum256([]byte(token))
+	match := 0
+	for i := range k.digests {
+		match |= subtle.ConstantTimeCompare(digest[:], k.digests[i][:])
+	}
+	return match == 1
+}
+
+// authMiddleware requires an "Authorization: Bearer <key>" header carrying
+// one of the API keys, answering 401 and auditing the attempt otherwise. It
+// passes every request through when no keys are configured.
+func (a *App) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.auth == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		reason := ""
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case !ok || token == "":
+			reason = "missing_key"
+		case !a.auth.Valid(token):
+			reason = "invalid_key"
+		}
+		if reason != "" {
+			if a.auditor != nil {
+				a.auditor.LogAuthFailure(getClientIP(r), middleware.GetReqID(r.Context()), r.Method, r.URL.Path, reason)
+			}
+			log.Printf("Authentication failed for IP: %s, Path=%s, Reason=%s", getClientIP(r), r.URL.Path, reason)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="radm"`)
+			writeErrorResponse(w, http.StatusUnauthorized, "UNAUTHORIZED", "A valid API key is required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ingestAuthMiddleware applies authMiddleware to the ingest and feedback
+// endpoints when AUTH_INGEST is enabled.
+func (a *App) ingestAuthMiddleware(next http.Handler) http.Handler {
+	if !a.cfg.Auth.Ingest {
+		return next
+	}
+	return a.authMiddleware(next)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"internal/audit"
+)
+
+// serveWithKey sends a request through the router with the given bearer key;
+// an empty key sends no Authorization header.
+func serveWithKey(router http.Handler, method, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthMiddleware_ProtectsAdminRoutes(t *testing.T) {
+	app := setupTestComponents(t)
+	app.auth = newAPIKeyAuth("first-key, second-key")
+	router := app.setupRouter()
+
+	for _, tc := range []struct {
+		name       string
+		key        string
+		authorized bool
+	}{
+		{"No key", "", false},
+		{"Wrong key", "first-ke", false},
+		{"First key", "first-key", true},
+		{"Second key", "second-key", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Promoting without a staged standby answers 409 once past authentication
+			rec := serveWithKey(router, http.MethodPost, "/detector/standby/promote", tc.key, "")
+			if authorized := rec.Code != http.StatusUnauthorized; authorized != tc.authorized {
+				t.Errorf("Expected authorized=%t, got %d: %s", tc.authorized, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	rec := serveWithKey(router, http.MethodGet, "/config/env", "", "")
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+		t.Errorf("Expected 401 with a WWW-Authenticate challenge, got %d", rec.Code)
+	}
+
+	// Read-only status endpoints stay open
+	if rec := serveWithKey(router, http.MethodGet, "/healthz", "", ""); rec.Code != http.StatusOK {
+		t.Errorf("Expected /healthz open, got %d", rec.Code)
+	}
+
+	events := app.auditor.GetEvents(100)
+	failures := 0
+	for _, event := range events {
+		if event.Type == audit.EventSecurity && event.Component == "auth" {
+			failures++
+		}
+	}
+	if failures != 3 {
+		t.Errorf("Expected 3 audited authentication failures, got %d", failures)
+	}
+}
+
+func TestAuthMiddleware_IngestOptional(t *testing.T) {
+	body := fmt.Sprintf(`{"timestamp":%d,"value":42}`, time.Now().Unix())
+
+	app := setupTestComponents(t)
+	app.auth = newAPIKeyAuth("ingest-key")
+	if rec := serveWithKey(app.setupRouter(), http.MethodPost, "/api/v1/data/ingest", "", body); rec.Code != http.StatusOK {
+		t.Errorf("Expected ingest open without AUTH_INGEST, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	app.cfg.Auth.Ingest = true
+	router := app.setupRouter()
+	if rec := serveWithKey(router, http.MethodPost, "/api/v1/data/ingest", "", body); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for ingest without a key, got %d", rec.Code)
+	}
+	if rec := serveWithKey(router, http.MethodPost, "/api/v1/data/ingest", "ingest-key", body); rec.Code != http.StatusOK {
+		t.Errorf("Expected ingest with a valid key to succeed, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestNewAPIKeyAuth(t *testing.T) {
+	if auth := newAPIKeyAuth(" , "); auth != nil {
+		t.Error("Expected no keys to disable authentication")
+	}
+	auth := newAPIKeyAuth("alpha,beta")
+	if !auth.Valid("beta") || auth.Valid("gamma") || auth.Valid("") {
+		t.Error("Expected only the configured keys to be valid")
+	}
+}
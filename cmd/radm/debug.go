@@ -0,0 +1,62 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// debugRecentActions is the number of recent healing actions in the debug bundle.
+const debugRecentActions = 10
+
+// debugStateHandler serves a one-shot diagnostic bundle of every subsystem's
+// configuration and state. It answers 404 unless debug endpoints are enabled,
+// and requires the configured bearer token when one is set.
+func (a *App) debugStateHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.cfg.Server.DebugEndpoints {
+		writeErrorResponse(w, http.StatusNotFound, "DEBUG_DISABLED", "Debug endpoints are disabled")
+		return
+	}
+	if a.cfg.Server.DebugToken != "" {
+		expected := "Bearer " + a.cfg.Server.DebugToken
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) != 1 {
+			writeErrorResponse(w, http.StatusUnauthorized, "UNAUTHORIZED", "A valid debug token is required")
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(a.debugState())
+}
+
+// debugState assembles the diagnostic bundle from the existing accessors.
+// Secrets are excluded: the configuration is encoded through its JSON tags,
+// which omit signing keys and the debug token.
+func (a *App) debugState() map[string]interface{} {
+	state := map[string]interface{}{
+		"timestamp":      time.Now(),
+		"uptime_seconds": time.Since(a.startTime).Seconds(),
+		"config":         a.cfg,
+		"detector": map[string]interface{}{
+			"config": a.cfg.Detector,
+			"stats":  a.getDetectorStats(),
+		},
+		"rate_limiter":    a.getRateLimitStats(),
+		"load_shedder":    a.getLoadShedStats(),
+		"monetization":    a.getMonetizationStats(),
+		"rejection_stats": a.getRejectionStats(),
+		"red_team":        a.getRedTeamStats(),
+	}
+
+	if a.hypervisor != nil {
+		state["hypervisor"] = a.hypervisor.GenerateSBOHReport()
+	}
+	if a.blueTeam != nil {
+		state["blue_team"] = map[string]interface{}{
+			"stats":          a.blueTeam.GetHealingStats(),
+			"recent_actions": a.blueTeam.GetHealingHistory(debugRecentActions),
+		}
+	}
+	return state
+}
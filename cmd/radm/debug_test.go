@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"internal/blueteam"
+	"internal/ratelimit"
+	"internal/redteam"
+)
+
+func TestDebugStateHandler_BundlesEverySubsystem(t *testing.T) {
+	app := setupTestComponents(t)
+	app.redTeam = redteam.NewRedTeam()
+	app.redTeam.SetupDefaultFaults()
+	app.blueTeam = blueteam.NewBlueTeam(blueteam.DefaultConfig())
+	app.blueTeam.HealOnDemand(blueteam.IssueHighLatency, blueteam.StrategyCircuitBreaker)
+	app.rateLimit = ratelimit.NewRateLimiter(100, 10)
+	app.hypervisor.RecordDecision(2.5, true, 0.001)
+
+	app.cfg.Signing.Key = "signing-secret"
+	app.cfg.Server.DebugToken = "debug-secret"
+
+	get := func(authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/debug/state", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		app.debugStateHandler(rec, req)
+		return rec
+	}
+
+	if rec := get("Bearer debug-secret"); rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected 404 while debug endpoints are disabled, got %d", rec.Code)
+	}
+
+	app.cfg.Server.DebugEndpoints = true
+	if rec := get("Bearer wrong"); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for a wrong token, got %d", rec.Code)
+	}
+
+	rec := get("Bearer debug-secret")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); strings.Contains(body, "signing-secret") || strings.Contains(body, "debug-secret") {
+		t.Errorf("Debug bundle exposes a secret: %s", body)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("Failed to decode bundle: %v", err)
+	}
+	state := make(map[string]map[string]interface{})
+	for _, section := range []string{"detector", "hypervisor", "red_team", "blue_team", "rate_limiter"} {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(raw[section], &fields); err != nil || fields == nil {
+			t.Fatalf("Expected %s section, got %s", section, raw[section])
+		}
+		state[section] = fields
+	}
+
+	expected := map[string][]string{
+		"detector":     {"config", "stats"},
+		"hypervisor":   {"sample_count", "p95_latency_ms", "total_decisions"},
+		"red_team":     {"configured_faults", "fault_configs"},
+		"blue_team":    {"stats", "recent_actions"},
+		"rate_limiter": {"available_tokens", "capacity", "refill_rate"},
+	}
+	for section, keys := range expected {
+		for _, key := range keys {
+			if _, found := state[section][key]; !found {
+				t.Errorf("Expected %s.%s in the bundle", section, key)
+			}
+		}
+	}
+	if actions, _ := state["blue_team"]["recent_actions"].([]interface{}); len(actions) != 1 {
+		t.Errorf("Expected the healing action in recent_actions, got %v", state["blue_team"]["recent_actions"])
+	}
+	if state["hypervisor"]["sample_count"] != 1.0 {
+		t.Errorf("Expected 1 hypervisor sample, got %v", state["hypervisor"]["sample_count"])
+	}
+}
@@ -1,29 +1,39 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"anomaly"
 	"internal/audit"
 	"internal/blueteam"
 	"internal/config"
+	"internal/httpclient"
 	"internal/hypervisor"
 	"internal/monetization"
 	"internal/ratelimit"
 	"internal/redteam"
+	"internal/signing"
 	"internal/validation"
 )
 
@@ -35,32 +45,202 @@ type Response struct {
 	Value       float64 `json:"value"`
 	ProcessingNS int64   `json:"processing_ns"`
 	Price       float64 `json:"price,omitempty"`
+	Currency    string  `json:"currency,omitempty"` // ISO 4217 code of Price
+	Direction   int     `json:"direction"`
+	Severity    string  `json:"severity"`
+	DedupKey    string  `json:"dedup_key,omitempty"` // Shared by the anomalies of one episode of the series
+	Degraded    bool    `json:"degraded,omitempty"` // Decided by the static fallback, or optional work skipped past the response budget
+	DegradedReason string `json:"degraded_reason,omitempty"` // "static_fallback" or "response_budget"
+	Duplicate   bool    `json:"duplicate,omitempty"` // Decision already recorded, e.g. by a retried request; not billed again
+	Draining    bool    `json:"draining,omitempty"`  // Answered while the service shuts down; send later points to another instance
+	Windows     *anomaly.MultiWindowDetection `json:"windows,omitempty"` // Short/long window stats when enabled
+	Ratio       *anomaly.RatioDetection       `json:"ratio,omitempty"`   // Set when this point completed an aligned ratio pair
+	Metadata    map[string]string             `json:"metadata,omitempty"` // Echo of the client's metadata
+	Explain     *anomaly.Explanation          `json:"explain,omitempty"`  // Scoring breakdown when ?explain=true
 }
 
+// BatchResponse represents the batch ingestion API response.
+// Partial is set when processing stopped at Error.Index; Results covers the points before it.
+type BatchResponse struct {
+	Results   []Response     `json:"results"`
+	Aggregate BatchAggregate `json:"aggregate"`
+	Partial   bool           `json:"partial"`
+	Error     *BatchError    `json:"error,omitempty"`
+}
+
+// BatchAggregate summarizes the processed points of a batch.
+// Rejected counts the points not processed because the batch stopped early;
+// the z-score statistics cover processed points only and are zero when there are none.
+type BatchAggregate struct {
+	Count        int     `json:"count"`
+	Anomalies    int     `json:"anomalies"`
+	Rejected     int     `json:"rejected"`
+	MinZScore    float64 `json:"min_z_score"`
+	MaxZScore    float64 `json:"max_z_score"`
+	MeanZScore   float64 `json:"mean_z_score"`
+	TotalPrice   float64 `json:"total_price"`
+	ProcessingNS int64   `json:"processing_ns"`
+}
+
+// add folds one processed point into the aggregate.
+func (a *BatchAggregate) add(detection anomaly.Detection, price float64) {
+	if a.Count == 0 || detection.ZScore < a.MinZScore {
+		a.MinZScore = detection.ZScore
+	}
+	if a.Count == 0 || detection.ZScore > a.MaxZScore {
+		a.MaxZScore = detection.ZScore
+	}
+	a.Count++
+	if detection.IsAnomaly {
+		a.Anomalies++
+	}
+	a.TotalPrice += price
+	// A running mean cannot overflow on math.MaxFloat64 z-scores, as a sum can
+	a.MeanZScore += (detection.ZScore - a.MeanZScore) / float64(a.Count)
+}
+
+// BatchError identifies the point that stopped a batch.
+type BatchError struct {
+	Index   int    `json:"index"`
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Rejection reasons tracked by recordRejection.
+const (
+	rejectRateLimited      = "rate_limited"
+	rejectInvalidJSON      = "invalid_json"
+	rejectValidationFailed = "validation_failed"
+	rejectOversized        = "oversized"
+	rejectOutOfOrder       = "out_of_order"
+	rejectProcessingError  = "processing_error"
+	rejectLoadShed         = "load_shed"
+	rejectCircuitOpen      = "circuit_open"
+	rejectSeriesLimited    = "series_rate_limited"
+	rejectDraining         = "draining"
+)
+
+// rejectedRequests is registered with the default Prometheus registry, so it is
+// the one counter shared by every App in the process.
+var rejectedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "radm_rejected_requests_total",
+	Help: "Total ingest requests rejected, by reason.",
+}, []string{"reason"})
+
+// resetSharedState clears the process-wide rejection counter. Tests call it so
+// suites sharing the process start from the same baseline regardless of order.
+func resetSharedState() {
+	rejectedRequests.Reset()
+}
+
+// newRejectionCounts returns a zeroed counter for each rejection reason.
+func newRejectionCounts() map[string]*atomic.Int64 {
+	return map[string]*atomic.Int64{
+		rejectRateLimited:      new(atomic.Int64),
+		rejectInvalidJSON:      new(atomic.Int64),
+		rejectValidationFailed: new(atomic.Int64),
+		rejectOversized:        new(atomic.Int64),
+		rejectOutOfOrder:       new(atomic.Int64),
+		rejectProcessingError:  new(atomic.Int64),
+		rejectLoadShed:         new(atomic.Int64),
+		rejectCircuitOpen:      new(atomic.Int64),
+		rejectSeriesLimited:    new(atomic.Int64),
+		rejectDraining:         new(atomic.Int64),
+	}
+}
+
+// recordRejection counts a rejected ingest request under reason.
+func (a *App) recordRejection(reason string) {
+	a.recordRejections(reason, 1)
+}
+
+// recordRejections counts n points rejected for reason, such as the points of
+// a batch dropped from the first failing one on.
+func (a *App) recordRejections(reason string, n int) {
+	if n <= 0 {
+		return
+	}
+	rejectedRequests.WithLabelValues(reason).Add(float64(n))
+	if counter, exists := a.rejectionCounts[reason]; exists {
+		counter.Add(int64(n))
+	}
+}
+
+// SeriesModeRequest selects the detection mode of a series.
+type SeriesModeRequest struct {
+	Mode   string             `json:"mode"`
+	Params map[string]float64 `json:"params,omitempty"` // Mode-specific parameters, e.g. percent_threshold
+}
+
+// SeriesModeResponse confirms the detection mode now applied to a series.
+type SeriesModeResponse struct {
+	SeriesID string             `json:"series_id"`
+	Mode     string             `json:"mode"`
+	Params   map[string]float64 `json:"params,omitempty"`
+}
+
+// FeedbackRequest reports the ground truth of one decision, identified by
+// the decision ID of its PoV record and audit event ("TS-<timestamp>", or
+// "TS-<series>-<timestamp>" for a keyed series).
+type FeedbackRequest struct {
+	DecisionID string   `json:"decision_id"`
+	Outcome    string   `json:"outcome"`           // true_positive, false_positive, true_negative or false_negative
+	ZScore     *float64 `json:"z_score,omitempty"` // The decision's z_score; lets threshold tuning replay the feedback
+}
+
+// errBatchTooLarge is returned when a batch exceeds the configured maximum size.
+var errBatchTooLarge = errors.New("batch exceeds maximum size")
+
 // ErrorResponse represents an error response.
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	RetryAfterMS int64 `json:"retry_after_ms,omitempty"` // Set on rate-limit rejections
 }
 
-var (
-	detector    *anomaly.AnomalyDetector
-	monTracker  *monetization.MonetizationTracker
-	validator   *validation.DataPointValidator
-	rateLimit   *ratelimit.RateLimiter
-	hypervisor  *hypervisor.Hypervisor
-	redTeam     *redteam.RedTeam
-	blueTeam    *blueteam.BlueTeam
-	healer      *blueteam.Healer
-	auditor     *audit.Auditor
-	cfg         *config.Config
-)
+// App holds every component the handlers depend on. Handlers and the router
+// are methods on App, so independent instances share no detector state.
+type App struct {
+	cfg              *config.Config
+	detector         *anomaly.AnomalyDetector
+	multiDetector    *anomaly.MultiDetector
+	monTracker       *monetization.MonetizationTracker
+	validator        *validation.DataPointValidator
+	units            *validation.UnitRegistry // Physical bounds per series; nil when none are declared
+	timestamps       *validation.TimestampGuard // Rejects timestamps that do not advance per series; nil when disabled
+	rateLimit        *ratelimit.RateLimiter
+	seriesLimit      *ratelimit.SeriesLimiter // Per-series processing cap; nil when disabled
+	hypervisor       *hypervisor.Hypervisor
+	redTeam          *redteam.RedTeam
+	blueTeam         *blueteam.BlueTeam
+	healer           *blueteam.Healer
+	auditor          *audit.Auditor
+	signer           *signing.Signer
+	fallbackDetector *anomaly.StaticThreshold
+	multiWindow      *anomaly.MultiWindowDetector
+	ratioDetector    *anomaly.RatioDetector
+	anomalies        *anomaly.AnomalyStore // Recent anomalies and open episodes, persisted when configured
+	decisions        *anomaly.DecisionLog  // Last decisions per series; nil when disabled
+	quality          *anomaly.QualityTracker // Client feedback on decisions; nil when disabled
+	tuner            *blueteam.ThresholdTuner // Tunes the detector threshold from feedback; nil when disabled
+	loadShedder      *ratelimit.LoadShedder
+	detectors        *anomaly.DetectorSwap // Live/standby slot; detector is the initial live detector
+	rejectionCounts  map[string]*atomic.Int64 // Mirrors rejectedRequests for the JSON /metrics endpoint
+	warmup           *warmupTracker           // Decisions suppressed while a series has fewer than MinSamples points
+	reloads          *reloadCoordinator       // Serializes configuration reloads from SIGHUP and the Blue Team
+	seriesWatchdog   *anomaly.SeriesWatchdog  // Alerts when an expected series stops reporting; nil when none are expected
+	sbohExporter     *hypervisor.SBOHExporter // Archives SBOH reports on an interval; nil when disabled
+	readiness        *readinessProbe          // Cached dependency checks behind /readyz; nil checks none
+	certs            *certReloader            // TLS certificate, reloaded on SIGHUP; nil when TLS is disabled
+	auth             *apiKeyAuth              // API keys required by admin routes; nil disables authentication
+	draining         chan struct{}            // Closed when shutdown begins so open streams end
+	startTime        time.Time
+}
 
 func main() {
 	// Load configuration
-	var err error
-	cfg, err = config.Load()
+	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
@@ -70,39 +250,196 @@ func main() {
 		log.Fatalf("Invalid configuration: %v", err)
 	}
 
+	// Initialize the shared outbound HTTP client used by all integrations
+	httpclient.Configure(httpclient.Config{
+		Timeout:             cfg.HTTPClient.Timeout,
+		DialTimeout:         cfg.HTTPClient.DialTimeout,
+		MaxIdleConns:        cfg.HTTPClient.MaxIdleConns,
+		MaxIdleConnsPerHost: cfg.HTTPClient.MaxIdleConnsPerHost,
+		IdleConnTimeout:     cfg.HTTPClient.IdleConnTimeout,
+	})
+
 	// Initialize components
-	initializeComponents()
+	app, err := newApp(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize components: %v", err)
+	}
 
 	// Setup HTTP server
-	router := setupRouter()
+	router := app.setupRouter()
 
-	// Setup graceful shutdown
-	setupGracefulShutdown(router)
+	server := newHTTPServer(cfg.Server, router)
+	if app.certs != nil {
+		server.TLSConfig = app.certs.TLSConfig()
+	}
+
+	// Setup graceful shutdown and configuration reload on SIGHUP
+	stopped := app.setupGracefulShutdown(server)
+	app.watchReloadSignal()
 
 	// Start server
-	serverAddr := cfg.Server.Host + ":" + cfg.Server.Port
-	log.Printf("Starting RADM server on %s", serverAddr)
+	log.Printf("Starting RADM server on %s", server.Addr)
 	log.Printf("Configuration: WindowSize=%d, Threshold=%.2f",
 		cfg.Detector.WindowSize, cfg.Detector.Threshold)
 
-	if err := http.ListenAndServe(serverAddr, router); err != nil && err != http.ErrServerClosed {
+	if app.certs != nil {
+		log.Printf("Serving HTTPS with certificate %s", cfg.Server.TLSCertFile)
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		log.Printf("WARNING: TLS is disabled; serving plaintext HTTP. Set SERVER_TLS_ENABLED=true in production")
+		err = server.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
 		log.Fatalf("Server failed to start: %v", err)
 	}
+
+	// ListenAndServe returns as soon as Shutdown begins; wait for records to be flushed
+	<-stopped
+}
+
+// newHTTPServer builds the HTTP server for handler with the configured
+// timeouts and header limit, so slow or oversized clients cannot hold
+// connections open indefinitely.
+func newHTTPServer(sc config.ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              sc.Host + ":" + sc.Port,
+		Handler:           handler,
+		ReadTimeout:       sc.ReadTimeout,
+		ReadHeaderTimeout: sc.ReadHeaderTimeout,
+		WriteTimeout:      sc.WriteTimeout,
+		IdleTimeout:       sc.IdleTimeout,
+		MaxHeaderBytes:    sc.MaxHeaderBytes,
+	}
 }
 
-// initializeComponents initializes all the core components.
-func initializeComponents() {
+// newApp initializes all the core components from cfg and starts their
+// background routines. The routines are started only once every step that
+// can fail has succeeded, so an error leaves nothing running.
+func newApp(cfg *config.Config) (_ *App, err error) {
+	a := &App{cfg: cfg, rejectionCounts: newRejectionCounts(), warmup: newWarmupTracker(),
+		draining: make(chan struct{}), auth: newAPIKeyAuth(cfg.Auth.Keys), startTime: time.Now()}
+
+	// The PoV tracker and auditor start their writers on construction
+	defer func() {
+		if err == nil {
+			return
+		}
+		if a.monTracker != nil {
+			a.monTracker.Close()
+		}
+		if a.auditor != nil {
+			a.auditor.Close()
+		}
+	}()
+
 	// Initialize anomaly detector
-	detector = anomaly.NewDetector(cfg.Detector.WindowSize, cfg.Detector.Threshold)
+	orderPolicy, err := anomaly.ParseOrderPolicy(cfg.Detector.OrderPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid detector configuration: %w", err)
+	}
+	a.detector, err = newConfiguredDetector(cfg.Detector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid detector configuration: %w", err)
+	}
+	a.detectors = anomaly.NewDetectorSwap(a.detector)
+
+	// Initialize static threshold fallback for degraded operation
+	if cfg.Detector.FallbackEnabled {
+		a.fallbackDetector = anomaly.NewStaticThreshold(cfg.Detector.FallbackMin, cfg.Detector.FallbackMax)
+	}
+
+	// Initialize short/long window detection for drift
+	if cfg.Detector.ShortWindowSize > 0 {
+		a.multiWindow = anomaly.NewMultiWindowDetector(cfg.Detector.ShortWindowSize, cfg.Detector.LongWindowSize,
+			cfg.Detector.Threshold, cfg.Detector.DivergencePercent)
+	}
+
+	// Initialize derived ratio detection (e.g., errors/requests)
+	if cfg.Detector.RatioNumerator != "" {
+		a.ratioDetector = anomaly.NewRatioDetector(cfg.Detector.RatioNumerator, cfg.Detector.RatioDenominator,
+			cfg.Detector.WindowSize, cfg.Detector.Threshold)
+	}
+
+	// Initialize per-series detectors for keyed ingestion
+	a.multiDetector = anomaly.NewMultiDetector(cfg.Detector.WindowSize, cfg.Detector.Threshold, cfg.Detector.MaxSeries)
+	a.multiDetector.OrderPolicy = orderPolicy
+	a.multiDetector.ReorderBuffer = cfg.Detector.ReorderBuffer
+	a.multiDetector.WarningMultiplier = cfg.Detector.WarningMultiplier
+	a.multiDetector.CriticalMultiplier = cfg.Detector.CriticalMultiplier
+	a.multiDetector.MinStdDev = cfg.Detector.MinStdDev
+	a.multiDetector.MinSamples = cfg.Detector.MinSamples
+	a.multiDetector.Mode = a.detector.Mode
+	a.multiDetector.PercentThreshold = a.detector.PercentThreshold
+	a.multiDetector.SeasonPeriod = a.detector.SeasonPeriod
+	a.multiDetector.SeasonBuckets = a.detector.SeasonBuckets
+	if cfg.Detector.SeriesModes != "" {
+		if err := a.applySeriesModes(cfg.Detector); err != nil {
+			return nil, fmt.Errorf("invalid series modes: %w", err)
+		}
+	}
+
+	// Initialize the recent-anomaly store, restoring state saved before a restart
+	a.anomalies, err = anomaly.NewAnomalyStore(cfg.Detector.AnomalyHistorySize, cfg.Detector.AnomalyStateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore anomaly state: %w", err)
+	}
+	a.anomalies.SetDedupKeyPrefix(cfg.Detector.DedupKeyPrefix)
+	if cfg.Detector.RecentDecisions > 0 {
+		a.decisions = anomaly.NewDecisionLog(cfg.Detector.RecentDecisions, cfg.Detector.MaxSeries)
+	}
+	if cfg.Detector.FeedbackWindow > 0 {
+		a.quality = anomaly.NewQualityTracker(cfg.Detector.FeedbackWindow)
+	}
+
+	// Watch the series that must keep reporting
+	if cfg.Detector.ExpectedSeries != "" {
+		expected, err := anomaly.ParseExpectedSeries(cfg.Detector.ExpectedSeries, cfg.Detector.SeriesFreshness)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expected series: %w", err)
+		}
+		a.seriesWatchdog = anomaly.NewSeriesWatchdog(expected)
+	}
 
 	// Initialize monetization tracker
 	if cfg.Monetization.Enabled {
+		tiers, err := monetization.ParseTiers(cfg.Monetization.Tiers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid monetization tiers: %w", err)
+		}
 		monConfig := monetization.Config{
 			BasePrice:            cfg.Monetization.BasePrice,
 			ComplexityMultiplier: cfg.Monetization.ComplexityMultiplier,
 			OutputFile:           cfg.Monetization.OutputFile,
+			MaxRecords:           cfg.Monetization.MaxRecords,
+			FallbackPrice:        cfg.Monetization.FallbackPrice,
+			WarmupPricing:        cfg.Monetization.WarmupPricing,
+			WarmupPrice:          cfg.Monetization.WarmupPrice,
+			Currency:             cfg.Monetization.Currency,
+			RoundingDP:           cfg.Monetization.RoundingDP,
+			DedupWindow:          cfg.Monetization.DedupWindow,
+			Tiers:                tiers,
+			BillingPeriod:        cfg.Monetization.BillingPeriod,
+			AnomalyRateRecords:   cfg.Monetization.AnomalyRateRecords,
+			AnomalyRateWindow:    cfg.Monetization.AnomalyRateWindow,
+		}
+		a.monTracker = monetization.NewTracker(monConfig)
+		a.monTracker.SetPriceFallbackHandler(a.auditPriceFallback)
+	}
+
+	// Initialize response signer for non-repudiation of decisions
+	if cfg.Signing.Enabled {
+		previousKeys, err := signing.ParsePreviousKeys(cfg.Signing.PreviousKeys)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous signing keys: %w", err)
+		}
+		a.signer, err = signing.NewSigner(signing.Config{
+			KeyID:        cfg.Signing.KeyID,
+			Key:          cfg.Signing.Key,
+			PreviousKeys: previousKeys,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize response signer: %w", err)
 		}
-		monTracker = monetization.NewTracker(monConfig)
 	}
 
 	// Initialize validator
@@ -114,41 +451,245 @@ func initializeComponents() {
 			MinTimestamp:  cfg.Validation.MinTimestamp,
 			AllowedSource: cfg.Validation.AllowedSource,
 		}
-		validator = validation.NewDataPointValidator(valConfig)
+		a.validator = validation.NewDataPointValidator(valConfig)
+	}
+
+	// Initialize per-series physical unit constraints
+	if cfg.Validation.SeriesUnits != "" {
+		units, err := validation.ParseSeriesUnits(cfg.Validation.SeriesUnits)
+		if err != nil {
+			return nil, fmt.Errorf("invalid series units: %w", err)
+		}
+		a.units, err = validation.NewUnitRegistry(units)
+		if err != nil {
+			return nil, fmt.Errorf("invalid series units: %w", err)
+		}
+	}
+
+	// Initialize per-series timestamp ordering for strictly time-ordered streams
+	if cfg.Validation.TimestampOrder != "" {
+		order, err := validation.ParseTimestampOrder(cfg.Validation.TimestampOrder)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp order: %w", err)
+		}
+		a.timestamps = validation.NewTimestampGuard(order)
 	}
 
 	// Initialize rate limiter
 	if cfg.RateLimit.Enabled {
-		rateLimit = ratelimit.NewRateLimiter(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.BurstSize)
+		a.rateLimit = ratelimit.NewRateLimiter(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.BurstSize)
+	}
+	if cfg.RateLimit.SeriesPerSecond > 0 {
+		a.seriesLimit = ratelimit.NewSeriesLimiter(cfg.RateLimit.SeriesPerSecond, cfg.RateLimit.SeriesBurst)
 	}
 
 	// Initialize hypervisor (Protocol ζ-Hypervisor)
 	hypConfig := hypervisor.DefaultConfig()
-	hypervisorInstance := hypervisor.NewHypervisor(hypConfig)
+	percentiles, err := hypervisor.ParsePercentiles(cfg.Hypervisor.Percentiles)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hypervisor percentiles: %w", err)
+	}
+	hypConfig.Percentiles = percentiles
+	hypConfig.SampleRate = cfg.Hypervisor.SampleRate
+	hypConfig.SlowThresholdMS = cfg.Hypervisor.SlowThresholdMS
+	hypConfig.WindowDuration = cfg.Hypervisor.WindowDuration
+	hypConfig.A4MinDecisions = cfg.Hypervisor.A4MinDecisions
+	a.hypervisor = hypervisor.NewHypervisor(hypConfig)
+
+	// Archive SBOH reports for compliance review
+	if cfg.Hypervisor.ExportDir != "" {
+		a.sbohExporter, err = hypervisor.NewSBOHExporter(a.hypervisor, cfg.Hypervisor.ExportDir, cfg.Hypervisor.ExportInterval)
+		if err != nil {
+			return nil, err
+		}
+		a.sbohExporter.SetPushURL(cfg.Hypervisor.ExportURL)
+	}
 
-	// Initialize Red Team (Protocol β-RedTeam)
-	redTeamInstance := redteam.NewRedTeam()
-	redTeamInstance.SetupDefaultFaults()
-	redTeamInstance.StartFaultCleanupRoutine()
+	// Initialize adaptive load shedding against the hypervisor's live P95
+	if cfg.LoadShed.Enabled {
+		a.loadShedder, err = ratelimit.NewLoadShedder(ratelimit.ShedderConfig{
+			SLOMS:          cfg.LoadShed.SLOMS,
+			StartRatio:     cfg.LoadShed.StartRatio,
+			MaxProbability: cfg.LoadShed.MaxProbability,
+			Curve:          cfg.LoadShed.Curve,
+		}, func() float64 { return a.hypervisor.P95LatencyMS() })
+		if err != nil {
+			return nil, fmt.Errorf("invalid load shedding configuration: %w", err)
+		}
+	}
 
 	// Initialize Auditor for comprehensive compliance verification
 	auditConfig := audit.DefaultConfig()
-	auditorInstance, err := audit.NewAuditor(auditConfig)
+	auditConfig.Format = cfg.Audit.Format
+	auditConfig.MaxFileBytes = cfg.Audit.MaxFileBytes
+	auditConfig.MaxBackups = cfg.Audit.MaxBackups
+	auditConfig.Async = cfg.Audit.Async
+	auditConfig.BufferSize = cfg.Audit.BufferSize
+	auditConfig.FlushInterval = cfg.Audit.FlushInterval
+	auditConfig.BatchSize = cfg.Audit.BatchSize
+	if cfg.Audit.BaseFields != "" {
+		auditConfig.BaseFields, err = audit.ParseBaseFields(cfg.Audit.BaseFields)
+		if err != nil {
+			return nil, fmt.Errorf("invalid audit base fields: %w", err)
+		}
+	}
+	a.auditor, err = audit.NewAuditor(auditConfig)
 	if err != nil {
-		log.Fatalf("Failed to initialize auditor: %v", err)
+		return nil, fmt.Errorf("failed to initialize auditor: %w", err)
+	}
+	if cfg.Audit.MaskSourceIP {
+		a.auditor.AddRedactor(audit.MaskSourceIP)
+	}
+
+	// Forward audit events and decisions to a SIEM over syslog
+	if cfg.Syslog.Address != "" {
+		sink, err := audit.NewSyslogSink(audit.SyslogConfig{
+			Network:  cfg.Syslog.Network,
+			Address:  cfg.Syslog.Address,
+			Facility: cfg.Syslog.Facility,
+			Timeout:  cfg.Syslog.Timeout,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid syslog configuration: %w", err)
+		}
+		if cfg.Syslog.BatchSize > 1 {
+			// Group events into one write per batch; Close flushes the rest on shutdown
+			a.auditor.AddSink(audit.NewBatchSink(sink, audit.BatchConfig{
+				MaxEvents:     cfg.Syslog.BatchSize,
+				FlushInterval: cfg.Syslog.BatchInterval,
+				MaxPending:    cfg.Syslog.BatchMaxPending,
+			}))
+		} else {
+			a.auditor.AddSink(sink)
+		}
 	}
 
 	// Initialize Blue Team for self-healing mechanisms (Protocol β-RedTeam/Blue Team)
 	blueTeamConfig := blueteam.DefaultConfig()
-	blueTeamInstance := blueteam.NewBlueTeam(blueTeamConfig)
-	blueTeamInstance.StartMonitoring()
+	blueTeamConfig.HistoryFile = cfg.BlueTeam.HistoryFile
+	blueTeamConfig.Breaker = blueteam.BreakerConfig{
+		FailureThreshold:  cfg.BlueTeam.BreakerFailureThreshold,
+		FailureWindow:     cfg.BlueTeam.BreakerFailureWindow,
+		OpenTimeout:       cfg.BlueTeam.BreakerOpenTimeout,
+		HalfOpenSuccesses: cfg.BlueTeam.BreakerHalfOpenSuccesses,
+	}
+	blueTeamConfig.HealCooldown = cfg.BlueTeam.HealCooldown
+	blueTeamConfig.FailureBackoff = cfg.BlueTeam.FailureBackoff
+	blueTeamConfig.MaxFailureBackoff = cfg.BlueTeam.MaxFailureBackoff
+	blueTeamConfig.MinSuccessRate = cfg.BlueTeam.MinSuccessRate
+	blueTeamConfig.MaxHeapBytes = uint64(cfg.BlueTeam.MaxHeapBytes)
+	blueTeamConfig.DryRun = cfg.BlueTeam.DryRun
+	a.blueTeam = blueteam.NewBlueTeam(blueTeamConfig)
+	a.reloads = newReloadCoordinator(cfg, a.applyReload)
+	a.blueTeam.SetDetectorReset(func() { a.liveDetector().Reset() })
+	a.blueTeam.SetConfigReload(func() error { return a.reloadConfig("blueteam") })
+	a.blueTeam.SetHealthMetrics(a.hypervisor)
 
 	// Initialize Blue Team Healer
-	healerInstance := blueteam.NewHealer(detector)
+	a.healer = blueteam.NewHealer(a.detector)
+
+	// Tune the detector threshold from client feedback
+	if cfg.BlueTeam.ThresholdTuning && a.quality != nil {
+		a.tuner, err = blueteam.NewThresholdTuner(a.healer, a.quality, blueteam.TunerConfig{
+			Interval:     cfg.BlueTeam.TuningInterval,
+			MinFeedback:  cfg.BlueTeam.TuningMinFeedback,
+			Step:         cfg.BlueTeam.TuningStep,
+			MinThreshold: cfg.BlueTeam.TuningMinThreshold,
+			MaxThreshold: cfg.BlueTeam.TuningMaxThreshold,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold tuning configuration: %w", err)
+		}
+		a.tuner.SetChangeHandler(a.auditThresholdChange)
+	}
+
+	// Load the TLS certificate; SIGHUP reloads it so certificates rotate without a restart
+	if cfg.Server.TLSEnabled {
+		a.certs, err = newCertReloader(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+	}
+
+	// Initialize Red Team (Protocol β-RedTeam)
+	a.redTeam = redteam.NewRedTeam()
+	a.redTeam.SetMaxFaultDuration(cfg.RedTeam.MaxFaultDuration)
+	a.redTeam.SetupDefaultFaults()
+	if cfg.RedTeam.CampaignFile != "" {
+		if err := a.redTeam.LoadCampaign(cfg.RedTeam.CampaignFile); err != nil {
+			return nil, fmt.Errorf("failed to load fault campaign: %w", err)
+		}
+	}
+
+	// Start background work last; nothing below can fail
+	a.redTeam.StartFaultCleanupRoutine()
+	a.blueTeam.StartMonitoring()
+	if a.seriesWatchdog != nil {
+		a.seriesWatchdog.Start(a.alertMissingSeries)
+	}
+	if a.sbohExporter != nil {
+		a.sbohExporter.Start()
+	}
+	if a.tuner != nil {
+		a.tuner.Start()
+	}
+
+	// Probe the files records are appended to, reusing results across rapid /readyz calls
+	a.readiness = newReadinessProbe(cfg.Server.ReadinessTimeout, cfg.Server.ReadinessCacheTTL)
+	if auditConfig.OutputFile != "" {
+		a.readiness.Add("audit", func() error { return probeAppendable(auditConfig.OutputFile) })
+	}
+	if a.monTracker != nil && cfg.Monetization.OutputFile != "" {
+		a.readiness.Add("monetization", func() error { return probeAppendable(cfg.Monetization.OutputFile) })
+	}
+
+	return a, nil
+}
+
+// newConfiguredDetector builds the primary detector from the detector configuration,
+// including its scoring mode.
+func newConfiguredDetector(dc config.DetectorConfig) (*anomaly.AnomalyDetector, error) {
+	orderPolicy, err := anomaly.ParseOrderPolicy(dc.OrderPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	ad := anomaly.NewDetector(dc.WindowSize, dc.Threshold)
+	ad.OrderPolicy = orderPolicy
+	ad.ReorderBuffer = dc.ReorderBuffer
+	ad.WarningMultiplier = dc.WarningMultiplier
+	ad.CriticalMultiplier = dc.CriticalMultiplier
+	ad.MinStdDev = dc.MinStdDev
+	ad.MinSamples = dc.MinSamples
+	if err := ad.ApplyMode(anomaly.Mode(dc.Mode), dc.ModeParams); err != nil {
+		return nil, err
+	}
+	return ad, nil
+}
+
+// applySeriesModes sets the configured per-series modes on the multi-detector,
+// passing each the global mode parameters it accepts.
+func (a *App) applySeriesModes(dc config.DetectorConfig) error {
+	modes, err := anomaly.ParseSeriesModes(dc.SeriesModes)
+	if err != nil {
+		return err
+	}
+	for seriesID, mode := range modes {
+		params := make(map[string]float64)
+		for _, name := range anomaly.ModeParams(mode) {
+			if value, exists := dc.ModeParams[name]; exists {
+				params[name] = value
+			}
+		}
+		if err := a.multiDetector.SetSeriesMode(seriesID, mode, params); err != nil {
+			return fmt.Errorf("series %q: %w", seriesID, err)
+		}
+	}
+	return nil
 }
 
 // setupRouter configures the HTTP router with all endpoints.
-func setupRouter() *chi.Mux {
+func (a *App) setupRouter() *chi.Mux {
 	r := chi.NewRouter()
 
 	// Global middleware
@@ -159,48 +700,156 @@ func setupRouter() *chi.Mux {
 
 	// Health check endpoint (Protocol β-RedTeam/Kubernetes)
 	r.Get("/healthz", healthCheckHandler)
-	r.Get("/readyz", readyCheckHandler)
+	r.Get("/readyz", a.readyCheckHandler)
 
 	// System endpoints (All Protocols)
-	r.Get("/metrics", metricsHandler)
-	r.Get("/sboh", sbohHandler)
-	r.Get("/redteam/status", redTeamStatusHandler)
-	r.Post("/redteam/fault/{type}", redTeamFaultHandler)
-	r.Get("/blueteam/status", blueTeamStatusHandler)
-	r.Post("/blueteam/heal/{type}", blueTeamHealHandler)
-	r.Get("/audit/events", auditEventsHandler)
-	r.Get("/audit/compliance", auditComplianceHandler)
-
-	// Main ingestion endpoint with rate limiting
-	r.With(rateLimitMiddleware).Post("/api/v1/data/ingest", ingestHandler)
+	r.Get("/metrics", a.metricsHandler)
+	r.Get("/sboh", a.sbohHandler)
+	r.Get("/monetization/reconcile", a.monetizationReconcileHandler)
+	r.Get("/monetization/rollup", a.monetizationRollupHandler)
+	r.Get("/redteam/status", a.redTeamStatusHandler)
+	r.Get("/blueteam/status", a.blueTeamStatusHandler)
+	r.Get("/audit/events", a.auditEventsHandler)
+	r.Get("/audit/compliance", a.auditComplianceHandler)
+	r.Get("/anomalies/recent", a.recentAnomaliesHandler)
+	r.Get("/openapi.json", openapiHandler)
+	r.Get("/debug/state", a.debugStateHandler)
+
+	// Admin endpoints that change the service's state require an API key
+	r.Group(func(r chi.Router) {
+		r.Use(a.authMiddleware)
+
+		r.Post("/redteam/fault/{type}", a.redTeamFaultHandler)
+		r.Post("/redteam/campaign", a.redTeamCampaignHandler)
+		r.Post("/blueteam/heal/{type}", a.blueTeamHealHandler)
+		r.Get("/config/env", a.configEnvHandler)
+
+		// Blue/green recalibration of the primary detector
+		r.Post("/detector/standby", a.stageStandbyHandler)
+		r.Post("/detector/standby/prime", a.primeStandbyHandler)
+		r.Post("/detector/standby/promote", a.promoteStandbyHandler)
+
+		// Per-series detection mode
+		r.Put("/series/{name}/mode", a.seriesModeHandler)
+	})
+	r.Get("/series/{name}/recent", a.seriesRecentHandler)
+
+	// Detection quality from client ground truth
+	r.With(a.ingestAuthMiddleware).Post("/api/v1/feedback", a.feedbackHandler)
+	r.Get("/api/v1/detector/quality", a.detectorQualityHandler)
+
+	// Main ingestion endpoint with rate limiting; rate limiting runs first so it also throttles key guessing
+	r.With(a.networkDelayMiddleware, a.rateLimitMiddleware, a.ingestAuthMiddleware, a.loadShedMiddleware, a.circuitBreakerMiddleware, a.drainMiddleware).Post("/api/v1/data/ingest", a.ingestHandler)
+	r.With(a.networkDelayMiddleware, a.rateLimitMiddleware, a.ingestAuthMiddleware, a.loadShedMiddleware, a.circuitBreakerMiddleware, a.drainMiddleware).Post("/api/v1/data/ingest/batch", a.batchIngestHandler)
+	r.With(a.networkDelayMiddleware, a.rateLimitMiddleware, a.ingestAuthMiddleware, a.loadShedMiddleware, a.circuitBreakerMiddleware, a.drainMiddleware).Post("/api/v1/data/stream", a.streamIngestHandler)
 
 	return r
 }
 
+// networkDelayMiddleware injects the Red Team's network delay fault at the
+// ingress boundary, before the request is handed off (Protocol β-RedTeam).
+func (a *App) networkDelayMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.redTeam != nil {
+			if delay := a.redTeam.InjectNetworkDelay(); delay > 0 && a.auditor != nil {
+				a.auditor.WithRequestID(a.correlationID(r)).LogFaultInjection("network_delay", true, delay)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // rateLimitMiddleware implements Protocol α-IngressGuard rate limiting.
-func rateLimitMiddleware(next http.Handler) http.Handler {
+func (a *App) rateLimitMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if rateLimit != nil {
-			allowed := rateLimit.Allow()
+		if a.rateLimit != nil {
+			allowed, retryAfter := a.rateLimit.AllowWithRetryAfter()
 			if !allowed {
 				// Audit rate limit violation
-				if auditorInstance != nil {
-					auditorInstance.LogRateLimit(false, getClientIP(r), middleware.GetReqID(r.Context()))
+				if a.auditor != nil {
+					a.auditor.LogRateLimit(false, getClientIP(r), middleware.GetReqID(r.Context()))
 				}
 				log.Printf("Rate limit exceeded for IP: %s", getClientIP(r))
-				writeErrorResponse(w, http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED",
-					"Rate limit exceeded. Please try again later.")
+				a.recordRejection(rejectRateLimited)
+				writeRateLimitResponse(w, retryAfter)
 				return
 			}
 			// Audit successful rate limit check
-			if auditorInstance != nil {
-				auditorInstance.LogRateLimit(true, getClientIP(r), middleware.GetReqID(r.Context()))
+			if a.auditor != nil {
+				a.auditor.LogRateLimit(true, getClientIP(r), middleware.GetReqID(r.Context()))
 			}
 		}
 		next.ServeHTTP(w, r)
 	})
 }
 
+// loadShedMiddleware sheds low-priority requests as P95 latency nears the SLO.
+// Requests marked "X-Priority: high" are never shed.
+func (a *App) loadShedMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.loadShedder != nil && !strings.EqualFold(r.Header.Get("X-Priority"), "high") && !a.loadShedder.Allow() {
+			log.Printf("Load shed request from IP: %s", getClientIP(r))
+			a.recordRejection(rejectLoadShed)
+			w.Header().Set("Retry-After", "1")
+			writeErrorResponse(w, http.StatusTooManyRequests, "LOAD_SHED",
+				"Latency budget is near its limit. Please retry later.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// circuitBreakerMiddleware rejects ingestion while the Blue Team's circuit
+// breaker is open, so a failing detector gets time to recover.
+func (a *App) circuitBreakerMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.blueTeam != nil && !a.blueTeam.CircuitBreaker().Allow() {
+			a.recordRejection(rejectCircuitOpen)
+			writeErrorResponse(w, http.StatusServiceUnavailable, "CIRCUIT_OPEN",
+				"Ingestion is paused while the service recovers. Please retry later.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// drainMiddleware rejects ingestion once shutdown has begun when the server
+// drain mode is "reject". In the default "indicate" mode requests are still
+// processed and their responses are marked "draining".
+func (a *App) drainMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.cfg.Server.DrainMode == "reject" && a.isDraining() {
+			a.recordRejection(rejectDraining)
+			writeErrorResponse(w, http.StatusServiceUnavailable, "DRAINING",
+				"The service is shutting down. Please retry against another instance.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isDraining reports whether shutdown has begun.
+func (a *App) isDraining() bool {
+	select {
+	case <-a.draining:
+		return true
+	default:
+		return false
+	}
+}
+
+// recordBreakerOutcome feeds the result of detection to the circuit breaker.
+func (a *App) recordBreakerOutcome(err error) {
+	if a.blueTeam == nil {
+		return
+	}
+	if err != nil {
+		a.blueTeam.CircuitBreaker().RecordFailure()
+	} else {
+		a.blueTeam.CircuitBreaker().RecordSuccess()
+	}
+}
+
 // Note: Allow method is implemented in the ratelimit package
 
 // healthCheckHandler handles health check requests.
@@ -210,198 +859,992 @@ func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // readyCheckHandler handles readiness check requests.
-func readyCheckHandler(w http.ResponseWriter, r *http.Request) {
+func (a *App) readyCheckHandler(w http.ResponseWriter, r *http.Request) {
 	// Check if all components are ready
-	if detector == nil {
+	if a.detector == nil {
 		w.WriteHeader(http.StatusServiceUnavailable)
 		w.Write([]byte("NOT_READY"))
 		return
 	}
 
+	// Dependency checks are cached, so rapid probes do not repeat their I/O
+	if a.readiness != nil {
+		if err := a.readiness.Check(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("NOT_READY"))
+			return
+		}
+	}
+
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("READY"))
 }
 
 // metricsHandler provides system metrics.
-func metricsHandler(w http.ResponseWriter, r *http.Request) {
+func (a *App) metricsHandler(w http.ResponseWriter, r *http.Request) {
 	stats := map[string]interface{}{
-		"detector_stats":     getDetectorStats(),
-		"rate_limit_stats":   getRateLimitStats(),
-		"monetization_stats": getMonetizationStats(),
-		"sboh_summary":       getSBOHSummary(),
-		"redteam_stats":      getRedTeamStats(),
-		"uptime_seconds":     time.Since(startTime).Seconds(),
+		"detector_stats":     a.getDetectorStats(),
+		"rate_limit_stats":   a.getRateLimitStats(),
+		"series_limit_stats": a.getSeriesLimitStats(),
+		"load_shed_stats":    a.getLoadShedStats(),
+		"monetization_stats": a.getMonetizationStats(),
+		"sboh_summary":       a.getSBOHSummary(),
+		"redteam_stats":      a.getRedTeamStats(),
+		"rejection_stats":    a.getRejectionStats(),
+		"warmup_stats":       a.getWarmupStats(),
+		"missing_series":     a.seriesWatchdog.Missing(),
+		"reload_stats":       a.getReloadStats(),
+		"uptime_seconds":     time.Since(a.startTime).Seconds(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(stats)
 }
 
+// configEnvHandler returns the effective configuration as KEY=value env lines, with secrets redacted.
+func (a *App) configEnvHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(a.cfg.DumpEnvRedacted()))
+}
+
 // sbohHandler provides comprehensive SBOH metrics (Protocol ζ-Hypervisor).
-func sbohHandler(w http.ResponseWriter, r *http.Request) {
-	if hypervisorInstance == nil {
+func (a *App) sbohHandler(w http.ResponseWriter, r *http.Request) {
+	if a.hypervisor == nil {
 		writeErrorResponse(w, http.StatusServiceUnavailable, "HYPERVISOR_UNAVAILABLE",
 			"Hypervisor not initialized")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(hypervisorInstance.GenerateSBOHReport())
+	json.NewEncoder(w).Encode(a.hypervisor.GenerateSBOHReport())
 }
 
 // ingestHandler handles data ingestion requests.
-func ingestHandler(w http.ResponseWriter, r *http.Request) {
+func (a *App) ingestHandler(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 
+	// Links the decision, PoV record, audit events and heals of this request
+	correlationID := a.correlationID(r)
+	auditor := a.auditor.WithRequestID(correlationID)
+
+	// Arrays are dispatched to batch processing when enabled
+	body := bufio.NewReader(r.Body)
+	if a.cfg.Server.AcceptArrays && peekJSONArray(body) {
+		a.ingestArray(w, r, body)
+		return
+	}
+
 	// Parse request body
 	var dp anomaly.DataPoint
-	if err := json.NewDecoder(r.Body).Decode(&dp); err != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON",
-			"Invalid JSON in request body")
+	if err := a.newIngestDecoder(body).Decode(&dp); err != nil {
+		a.recordRejection(rejectInvalidJSON)
+		writeDecodeError(w, err, "Invalid JSON in request body")
 		return
 	}
 
 	// 1. Input Validation (Now using validator/v10 via the module)
-	if err := validation.ValidateDataPoint(dp); err != nil {
+	if err := a.validateDataPoint(dp); err != nil {
 		// Example of triggering a Soft Patch on persistent validation failures
-		go hypervisor.TriggerHealing(healerInstance, "High validation failure rate detected", false)
+		go hypervisor.TriggerHealing(a.healer, "High validation failure rate detected", false)
+		a.recordRejection(rejectValidationFailed)
+		writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_FAILED", fmt.Sprintf("Schema Validation Failure: %v", err))
+		return
+	}
+
+	// Shed points of a series past its own rate, sparing the other series
+	if allowed, retryAfter := a.seriesLimit.Allow(dp.SeriesID); !allowed {
+		a.recordRejection(rejectSeriesLimited)
+		writeThrottleResponse(w, retryAfter, "SERIES_RATE_LIMITED",
+			fmt.Sprintf("Series %q exceeded its processing rate. Please try again later.", dp.SeriesID))
+		return
+	}
+
+	// The timestamp is committed only once the point is processed, so a shed
+	// or failed point can be retried
+	if err := a.timestamps.Check(dp.SeriesID, dp.Timestamp); err != nil {
+		a.recordRejection(rejectValidationFailed)
 		writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_FAILED", fmt.Sprintf("Schema Validation Failure: %v", err))
 		return
 	}
 
+	// Pin the live detector so a concurrent promotion cannot split this request
+	live, release := a.acquireDetector()
+	defer release()
+
 	// 2. Process Data (Wrapped by Hypervisor for A-2 latency tracking)
 	// The closure passed to ObserveExecution calls the core logic.
-	isAnomaly, zScore, err := hypervisorInstance.ObserveExecution(func() (bool, float64, error) {
-		// Inject processing faults (Protocol β-RedTeam)
-		if redTeamInstance != nil {
-			if err := redTeamInstance.InjectProcessingFault(); err != nil {
+	explain := r.URL.Query().Get("explain") == "true"
+	// Keyed series are scored by the multi-detector, which has no checkpoints
+	verify := a.cfg.Audit.Determinism != "off" && (dp.SeriesID == "" || a.multiDetector == nil)
+	var detection anomaly.Detection
+	var explanation *anomaly.Explanation
+	var checkpoint *anomaly.AnomalyDetector
+	isAnomaly, zScore, err := a.hypervisor.ObserveExecution(func() (bool, float64, error) {
+		// Inject resource and processing faults (Protocol β-RedTeam)
+		if a.redTeam != nil {
+			a.redTeam.InjectMemoryPressure()
+			a.redTeam.InjectCPUStress()
+			if err := a.redTeam.InjectProcessingFault(); err != nil {
 				// Audit fault injection
-				if auditorInstance != nil {
-					auditorInstance.LogFaultInjection("processing", true, time.Second*30)
+				if auditor != nil {
+					auditor.LogFaultInjection("processing", true, time.Second*30)
 				}
 				return false, 0.0, err
 			}
 		}
 
-		return detector.ProcessData(dp)
+		// Route keyed data points to their own series window
+		var err error
+		switch {
+		case explain && dp.SeriesID != "" && a.multiDetector != nil:
+			var e anomaly.Explanation
+			detection, e, err = a.multiDetector.ProcessDataExplained(dp.SeriesID, dp)
+			explanation = &e
+		case verify:
+			// The replay against the checkpoint also yields the explanation
+			detection, checkpoint, err = live.ProcessDataCheckpointed(dp)
+		case explain:
+			var e anomaly.Explanation
+			detection, e, err = live.ProcessDataExplained(dp)
+			explanation = &e
+		case dp.SeriesID != "" && a.multiDetector != nil:
+			detection, err = a.multiDetector.ProcessDataDetailed(dp.SeriesID, dp)
+		default:
+			detection, err = live.ProcessDataDetailed(dp)
+		}
+		return detection.IsAnomaly, detection.ZScore, err
+	})
+
+	if errors.Is(err, anomaly.ErrOutOfOrder) {
+		a.recordRejection(rejectOutOfOrder)
+		writeErrorResponse(w, http.StatusConflict, "OUT_OF_ORDER", err.Error())
+		return
+	}
+	a.recordBreakerOutcome(err)
+
+	// Degrade to the static threshold so detection continues while the detector heals
+	degraded := false
+	if err != nil && a.fallbackDetector != nil {
+		go hypervisor.TriggerHealing(a.healer, fmt.Sprintf("Critical algorithm error: %v", err), true)
+		if auditor != nil {
+			auditor.LogDegradation("anomaly_detector", err.Error(), map[string]interface{}{
+				"fallback":  "static_threshold",
+				"timestamp": dp.Timestamp,
+				"min":       a.fallbackDetector.Min,
+				"max":       a.fallbackDetector.Max,
+			})
+		}
+		detection = a.fallbackDetector.Evaluate(dp)
+		isAnomaly, zScore, err = detection.IsAnomaly, detection.ZScore, nil
+		degraded = true
+		explanation = nil // The static fallback has no window to explain
+	}
+
+	if err != nil {
+		// Example of triggering a Hard Reversion on critical error
+		go hypervisor.TriggerHealing(a.healer, fmt.Sprintf("Critical algorithm error: %v", err), true)
+		a.recordRejection(rejectProcessingError)
+		writeErrorResponse(w, http.StatusInternalServerError, "PROCESSING_ERROR",
+			"Internal processing error")
+		return
+	}
+	a.timestamps.Commit(dp.SeriesID, dp.Timestamp)
+
+	// Past the response budget, return the decision made so far and skip optional work
+	overBudget := a.overResponseBudget(time.Since(start))
+
+	// Short/long window comparison catches slow drifts the primary window absorbs
+	var windows *anomaly.MultiWindowDetection
+	if a.multiWindow != nil && dp.SeriesID == "" && !degraded && !overBudget {
+		if result, mwErr := a.multiWindow.ProcessData(dp); mwErr == nil {
+			windows = &result
+			isAnomaly = isAnomaly || result.IsAnomaly
+		}
+	}
+
+	// Score the derived ratio once both source series report this timestamp
+	var ratio *anomaly.RatioDetection
+	if a.ratioDetector != nil && a.ratioDetector.Matches(dp.SeriesID) && !degraded && !overBudget {
+		result, ready, ratioErr := a.ratioDetector.ProcessData(dp)
+		if ratioErr != nil {
+			log.Printf("Ratio detection skipped: TS=%d, %v", dp.Timestamp, ratioErr)
+		} else if ready {
+			ratio = &result
+		}
+	}
+
+	// Track anomaly episodes so a recovery is signalled once per episode
+	dedupKey := a.recordAnomaly(dp, detection)
+
+	// Metadata is recorded redacted; the response echoes it as sent
+	recordedMetadata := a.redactMetadata(dp.Metadata)
+
+	// Audit decision
+	if auditor != nil {
+		decisionID := decisionIDFor(dp)
+		auditor.LogDecisionWithDedupKey(decisionID, isAnomaly, zScore, time.Since(start).Nanoseconds(), getClientIP(r), detection.Severity, dedupKey, recordedMetadata)
+	}
+
+	// Verify determinism (Axiom A-1): replaying the point against the state it
+	// was scored on must reproduce the same detection
+	if checkpoint != nil && !degraded {
+		e, verifyErr := checkpoint.VerifyDeterminism(dp, detection)
+		if explain {
+			explanation = &e
+		}
+
+		inputBytes, _ := json.Marshal(dp)
+		inputHash := fmt.Sprintf("%x", sha256.Sum256(inputBytes))
+		outputHash := anomaly.DetectionHash(detection)
+
+		checkpointHash := ""
+		if verifyErr != nil {
+			log.Printf("Determinism violation detected: %v", verifyErr)
+			// Create checkpoint for recovery
+			recovery := live.CreateCheckpoint(inputHash, outputHash)
+			checkpointHash = recovery.StateHash
+			log.Printf("Created recovery checkpoint: %s", recovery.StateHash[:16]+"...")
+		}
+		a.auditDeterminism(auditor, inputHash, outputHash, verifyErr, checkpointHash)
+	}
+
+	// Record monetization data (Axiom A-4 Hook)
+	latencyNS := time.Since(start).Nanoseconds()
+
+	// Inject latency faults (Protocol β-RedTeam)
+	originalLatency := time.Duration(latencyNS)
+	if a.redTeam != nil {
+		injectedLatency := a.redTeam.InjectLatency(originalLatency)
+		if injectedLatency != originalLatency {
+			// Audit latency fault injection
+			if auditor != nil {
+				auditor.LogFaultInjection("latency", true, time.Minute*2)
+			}
+		}
+		latencyNS = injectedLatency.Nanoseconds()
+	}
+	overBudget = overBudget || a.overResponseBudget(time.Duration(latencyNS))
+
+	latencyMS := float64(latencyNS) / 1e6
+	price := 0.0
+	success := err == nil
+
+	// The decision is billed regardless; its compliance audit events are optional work
+	complianceAuditor := auditor
+	if overBudget {
+		complianceAuditor = nil
+		log.Printf("Response budget %v exceeded: TS=%d, Latency=%dns; optional work skipped",
+			a.cfg.Server.ResponseBudget, dp.Timestamp, latencyNS)
+	}
+
+	billed := true // False for a decision already recorded, which was billed the first time
+	if a.monTracker != nil {
+		decisionID := decisionIDFor(dp)
+		price, billed = a.recordMonetization(decisionID, correlationID, dp.Value, latencyNS, detection, zScore, isAnomaly, recordedMetadata)
+	}
+
+	// Record in hypervisor for SBOH tracking (Protocol ζ-Hypervisor)
+	if a.hypervisor != nil && billed {
+		a.hypervisor.RecordDecisionWithWarmup(latencyMS, success, price, isAnomaly, detection.WarmingUp)
+
+		// Self-healing: Check for compliance violations and trigger healing
+		if a.blueTeam != nil {
+			// Check Axiom A-2 compliance (P95 latency ≤ 50ms)
+			if !a.hypervisor.IsAxiomA2Compliant() {
+				// Audit compliance failure
+				if complianceAuditor != nil {
+					complianceAuditor.LogCompliance("γ-Axiomatic Control", "A-2",
+						false, map[string]interface{}{"p95_latency_ms": latencyMS})
+				}
+				// Trigger self-healing
+				a.blueTeam.HealOnDemandWithCorrelation(blueteam.IssueHighLatency, blueteam.StrategyCircuitBreaker, correlationID)
+			} else {
+				// Audit compliance success
+				if complianceAuditor != nil {
+					complianceAuditor.LogCompliance("γ-Axiomatic Control", "A-2",
+						true, map[string]interface{}{"p95_latency_ms": latencyMS})
+				}
+			}
+
+			// Check Axiom A-4 compliance (monetization accuracy)
+			if !a.hypervisor.IsAxiomA4Compliant() {
+				// Audit compliance failure
+				if complianceAuditor != nil {
+					complianceAuditor.LogCompliance("ζ-Hypervisor", "A-4",
+						false, map[string]interface{}{"monetization_accuracy": price})
+				}
+				// Trigger self-healing
+				a.blueTeam.HealOnDemandWithCorrelation(blueteam.IssueComplianceFailure, blueteam.StrategyConfigReload, correlationID)
+			} else {
+				// Audit compliance success
+				if complianceAuditor != nil {
+					complianceAuditor.LogCompliance("ζ-Hypervisor", "A-4",
+						true, map[string]interface{}{"monetization_accuracy": price})
+				}
+			}
+		}
+	}
+
+	// Prepare response (Protocol δ-EgressGuard)
+	response := Response{
+		IsAnomaly:    isAnomaly,
+		ZScore:       zScore,
+		Timestamp:    dp.Timestamp,
+		Value:        dp.Value,
+		ProcessingNS: latencyNS,
+		Price:        price,
+		Currency:     a.currency(),
+		Direction:    detection.Direction,
+		Severity:     detection.Severity,
+		DedupKey:     dedupKey,
+		Degraded:     degraded || overBudget,
+		Duplicate:    !billed,
+		Draining:     a.isDraining(),
+		Windows:      windows,
+		Ratio:        ratio,
+		Metadata:     dp.Metadata,
+		Explain:      explanation,
+	}
+
+	switch {
+	case degraded:
+		response.DegradedReason = degradedStaticFallback
+	case overBudget:
+		response.DegradedReason = degradedResponseBudget
+		response.Price, response.Currency = 0, "" // Pricing detail is optional; the decision is still billed
+	}
+	newOutputRounder(a.cfg.Detector.OutputDecimals).Response(&response)
+
+	a.writeSignedJSON(w, response)
+
+	log.Printf("Processed: TS=%d, Value=%.2f, Anomaly=%t, ZScore=%.3f, Latency=%dns",
+		dp.Timestamp, dp.Value, isAnomaly, zScore, latencyNS)
+}
+
+// Reasons a response is marked degraded.
+const (
+	degradedStaticFallback = "static_fallback" // Decided by the static fallback detector
+	degradedResponseBudget = "response_budget" // Optional work skipped past the response budget
+)
+
+// overResponseBudget reports whether a request that has taken elapsed is past
+// the soft response budget, so only the essential decision should be returned.
+func (a *App) overResponseBudget(elapsed time.Duration) bool {
+	return a.cfg.Server.ResponseBudget > 0 && elapsed > a.cfg.Server.ResponseBudget
+}
+
+// auditDeterminism records a determinism verification outcome at the
+// configured audit level: violations only, every check, or none.
+func (a *App) auditDeterminism(auditor *audit.Auditor, inputHash, outputHash string, err error, checkpoint string) {
+	if auditor == nil {
+		return
+	}
+	switch a.cfg.Audit.Determinism {
+	case "off":
+		return
+	case "all":
+	default:
+		if err == nil {
+			return
+		}
+	}
+	auditor.LogDeterminism(inputHash, outputHash, err, checkpoint)
+}
+
+// peekJSONArray reports whether the next non-whitespace byte in body is '['.
+// Leading whitespace is consumed; the bracket itself is left unread.
+func peekJSONArray(body *bufio.Reader) bool {
+	for {
+		b, err := body.Peek(1)
+		if err != nil {
+			return false
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			body.ReadByte()
+		default:
+			return b[0] == '['
+		}
+	}
+}
+
+// ingestArray handles a JSON array posted to the single-point ingest endpoint,
+// responding with an array of Response to match the request shape.
+// A partial result is signalled with the X-Batch-Partial and X-Batch-Error-Index headers.
+func (a *App) ingestArray(w http.ResponseWriter, r *http.Request, body *bufio.Reader) {
+	points, err := decodeBatch(a.newIngestDecoder(body), a.cfg.Server.MaxBatchSize)
+	if errors.Is(err, errBatchTooLarge) {
+		a.recordRejection(rejectOversized)
+		writeErrorResponse(w, http.StatusRequestEntityTooLarge, "BATCH_TOO_LARGE",
+			fmt.Sprintf("Batch exceeds maximum of %d data points", a.cfg.Server.MaxBatchSize))
+		return
+	}
+	if err != nil {
+		a.recordRejection(rejectInvalidJSON)
+		writeDecodeError(w, err, "Invalid JSON in request body")
+		return
+	}
+
+	batch := a.processBatch(r, points)
+	if batch.Partial && batch.Error != nil {
+		w.Header().Set("X-Batch-Partial", "true")
+		w.Header().Set("X-Batch-Error-Index", strconv.Itoa(batch.Error.Index))
+	}
+
+	a.writeSignedJSON(w, batch.Results)
+}
+
+// batchIngestHandler handles batch ingestion of a JSON array of data points.
+func (a *App) batchIngestHandler(w http.ResponseWriter, r *http.Request) {
+	points, err := decodeBatch(a.newIngestDecoder(r.Body), a.cfg.Server.MaxBatchSize)
+	if errors.Is(err, errBatchTooLarge) {
+		a.recordRejection(rejectOversized)
+		writeErrorResponse(w, http.StatusRequestEntityTooLarge, "BATCH_TOO_LARGE",
+			fmt.Sprintf("Batch exceeds maximum of %d data points", a.cfg.Server.MaxBatchSize))
+		return
+	}
+	if err != nil {
+		a.recordRejection(rejectInvalidJSON)
+		writeDecodeError(w, err, "Request body must be a JSON array of data points")
+		return
+	}
+
+	batch := a.processBatch(r, points)
+	if r.URL.Query().Get("summary_only") == "true" {
+		a.writeSignedJSON(w, batch.Aggregate)
+		return
+	}
+	a.writeSignedJSON(w, batch)
+}
+
+// newIngestDecoder returns a decoder for ingest payloads that rejects
+// unknown fields when strict JSON decoding is enabled.
+func (a *App) newIngestDecoder(body io.Reader) *json.Decoder {
+	dec := json.NewDecoder(body)
+	if a.cfg.Server.StrictJSON {
+		dec.DisallowUnknownFields()
+	}
+	return dec
+}
+
+// writeDecodeError writes a 400 for a payload that failed to decode, naming the
+// offending field when strict decoding rejected an unknown one.
+func writeDecodeError(w http.ResponseWriter, err error, message string) {
+	const unknownFieldPrefix = "json: unknown field "
+	if field, found := strings.CutPrefix(err.Error(), unknownFieldPrefix); found {
+		writeErrorResponse(w, http.StatusBadRequest, "UNKNOWN_FIELD",
+			fmt.Sprintf("Unexpected field %s in request body", field))
+		return
+	}
+	writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", message)
+}
+
+// decodeBatch streams a JSON array of data points, failing once maxSize is exceeded
+// so oversized batches are rejected without buffering them in full.
+func decodeBatch(dec *json.Decoder, maxSize int) ([]anomaly.DataPoint, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("expected a JSON array of data points")
+	}
+
+	var points []anomaly.DataPoint
+	for dec.More() {
+		if len(points) >= maxSize {
+			return nil, errBatchTooLarge
+		}
+		var dp anomaly.DataPoint
+		if err := dec.Decode(&dp); err != nil {
+			return nil, err
+		}
+		points = append(points, dp)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// processBatch validates, scores and bills points in order.
+// Processing stops at the first point that fails validation or detection,
+// returning the results gathered so far as a partial response. The failing
+// point and those after it are counted as rejected for the failure's reason.
+func (a *App) processBatch(r *http.Request, points []anomaly.DataPoint) BatchResponse {
+	start := time.Now()
+	correlationID := a.correlationID(r)
+	auditor := a.auditor.WithRequestID(correlationID)
+	response := BatchResponse{Results: make([]Response, 0, len(points))}
+
+	// 1. Input Validation: only the prefix before the first invalid point is processed
+	valid := points
+	timestamps := a.timestamps.Batch()
+	rejectReason := ""
+	for i, dp := range points {
+		if err := a.validateDataPoint(dp); err != nil {
+			rejectReason = rejectValidationFailed
+			valid = points[:i]
+			response.Partial = true
+			response.Error = &BatchError{
+				Index:   i,
+				Error:   "VALIDATION_FAILED",
+				Message: fmt.Sprintf("Schema Validation Failure: %v", err),
+			}
+			break
+		}
+		if allowed, _ := a.seriesLimit.Allow(dp.SeriesID); !allowed {
+			rejectReason = rejectSeriesLimited
+			valid = points[:i]
+			response.Partial = true
+			response.Error = &BatchError{
+				Index:   i,
+				Error:   "SERIES_RATE_LIMITED",
+				Message: fmt.Sprintf("Series %q exceeded its processing rate", dp.SeriesID),
+			}
+			break
+		}
+		if err := timestamps.Check(dp.SeriesID, dp.Timestamp); err != nil {
+			rejectReason = rejectValidationFailed
+			valid = points[:i]
+			response.Partial = true
+			response.Error = &BatchError{
+				Index:   i,
+				Error:   "VALIDATION_FAILED",
+				Message: fmt.Sprintf("Schema Validation Failure: %v", err),
+			}
+			break
+		}
+	}
+	// The failing point and the points after it are dropped for its reason
+	a.recordRejections(rejectReason, len(points)-len(valid))
+
+	// Inject resource and processing faults (Protocol β-RedTeam)
+	if a.redTeam != nil {
+		a.redTeam.InjectMemoryPressure()
+		a.redTeam.InjectCPUStress()
+		if err := a.redTeam.InjectProcessingFault(); err != nil {
+			if auditor != nil {
+				auditor.LogFaultInjection("processing", true, time.Second*30)
+			}
+			a.recordBreakerOutcome(err)
+			response.Partial = true
+			response.Error = &BatchError{Index: 0, Error: "PROCESSING_ERROR", Message: "Internal processing error"}
+			response.Aggregate.Rejected = len(points)
+			a.recordRejections(rejectProcessingError, len(valid))
+			return response
+		}
+	}
+
+	// 2. Process Data: unkeyed batches share one lock acquisition on the global detector
+	detections, err := a.detectBatch(valid)
+	if !errors.Is(err, anomaly.ErrOutOfOrder) {
+		a.recordBreakerOutcome(err)
+	}
+	if err != nil {
+		response.Partial = true
+		response.Error = &BatchError{Index: len(detections), Error: "PROCESSING_ERROR", Message: err.Error()}
+		reason := rejectProcessingError
+		if errors.Is(err, anomaly.ErrOutOfOrder) {
+			response.Error.Error = "OUT_OF_ORDER"
+			reason = rejectOutOfOrder
+		}
+		a.recordRejections(reason, len(valid)-len(detections))
+	}
+
+	// 3. Monetization and SBOH tracking, with processing time shared evenly across points
+	latencyNS := time.Since(start).Nanoseconds()
+	if len(detections) > 0 {
+		latencyNS /= int64(len(detections))
+	}
+	latencyMS := float64(latencyNS) / 1e6
+
+	for i, detection := range detections {
+		dp := valid[i]
+		a.timestamps.Commit(dp.SeriesID, dp.Timestamp)
+		decisionID := decisionIDFor(dp)
+
+		recordedMetadata := a.redactMetadata(dp.Metadata)
+		dedupKey := a.recordAnomaly(dp, detection)
+
+		price, billed := 0.0, true
+		if a.monTracker != nil {
+			price, billed = a.recordMonetization(decisionID, correlationID, dp.Value, latencyNS, detection, detection.ZScore, detection.IsAnomaly, recordedMetadata)
+		}
+
+		if a.hypervisor != nil && billed {
+			a.hypervisor.RecordDecisionWithWarmup(latencyMS, true, price, detection.IsAnomaly, detection.WarmingUp)
+		}
+
+		if auditor != nil {
+			auditor.LogDecisionWithDedupKey(decisionID, detection.IsAnomaly, detection.ZScore, latencyNS, getClientIP(r), detection.Severity, dedupKey, recordedMetadata)
+		}
+
+		response.Results = append(response.Results, Response{
+			IsAnomaly:    detection.IsAnomaly,
+			ZScore:       detection.ZScore,
+			Timestamp:    dp.Timestamp,
+			Value:        dp.Value,
+			ProcessingNS: latencyNS,
+			Price:        price,
+			Currency:     a.currency(),
+			Direction:    detection.Direction,
+			Severity:     detection.Severity,
+			DedupKey:     dedupKey,
+			Duplicate:    !billed,
+			Draining:     a.isDraining(),
+			Metadata:     dp.Metadata,
+		})
+
+		response.Aggregate.add(detection, price)
+	}
+
+	response.Aggregate.Rejected = len(points) - response.Aggregate.Count
+	response.Aggregate.ProcessingNS = time.Since(start).Nanoseconds()
+	newOutputRounder(a.cfg.Detector.OutputDecimals).Batch(&response)
+
+	log.Printf("Processed batch: Points=%d, Anomalies=%d, Partial=%t, Latency=%dns",
+		response.Aggregate.Count, response.Aggregate.Anomalies, response.Partial, response.Aggregate.ProcessingNS)
+
+	return response
+}
+
+// validateDataPoint applies schema validation, the series' unit constraints and the
+// metadata size caps. The timestamp order is checked separately, past the series
+// limiter, and committed once the point is processed.
+func (a *App) validateDataPoint(dp anomaly.DataPoint) error {
+	if err := validation.ValidateDataPoint(dp); err != nil {
+		return err
+	}
+	if err := a.units.Validate(dp.SeriesID, dp.Value); err != nil {
+		return err
+	}
+	if len(dp.Metadata) > 0 {
+		if err := validation.ValidateMetadata(dp.Metadata, a.cfg.Metadata.MaxKeys, a.cfg.Metadata.MaxLength); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordMonetization records the PoV record of a decision and returns its
+// price. isAnomaly is the final verdict, which may differ from detection's
+// when other detectors also scored the point. Decisions made during warm-up
+// are recorded as such, so they can be billed at the warm-up price. A
+// decision already recorded, e.g. by a retried request, is not billed again:
+// recorded is false and the price 0.
+func (a *App) recordMonetization(decisionID, correlationID string, value float64, latencyNS int64, detection anomaly.Detection, zScore float64, isAnomaly bool, metadata map[string]string) (price float64, recorded bool) {
+	if detection.WarmingUp {
+		return a.monTracker.RecordWarmupDecision(decisionID, correlationID, value, latencyNS, zScore, isAnomaly, metadata)
+	}
+	return a.monTracker.RecordDecisionWithCorrelation(decisionID, correlationID, value, latencyNS, zScore, isAnomaly, metadata)
+}
+
+// currency returns the ISO 4217 code of decision prices, or "" when
+// monetization is disabled.
+func (a *App) currency() string {
+	if a.monTracker == nil {
+		return ""
+	}
+	return a.monTracker.Currency()
+}
+
+// decisionIDFor returns the ID a point's decision is audited, billed and
+// deduplicated under. Keyed series are part of it, so points of different
+// series sharing a timestamp are distinct decisions.
+func decisionIDFor(dp anomaly.DataPoint) string {
+	if dp.SeriesID == "" {
+		return fmt.Sprintf("TS-%d", dp.Timestamp)
+	}
+	return fmt.Sprintf("TS-%s-%d", dp.SeriesID, dp.Timestamp)
+}
+
+// recordAnomaly tracks a scored point in the warm-up counts, the recent
+// decisions and the anomaly store, logging the recovery of a series whose
+// anomaly episode has ended. It returns the dedup key of the episode an
+// anomaly belongs to, or "" for a normal point.
+func (a *App) recordAnomaly(dp anomaly.DataPoint, detection anomaly.Detection) string {
+	if a.warmup != nil {
+		a.warmup.Record(dp.SeriesID, detection)
+	}
+	a.decisions.Record(dp, detection)
+	if a.seriesWatchdog.Seen(dp.SeriesID) {
+		log.Printf("Expected series reporting again: Series=%q", dp.SeriesID)
+	}
+	if a.anomalies == nil {
+		return ""
+	}
+	if episode := a.anomalies.Record(dp, detection); episode != nil {
+		log.Printf("Anomaly episode recovered: Series=%q, Start=%d, End=%d, Anomalies=%d, PeakZScore=%.3f, DedupKey=%s",
+			episode.SeriesID, episode.StartTimestamp, dp.Timestamp, episode.Count, episode.PeakZScore, episode.DedupKey)
+	}
+	if !detection.IsAnomaly {
+		return ""
+	}
+	dedupKey, _ := a.anomalies.DedupKey(dp.SeriesID)
+	return dedupKey
+}
+
+// alertMissingSeries logs and audits an expected series that stopped reporting.
+func (a *App) alertMissingSeries(m anomaly.MissingSeries) {
+	log.Printf("Expected series missing: Series=%q, LastSeen=%v, Freshness=%v", m.SeriesID, m.LastSeen, m.Freshness)
+	if a.auditor == nil {
+		return
+	}
+	details := map[string]interface{}{
+		"series_id": m.SeriesID,
+		"freshness": m.Freshness.String(),
+	}
+	if !m.LastSeen.IsZero() {
+		details["last_seen"] = m.LastSeen
+	}
+	a.auditor.LogDegradation("series_watchdog", "expected series stopped reporting", details)
+}
+
+// auditThresholdChange audits a threshold change made by the tuner.
+func (a *App) auditThresholdChange(change blueteam.ThresholdChange) {
+	if a.auditor == nil {
+		return
+	}
+	a.auditor.LogConfigReload("threshold_tuning", 1, nil, map[string]interface{}{
+		"threshold_from":   change.From,
+		"threshold_to":     change.To,
+		"reason":           change.Reason,
+		"replay_f1_before": change.ReplayF1Before,
+		"replay_f1_after":  change.ReplayF1After,
+	})
+}
+
+// auditPriceFallback audits a decision billed at the fallback price because
+// its computed price was not finite.
+func (a *App) auditPriceFallback(decisionID string, processingNS int64, zScore float64) {
+	if a.auditor == nil {
+		return
+	}
+	a.auditor.LogDegradation("monetization", "non-finite price replaced by fallback price", map[string]interface{}{
+		"decision_id":   decisionID,
+		"processing_ns": processingNS,
+		"z_score":       fmt.Sprint(zScore), // NaN and Inf cannot be encoded as JSON numbers
+	})
+}
+
+// redactMetadata returns the copy of metadata safe to persist in audit and PoV records.
+func (a *App) redactMetadata(metadata map[string]string) map[string]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+	return validation.RedactMetadata(metadata, validation.ParseRedactKeys(a.cfg.Metadata.RedactKeys))
+}
+
+// detectBatch scores points in order, routing keyed points to their own series.
+// On error it returns the detections for the points before the failing one.
+func (a *App) detectBatch(points []anomaly.DataPoint) ([]anomaly.Detection, error) {
+	live, release := a.acquireDetector()
+	defer release()
+
+	keyed := false
+	for _, dp := range points {
+		if dp.SeriesID != "" {
+			keyed = true
+			break
+		}
+	}
+
+	if !keyed || a.multiDetector == nil {
+		return live.ProcessBatch(points)
+	}
+
+	detections := make([]anomaly.Detection, 0, len(points))
+	for i, dp := range points {
+		var detection anomaly.Detection
+		var err error
+		if dp.SeriesID != "" {
+			detection, err = a.multiDetector.ProcessDataDetailed(dp.SeriesID, dp)
+		} else {
+			detection, err = live.ProcessDataDetailed(dp)
+		}
+		if err != nil {
+			return detections, fmt.Errorf("batch point %d: %w", i, err)
+		}
+		detections = append(detections, detection)
+	}
+
+	return detections, nil
+}
+
+// acquireDetector pins the live primary detector for the duration of a request.
+// The returned release func must be called when the request is done with it.
+func (a *App) acquireDetector() (*anomaly.AnomalyDetector, func()) {
+	if a.detectors == nil {
+		return a.detector, func() {}
+	}
+	return a.detectors.Acquire()
+}
+
+// liveDetector returns the primary detector currently serving requests.
+func (a *App) liveDetector() *anomaly.AnomalyDetector {
+	if a.detectors == nil {
+		return a.detector
+	}
+	return a.detectors.Live()
+}
+
+// stageStandbyHandler builds a standby detector from the window_size and
+// threshold query parameters, defaulting to the live detector's values.
+func (a *App) stageStandbyHandler(w http.ResponseWriter, r *http.Request) {
+	if a.detectors == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "NOT_READY", "Detector not initialized")
+		return
+	}
+
+	live := a.detectors.Live()
+	windowSize, threshold := live.WindowSize, live.Threshold
+
+	if ws := r.URL.Query().Get("window_size"); ws != "" {
+		v, err := strconv.Atoi(ws)
+		if err != nil || v <= 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "window_size must be a positive integer")
+			return
+		}
+		windowSize = v
+	}
+	if th := r.URL.Query().Get("threshold"); th != "" {
+		v, err := strconv.ParseFloat(th, 64)
+		if err != nil || v <= 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "threshold must be a positive number")
+			return
+		}
+		threshold = v
+	}
+
+	standby := anomaly.NewDetector(windowSize, threshold)
+	standby.OrderPolicy = live.OrderPolicy
+	standby.ReorderBuffer = live.ReorderBuffer
+	standby.WarningMultiplier = live.WarningMultiplier
+	standby.CriticalMultiplier = live.CriticalMultiplier
+	standby.MinStdDev = live.MinStdDev
+	standby.MinSamples = live.MinSamples
+	standby.Mode = live.Mode
+	standby.PercentThreshold = live.PercentThreshold
+	standby.SeasonPeriod = live.SeasonPeriod
+	standby.SeasonBuckets = live.SeasonBuckets
+	a.detectors.Stage(standby)
+
+	log.Printf("Standby detector staged: WindowSize=%d, Threshold=%.2f", windowSize, threshold)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":      "staged",
+		"window_size": windowSize,
+		"threshold":   threshold,
 	})
+}
+
+// seriesModeHandler switches the detection mode of one series for its
+// subsequent points.
+func (a *App) seriesModeHandler(w http.ResponseWriter, r *http.Request) {
+	if a.multiDetector == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "NOT_READY", "Detector not initialized")
+		return
+	}
+
+	var req SeriesModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid series mode request")
+		return
+	}
+
+	seriesID := chi.URLParam(r, "name")
+	if err := a.multiDetector.SetSeriesMode(seriesID, anomaly.Mode(req.Mode), req.Params); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_MODE", err.Error())
+		return
+	}
+
+	log.Printf("Series detection mode changed: Series=%q, Mode=%s", seriesID, a.multiDetector.SeriesMode(seriesID))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(SeriesModeResponse{
+		SeriesID: seriesID,
+		Mode:     string(a.multiDetector.SeriesMode(seriesID)),
+		Params:   req.Params,
+	})
+}
 
-	if err != nil {
-		// Example of triggering a Hard Reversion on critical error
-		go hypervisor.TriggerHealing(healerInstance, fmt.Sprintf("Critical algorithm error: %v", err), true)
-		writeErrorResponse(w, http.StatusInternalServerError, "PROCESSING_ERROR",
-			"Internal processing error")
+// primeStandbyHandler primes the standby detector with a JSON array of
+// historical data points, or with the live detector's window if the body is empty.
+func (a *App) primeStandbyHandler(w http.ResponseWriter, r *http.Request) {
+	if a.detectors == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "NOT_READY", "Detector not initialized")
 		return
 	}
 
-	// Audit decision
-	if auditorInstance != nil {
-		decisionID := fmt.Sprintf("TS-%d", dp.Timestamp)
-		auditorInstance.LogDecision(decisionID, isAnomaly, zScore, latencyNS, getClientIP(r))
+	var points []anomaly.DataPoint
+	if err := json.NewDecoder(r.Body).Decode(&points); err != nil && !errors.Is(err, io.EOF) {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON array of data points")
+		return
 	}
 
-	// Create output hash for determinism verification
-	outputData := map[string]interface{}{
-		"is_anomaly":   isAnomaly,
-		"z_score":      zScore,
-		"timestamp":    dp.Timestamp,
-		"value":        dp.Value,
-		"processing_ns": time.Since(start).Nanoseconds(),
+	var primed int
+	var err error
+	source := "request"
+	if len(points) == 0 {
+		source = "live"
+		primed, err = a.detectors.PrimeFromLive()
+	} else {
+		primed, err = a.detectors.Prime(points)
 	}
-	outputBytes, _ := json.Marshal(outputData)
-	outputHash := fmt.Sprintf("%x", sha256.Sum256(outputBytes))
 
-	// Verify determinism (Axiom A-1)
-	if err := detector.VerifyDeterminism(inputHash, outputHash); err != nil {
-		log.Printf("Determinism violation detected: %v", err)
-		// Create checkpoint for recovery
-		checkpoint := detector.CreateCheckpoint(inputHash, outputHash)
-		log.Printf("Created recovery checkpoint: %s", checkpoint.StateHash[:16]+"...")
+	if errors.Is(err, anomaly.ErrNoStandby) {
+		writeErrorResponse(w, http.StatusConflict, "NO_STANDBY", err.Error())
+		return
 	}
-
-	// Record monetization data (Axiom A-4 Hook)
-	latencyNS := time.Since(start).Nanoseconds()
-
-	// Inject latency faults (Protocol β-RedTeam)
-	originalLatency := time.Duration(latencyNS)
-	if redTeamInstance != nil {
-		injectedLatency := redTeamInstance.InjectLatency(originalLatency)
-		if injectedLatency != originalLatency {
-			// Audit latency fault injection
-			if auditorInstance != nil {
-				auditorInstance.LogFaultInjection("latency", true, time.Minute*2)
-			}
-		}
-		latencyNS = injectedLatency.Nanoseconds()
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "PRIME_FAILED",
+			fmt.Sprintf("Primed %d points before failure: %v", primed, err))
+		return
 	}
 
-	latencyMS := float64(latencyNS) / 1e6
-	price := 0.0
-	success := err == nil
-
-	if monTracker != nil {
-		decisionID := fmt.Sprintf("TS-%d", dp.Timestamp)
-		monTracker.RecordDecision(decisionID, value, latencyNS, zScore)
-		price = monTracker.CalculatePrice(latencyNS, zScore)
-	}
+	log.Printf("Standby detector primed: Points=%d, Source=%s", primed, source)
 
-	// Record in hypervisor for SBOH tracking (Protocol ζ-Hypervisor)
-	if hypervisorInstance != nil {
-		hypervisorInstance.RecordDecision(latencyMS, success, price)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "primed",
+		"primed": primed,
+		"source": source,
+	})
+}
 
-		// Self-healing: Check for compliance violations and trigger healing
-		if blueTeamInstance != nil {
-			// Check Axiom A-2 compliance (P95 latency ≤ 50ms)
-			if !hypervisorInstance.IsAxiomA2Compliant() {
-				// Audit compliance failure
-				if auditorInstance != nil {
-					auditorInstance.LogCompliance("γ-Axiomatic Control", "A-2",
-						false, map[string]interface{}{"p95_latency_ms": latencyMS})
-				}
-				// Trigger self-healing
-				blueTeamInstance.HealOnDemand(blueteam.IssueHighLatency, blueteam.StrategyCircuitBreaker)
-			} else {
-				// Audit compliance success
-				if auditorInstance != nil {
-					auditorInstance.LogCompliance("γ-Axiomatic Control", "A-2",
-						true, map[string]interface{}{"p95_latency_ms": latencyMS})
-				}
-			}
+// promoteStandbyHandler atomically swaps the standby detector in as the live one
+// and waits for in-flight requests on the previous detector to drain.
+func (a *App) promoteStandbyHandler(w http.ResponseWriter, r *http.Request) {
+	if a.detectors == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "NOT_READY", "Detector not initialized")
+		return
+	}
 
-			// Check Axiom A-4 compliance (monetization accuracy)
-			if !hypervisorInstance.IsAxiomA4Compliant() {
-				// Audit compliance failure
-				if auditorInstance != nil {
-					auditorInstance.LogCompliance("ζ-Hypervisor", "A-4",
-						false, map[string]interface{}{"monetization_accuracy": price})
-				}
-				// Trigger self-healing
-				blueTeamInstance.HealOnDemand(blueteam.IssueComplianceFailure, blueteam.StrategyConfigReload)
-			} else {
-				// Audit compliance success
-				if auditorInstance != nil {
-					auditorInstance.LogCompliance("ζ-Hypervisor", "A-4",
-						true, map[string]interface{}{"monetization_accuracy": price})
-				}
-			}
-		}
+	_, drained, err := a.detectors.Promote(a.cfg.Detector.DrainTimeout)
+	if errors.Is(err, anomaly.ErrNoStandby) {
+		writeErrorResponse(w, http.StatusConflict, "NO_STANDBY", err.Error())
+		return
 	}
 
-	// Prepare response (Protocol δ-EgressGuard)
-	response := Response{
-		IsAnomaly:    isAnomaly,
-		ZScore:       zScore,
-		Timestamp:    dp.Timestamp,
-		Value:        dp.Value,
-		ProcessingNS: latencyNS,
-		Price:        price,
+	live := a.detectors.Live()
+	if a.healer != nil {
+		a.healer.Detector = live
 	}
+	if !drained {
+		log.Printf("Standby promoted before previous detector drained (timeout %s)", a.cfg.Detector.DrainTimeout)
+	}
+	log.Printf("Standby detector promoted: WindowSize=%d, Threshold=%.2f", live.WindowSize, live.Threshold)
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
-
-	log.Printf("Processed: TS=%d, Value=%.2f, Anomaly=%t, ZScore=%.3f, Latency=%dns",
-		dp.Timestamp, dp.Value, isAnomaly, zScore, latencyNS)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":      "promoted",
+		"drained":     drained,
+		"window_size": live.WindowSize,
+		"threshold":   live.Threshold,
+	})
 }
 
 // writeErrorResponse writes a standardized error response.
@@ -416,6 +1859,49 @@ func writeErrorResponse(w http.ResponseWriter, statusCode int, code, message str
 	json.NewEncoder(w).Encode(errorResp)
 }
 
+// writeRateLimitResponse writes a 429 telling the client when a token will be available.
+// Retry-After is in whole seconds, rounded up; retry_after_ms carries the precise wait.
+func writeRateLimitResponse(w http.ResponseWriter, retryAfter time.Duration) {
+	writeThrottleResponse(w, retryAfter, "RATE_LIMIT_EXCEEDED", "Rate limit exceeded. Please try again later.")
+}
+
+// writeThrottleResponse writes a 429 with the given code and message, telling
+// the client when to retry.
+func writeThrottleResponse(w http.ResponseWriter, retryAfter time.Duration, code, message string) {
+	retrySeconds := int64((retryAfter + time.Second - 1) / time.Second)
+	if retrySeconds < 1 {
+		retrySeconds = 1
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.FormatInt(retrySeconds, 10))
+	w.WriteHeader(http.StatusTooManyRequests)
+
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Error:        code,
+		Message:      message,
+		RetryAfterMS: int64((retryAfter + time.Millisecond - 1) / time.Millisecond),
+	})
+}
+
+// writeSignedJSON writes v as JSON, signing the exact body bytes when a signer is configured.
+func (a *App) writeSignedJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "ENCODING_ERROR",
+			"Failed to encode response")
+		return
+	}
+	body = append(body, '\n')
+
+	w.Header().Set("Content-Type", "application/json")
+	if a.signer != nil {
+		w.Header().Set(signing.HeaderSignature, a.signer.Sign(body))
+		w.Header().Set(signing.HeaderKeyID, a.signer.KeyID())
+	}
+	w.Write(body)
+}
+
 // getClientIP extracts the client IP address from the request.
 func getClientIP(r *http.Request) string {
 	// Check X-Forwarded-For header first
@@ -442,111 +1928,214 @@ func getClientIP(r *http.Request) string {
 }
 
 // getDetectorStats returns current detector statistics.
-func getDetectorStats() map[string]interface{} {
-	if detector == nil {
+func (a *App) getDetectorStats() map[string]interface{} {
+	if a.detector == nil {
 		return nil
 	}
-	count, mean, stdDev := detector.GetStats()
-	return map[string]interface{}{
-		"window_size": detector.WindowSize,
-		"threshold":   detector.Threshold,
+	live := a.liveDetector()
+	count, mean, stdDev := live.GetStats()
+	stats := map[string]interface{}{
+		"window_size": live.WindowSize,
+		"threshold":   live.Threshold,
 		"count":       count,
 		"mean":        mean,
 		"std_dev":     stdDev,
+		"out_of_order": live.OutOfOrderCount(),
+		"effective_std_dev": live.EffectiveStdDev(),
+		"min_std_dev":       live.MinStdDev,
+	}
+	if since, full := live.WindowFullSince(); full {
+		stats["window_full_since"] = since
+	}
+	if a.detectors != nil {
+		stats["standby_staged"] = a.detectors.Standby() != nil
+	}
+	if a.multiDetector != nil {
+		stats["series_count"] = a.multiDetector.SeriesCount()
+		stats["max_series"] = a.multiDetector.MaxSeries
 	}
+	if a.ratioDetector != nil {
+		dropped, zeroDenominators, pending := a.ratioDetector.GetStats()
+		stats["ratio_dropped"] = dropped
+		stats["ratio_zero_denominators"] = zeroDenominators
+		stats["ratio_pending"] = pending
+	}
+	return stats
 }
 
 // getRateLimitStats returns current rate limiter statistics.
-func getRateLimitStats() map[string]interface{} {
-	if rateLimit == nil {
+func (a *App) getRateLimitStats() map[string]interface{} {
+	if a.rateLimit == nil {
+		return nil
+	}
+	return a.rateLimit.GetStats()
+}
+
+// getSeriesLimitStats returns the per-series processing cap statistics.
+func (a *App) getSeriesLimitStats() map[string]interface{} {
+	if a.seriesLimit == nil {
+		return nil
+	}
+	return a.seriesLimit.GetStats()
+}
+
+// getLoadShedStats returns current load shedder statistics.
+func (a *App) getLoadShedStats() map[string]interface{} {
+	if a.loadShedder == nil {
+		return nil
+	}
+	return a.loadShedder.GetStats()
+}
+
+// getRejectionStats returns rejected ingest request counts by reason.
+func (a *App) getRejectionStats() map[string]int64 {
+	stats := make(map[string]int64, len(a.rejectionCounts))
+	for reason, counter := range a.rejectionCounts {
+		stats[reason] = counter.Load()
+	}
+	return stats
+}
+
+// getWarmupStats returns the decisions suppressed during series warm-up.
+func (a *App) getWarmupStats() map[string]interface{} {
+	if a.warmup == nil {
 		return nil
 	}
-	return rateLimit.GetStats()
+	return a.warmup.Stats()
 }
 
 // getMonetizationStats returns current monetization statistics.
-func getMonetizationStats() map[string]interface{} {
-	if monTracker == nil {
+func (a *App) getMonetizationStats() map[string]interface{} {
+	if a.monTracker == nil {
 		return nil
 	}
-	return monTracker.GetStats()
+	return a.monTracker.GetStats()
 }
 
 // getSBOHSummary returns a summary of SBOH metrics for the main metrics endpoint.
-func getSBOHSummary() map[string]interface{} {
-	if hypervisorInstance == nil {
+func (a *App) getSBOHSummary() map[string]interface{} {
+	if a.hypervisor == nil {
 		return nil
 	}
 
-	metrics := hypervisorInstance.GetSBOHMetrics()
+	metrics := a.hypervisor.GetSBOHMetrics()
 	return map[string]interface{}{
+		"p50_latency_ms":        metrics.P50LatencyMS,
+		"p90_latency_ms":        metrics.P90LatencyMS,
 		"p95_latency_ms":        metrics.P95LatencyMS,
+		"p99_latency_ms":        metrics.P99LatencyMS,
 		"decision_success_rate": metrics.DecisionSuccessRate,
 		"monetization_accuracy": metrics.MonetizationAccuracy,
-		"axiom_a2_compliant":    hypervisorInstance.IsAxiomA2Compliant(),
-		"axiom_a4_compliant":    hypervisorInstance.IsAxiomA4Compliant(),
+		"axiom_a2_compliant":    a.hypervisor.IsAxiomA2Compliant(),
+		"axiom_a4_compliant":    a.hypervisor.IsAxiomA4Compliant(),
+		"axiom_a4_status":       a.hypervisor.A4Status(),
 		"total_decisions":       metrics.TotalDecisions,
 		"total_revenue":         metrics.TotalRevenue,
 	}
 }
 
 // setupGracefulShutdown handles graceful shutdown on SIGTERM/SIGINT.
-func setupGracefulShutdown(server *chi.Mux) {
+// The returned channel is closed once shutdown has completed.
+func (a *App) setupGracefulShutdown(server *http.Server) <-chan struct{} {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	stopped := make(chan struct{})
 
 	go func() {
 		<-c
 		log.Println("Shutting down server...")
 
-		// Save final monetization data if enabled
-		if monTracker != nil {
-			log.Printf("Final monetization stats: %+v", monTracker.GetStats())
-		}
+		ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Server.ShutdownTimeout)
+		defer cancel()
 
-		// Stop Blue Team monitoring
-		if blueTeamInstance != nil {
-			blueTeamInstance.StopMonitoring()
-			log.Println("Blue Team monitoring stopped")
+		if err := a.shutdownServer(ctx, server); err != nil {
+			log.Printf("Error during shutdown: %v", err)
 		}
 
-		// Close healer (no special cleanup needed)
-		if healerInstance != nil {
-			log.Println("Blue Team healer shutdown complete")
+		log.Println("Server gracefully stopped")
+		close(stopped)
+	}()
+
+	return stopped
+}
+
+// shutdownServer stops the server in dependency order: stop accepting requests
+// and wait for in-flight handlers, then stop background work, then flush and
+// close the sinks those handlers write to.
+func (a *App) shutdownServer(ctx context.Context, server *http.Server) error {
+	// Drain: no new connections, in-flight decisions run to completion and
+	// open streams stop after the point they are scoring
+	if a.draining != nil {
+		close(a.draining)
+	}
+	err := server.Shutdown(ctx)
+	if err != nil {
+		log.Printf("In-flight requests did not drain: %v", err)
+	}
+
+	// Stop the expected-series checks
+	if a.seriesWatchdog != nil {
+		a.seriesWatchdog.Stop()
+	}
+
+	// Stop threshold tuning before the healer it patches through
+	if a.tuner != nil {
+		a.tuner.Stop()
+	}
+
+	// Stop Blue Team monitoring
+	if a.blueTeam != nil {
+		a.blueTeam.StopMonitoring()
+		a.blueTeam.Flush()
+		log.Println("Blue Team monitoring stopped")
+	}
+
+	// Close healer (no special cleanup needed)
+	if a.healer != nil {
+		log.Println("Blue Team healer shutdown complete")
+	}
+
+	// Archive a final SBOH report covering every decision above
+	if a.sbohExporter != nil {
+		a.sbohExporter.Stop()
+		log.Println("SBOH exporter stopped")
+	}
+
+	// Flush pending PoV records
+	if a.monTracker != nil {
+		if closeErr := a.monTracker.Close(); closeErr != nil {
+			log.Printf("Error flushing monetization records: %v", closeErr)
 		}
+		log.Printf("Final monetization stats: %+v", a.monTracker.GetStats())
+	}
 
-		// Close auditor
-		if auditorInstance != nil {
-			if err := auditorInstance.Close(); err != nil {
-				log.Printf("Error closing auditor: %v", err)
-			} else {
-				log.Println("Auditor closed successfully")
-			}
+	// Close auditor last so every decision above is recorded
+	if a.auditor != nil {
+		if closeErr := a.auditor.Close(); closeErr != nil {
+			log.Printf("Error closing auditor: %v", closeErr)
+		} else {
+			log.Println("Auditor closed successfully")
 		}
+	}
 
-		log.Println("Server gracefully stopped")
-		os.Exit(0)
-	}()
+	return err
 }
 
-// startTime tracks when the server started.
-var startTime = time.Now()
-
 // redTeamStatusHandler provides Red Team status and statistics.
-func redTeamStatusHandler(w http.ResponseWriter, r *http.Request) {
-	if redTeamInstance == nil {
+func (a *App) redTeamStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if a.redTeam == nil {
 		writeErrorResponse(w, http.StatusServiceUnavailable, "REDTEAM_UNAVAILABLE",
 			"Red Team not initialized")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(redTeamInstance.GetFaultStats())
+	json.NewEncoder(w).Encode(a.redTeam.GetFaultStats())
 }
 
 // redTeamFaultHandler allows manual control of fault injection.
-func redTeamFaultHandler(w http.ResponseWriter, r *http.Request) {
-	if redTeamInstance == nil {
+func (a *App) redTeamFaultHandler(w http.ResponseWriter, r *http.Request) {
+	if a.redTeam == nil {
 		writeErrorResponse(w, http.StatusServiceUnavailable, "REDTEAM_UNAVAILABLE",
 			"Red Team not initialized")
 		return
@@ -564,72 +2153,261 @@ func redTeamFaultHandler(w http.ResponseWriter, r *http.Request) {
 		action = "toggle"
 	}
 
-	switch redteam.FaultType(faultType) {
-	case redteam.FaultLatency, redteam.FaultValidationFail, redteam.FaultProcessingFail:
-		switch action {
-		case "enable":
-			redTeamInstance.EnableFault(redteam.FaultType(faultType))
-		case "disable":
-			redTeamInstance.DisableFault(redteam.FaultType(faultType))
-		default:
-			// Toggle behavior
-			activeFaults := redTeamInstance.GetActiveFaults()
-			if _, isActive := activeFaults[redteam.FaultType(faultType)]; isActive {
-				redTeamInstance.DisableFault(redteam.FaultType(faultType))
-			} else {
-				redTeamInstance.EnableFault(redteam.FaultType(faultType))
-			}
-		}
+	fault := redteam.FaultType(faultType)
+	if !fault.Valid() {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_FAULT_TYPE",
+			"Unsupported fault type: "+faultType)
+		return
+	}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"fault_type": faultType,
-			"action":     action,
-			"status":     "success",
-		})
+	switch action {
+	case "enable", "disable", "toggle":
+	default:
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_FAULT_ACTION",
+			"Unsupported fault action: "+action+" (expected enable, disable or toggle)")
+		return
+	}
 
+	// Enabling or toggling a fault without a configuration would silently do nothing
+	if _, configured := a.redTeam.FaultSummary(fault); !configured {
+		writeErrorResponse(w, http.StatusNotFound, "FAULT_NOT_CONFIGURED",
+			"Fault is not configured: "+faultType)
+		return
+	}
+
+	switch action {
+	case "enable":
+		a.redTeam.EnableFault(fault)
+	case "disable":
+		a.redTeam.DisableFault(fault)
 	default:
-		writeErrorResponse(w, http.StatusBadRequest, "INVALID_FAULT_TYPE",
-			"Unsupported fault type: "+faultType)
+		if _, err := a.redTeam.ToggleFault(fault); err != nil {
+			writeErrorResponse(w, http.StatusNotFound, "FAULT_NOT_CONFIGURED", err.Error())
+			return
+		}
+	}
+
+	summary, _ := a.redTeam.FaultSummary(fault)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"fault_type": faultType,
+		"action":     action,
+		"status":     "success",
+		"fault":      summary,
+	})
+}
+
+// redTeamCampaignHandler applies a fault campaign, replacing the schedule of
+// the previous one. Nothing is applied unless every fault is valid.
+func (a *App) redTeamCampaignHandler(w http.ResponseWriter, r *http.Request) {
+	if a.redTeam == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "REDTEAM_UNAVAILABLE",
+			"Red Team not initialized")
+		return
+	}
+
+	var campaign redteam.Campaign
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&campaign); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid campaign: "+err.Error())
+		return
+	}
+	if err := a.redTeam.ApplyCampaign(campaign); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_CAMPAIGN", err.Error())
+		return
 	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"campaign": campaign.Name,
+		"faults":   len(campaign.Faults),
+		"status":   "applied",
+	})
 }
 
 // getRedTeamStats returns current Red Team statistics.
-func getRedTeamStats() map[string]interface{} {
-	if redTeamInstance == nil {
+func (a *App) getRedTeamStats() map[string]interface{} {
+	if a.redTeam == nil {
 		return nil
 	}
-	return redTeamInstance.GetFaultStats()
+	return a.redTeam.GetFaultStats()
 }
 
 // auditEventsHandler provides recent audit events.
-func auditEventsHandler(w http.ResponseWriter, r *http.Request) {
-	if auditorInstance == nil {
+func (a *App) auditEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if a.auditor == nil {
 		writeErrorResponse(w, http.StatusServiceUnavailable, "AUDITOR_UNAVAILABLE",
 			"Auditor not initialized")
 		return
 	}
 
-	// Get limit from query parameter
-	limit := 100 // default
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_QUERY", err.Error())
+		return
+	}
+
+	events := a.auditor.QueryEvents(filter)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"events": events,
+		"count":  len(events),
+		"limit":  filter.Limit,
+	})
+}
+
+// recentAnomaliesHandler returns the most recent anomalies, oldest first,
+// and the anomaly episodes still in progress.
+func (a *App) recentAnomaliesHandler(w http.ResponseWriter, r *http.Request) {
+	if a.anomalies == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "ANOMALY_STORE_UNAVAILABLE",
+			"Anomaly store not initialized")
+		return
+	}
+
+	limit := 0 // all
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		if parsedLimit := parseInt(limitStr); parsedLimit > 0 {
 			limit = parsedLimit
 		}
 	}
 
-	events := auditorInstance.GetEvents(limit)
+	anomalies := a.anomalies.Recent(limit)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"events": events,
-		"count":  len(events),
-		"limit":  limit,
+		"anomalies":     anomalies,
+		"count":         len(anomalies),
+		"open_episodes": a.anomalies.OpenEpisodes(),
+	})
+}
+
+// feedbackHandler records a client's ground truth on a decision for the
+// detection quality report.
+func (a *App) feedbackHandler(w http.ResponseWriter, r *http.Request) {
+	if a.quality == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "FEEDBACK_DISABLED",
+			"Detection feedback is disabled; set AD_FEEDBACK_WINDOW")
+		return
+	}
+
+	var req FeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid feedback request")
+		return
+	}
+	var err error
+	if req.ZScore != nil {
+		err = a.quality.RecordScored(req.DecisionID, anomaly.FeedbackOutcome(req.Outcome), *req.ZScore)
+	} else {
+		err = a.quality.Record(req.DecisionID, anomaly.FeedbackOutcome(req.Outcome))
+	}
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_FEEDBACK", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"decision_id": req.DecisionID,
+		"outcome":     req.Outcome,
+		"status":      "recorded",
+	})
+}
+
+// detectorQualityHandler returns precision, recall and false-positive rate
+// computed from the feedback in the window.
+func (a *App) detectorQualityHandler(w http.ResponseWriter, r *http.Request) {
+	if a.quality == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "FEEDBACK_DISABLED",
+			"Detection feedback is disabled; set AD_FEEDBACK_WINDOW")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(a.quality.Report())
+}
+
+// seriesRecentHandler returns the last decisions of one series, newest first,
+// limited by limit.
+func (a *App) seriesRecentHandler(w http.ResponseWriter, r *http.Request) {
+	if a.decisions == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "RECENT_DECISIONS_DISABLED",
+			"Recent decisions are disabled; set AD_RECENT_DECISIONS")
+		return
+	}
+
+	limit := 0 // all
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit := parseInt(limitStr); parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	seriesID := chi.URLParam(r, "name")
+	decisions, ok := a.decisions.Recent(seriesID, limit)
+	if !ok {
+		writeErrorResponse(w, http.StatusNotFound, "SERIES_NOT_FOUND",
+			fmt.Sprintf("No recent decisions for series %q", seriesID))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"series_id": seriesID,
+		"decisions": decisions,
+		"count":     len(decisions),
 	})
 }
 
+// parseEventFilter reads the audit event filter from the query string.
+// type and status may be repeated or comma-separated; since and until are RFC 3339.
+func parseEventFilter(r *http.Request) (audit.EventFilter, error) {
+	query := r.URL.Query()
+	filter := audit.EventFilter{Limit: 100} // default
+
+	// Get limit from query parameter
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if parsedLimit := parseInt(limitStr); parsedLimit > 0 {
+			filter.Limit = parsedLimit
+		}
+	}
+
+	for _, t := range splitQueryValues(query["type"]) {
+		filter.Types = append(filter.Types, audit.EventType(t))
+	}
+	for _, s := range splitQueryValues(query["status"]) {
+		filter.Statuses = append(filter.Statuses, audit.ComplianceStatus(s))
+	}
+
+	for name, bound := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if value := query.Get(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return filter, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+			}
+			*bound = parsed
+		}
+	}
+	return filter, nil
+}
+
+// splitQueryValues flattens repeated and comma-separated query values, dropping empties.
+func splitQueryValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
 // auditComplianceHandler provides compliance reports.
-func auditComplianceHandler(w http.ResponseWriter, r *http.Request) {
-	if auditorInstance == nil {
+func (a *App) auditComplianceHandler(w http.ResponseWriter, r *http.Request) {
+	if a.auditor == nil {
 		writeErrorResponse(w, http.StatusServiceUnavailable, "AUDITOR_UNAVAILABLE",
 			"Auditor not initialized")
 		return
@@ -643,7 +2421,7 @@ func auditComplianceHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	report := auditorInstance.GetComplianceReport(since)
+	report := a.auditor.GetComplianceReport(since)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(report)
 }
@@ -663,20 +2441,25 @@ func parseInt(s string) int {
 }
 
 // blueTeamStatusHandler provides Blue Team status and healing history.
-func blueTeamStatusHandler(w http.ResponseWriter, r *http.Request) {
-	if blueTeamInstance == nil {
+func (a *App) blueTeamStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if a.blueTeam == nil {
 		writeErrorResponse(w, http.StatusServiceUnavailable, "BLUETEAM_UNAVAILABLE",
 			"Blue Team not initialized")
 		return
 	}
 
+	stats := a.blueTeam.GetHealingStats()
+	if a.tuner != nil {
+		stats["threshold_tuning"] = a.tuner.Stats()
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(blueTeamInstance.GetHealingStats())
+	json.NewEncoder(w).Encode(stats)
 }
 
 // blueTeamHealHandler allows manual triggering of healing actions.
-func blueTeamHealHandler(w http.ResponseWriter, r *http.Request) {
-	if blueTeamInstance == nil {
+func (a *App) blueTeamHealHandler(w http.ResponseWriter, r *http.Request) {
+	if a.blueTeam == nil {
 		writeErrorResponse(w, http.StatusServiceUnavailable, "BLUETEAM_UNAVAILABLE",
 			"Blue Team not initialized")
 		return
@@ -713,25 +2496,16 @@ func blueTeamHealHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	switch strategy {
-	case "reset_detector":
-		healStrategy = blueteam.StrategyResetDetector
-	case "circuit_breaker":
-		healStrategy = blueteam.StrategyCircuitBreaker
-	case "fallback_mode":
-		healStrategy = blueteam.StrategyFallbackMode
-	case "resource_cleanup":
-		healStrategy = blueteam.StrategyResourceCleanup
-	case "config_reload":
-		healStrategy = blueteam.StrategyConfigReload
-	default:
+	// Accept built-in strategies and any registered at runtime
+	healStrategy = blueteam.HealingStrategy(strategy)
+	if !a.blueTeam.HasStrategy(healStrategy) {
 		writeErrorResponse(w, http.StatusBadRequest, "INVALID_STRATEGY",
 			"Unsupported healing strategy: "+strategy)
 		return
 	}
 
 	// Trigger healing
-	action := blueTeamInstance.HealOnDemand(issue, healStrategy)
+	action := a.blueTeam.HealOnDemandWithCorrelation(issue, healStrategy, a.correlationID(r))
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -740,10 +2514,19 @@ func blueTeamHealHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// correlationID returns the ID linking the records of the request r, taken
+// from the request ID middleware, or "" when correlation IDs are disabled.
+func (a *App) correlationID(r *http.Request) string {
+	if !a.cfg.Server.CorrelationIDs {
+		return ""
+	}
+	return middleware.GetReqID(r.Context())
+}
+
 // getBlueTeamStats returns current Blue Team statistics.
-func getBlueTeamStats() map[string]interface{} {
-	if blueTeamInstance == nil {
+func (a *App) getBlueTeamStats() map[string]interface{} {
+	if a.blueTeam == nil {
 		return nil
 	}
-	return blueTeamInstance.GetHealingStats()
+	return a.blueTeam.GetHealingStats()
 }
\ No newline at end of file
@@ -0,0 +1,1905 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"anomaly"
+	"internal/audit"
+	"internal/blueteam"
+	"internal/config"
+	"internal/hypervisor"
+	"internal/monetization"
+	"internal/ratelimit"
+	"internal/redteam"
+	"internal/validation"
+)
+
+// setupTestComponents builds an App with the minimal set of components the handlers need.
+func setupTestComponents(t *testing.T) *App {
+	t.Helper()
+
+	cfg := config.DefaultConfig()
+	detector := anomaly.NewDetector(cfg.Detector.WindowSize, cfg.Detector.Threshold)
+	app := &App{
+		cfg:             cfg,
+		detector:        detector,
+		multiDetector:   anomaly.NewMultiDetector(cfg.Detector.WindowSize, cfg.Detector.Threshold, cfg.Detector.MaxSeries),
+		hypervisor:      hypervisor.NewHypervisor(hypervisor.DefaultConfig()),
+		healer:          blueteam.NewHealer(detector),
+		detectors:       anomaly.NewDetectorSwap(detector),
+		rejectionCounts: newRejectionCounts(),
+		warmup:          newWarmupTracker(),
+		draining:        make(chan struct{}),
+		startTime:       time.Now(),
+	}
+	resetSharedState()
+
+	auditConfig := audit.DefaultConfig()
+	auditConfig.OutputFile = filepath.Join(t.TempDir(), "audit.log")
+	var err error
+	app.auditor, err = audit.NewAuditor(auditConfig)
+	if err != nil {
+		t.Fatalf("Failed to create auditor: %v", err)
+	}
+
+	app.anomalies, err = anomaly.NewAnomalyStore(cfg.Detector.AnomalyHistorySize, "")
+	if err != nil {
+		t.Fatalf("Failed to create anomaly store: %v", err)
+	}
+	return app
+}
+
+// postJSON sends body to handler and returns the recorded response.
+func postJSON(t *testing.T, handler http.HandlerFunc, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+func TestIngestHandler_StaticFallbackOnProcessingFault(t *testing.T) {
+	app := setupTestComponents(t)
+	app.fallbackDetector = anomaly.NewStaticThreshold(0.0, 100.0)
+
+	// Force every point through the processing fault path
+	app.redTeam = redteam.NewRedTeam()
+	app.redTeam.ConfigureFault(redteam.FaultConfig{
+		Type:        redteam.FaultProcessingFail,
+		Probability: 1.0,
+		Duration:    time.Minute,
+	})
+
+	rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest",
+		anomaly.DataPoint{Timestamp: time.Now().Unix(), Value: 250.0})
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from static fallback, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !resp.Degraded || resp.DegradedReason != degradedStaticFallback {
+		t.Errorf("Expected response to be marked degraded by the static fallback, got %q", resp.DegradedReason)
+	}
+	if !resp.IsAnomaly || resp.Direction != 1 {
+		t.Errorf("Expected static fallback to flag value above max, got %+v", resp)
+	}
+
+	degradations := 0
+	for _, event := range app.auditor.GetEvents(0) {
+		if event.Type == audit.EventDegradation {
+			degradations++
+		}
+	}
+	if degradations != 1 {
+		t.Errorf("Expected 1 degradation audit event, got %d", degradations)
+	}
+}
+
+func TestIngestHandler_ProcessingFaultWithoutFallback(t *testing.T) {
+	app := setupTestComponents(t)
+
+	app.redTeam = redteam.NewRedTeam()
+	app.redTeam.ConfigureFault(redteam.FaultConfig{
+		Type:        redteam.FaultProcessingFail,
+		Probability: 1.0,
+		Duration:    time.Minute,
+	})
+
+	rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest",
+		anomaly.DataPoint{Timestamp: time.Now().Unix(), Value: 250.0})
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 without fallback, got %d", rec.Code)
+	}
+}
+
+func TestIngestHandler_AcceptsObjectOrArray(t *testing.T) {
+	app := setupTestComponents(t)
+	now := time.Now().Unix()
+
+	// Single object yields a single Response
+	rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest",
+		anomaly.DataPoint{Timestamp: now, Value: 10.0})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for object, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := bytes.TrimSpace(rec.Body.Bytes()); len(body) == 0 || body[0] != '{' {
+		t.Fatalf("Expected JSON object response, got %s", rec.Body.String())
+	}
+	var single Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &single); err != nil {
+		t.Fatalf("Failed to decode single response: %v", err)
+	}
+	if single.Timestamp != now {
+		t.Errorf("Expected timestamp %d, got %d", now, single.Timestamp)
+	}
+
+	// Array (with leading whitespace) yields an array of Response
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", bytes.NewBufferString(
+		fmt.Sprintf(" \n[{\"timestamp\":%d,\"value\":11.0},{\"timestamp\":%d,\"value\":12.0}]", now+1, now+2)))
+	rec = httptest.NewRecorder()
+	app.ingestHandler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for array, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := bytes.TrimSpace(rec.Body.Bytes()); len(body) == 0 || body[0] != '[' {
+		t.Fatalf("Expected JSON array response, got %s", rec.Body.String())
+	}
+	var results []Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+		t.Fatalf("Failed to decode array response: %v", err)
+	}
+	if len(results) != 2 || results[1].Value != 12.0 {
+		t.Errorf("Expected 2 results in request order, got %+v", results)
+	}
+}
+
+func TestIngestHandler_ArraysDisabled(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Server.AcceptArrays = false
+
+	rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest",
+		[]anomaly.DataPoint{{Timestamp: time.Now().Unix(), Value: 10.0}})
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for array when disabled, got %d", rec.Code)
+	}
+}
+
+func TestIngestHandler_SurfacesWindowStats(t *testing.T) {
+	app := setupTestComponents(t)
+	app.multiWindow = anomaly.NewMultiWindowDetector(5, 50, app.cfg.Detector.Threshold, 5.0)
+
+	now := time.Now().Unix()
+	var resp Response
+	for i := 0; i < 10; i++ {
+		rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest",
+			anomaly.DataPoint{Timestamp: now + int64(i), Value: 10.0 + float64(i%2)})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		resp = Response{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+
+	if resp.Windows == nil {
+		t.Fatal("Expected short/long window stats in response")
+	}
+	if resp.Windows.Short.Count != 5 || resp.Windows.Long.Count != 10 {
+		t.Errorf("Expected window counts 5/10, got %d/%d", resp.Windows.Short.Count, resp.Windows.Long.Count)
+	}
+}
+
+func TestStandbyDetector_PromoteWithoutDroppingRequests(t *testing.T) {
+	app := setupTestComponents(t)
+	start := time.Now().Unix()
+
+	// Stable baseline around 100 with a small spread
+	for i := 0; i < 40; i++ {
+		rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest",
+			anomaly.DataPoint{Timestamp: start + int64(i), Value: 100.0 + float64(i%5)})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	// Keep traffic flowing across the swap
+	var wg sync.WaitGroup
+	var failures atomic.Int64
+	stop := make(chan struct{})
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", bytes.NewBufferString(
+					fmt.Sprintf(`{"timestamp":%d,"value":102.0}`, start+100+int64(i))))
+				rec := httptest.NewRecorder()
+				app.ingestHandler(rec, req)
+				if rec.Code != http.StatusOK {
+					failures.Add(1)
+				}
+			}
+		}(g)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/detector/standby?threshold=1.0", nil)
+	rec := httptest.NewRecorder()
+	app.stageStandbyHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 staging standby, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/detector/standby/prime", nil)
+	rec = httptest.NewRecorder()
+	app.primeStandbyHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 priming standby, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/detector/standby/promote", nil)
+	rec = httptest.NewRecorder()
+	app.promoteStandbyHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 promoting standby, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var promoted map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &promoted); err != nil {
+		t.Fatalf("Failed to decode promote response: %v", err)
+	}
+	if promoted["drained"] != true {
+		t.Errorf("Expected previous detector to drain, got %v", promoted)
+	}
+
+	close(stop)
+	wg.Wait()
+	if n := failures.Load(); n != 0 {
+		t.Errorf("Expected no failed requests across the swap, got %d", n)
+	}
+
+	if threshold := app.getDetectorStats()["threshold"]; threshold != 1.0 {
+		t.Errorf("Expected live threshold 1.0 after promotion, got %v", threshold)
+	}
+	if app.liveDetector() == app.detector {
+		t.Error("Expected the initial detector to have been replaced")
+	}
+
+	// Subsequent requests land on the promoted detector only
+	previousCount, _, _ := app.detector.GetStats()
+	liveOut := app.liveDetector().OutOfOrderCount()
+	rec = postJSON(t, app.ingestHandler, "/api/v1/data/ingest",
+		anomaly.DataPoint{Timestamp: start, Value: 100.0})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 after promotion, got %d", rec.Code)
+	}
+	if count, _, _ := app.detector.GetStats(); count != previousCount {
+		t.Errorf("Expected previous detector untouched after promotion, count %d -> %d", previousCount, count)
+	}
+	if app.liveDetector().OutOfOrderCount() != liveOut+1 {
+		t.Error("Expected the replayed timestamp to be scored by the promoted detector")
+	}
+
+	// Nothing left to promote
+	rec = httptest.NewRecorder()
+	app.promoteStandbyHandler(rec, httptest.NewRequest(http.MethodPost, "/detector/standby/promote", nil))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 without a staged standby, got %d", rec.Code)
+	}
+}
+
+func TestRateLimitMiddleware_RetryAfter(t *testing.T) {
+	app := setupTestComponents(t)
+	app.rateLimit = ratelimit.NewRateLimiter(2, 1) // One token every 500ms
+
+	handler := app.rateLimitMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected first request allowed, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", nil))
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", rec.Code)
+	}
+	if retryAfter := rec.Header().Get("Retry-After"); retryAfter != "1" {
+		t.Errorf("Expected Retry-After 1 (rounded up), got %q", retryAfter)
+	}
+
+	var resp ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if resp.Error != "RATE_LIMIT_EXCEEDED" {
+		t.Errorf("Expected RATE_LIMIT_EXCEEDED, got %s", resp.Error)
+	}
+	if resp.RetryAfterMS <= 400 || resp.RetryAfterMS > 500 {
+		t.Errorf("Expected retry_after_ms near 500, got %d", resp.RetryAfterMS)
+	}
+}
+
+func TestIngestHandler_ShedsSeriesOverItsCap(t *testing.T) {
+	app := setupTestComponents(t)
+	app.seriesLimit = ratelimit.NewSeriesLimiter(1, 3)
+
+	ingest := func(seriesID string, ts int64) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"series_id":%q,"timestamp":%d,"value":10.0}`, seriesID, ts)
+		rec := httptest.NewRecorder()
+		app.ingestHandler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", bytes.NewBufferString(body)))
+		return rec
+	}
+
+	now := time.Now().Unix()
+	shed := 0
+	for i := 0; i < 10; i++ {
+		rec := ingest("hot", now+int64(i))
+		if rec.Code == http.StatusTooManyRequests {
+			shed++
+			var resp ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Error != "SERIES_RATE_LIMITED" {
+				t.Errorf("Expected SERIES_RATE_LIMITED, got %s", rec.Body.String())
+			}
+		} else if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200 or 429, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+	if shed != 7 {
+		t.Errorf("Expected 7 of 10 hot points shed past the burst of 3, got %d", shed)
+	}
+
+	// Another series under its cap is unaffected
+	for i := 0; i < 3; i++ {
+		if rec := ingest("cold", now+int64(i)); rec.Code != http.StatusOK {
+			t.Fatalf("Expected cold point %d accepted, got %d: %s", i, rec.Code, rec.Body.String())
+		}
+	}
+
+	if got := app.rejectionCounts[rejectSeriesLimited].Load(); got != 7 {
+		t.Errorf("Expected 7 series_rate_limited rejections, got %d", got)
+	}
+}
+
+func TestIngestHandler_ShedPointCanBeRetried(t *testing.T) {
+	app := setupTestComponents(t)
+	app.timestamps = validation.NewTimestampGuard(validation.TimestampStrict)
+	app.seriesLimit = ratelimit.NewSeriesLimiter(1, 1)
+
+	now := time.Now().Unix()
+	ingest := func(ts int64) int {
+		return postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{SeriesID: "hot", Timestamp: ts, Value: 10}).Code
+	}
+	if code := ingest(now); code != http.StatusOK {
+		t.Fatalf("Expected the first point accepted, got %d", code)
+	}
+	if code := ingest(now + 1); code != http.StatusTooManyRequests {
+		t.Fatalf("Expected the second point shed, got %d", code)
+	}
+
+	// The shed point never advanced the guard, so its retry is in order
+	app.seriesLimit = nil
+	if code := ingest(now + 1); code != http.StatusOK {
+		t.Fatalf("Expected the retried point accepted, got %d", code)
+	}
+	if code := ingest(now + 1); code != http.StatusBadRequest {
+		t.Errorf("Expected a processed timestamp to be rejected as a duplicate, got %d", code)
+	}
+
+	// Within a batch, earlier points count although none is committed yet
+	rec := postJSON(t, app.batchIngestHandler, "/api/v1/data/ingest/batch", []anomaly.DataPoint{
+		{SeriesID: "hot", Timestamp: now + 2, Value: 10},
+		{SeriesID: "hot", Timestamp: now + 2, Value: 10},
+	})
+	var resp BatchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode batch response: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Error == nil || resp.Error.Index != 1 || resp.Error.Error != "VALIDATION_FAILED" {
+		t.Errorf("Expected the duplicate second point rejected, got %s", rec.Body.String())
+	}
+}
+
+func TestLoadShedMiddleware_ShedsLowPriorityNearSLO(t *testing.T) {
+	app := setupTestComponents(t)
+	var err error
+	app.loadShedder, err = ratelimit.NewLoadShedder(ratelimit.ShedderConfig{
+		SLOMS:          50,
+		StartRatio:     0.8,
+		MaxProbability: 1,
+		Curve:          ratelimit.CurveStep,
+	}, func() float64 { return app.hypervisor.P95LatencyMS() })
+	if err != nil {
+		t.Fatalf("Failed to create load shedder: %v", err)
+	}
+
+	handler := app.loadShedMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(priority string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", nil)
+		if priority != "" {
+			req.Header.Set("X-Priority", priority)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := serve(""); rec.Code != http.StatusOK {
+		t.Fatalf("Expected request allowed with no latency, got %d", rec.Code)
+	}
+
+	// Push P95 past the SLO so the step curve sheds everything low-priority
+	for i := 0; i < 100; i++ {
+		app.hypervisor.RecordDecision(80, true, 0.001)
+	}
+
+	rec := serve("")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 past the SLO, got %d", rec.Code)
+	}
+	var resp ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if resp.Error != "LOAD_SHED" || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected LOAD_SHED with Retry-After, got %s %q", resp.Error, rec.Header().Get("Retry-After"))
+	}
+
+	if rec := serve("high"); rec.Code != http.StatusOK {
+		t.Errorf("Expected high-priority request to bypass shedding, got %d", rec.Code)
+	}
+}
+
+func TestNewConfiguredDetector_ModeFromEnv(t *testing.T) {
+	cases := []struct {
+		env       map[string]string
+		mode      anomaly.Mode
+		threshold float64
+	}{
+		{map[string]string{}, anomaly.ModeZScore, 0},
+		{map[string]string{"AD_MODE": "zscore"}, anomaly.ModeZScore, 0},
+		{map[string]string{"AD_MODE": "percent_change", "AD_PERCENT_THRESHOLD": "20"}, anomaly.ModePercentChange, 20},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.mode)+fmt.Sprint(len(tc.env)), func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+			loaded, err := config.Load()
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+
+			ad, err := newConfiguredDetector(loaded.Detector)
+			if err != nil {
+				t.Fatalf("Failed to build detector: %v", err)
+			}
+			if ad.Mode != tc.mode || ad.PercentThreshold != tc.threshold {
+				t.Errorf("Expected mode %q at %.1f, got %q at %.1f", tc.mode, tc.threshold, ad.Mode, ad.PercentThreshold)
+			}
+		})
+	}
+
+	if _, err := newConfiguredDetector(config.DetectorConfig{WindowSize: 10, Mode: "percent_change"}); err == nil {
+		t.Error("Expected error for percent_change without a threshold")
+	}
+}
+
+func TestIngestHandler_StrictJSONRejectsUnknownFields(t *testing.T) {
+	app := setupTestComponents(t)
+	// series_id misspelled: lenient decoding would silently route this to the default series
+	payload := fmt.Sprintf(`{"timestamp":%d,"value":42.5,"seriesid":"cpu"}`, time.Now().Unix())
+
+	serve := func(handler http.HandlerFunc, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", strings.NewReader(body)))
+		return rec
+	}
+
+	// Lenient mode silently ignores the misspelled field
+	if rec := serve(app.ingestHandler, payload); rec.Code != http.StatusOK {
+		t.Fatalf("Expected lenient mode to accept payload, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	app.cfg.Server.StrictJSON = true
+	rec := serve(app.ingestHandler, payload)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 in strict mode, got %d", rec.Code)
+	}
+	var resp ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if resp.Error != "UNKNOWN_FIELD" || !strings.Contains(resp.Message, `"seriesid"`) {
+		t.Errorf("Expected UNKNOWN_FIELD naming seriesid, got %s: %s", resp.Error, resp.Message)
+	}
+
+	rec = serve(app.batchIngestHandler, "["+payload+"]")
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "seriesid") {
+		t.Errorf("Expected batch endpoint to reject unknown field, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRejectionCounters_ByReason(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Server.MaxBatchSize = 2
+	app.rateLimit = ratelimit.NewRateLimiter(1, 1)
+	before := app.getRejectionStats()
+
+	handler := app.rateLimitMiddleware(http.HandlerFunc(app.ingestHandler))
+	serve := func(body string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", bytes.NewBufferString(body)))
+		return rec.Code
+	}
+
+	// Consume the only token, then get rate limited twice
+	if code := serve(fmt.Sprintf(`{"timestamp":%d,"value":1.0}`, time.Now().Unix())); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	for i := 0; i < 2; i++ {
+		if code := serve(`{"timestamp":1,"value":1.0}`); code != http.StatusTooManyRequests {
+			t.Fatalf("Expected 429, got %d", code)
+		}
+	}
+
+	// Bypass the limiter for the remaining paths
+	app.rateLimit = nil
+	for i := 0; i < 3; i++ {
+		if code := serve(`{"timestamp":0,"value":1.0}`); code != http.StatusBadRequest {
+			t.Fatalf("Expected 400 for validation failure, got %d", code)
+		}
+	}
+	if code := serve(`[{"timestamp":1,"value":1},{"timestamp":2,"value":2},{"timestamp":3,"value":3}]`); code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("Expected 413 for oversized batch, got %d", code)
+	}
+	if code := serve(`{not json`); code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for invalid JSON, got %d", code)
+	}
+
+	after := app.getRejectionStats()
+	expected := map[string]int64{
+		rejectRateLimited:      2,
+		rejectValidationFailed: 3,
+		rejectOversized:        1,
+		rejectInvalidJSON:      1,
+		rejectOutOfOrder:       0,
+		rejectProcessingError:  0,
+	}
+	for reason, want := range expected {
+		if got := after[reason] - before[reason]; got != want {
+			t.Errorf("Expected %d %s rejections, got %d", want, reason, got)
+		}
+	}
+}
+
+func TestProcessBatch_CountsEveryRejectedPoint(t *testing.T) {
+	app := setupTestComponents(t)
+	before := app.getRejectionStats()
+
+	now := time.Now().Unix()
+	body := fmt.Sprintf(`[{"timestamp":%d,"value":1},{"timestamp":0,"value":2},{"timestamp":%d,"value":3}]`, now, now+1)
+	rec := httptest.NewRecorder()
+	app.ingestHandler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", bytes.NewBufferString(body)))
+
+	// The invalid point and the one queued behind it are both dropped
+	after := app.getRejectionStats()
+	if got := after[rejectValidationFailed] - before[rejectValidationFailed]; got != 2 {
+		t.Errorf("Expected 2 validation_failed rejections, got %d", got)
+	}
+}
+
+func TestIngestHandler_MetadataPassthrough(t *testing.T) {
+	app := setupTestComponents(t)
+	povFile := filepath.Join(t.TempDir(), "pov_records.jsonl")
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           povFile,
+	})
+
+	metadata := map[string]string{"device_id": "dev-42", "token": "s3cret"}
+	rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{
+		Timestamp: time.Now().Unix(),
+		Value:     10.0,
+		Metadata:  metadata,
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Metadata["device_id"] != "dev-42" || resp.Metadata["token"] != "s3cret" {
+		t.Errorf("Expected metadata echoed in response, got %v", resp.Metadata)
+	}
+
+	// Persisted asynchronously; wait for the PoV record to land
+	var record monetization.DecisionRecord
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		data, _ := os.ReadFile(povFile)
+		if len(data) > 0 && json.Unmarshal(bytes.TrimSpace(data), &record) == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for persisted PoV record")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if record.Metadata["device_id"] != "dev-42" {
+		t.Errorf("Expected metadata in persisted record, got %v", record.Metadata)
+	}
+	if record.Metadata["token"] != validation.RedactedValue {
+		t.Errorf("Expected token redacted in persisted record, got %q", record.Metadata["token"])
+	}
+
+	var audited map[string]string
+	for _, event := range app.auditor.GetEvents(0) {
+		if event.Type == audit.EventDecision {
+			audited, _ = event.Details["metadata"].(map[string]string)
+		}
+	}
+	if audited["device_id"] != "dev-42" || audited["token"] != validation.RedactedValue {
+		t.Errorf("Expected redacted metadata in audit details, got %v", audited)
+	}
+}
+
+func TestIngestHandler_MetadataTooLarge(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Metadata.MaxKeys = 1
+
+	rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{
+		Timestamp: time.Now().Unix(),
+		Value:     10.0,
+		Metadata:  map[string]string{"a": "1", "b": "2"},
+	})
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for oversized metadata, got %d", rec.Code)
+	}
+}
+
+func TestShutdownServer_RecordsInFlightDecision(t *testing.T) {
+	app := setupTestComponents(t)
+	dir := t.TempDir()
+
+	auditFile := filepath.Join(dir, "audit.log")
+	auditConfig := audit.DefaultConfig()
+	auditConfig.OutputFile = auditFile
+	var err error
+	app.auditor, err = audit.NewAuditor(auditConfig)
+	if err != nil {
+		t.Fatalf("Failed to create auditor: %v", err)
+	}
+
+	povFile := filepath.Join(dir, "pov_records.jsonl")
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           povFile,
+	})
+
+	// A handler that is still running when shutdown begins
+	started := make(chan struct{})
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		app.ingestHandler(w, r)
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := &http.Server{Handler: slow}
+	go server.Serve(listener)
+
+	responded := make(chan int, 1)
+	go func() {
+		body := fmt.Sprintf(`{"timestamp":%d,"value":42.0}`, time.Now().Unix())
+		resp, err := http.Post("http://"+listener.Addr().String()+"/api/v1/data/ingest", "application/json",
+			bytes.NewBufferString(body))
+		if err != nil {
+			responded <- 0
+			return
+		}
+		resp.Body.Close()
+		responded <- resp.StatusCode
+	}()
+
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.shutdownServer(ctx, server); err != nil {
+		t.Fatalf("Unexpected shutdown error: %v", err)
+	}
+
+	if code := <-responded; code != http.StatusOK {
+		t.Errorf("Expected in-flight request to complete with 200, got %d", code)
+	}
+
+	// Everything the in-flight request recorded must be on disk once shutdown returns
+	auditLog, err := os.ReadFile(auditFile)
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	decision := strings.Index(string(auditLog), `"type":"decision"`)
+	closing := strings.Index(string(auditLog), "Audit system shutdown")
+	if decision < 0 || closing < 0 || decision > closing {
+		t.Errorf("Expected decision audited before auditor close, got:\n%s", auditLog)
+	}
+
+	povLog, err := os.ReadFile(povFile)
+	if err != nil {
+		t.Fatalf("Failed to read PoV records: %v", err)
+	}
+	if lines := strings.Count(string(povLog), "\n"); lines != 1 {
+		t.Errorf("Expected 1 persisted PoV record, got %d", lines)
+	}
+}
+
+func TestBatchIngestHandler_SummaryMatchesResults(t *testing.T) {
+	app := setupTestComponents(t)
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           filepath.Join(t.TempDir(), "pov_records.jsonl"),
+	})
+
+	now := time.Now().Unix()
+	var points []anomaly.DataPoint
+	for i := 0; i < 20; i++ {
+		points = append(points, anomaly.DataPoint{Timestamp: now + int64(i), Value: 10 + float64(i%3)})
+	}
+	points = append(points,
+		anomaly.DataPoint{Timestamp: now + 20, Value: 500},
+		anomaly.DataPoint{Timestamp: now + 21, Value: 11},
+		anomaly.DataPoint{Timestamp: 0, Value: 12}, // Fails validation, stopping the batch
+		anomaly.DataPoint{Timestamp: now + 23, Value: 13},
+	)
+
+	rec := postJSON(t, app.batchIngestHandler, "/api/v1/data/ingest/batch", points)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var batch BatchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &batch); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	summary := batch.Aggregate
+	if summary.Count != len(batch.Results) || summary.Count != 22 {
+		t.Fatalf("Expected 22 processed points matching the results, got count %d and %d results", summary.Count, len(batch.Results))
+	}
+	if summary.Rejected != 2 {
+		t.Errorf("Expected 2 rejected points, got %d", summary.Rejected)
+	}
+
+	anomalies, minZ, maxZ, sumZ, totalPrice := 0, batch.Results[0].ZScore, batch.Results[0].ZScore, 0.0, 0.0
+	for _, result := range batch.Results {
+		if result.IsAnomaly {
+			anomalies++
+		}
+		minZ = math.Min(minZ, result.ZScore)
+		maxZ = math.Max(maxZ, result.ZScore)
+		sumZ += result.ZScore
+		totalPrice += result.Price
+	}
+	if anomalies == 0 || summary.Anomalies != anomalies {
+		t.Errorf("Expected %d anomalies (at least one), got %d", anomalies, summary.Anomalies)
+	}
+	if summary.MinZScore != minZ || summary.MaxZScore != maxZ {
+		t.Errorf("Expected z-score range [%f, %f], got [%f, %f]", minZ, maxZ, summary.MinZScore, summary.MaxZScore)
+	}
+	if math.Abs(summary.MeanZScore-sumZ/22) > 1e-9 {
+		t.Errorf("Expected mean z-score %f, got %f", sumZ/22, summary.MeanZScore)
+	}
+	if totalPrice == 0 || math.Abs(summary.TotalPrice-totalPrice) > 1e-9 {
+		t.Errorf("Expected total price %f, got %f", totalPrice, summary.TotalPrice)
+	}
+
+	// summary_only returns the aggregate alone
+	more := []anomaly.DataPoint{{Timestamp: now + 30, Value: 10}, {Timestamp: now + 31, Value: 11}}
+	rec = postJSON(t, app.batchIngestHandler, "/api/v1/data/ingest/batch?summary_only=true", more)
+	var raw map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("Failed to decode summary: %v", err)
+	}
+	if _, found := raw["results"]; found || raw["count"] != 2.0 || raw["rejected"] != 0.0 {
+		t.Errorf("Expected only the summary of 2 points, got %v", raw)
+	}
+}
+
+func TestBatchIngestHandler_SummaryOfZeroVarianceSpikes(t *testing.T) {
+	app := setupTestComponents(t)
+	app.detector.Mode = anomaly.ModeMAD
+
+	// Over a constant window the MAD is zero, so both spikes score math.MaxFloat64
+	now := time.Now().Unix()
+	var points []anomaly.DataPoint
+	for i, value := range []float64{10, 10, 10, 10, 10, 50, 60} {
+		points = append(points, anomaly.DataPoint{Timestamp: now + int64(i), Value: value})
+	}
+
+	rec := postJSON(t, app.batchIngestHandler, "/api/v1/data/ingest/batch", points)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var batch BatchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &batch); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	summary := batch.Aggregate
+	if summary.Anomalies != 2 || summary.MaxZScore != math.MaxFloat64 {
+		t.Fatalf("Expected 2 spikes scoring MaxFloat64, got %+v", summary)
+	}
+	if math.IsInf(summary.MeanZScore, 0) || summary.MeanZScore < math.MaxFloat64/4 {
+		t.Errorf("Expected a finite mean z-score of about 2/7 MaxFloat64, got %v", summary.MeanZScore)
+	}
+}
+
+func TestIngestHandler_ExplainReconstructsDecision(t *testing.T) {
+	app := setupTestComponents(t)
+
+	now := time.Now().Unix()
+	for i := 0; i < 20; i++ {
+		postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: 10 + float64(i%5)*0.2})
+	}
+
+	// The default path carries no breakdown
+	rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + 20, Value: 10})
+	if strings.Contains(rec.Body.String(), `"explain"`) {
+		t.Errorf("Expected no explain payload by default, got %s", rec.Body.String())
+	}
+
+	rec = postJSON(t, app.ingestHandler, "/api/v1/data/ingest?explain=true", anomaly.DataPoint{Timestamp: now + 21, Value: 25})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	e := resp.Explain
+	if e == nil {
+		t.Fatalf("Expected explain payload, got %s", rec.Body.String())
+	}
+
+	if e.WindowCount != 22 || e.Threshold != app.cfg.Detector.Threshold {
+		t.Errorf("Unexpected window count %d or threshold %f", e.WindowCount, e.Threshold)
+	}
+	if e.EffectiveStdDev != math.Max(math.Sqrt(e.Variance), e.MinStdDev) {
+		t.Errorf("Effective std dev %f does not follow from variance %f", e.EffectiveStdDev, e.Variance)
+	}
+	rawZScore := (resp.Value - e.Mean) / e.EffectiveStdDev
+	if e.RawZScore != rawZScore || resp.ZScore != math.Abs(rawZScore) {
+		t.Errorf("Z-score %f does not reconstruct from the breakdown (%f)", resp.ZScore, rawZScore)
+	}
+	if !resp.IsAnomaly || resp.IsAnomaly != (math.Abs(rawZScore) > e.Threshold) || e.Direction != resp.Direction {
+		t.Errorf("Decision %t does not follow from |%f| > %f", resp.IsAnomaly, rawZScore, e.Threshold)
+	}
+}
+
+func TestAuditEventsHandler_Filters(t *testing.T) {
+	app := setupTestComponents(t)
+	app.auditor.LogValidation(false, "value", 1, "10.0.0.1", fmt.Errorf("too large"))
+	app.auditor.LogRateLimit(false, "10.0.0.1", "req-1")
+	app.auditor.LogValidation(true, "value", 2, "10.0.0.1", nil)
+
+	get := func(query string) (int, []audit.AuditEvent) {
+		rec := httptest.NewRecorder()
+		app.auditEventsHandler(rec, httptest.NewRequest(http.MethodGet, "/audit/events?"+query, nil))
+		var body struct {
+			Events []audit.AuditEvent `json:"events"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &body)
+		return rec.Code, body.Events
+	}
+
+	if code, events := get("type=validation,rate_limit&status=error&status=warning"); code != http.StatusOK || len(events) != 2 {
+		t.Errorf("Expected the failed validation and rate limit events, got %d: %+v", code, events)
+	}
+	since := url.QueryEscape(time.Now().Add(time.Hour).Format(time.RFC3339))
+	if code, events := get("type=validation&since=" + since); code != http.StatusOK || len(events) != 0 {
+		t.Errorf("Expected no events since an hour from now, got %d: %+v", code, events)
+	}
+	if code, _ := get("until=yesterday"); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid until, got %d", code)
+	}
+}
+
+// suiteSnapshot is the order-sensitive state a test suite leaves behind.
+type suiteSnapshot struct {
+	decisions  int64
+	rejections map[string]int64
+	count      int
+	mean       float64
+}
+
+func TestSetupTestComponents_SuitesIndependentOfOrder(t *testing.T) {
+	now := time.Now().Unix()
+	suites := map[string]func(t *testing.T) suiteSnapshot{
+		"steady": func(t *testing.T) suiteSnapshot {
+			app := setupTestComponents(t)
+			for i := 0; i < 30; i++ {
+				postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: 100})
+			}
+			return snapshotSuite(app)
+		},
+		"noisy": func(t *testing.T) suiteSnapshot {
+			app := setupTestComponents(t)
+			for i := 0; i < 10; i++ {
+				postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: float64(i*7 + 1)})
+			}
+			postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: 0, Value: 1})
+			return snapshotSuite(app)
+		},
+	}
+
+	results := make(map[string][]suiteSnapshot)
+	for _, order := range [][]string{{"steady", "noisy"}, {"noisy", "steady"}} {
+		for _, name := range order {
+			t.Run(name, func(t *testing.T) {
+				results[name] = append(results[name], suites[name](t))
+			})
+		}
+	}
+
+	for name, runs := range results {
+		if len(runs) != 2 {
+			t.Fatalf("Expected %s to run twice, got %d", name, len(runs))
+		}
+		first, second := runs[0], runs[1]
+		if first.decisions != second.decisions || first.count != second.count || first.mean != second.mean {
+			t.Errorf("Suite %s depends on run order: %+v vs %+v", name, first, second)
+		}
+		for reason, n := range first.rejections {
+			if second.rejections[reason] != n {
+				t.Errorf("Suite %s %s rejections depend on run order: %d vs %d", name, reason, n, second.rejections[reason])
+			}
+		}
+	}
+	if results["steady"][0].count != 30 || results["noisy"][0].count != 10 || results["noisy"][0].rejections[rejectValidationFailed] != 1 {
+		t.Errorf("Unexpected suite metrics: %+v", results)
+	}
+}
+
+// snapshotSuite captures the metrics a suite leaves in its App.
+func snapshotSuite(app *App) suiteSnapshot {
+	count, mean, _ := app.detector.GetStats()
+	return suiteSnapshot{
+		decisions:  app.hypervisor.GetSBOHMetrics().TotalDecisions,
+		rejections: app.getRejectionStats(),
+		count:      count,
+		mean:       mean,
+	}
+}
+
+func TestApp_InstancesDoNotShareDetectors(t *testing.T) {
+	first, second := setupTestComponents(t), setupTestComponents(t)
+	if first.detector == second.detector || first.multiDetector == second.multiDetector {
+		t.Fatal("Expected each App to own its detectors")
+	}
+
+	now := time.Now().Unix()
+	for i := 0; i < 10; i++ {
+		postJSON(t, first.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: 100})
+	}
+	postJSON(t, first.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now, Value: 5, SeriesID: "cpu"})
+
+	if count, _, _ := first.detector.GetStats(); count != 10 {
+		t.Errorf("Expected 10 points in the first App's detector, got %d", count)
+	}
+	if count, _, _ := second.detector.GetStats(); count != 0 {
+		t.Errorf("Expected the second App's detector untouched, got %d points", count)
+	}
+	if first.multiDetector.SeriesCount() != 1 || second.multiDetector.SeriesCount() != 0 {
+		t.Errorf("Expected series only in the first App, got %d and %d",
+			first.multiDetector.SeriesCount(), second.multiDetector.SeriesCount())
+	}
+
+	// A timestamp the first App has already seen is fresh to the second
+	rec := postJSON(t, second.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now, Value: 100})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from the second App, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if first.liveDetector().OutOfOrderCount() != 0 || second.liveDetector().OutOfOrderCount() != 0 {
+		t.Error("Expected no out-of-order points in either App")
+	}
+	if stats := second.getDetectorStats(); stats["count"] != 1 {
+		t.Errorf("Expected 1 point in the second App, got %v", stats["count"])
+	}
+}
+
+func TestApp_IngestThroughRouter(t *testing.T) {
+	app := setupTestComponents(t)
+	server := httptest.NewServer(app.setupRouter())
+	defer server.Close()
+
+	post := func(body string) int {
+		resp, err := http.Post(server.URL+"/api/v1/data/ingest", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	now := time.Now().Unix()
+	for i := 0; i < 3; i++ {
+		if code := post(fmt.Sprintf(`{"timestamp":%d,"value":%d}`, now+int64(i), 10+i)); code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", code)
+		}
+	}
+	if code := post(`{not json`); code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for invalid JSON, got %d", code)
+	}
+
+	if count, mean, _ := app.detector.GetStats(); count != 3 || mean != 11 {
+		t.Errorf("Expected the App's detector to hold 3 points with mean 11, got %d and %f", count, mean)
+	}
+	if rejected := app.getRejectionStats()[rejectInvalidJSON]; rejected != 1 {
+		t.Errorf("Expected 1 invalid JSON rejection on the App, got %d", rejected)
+	}
+	if decisions := app.auditor.QueryEvents(audit.EventFilter{Types: []audit.EventType{audit.EventDecision}}); len(decisions) != 3 {
+		t.Errorf("Expected 3 audited decisions, got %d", len(decisions))
+	}
+}
+
+func TestIngestHandler_RejectsImpossibleUnitValues(t *testing.T) {
+	app := setupTestComponents(t)
+	var err error
+	app.units, err = validation.NewUnitRegistry(map[string]validation.Unit{"cpu": validation.UnitPercent})
+	if err != nil {
+		t.Fatalf("Failed to create unit registry: %v", err)
+	}
+
+	now := time.Now().Unix()
+	rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now, Value: 150, SeriesID: "cpu"})
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "percent value must be between 0 and 100") {
+		t.Errorf("Expected 400 naming the percent bounds, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if count := app.multiDetector.SeriesCount(); count != 0 {
+		t.Errorf("Expected the impossible value rejected before detection, got %d series", count)
+	}
+
+	rec = postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now, Value: 42, SeriesID: "cpu"})
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 for a valid percentage, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRecentAnomaliesHandler_TracksEpisodes(t *testing.T) {
+	app := setupTestComponents(t)
+
+	now := time.Now().Unix()
+	for i := 0; i < 20; i++ {
+		postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: 10 + float64(i%5)*0.2, SeriesID: "cpu"})
+	}
+	postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + 20, Value: 25, SeriesID: "cpu"})
+	postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + 21, Value: 30, SeriesID: "cpu"})
+
+	var body struct {
+		Anomalies    []anomaly.AnomalyRecord `json:"anomalies"`
+		Count        int                     `json:"count"`
+		OpenEpisodes []anomaly.Episode       `json:"open_episodes"`
+	}
+	get := func(path string) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		app.recentAnomaliesHandler(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		body.Anomalies, body.OpenEpisodes = nil, nil
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+
+	get("/anomalies/recent?limit=1")
+	if body.Count != 1 || body.Anomalies[0].Timestamp != now+21 {
+		t.Errorf("Expected only the latest anomaly, got %+v", body.Anomalies)
+	}
+	if len(body.OpenEpisodes) != 1 || body.OpenEpisodes[0].StartTimestamp != now+20 || body.OpenEpisodes[0].Count != 2 {
+		t.Errorf("Expected one open cpu episode of 2 anomalies, got %+v", body.OpenEpisodes)
+	}
+
+	// A normal point ends the episode
+	postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + 22, Value: 10, SeriesID: "cpu"})
+	get("/anomalies/recent")
+	if body.Count != 2 || len(body.OpenEpisodes) != 0 {
+		t.Errorf("Expected 2 anomalies and no open episode after recovery, got %+v", body)
+	}
+}
+
+func TestSeriesModeHandler_SwitchesSeriesMidStream(t *testing.T) {
+	app := setupTestComponents(t)
+	router := app.setupRouter()
+
+	ingest := func(ts int64, value float64) Response {
+		t.Helper()
+		rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest?explain=true", anomaly.DataPoint{Timestamp: ts, Value: value, SeriesID: "latency"})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp Response
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+	setMode := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/series/latency/mode", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Two outliers inflate the standard deviation enough to hide a shift to 20
+	now := time.Now().Unix()
+	for i := 0; i < 20; i++ {
+		value := 10.0 + float64(i%2)
+		if i >= 18 {
+			value = 100
+		}
+		ingest(now+int64(i), value)
+	}
+	if resp := ingest(now+20, 20); resp.IsAnomaly || resp.Explain.Mode != anomaly.ModeZScore {
+		t.Errorf("Expected no anomaly in zscore mode, got %+v", resp)
+	}
+
+	if rec := setMode(`{"mode":"mad","params":{"percent_threshold":5}}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a parameter mad does not accept, got %d", rec.Code)
+	}
+	if rec := setMode(`{"mode":"mad"}`); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"mode":"mad"`) {
+		t.Fatalf("Expected the mode change confirmed, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	resp := ingest(now+21, 20)
+	if !resp.IsAnomaly || resp.Explain.Mode != anomaly.ModeMAD || resp.Explain.Median != 11 || resp.Explain.MAD != 1 {
+		t.Errorf("Expected the shift flagged in mad mode, got %+v (explain %+v)", resp, resp.Explain)
+	}
+	if resp.Explain.WindowCount != 22 {
+		t.Errorf("Expected the series window kept across the switch, got %d points", resp.Explain.WindowCount)
+	}
+}
+
+func TestDetectorQuality_FromFeedback(t *testing.T) {
+	app := setupTestComponents(t)
+	app.quality = anomaly.NewQualityTracker(app.cfg.Detector.FeedbackWindow)
+	router := app.setupRouter()
+
+	for decisionID, outcome := range map[string]string{
+		"TS-1": "true_positive",
+		"TS-2": "true_positive",
+		"TS-3": "false_positive",
+		"TS-4": "false_negative",
+		"TS-5": "true_negative",
+		"TS-6": "true_negative",
+		"TS-7": "true_negative",
+	} {
+		rec := postJSON(t, router.ServeHTTP, "/api/v1/feedback", FeedbackRequest{DecisionID: decisionID, Outcome: outcome})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected feedback accepted, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+	if rec := postJSON(t, router.ServeHTTP, "/api/v1/feedback", FeedbackRequest{DecisionID: "TS-8", Outcome: "unsure"}); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "INVALID_FEEDBACK") {
+		t.Errorf("Expected 400 INVALID_FEEDBACK, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/detector/quality", nil))
+	var report anomaly.QualityReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to decode report: %v", err)
+	}
+	if report.Feedback != 7 || report.TruePositives != 2 || report.FalsePositives != 1 || report.FalseNegatives != 1 || report.TrueNegatives != 3 {
+		t.Fatalf("Unexpected counts %+v", report)
+	}
+	if math.Abs(report.Precision-2.0/3) > 1e-9 || math.Abs(report.Recall-2.0/3) > 1e-9 || math.Abs(report.FalsePositiveRate-0.25) > 1e-9 {
+		t.Errorf("Expected precision 2/3, recall 2/3 and FPR 0.25, got %+v", report)
+	}
+}
+
+func TestDetectorQuality_Disabled(t *testing.T) {
+	app := setupTestComponents(t)
+	rec := httptest.NewRecorder()
+	app.setupRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/detector/quality", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 without a quality tracker, got %d", rec.Code)
+	}
+}
+
+func TestSeriesRecentHandler_NewestFirst(t *testing.T) {
+	app := setupTestComponents(t)
+	app.decisions = anomaly.NewDecisionLog(5, 10)
+	router := app.setupRouter()
+
+	now := time.Now().Unix()
+	for i := 0; i < 20; i++ {
+		postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: 10 + float64(i%2), SeriesID: "cpu"})
+	}
+	postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + 20, Value: 100, SeriesID: "cpu"})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/series/cpu/recent?limit=3", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		SeriesID  string                   `json:"series_id"`
+		Decisions []anomaly.DecisionRecord `json:"decisions"`
+		Count     int                      `json:"count"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.SeriesID != "cpu" || body.Count != 3 || len(body.Decisions) != 3 {
+		t.Fatalf("Expected 3 decisions for cpu, got %+v", body)
+	}
+	newest := body.Decisions[0]
+	if newest.Timestamp != now+20 || newest.Value != 100 || !newest.IsAnomaly || newest.ZScore <= 0 {
+		t.Errorf("Expected the spike first with its z-score, got %+v", newest)
+	}
+	for i, d := range body.Decisions[1:] {
+		if want := now + 19 - int64(i); d.Timestamp != want || d.IsAnomaly || d.Value != 10+float64((want-now)%2) {
+			t.Errorf("Expected normal decision at timestamp %d, got %+v", want, d)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/series/mem/recent", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a series with no decisions, got %d", rec.Code)
+	}
+}
+
+func TestIngest_RejectedWhileCircuitBreakerOpen(t *testing.T) {
+	app := setupTestComponents(t)
+	config := blueteam.DefaultConfig()
+	config.Breaker.OpenTimeout = 20 * time.Millisecond
+	config.Breaker.HalfOpenSuccesses = 1
+	app.blueTeam = blueteam.NewBlueTeam(config)
+	router := app.setupRouter()
+
+	ingest := func(ts int64) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(anomaly.DataPoint{Timestamp: ts, Value: 10})
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", bytes.NewReader(payload)))
+		return rec
+	}
+
+	now := time.Now().Unix()
+	app.blueTeam.HealOnDemand(blueteam.IssueHighLatency, blueteam.StrategyCircuitBreaker)
+	if rec := ingest(now); rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), "CIRCUIT_OPEN") {
+		t.Fatalf("Expected 503 CIRCUIT_OPEN, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if count, _, _ := app.detector.GetStats(); count != 0 {
+		t.Errorf("Expected the rejected point not processed, got %d points", count)
+	}
+	if got := app.getRejectionStats()[rejectCircuitOpen]; got != 1 {
+		t.Errorf("Expected 1 circuit_open rejection, got %d", got)
+	}
+
+	// After the open timeout a successful probe closes the breaker
+	time.Sleep(30 * time.Millisecond)
+	if rec := ingest(now + 1); rec.Code != http.StatusOK {
+		t.Fatalf("Expected the probe processed, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if state := app.blueTeam.CircuitBreaker().State(); state != blueteam.BreakerClosed {
+		t.Errorf("Expected the breaker closed after the probe, got %s", state)
+	}
+}
+
+func TestIngest_DrainIndicateMarksResponses(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Server.DrainMode = "indicate"
+	router := app.setupRouter()
+
+	now := time.Now().Unix()
+	ingest := func(path string, body interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload)))
+		return rec
+	}
+
+	if rec := ingest("/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now, Value: 10}); strings.Contains(rec.Body.String(), `"draining"`) {
+		t.Errorf("Expected no draining marker before shutdown, got %s", rec.Body.String())
+	}
+
+	close(app.draining)
+	rec := ingest("/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + 1, Value: 11})
+	var resp Response
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &resp) != nil || !resp.Draining {
+		t.Fatalf("Expected a 200 marked draining, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = ingest("/api/v1/data/ingest/batch", []anomaly.DataPoint{{Timestamp: now + 2, Value: 10}})
+	var batch BatchResponse
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &batch) != nil || len(batch.Results) != 1 || !batch.Results[0].Draining {
+		t.Fatalf("Expected a batch result marked draining, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if count, _, _ := app.detector.GetStats(); count != 3 {
+		t.Errorf("Expected every point processed while draining, got %d", count)
+	}
+}
+
+func TestIngest_DrainRejectAnswers503(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Server.DrainMode = "reject"
+	router := app.setupRouter()
+	close(app.draining)
+
+	now := time.Now().Unix()
+	for _, tc := range []struct {
+		path string
+		body interface{}
+	}{
+		{"/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now, Value: 10}},
+		{"/api/v1/data/ingest/batch", []anomaly.DataPoint{{Timestamp: now + 1, Value: 10}}},
+	} {
+		payload, _ := json.Marshal(tc.body)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tc.path, bytes.NewReader(payload)))
+		if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), `"DRAINING"`) {
+			t.Errorf("Expected 503 DRAINING from %s, got %d: %s", tc.path, rec.Code, rec.Body.String())
+		}
+	}
+
+	if count, _, _ := app.detector.GetStats(); count != 0 {
+		t.Errorf("Expected the rejected points not processed, got %d points", count)
+	}
+	if got := app.getRejectionStats()[rejectDraining]; got != 2 {
+		t.Errorf("Expected 2 draining rejections, got %d", got)
+	}
+}
+
+func TestMetrics_WarmupSuppressionPerSeries(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Detector.MinSamples = 4
+	app.multiDetector.MinSamples = 4
+
+	warmupStats := func() (int64, map[string]int64) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		app.metricsHandler(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		var body struct {
+			WarmupStats struct {
+				SuppressedTotal int64            `json:"suppressed_total"`
+				WarmingSeries   map[string]int64 `json:"warming_series"`
+			} `json:"warmup_stats"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode metrics: %v", err)
+		}
+		return body.WarmupStats.SuppressedTotal, body.WarmupStats.WarmingSeries
+	}
+
+	now := time.Now().Unix()
+	for i := 0; i < 3; i++ {
+		postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: 10, SeriesID: "cpu"})
+		total, series := warmupStats()
+		if total != int64(i+1) || series["cpu"] != int64(i+1) {
+			t.Fatalf("After %d points expected %d suppressed decisions, got total=%d series=%v", i+1, i+1, total, series)
+		}
+	}
+
+	// The fourth point warms the series: its count is dropped and the total stops growing
+	for i := 3; i < 6; i++ {
+		postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: 10, SeriesID: "cpu"})
+	}
+	total, series := warmupStats()
+	if total != 3 {
+		t.Errorf("Expected the total to stop at 3 once warm, got %d", total)
+	}
+	if _, warming := series["cpu"]; warming {
+		t.Errorf("Expected cpu to leave the warming series once warm, got %v", series)
+	}
+}
+
+func TestIngest_WarmupDecisionsBilledAtWarmupPrice(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Detector.MinSamples = 3
+	app.multiDetector.MinSamples = 3
+	povFile := filepath.Join(t.TempDir(), "pov_records.jsonl")
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           povFile,
+		WarmupPricing:        true,
+		WarmupPrice:          0,
+	})
+
+	now := time.Now().Unix()
+	for i := 0; i < 4; i++ {
+		rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: 10, SeriesID: "cpu"})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+	app.monTracker.Flush()
+
+	data, err := os.ReadFile(povFile)
+	if err != nil {
+		t.Fatalf("Failed to read PoV records: %v", err)
+	}
+	// Records are persisted asynchronously, so order them by decision
+	warmup := make([]bool, 4)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var record monetization.DecisionRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Failed to decode PoV record: %v", err)
+		}
+		var ts int64
+		fmt.Sscanf(record.DecisionID, "TS-cpu-%d", &ts)
+		warmup[ts-now] = record.Warmup
+	}
+	if fmt.Sprint(warmup) != "[true true false false]" {
+		t.Errorf("Expected the 2 points before MinSamples tagged as warm-up, got %v", warmup)
+	}
+
+	// Only the scored decisions are billed
+	want := 2 * app.monTracker.CalculatePrice(0, 0)
+	if total := app.monTracker.GetTotalValue(); total <= 0 || total >= want*1.5 {
+		t.Errorf("Expected only the 2 scored decisions billed (about %v), got %v", want, total)
+	}
+}
+
+func TestIngest_SeriesSharingATimestampAreBothBilled(t *testing.T) {
+	app := setupTestComponents(t)
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		DedupWindow:          100,
+	})
+
+	now := time.Now().Unix()
+	for _, series := range []string{"cpu", "mem"} {
+		rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now, Value: 10, SeriesID: series})
+		if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), `"duplicate":true`) {
+			t.Fatalf("Expected series %s billed, got %d: %s", series, rec.Code, rec.Body.String())
+		}
+	}
+	if count := app.monTracker.GetStats()["total_decisions"]; count != 2 {
+		t.Errorf("Expected both series billed, got %d decisions", count)
+	}
+
+	// A retry of the same series and timestamp is still a duplicate
+	rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now, Value: 10, SeriesID: "cpu"})
+	if !strings.Contains(rec.Body.String(), `"duplicate":true`) {
+		t.Errorf("Expected the retried point answered as a duplicate, got %s", rec.Body.String())
+	}
+}
+
+func TestIngest_FreeWarmupKeepsA4Compliant(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Detector.MinSamples = 3
+	app.detector.MinSamples = 3
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           filepath.Join(t.TempDir(), "pov_records.jsonl"),
+		WarmupPricing:        true,
+		WarmupPrice:          0,
+	})
+	hypConfig := hypervisor.DefaultConfig()
+	hypConfig.A4MinDecisions = 4
+	app.hypervisor = hypervisor.NewHypervisor(hypConfig)
+
+	// Two free warm-up decisions, then two scored ones, single and batched
+	now := time.Now().Unix()
+	postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now, Value: 10})
+	postJSON(t, app.batchIngestHandler, "/api/v1/data/ingest/batch", []anomaly.DataPoint{
+		{Timestamp: now + 1, Value: 11},
+		{Timestamp: now + 2, Value: 10},
+		{Timestamp: now + 3, Value: 11},
+	})
+
+	if total := app.hypervisor.GetSBOHMetrics().TotalDecisions; total < 4 {
+		t.Fatalf("Expected at least 4 decisions recorded, got %d", total)
+	}
+	if status := app.hypervisor.A4Status(); status != hypervisor.A4Compliant {
+		t.Errorf("Expected free warm-up decisions to keep A-4 %s, got %s", hypervisor.A4Compliant, status)
+	}
+}
+
+func TestPriceFallback_IsAudited(t *testing.T) {
+	app := setupTestComponents(t)
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           filepath.Join(t.TempDir(), "pov.jsonl"),
+	})
+	app.monTracker.SetPriceFallbackHandler(app.auditPriceFallback)
+
+	app.monTracker.RecordDecision("TS-1", 1, 1000, math.Inf(1), true)
+	app.monTracker.Flush()
+
+	events := app.auditor.QueryEvents(audit.EventFilter{Types: []audit.EventType{audit.EventDegradation}})
+	if len(events) != 1 {
+		t.Fatalf("Expected one degradation event, got %+v", events)
+	}
+	if events[0].Component != "monetization" || events[0].Details["decision_id"] != "TS-1" || events[0].Details["z_score"] != "+Inf" {
+		t.Errorf("Unexpected price fallback event: %+v", events[0])
+	}
+	if total := app.monTracker.GetTotalValue(); total != 0.001 {
+		t.Errorf("Expected the decision billed at the base price, got %v", total)
+	}
+}
+
+func TestIngest_SteadyStreamHasNoDeterminismViolations(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Audit.Determinism = "violations"
+	determinismEvents := func() []audit.AuditEvent {
+		return app.auditor.QueryEvents(audit.EventFilter{Types: []audit.EventType{audit.EventDeterminism}})
+	}
+	now := time.Now().Unix()
+
+	// Every point changes the window; replaying it on the prior state still matches
+	for i := 0; i < 30; i++ {
+		dp := anomaly.DataPoint{Timestamp: now + int64(i), Value: 10.0 + float64(i%5)}
+		if rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest?explain=true", dp); rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+	if events := determinismEvents(); len(events) != 0 {
+		t.Fatalf("Expected no determinism violations for a steady stream, got %+v", events)
+	}
+
+	// At the "all" level passing checks are recorded as compliant
+	app.cfg.Audit.Determinism = "all"
+	postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + 30, Value: 12.0})
+	events := determinismEvents()
+	if len(events) != 1 {
+		t.Fatalf("Expected one determinism event, got %+v", events)
+	}
+	event := events[0]
+	if event.Status != audit.StatusCompliant || event.Details["checkpoint_created"] != false {
+		t.Errorf("Expected a compliant event without a recovery checkpoint, got %+v", event)
+	}
+	if event.Details["input_hash"] == "" || event.Details["output_hash"] == "" {
+		t.Errorf("Expected the input and output hashes recorded, got %+v", event.Details)
+	}
+
+	// The audit level can exclude determinism events entirely
+	app.cfg.Audit.Determinism = "off"
+	postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + 31, Value: 12.0})
+	if events := determinismEvents(); len(events) != 1 {
+		t.Errorf("Expected no determinism events once disabled, got %d", len(events))
+	}
+}
+
+func TestBlueTeamHeal_AcceptsRegisteredStrategy(t *testing.T) {
+	app := setupTestComponents(t)
+	app.blueTeam = blueteam.NewBlueTeam(blueteam.DefaultConfig())
+	router := app.setupRouter()
+
+	heal := func(strategy string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/blueteam/heal/high_latency?strategy="+strategy, nil))
+		return rec
+	}
+
+	if rec := heal("drain_node"); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "INVALID_STRATEGY") {
+		t.Fatalf("Expected 400 INVALID_STRATEGY for an unregistered strategy, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if err := app.blueTeam.RegisterStrategy("drain_node", func(action *blueteam.HealingAction) bool { return true }); err != nil {
+		t.Fatalf("Failed to register strategy: %v", err)
+	}
+	rec := heal("drain_node")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"strategy":"drain_node"`) {
+		t.Errorf("Expected the registered strategy to heal, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestIngestHandler_PropagatesCorrelationID(t *testing.T) {
+	app := setupTestComponents(t)
+	app.blueTeam = blueteam.NewBlueTeam(blueteam.DefaultConfig())
+	povFile := filepath.Join(t.TempDir(), "pov_records.jsonl")
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           povFile,
+	})
+	// Slow decisions break A-2, so the ingest triggers a heal
+	for i := 0; i < 20; i++ {
+		app.hypervisor.RecordDecision(500, true, 0.001)
+	}
+	router := app.setupRouter()
+
+	body, _ := json.Marshal(anomaly.DataPoint{Timestamp: time.Now().Unix(), Value: 10})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", "req-7f3a")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var record monetization.DecisionRecord
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		data, _ := os.ReadFile(povFile)
+		if len(data) > 0 && json.Unmarshal(bytes.TrimSpace(data), &record) == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for persisted PoV record")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if record.CorrelationID != "req-7f3a" {
+		t.Errorf("Expected the PoV record correlated to req-7f3a, got %q", record.CorrelationID)
+	}
+
+	events := app.auditor.QueryEvents(audit.EventFilter{Types: []audit.EventType{audit.EventDecision}})
+	if len(events) != 1 || events[0].RequestID != "req-7f3a" {
+		t.Errorf("Expected the decision audit event correlated to req-7f3a, got %+v", events)
+	}
+
+	history := app.blueTeam.GetHealingHistory(0)
+	if len(history) == 0 {
+		t.Fatal("Expected the A-2 violation to trigger a heal")
+	}
+	for _, action := range history {
+		if action.CorrelationID != "req-7f3a" {
+			t.Errorf("Expected heal %s correlated to req-7f3a, got %q", action.ID, action.CorrelationID)
+		}
+	}
+}
+
+func TestIngest_NetworkDelayFault(t *testing.T) {
+	app := setupTestComponents(t)
+	app.redTeam = redteam.NewRedTeam()
+	app.redTeam.ConfigureFault(redteam.FaultConfig{
+		Type:        redteam.FaultNetworkDelay,
+		Probability: 1.0,
+		Duration:    time.Minute,
+		Parameters:  map[string]interface{}{"delay_ms": float64(50)},
+	})
+	router := app.setupRouter()
+
+	ingest := func() time.Duration {
+		body, _ := json.Marshal(anomaly.DataPoint{Timestamp: time.Now().Unix(), Value: 10})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		start := time.Now()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		return time.Since(start)
+	}
+
+	if elapsed := ingest(); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected the ingest delayed by 50ms, took %v", elapsed)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/redteam/fault/network_delay?action=disable", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the network delay fault to be controllable, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if elapsed := ingest(); elapsed >= 50*time.Millisecond {
+		t.Errorf("Expected no delay once disabled, took %v", elapsed)
+	}
+}
+
+func TestIngest_ResponseBudgetDegradesOutput(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Server.ResponseBudget = 50 * time.Millisecond
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:  0.001,
+		OutputFile: filepath.Join(t.TempDir(), "pov_records.jsonl"),
+	})
+
+	ingest := func(ts int64, value float64) Response {
+		rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: ts, Value: value})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp Response
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	now := time.Now().Unix()
+	for i := 0; i < 20; i++ {
+		if resp := ingest(now+int64(i), float64(10+i%2)); resp.Degraded || resp.Price == 0 {
+			t.Fatalf("Expected a priced, undegraded response within budget, got %+v", resp)
+		}
+	}
+
+	// Injected latency pushes the request past its budget
+	app.redTeam = redteam.NewRedTeam()
+	app.redTeam.ConfigureFault(redteam.FaultConfig{
+		Type:        redteam.FaultLatency,
+		Probability: 1.0,
+		Duration:    time.Minute,
+		Parameters:  map[string]interface{}{"multiplier": float64(1e6)},
+	})
+
+	resp := ingest(now+20, 1000)
+	if !resp.Degraded || resp.DegradedReason != degradedResponseBudget {
+		t.Errorf("Expected the response degraded by the response budget, got %+v", resp)
+	}
+	if !resp.IsAnomaly || resp.ZScore <= app.cfg.Detector.Threshold || resp.Severity == "" {
+		t.Errorf("Expected the anomaly decision to be returned, got %+v", resp)
+	}
+	if resp.Price != 0 || resp.Currency != "" {
+		t.Errorf("Expected the pricing detail skipped, got %v %s", resp.Price, resp.Currency)
+	}
+	if stats := app.monTracker.GetStats(); stats["total_decisions"] != 21 {
+		t.Errorf("Expected the degraded decision still billed, got %v decisions", stats["total_decisions"])
+	}
+}
+
+func TestRedTeamFault_ReturnsResultingState(t *testing.T) {
+	app := setupTestComponents(t)
+	app.redTeam = redteam.NewRedTeam()
+	app.redTeam.ConfigureFault(redteam.FaultConfig{
+		Type:        redteam.FaultLatency,
+		Probability: 0.25,
+		Duration:    time.Minute,
+	})
+	router := app.setupRouter()
+
+	fault := func(faultType, action string) (int, map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/redteam/fault/"+faultType+"?action="+action, nil))
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return rec.Code, body
+	}
+
+	for _, step := range []struct {
+		action  string
+		enabled bool
+	}{
+		{"toggle", false}, // ConfigureFault enables the fault
+		{"toggle", true},
+		{"disable", false},
+		{"enable", true},
+	} {
+		code, body := fault("latency", step.action)
+		if code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %v", step.action, code, body)
+		}
+		summary, _ := body["fault"].(map[string]interface{})
+		if summary["enabled"] != step.enabled || summary["probability"] != 0.25 || summary["duration"] != "1m0s" {
+			t.Errorf("%s: expected enabled=%t with the configured probability and duration, got %v", step.action, step.enabled, summary)
+		}
+	}
+
+	code, body := fault("processing_fail", "toggle")
+	if code != http.StatusNotFound || body["error"] != "FAULT_NOT_CONFIGURED" {
+		t.Errorf("Expected 404 FAULT_NOT_CONFIGURED for an unconfigured fault, got %d: %v", code, body)
+	}
+	if _, configured := app.redTeam.FaultSummary(redteam.FaultProcessingFail); configured {
+		t.Error("Expected the unconfigured fault to stay unconfigured")
+	}
+}
+
+func TestRedTeamCampaign_AppliesValidCampaignOnly(t *testing.T) {
+	app := setupTestComponents(t)
+	app.redTeam = redteam.NewRedTeam()
+	router := app.setupRouter()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/redteam/campaign", strings.NewReader(body)))
+		return rec
+	}
+
+	rec := post(`{"name":"bad","faults":[{"type":"latency","probability":0.2,"duration":"1m"},{"type":"processing_fail","probability":2,"duration":"1m"}]}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "INVALID_CAMPAIGN") || !strings.Contains(rec.Body.String(), "between 0 and 1") {
+		t.Errorf("Expected 400 INVALID_CAMPAIGN describing the probability, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if _, configured := app.redTeam.FaultSummary(redteam.FaultLatency); configured {
+		t.Error("Expected nothing applied from an invalid campaign")
+	}
+
+	rec = post(`{"name":"steady","faults":[{"type":"latency","probability":0.2,"duration":"1m"}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if summary, configured := app.redTeam.FaultSummary(redteam.FaultLatency); !configured || summary["probability"] != 0.2 {
+		t.Errorf("Expected the campaign's latency fault applied, got %v", summary)
+	}
+}
+
+func TestNewHTTPServer_AppliesTimeoutsAndHeaderLimit(t *testing.T) {
+	sc := config.DefaultConfig().Server
+	server := newHTTPServer(sc, http.NotFoundHandler())
+
+	if server.Addr != "0.0.0.0:8080" {
+		t.Errorf("Expected address 0.0.0.0:8080, got %s", server.Addr)
+	}
+	if server.ReadTimeout != sc.ReadTimeout || server.WriteTimeout != sc.WriteTimeout || server.IdleTimeout != sc.IdleTimeout {
+		t.Errorf("Expected the configured read, write and idle timeouts, got %v, %v, %v",
+			server.ReadTimeout, server.WriteTimeout, server.IdleTimeout)
+	}
+	if server.ReadHeaderTimeout != 5*time.Second {
+		t.Errorf("Expected a 5s read header timeout, got %v", server.ReadHeaderTimeout)
+	}
+	if server.MaxHeaderBytes != 1<<20 {
+		t.Errorf("Expected a 1 MiB header limit, got %d", server.MaxHeaderBytes)
+	}
+}
+
+func TestNewHTTPServer_ClosesSlowHeaderConnections(t *testing.T) {
+	sc := config.DefaultConfig().Server
+	sc.ReadHeaderTimeout = 50 * time.Millisecond
+	server := newHTTPServer(sc, http.NotFoundHandler())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go server.Serve(listener)
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	// Send part of a request line and stall, as a slow-loris client does
+	fmt.Fprint(conn, "GET / HTTP/1.1\r\nHost: localhost\r\n")
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := io.ReadAll(conn); err != nil {
+		t.Errorf("Expected the server to close the stalled connection, got %v", err)
+	}
+}
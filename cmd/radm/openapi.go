@@ -0,0 +1,238 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"anomaly"
+	"internal/redteam"
+)
+
+// apiOperation describes one registered route in the generated OpenAPI document.
+// Request and response schemas are derived from the Go types by reflection,
+// so they cannot drift from what the handlers actually encode.
+type apiOperation struct {
+	Method      string
+	Path        string
+	Summary     string
+	Request     interface{} // Zero value of the JSON request body; nil for none
+	Response    interface{} // Zero value of the JSON 200 body; nil when ContentType is set
+	ContentType string      // Non-JSON 200 content type, e.g. text/plain
+	Errors      []int       // Status codes answered with an ErrorResponse
+}
+
+// apiOperations lists every route served by setupRouter.
+var apiOperations = []apiOperation{
+	{Method: http.MethodGet, Path: "/healthz", Summary: "Liveness probe", ContentType: "text/plain"},
+	{Method: http.MethodGet, Path: "/readyz", Summary: "Readiness probe; 503 with NOT_READY until initialized or while a cached dependency check fails", ContentType: "text/plain"},
+	{Method: http.MethodGet, Path: "/metrics", Summary: "Service metrics", Response: map[string]interface{}{}},
+	{Method: http.MethodGet, Path: "/sboh", Summary: "Software Bill of Health report", Response: map[string]interface{}{},
+		Errors: []int{http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/monetization/reconcile", Summary: "Decisions recorded versus PoV records persisted (Axiom A-4)", Response: ReconcileReport{},
+		Errors: []int{http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/monetization/rollup", Summary: "Decisions, anomalies and revenue per period; query period and since", Response: RollupResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/redteam/status", Summary: "Red Team fault injection status", Response: map[string]interface{}{}},
+	{Method: http.MethodPost, Path: "/redteam/fault/{type}", Summary: "Enable or disable an injected fault", Response: map[string]interface{}{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusServiceUnavailable}},
+	{Method: http.MethodPost, Path: "/redteam/campaign", Summary: "Apply a scheduled fault campaign", Request: redteam.Campaign{}, Response: map[string]interface{}{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/blueteam/status", Summary: "Blue Team healing status", Response: map[string]interface{}{}},
+	{Method: http.MethodPost, Path: "/blueteam/heal/{type}", Summary: "Trigger a healing action", Response: map[string]interface{}{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/audit/events", Summary: "Recent audit events, filtered by type, status, since and until", Response: map[string]interface{}{},
+		Errors: []int{http.StatusBadRequest, http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/audit/compliance", Summary: "Audit compliance report", Response: map[string]interface{}{},
+		Errors: []int{http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/anomalies/recent", Summary: "Recent anomalies, limited by limit, and open anomaly episodes", Response: map[string]interface{}{},
+		Errors: []int{http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/config/env", Summary: "Effective configuration as environment variables", ContentType: "text/plain",
+		Errors: []int{http.StatusUnauthorized}},
+	{Method: http.MethodGet, Path: "/openapi.json", Summary: "This OpenAPI document", Response: map[string]interface{}{}},
+	{Method: http.MethodGet, Path: "/debug/state", Summary: "Diagnostic bundle of every subsystem's state; requires SERVER_DEBUG_ENDPOINTS", Response: map[string]interface{}{},
+		Errors: []int{http.StatusUnauthorized, http.StatusNotFound}},
+	{Method: http.MethodPost, Path: "/detector/standby", Summary: "Stage a standby detector", Response: map[string]interface{}{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+	{Method: http.MethodPost, Path: "/detector/standby/prime", Summary: "Prime the standby detector", Response: map[string]interface{}{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusConflict}},
+	{Method: http.MethodPost, Path: "/detector/standby/promote", Summary: "Promote the standby detector to live", Response: map[string]interface{}{},
+		Errors: []int{http.StatusUnauthorized, http.StatusConflict}},
+	{Method: http.MethodPut, Path: "/series/{name}/mode", Summary: "Set the detection mode (zscore, percent_change, mad, seasonal) of one series", Request: SeriesModeRequest{}, Response: SeriesModeResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/series/{name}/recent", Summary: "Last decisions of one series, newest first, limited by limit", Response: map[string]interface{}{},
+		Errors: []int{http.StatusNotFound, http.StatusServiceUnavailable}},
+	{Method: http.MethodPost, Path: "/api/v1/feedback", Summary: "Report whether a decision was a true or false positive or negative", Request: FeedbackRequest{}, Response: map[string]interface{}{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/api/v1/detector/quality", Summary: "Precision, recall and false-positive rate over the feedback window", Response: anomaly.QualityReport{},
+		Errors: []int{http.StatusServiceUnavailable}},
+	{Method: http.MethodPost, Path: "/api/v1/data/ingest", Summary: "Ingest a single data point; ?explain=true adds the scoring breakdown", Request: anomaly.DataPoint{}, Response: Response{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusRequestEntityTooLarge, http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable}},
+	{Method: http.MethodPost, Path: "/api/v1/data/ingest/batch", Summary: "Ingest a batch of data points; ?summary_only=true returns just the aggregate", Request: []anomaly.DataPoint{}, Response: BatchResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusRequestEntityTooLarge, http.StatusTooManyRequests, http.StatusServiceUnavailable}},
+	{Method: http.MethodPost, Path: "/api/v1/data/stream", Summary: "Ingest a newline-delimited JSON stream of data points, answering one StreamResult line per point", Request: anomaly.DataPoint{}, ContentType: "application/x-ndjson",
+		Errors: []int{http.StatusUnauthorized, http.StatusTooManyRequests, http.StatusServiceUnavailable}},
+}
+
+// openapiHandler serves the OpenAPI 3 document describing every endpoint.
+func openapiHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(openapiSpec())
+}
+
+// openapiSpec builds the OpenAPI document from apiOperations.
+func openapiSpec() map[string]interface{} {
+	schemas := map[string]interface{}{}
+	errorRef := schemaFor(reflect.TypeOf(ErrorResponse{}), schemas)
+
+	paths := map[string]map[string]interface{}{}
+	for _, op := range apiOperations {
+		operation := map[string]interface{}{"summary": op.Summary}
+
+		var parameters []interface{}
+		for _, segment := range strings.Split(op.Path, "/") {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				parameters = append(parameters, map[string]interface{}{
+					"name":     strings.Trim(segment, "{}"),
+					"in":       "path",
+					"required": true,
+					"schema":   map[string]interface{}{"type": "string"},
+				})
+			}
+		}
+		if parameters != nil {
+			operation["parameters"] = parameters
+		}
+
+		if op.Request != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  jsonContent(schemaFor(reflect.TypeOf(op.Request), schemas)),
+			}
+		}
+
+		ok := map[string]interface{}{"description": http.StatusText(http.StatusOK)}
+		if op.Response != nil {
+			ok["content"] = jsonContent(schemaFor(reflect.TypeOf(op.Response), schemas))
+		} else if op.ContentType != "" {
+			ok["content"] = map[string]interface{}{
+				op.ContentType: map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+			}
+		}
+		responses := map[string]interface{}{"200": ok}
+		for _, code := range op.Errors {
+			responses[strconv.Itoa(code)] = map[string]interface{}{
+				"description": http.StatusText(code),
+				"content":     jsonContent(errorRef),
+			}
+		}
+		operation["responses"] = responses
+
+		if paths[op.Path] == nil {
+			paths[op.Path] = map[string]interface{}{}
+		}
+		paths[op.Path][strings.ToLower(op.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":   "RADM - Real-Time Anomaly Detection Microservice API",
+			"version": "1.0.0",
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas},
+	}
+}
+
+// jsonContent wraps schema as an application/json media type.
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schema},
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaFor returns the JSON schema for t, registering named structs in schemas
+// and referring to them by $ref.
+func schemaFor(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		if _, exists := schemas[t.Name()]; !exists {
+			schemas[t.Name()] = nil // Reserve the name so recursive types terminate
+			schemas[t.Name()] = structSchema(t, schemas)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		return structSchema(t, schemas)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema describes a struct's JSON encoding. Fields without omitempty
+// are always encoded and so are listed as required; embedded structs are flattened.
+func structSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+				walk(field.Type)
+				continue
+			}
+			if !field.IsExported() || tag == "-" {
+				continue
+			}
+
+			name, options, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = schemaFor(field.Type, schemas)
+			if !strings.Contains(options, "omitempty") {
+				required = append(required, name)
+			}
+		}
+	}
+	walk(t)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if required != nil {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// decodeSpec fetches /openapi.json and decodes it.
+func decodeSpec(t *testing.T) map[string]interface{} {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	openapiHandler(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+
+	var spec map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("Spec is not valid JSON: %v", err)
+	}
+	return spec
+}
+
+func TestOpenAPISpec_CoversEveryRoute(t *testing.T) {
+	app := setupTestComponents(t)
+	paths, _ := decodeSpec(t)["paths"].(map[string]interface{})
+
+	routes := 0
+	err := chi.Walk(app.setupRouter(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routes++
+		operations, _ := paths[route].(map[string]interface{})
+		if _, exists := operations[strings.ToLower(method)]; !exists {
+			t.Errorf("Route %s %s is missing from the OpenAPI spec", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to walk routes: %v", err)
+	}
+	if routes != len(apiOperations) {
+		t.Errorf("Spec lists %d operations but the router serves %d", len(apiOperations), routes)
+	}
+}
+
+func TestOpenAPISpec_IngestRequestSchema(t *testing.T) {
+	spec := decodeSpec(t)
+	if spec["openapi"] != "3.1.0" {
+		t.Errorf("Unexpected openapi version %v", spec["openapi"])
+	}
+
+	paths := spec["paths"].(map[string]interface{})
+	ingest, ok := paths["/api/v1/data/ingest"].(map[string]interface{})["post"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected POST /api/v1/data/ingest in spec")
+	}
+
+	content := ingest["requestBody"].(map[string]interface{})["content"].(map[string]interface{})
+	ref := content["application/json"].(map[string]interface{})["schema"].(map[string]interface{})["$ref"]
+	if ref != "#/components/schemas/DataPoint" {
+		t.Fatalf("Expected DataPoint request schema, got %v", ref)
+	}
+
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	dataPoint := schemas["DataPoint"].(map[string]interface{})
+	properties := dataPoint["properties"].(map[string]interface{})
+	for _, field := range []string{"timestamp", "value", "series_id", "metadata"} {
+		if _, exists := properties[field]; !exists {
+			t.Errorf("Expected DataPoint property %q", field)
+		}
+	}
+	required, _ := json.Marshal(dataPoint["required"])
+	if string(required) != `["timestamp","value"]` {
+		t.Errorf("Expected timestamp and value required, got %s", required)
+	}
+
+	for _, name := range []string{"Response", "ErrorResponse", "BatchResponse"} {
+		if _, exists := schemas[name]; !exists {
+			t.Errorf("Expected %s schema in components", name)
+		}
+	}
+	if _, exists := ingest["responses"].(map[string]interface{})["429"]; !exists {
+		t.Error("Expected 429 response on ingest")
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// readinessCheck probes one dependency; a non-nil error means it is not ready.
+type readinessCheck struct {
+	name  string
+	probe func() error
+}
+
+// readinessProbe runs the readiness checks, each bounded by a timeout, and
+// reuses the result for a TTL so frequent /readyz probes do not repeat the
+// I/O. Concurrent probes past the TTL wait for a single run.
+type readinessProbe struct {
+	mu        sync.Mutex
+	checks    []readinessCheck
+	timeout   time.Duration // Longest one check may run; 0 is unbounded
+	ttl       time.Duration // How long a result is reused; 0 runs the checks on every probe
+	checkedAt time.Time     // When the cached result was computed; zero before the first run
+	err       error         // Cached result
+	now       func() time.Time
+}
+
+func newReadinessProbe(timeout, ttl time.Duration) *readinessProbe {
+	return &readinessProbe{timeout: timeout, ttl: ttl, now: time.Now}
+}
+
+// Add registers a check run on every probe not served from the cache.
+func (p *readinessProbe) Add(name string, probe func() error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checks = append(p.checks, readinessCheck{name: name, probe: probe})
+	p.checkedAt = time.Time{} // The cached result does not cover the new check
+}
+
+// Check returns the first failing check's error, or nil when every check
+// passed, reusing a result computed within the TTL.
+func (p *readinessProbe) Check() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if !p.checkedAt.IsZero() && now.Sub(p.checkedAt) < p.ttl {
+		return p.err
+	}
+
+	p.err = nil
+	for _, check := range p.checks {
+		if err := p.run(check); err != nil {
+			p.err = fmt.Errorf("%s: %w", check.name, err)
+			log.Printf("Readiness: %v", p.err)
+			break
+		}
+	}
+	p.checkedAt = now
+	return p.err
+}
+
+// run runs check, failing it once the timeout passes. A timed-out probe is
+// left to finish in the background.
+func (p *readinessProbe) run(check readinessCheck) error {
+	if p.timeout <= 0 {
+		return check.probe()
+	}
+
+	result := make(chan error, 1)
+	go func() { result <- check.probe() }()
+
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-result:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("check timed out after %v", p.timeout)
+	}
+}
+
+// probeAppendable reports whether path can be opened for appending, as the
+// auditor and monetization tracker do when writing records.
+func probeAppendable(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// probeReady calls the /readyz handler and returns its status code and body.
+func probeReady(app *App) (int, string) {
+	rec := httptest.NewRecorder()
+	app.readyCheckHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestReadyz_CachesDependencyChecks(t *testing.T) {
+	app := setupTestComponents(t)
+	now := time.Unix(1700000000, 0)
+	app.readiness = newReadinessProbe(time.Second, 5*time.Second)
+	app.readiness.now = func() time.Time { return now }
+
+	calls := 0
+	app.readiness.Add("counted", func() error {
+		calls++
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if code, body := probeReady(app); code != http.StatusOK || body != "READY" {
+			t.Fatalf("Expected 200 READY, got %d %s", code, body)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected probes within the TTL to reuse one check, got %d checks", calls)
+	}
+
+	// A probe past the TTL runs the checks again
+	now = now.Add(5 * time.Second)
+	probeReady(app)
+	if calls != 2 {
+		t.Errorf("Expected the check to run again after the TTL, got %d checks", calls)
+	}
+}
+
+func TestReadyz_CheckTimeoutReportsNotReady(t *testing.T) {
+	app := setupTestComponents(t)
+	app.readiness = newReadinessProbe(20*time.Millisecond, 0)
+
+	release := make(chan struct{})
+	defer close(release)
+	app.readiness.Add("stuck", func() error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	if code, body := probeReady(app); code != http.StatusServiceUnavailable || body != "NOT_READY" {
+		t.Errorf("Expected 503 NOT_READY, got %d %s", code, body)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the probe to give up after its timeout, took %v", elapsed)
+	}
+}
+
+func TestProbeAppendable(t *testing.T) {
+	if err := probeAppendable(filepath.Join(t.TempDir(), "audit.log")); err != nil {
+		t.Errorf("Expected a writable path to pass, got %v", err)
+	}
+	if err := probeAppendable(filepath.Join(t.TempDir(), "missing", "audit.log")); err == nil {
+		t.Error("Expected a path in a missing directory to fail")
+	}
+}
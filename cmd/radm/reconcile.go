@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// ReconcileReport compares the decisions the hypervisor recorded with the PoV
+// records actually persisted, proving Axiom A-4 end to end.
+type ReconcileReport struct {
+	Timestamp           time.Time `json:"timestamp"`
+	HypervisorDecisions int64     `json:"hypervisor_decisions"`
+	PoVRecorded         int64     `json:"pov_recorded"`
+	PoVPersisted        int64     `json:"pov_persisted"`
+	PersistFailures     int64     `json:"persist_failures"`
+	PersistRetries      int64     `json:"persist_retries"` // Failed writes that were tried again
+	Discrepancy         int64     `json:"discrepancy"`     // HypervisorDecisions - PoVPersisted
+	Reconciled          bool      `json:"reconciled"`
+}
+
+// monetizationReconcileHandler serves the monetization reconciliation report.
+func (a *App) monetizationReconcileHandler(w http.ResponseWriter, r *http.Request) {
+	if a.monTracker == nil || a.hypervisor == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "MONETIZATION_UNAVAILABLE",
+			"Monetization tracking not initialized")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(a.reconcileMonetization())
+}
+
+// reconcileMonetization builds the reconciliation report, logging and auditing
+// any discrepancy as an Axiom A-4 compliance failure.
+func (a *App) reconcileMonetization() ReconcileReport {
+	// Persistence stats wait for in-flight writes, so read the hypervisor after them
+	stats := a.monTracker.PersistenceStats()
+	report := ReconcileReport{
+		Timestamp:           time.Now(),
+		HypervisorDecisions: a.hypervisor.BilledDecisions(),
+		PoVRecorded:         stats.Recorded,
+		PoVPersisted:        stats.Persisted,
+		PersistFailures:     stats.Failed,
+		PersistRetries:      stats.Retried,
+	}
+	report.Discrepancy = report.HypervisorDecisions - report.PoVPersisted
+	report.Reconciled = report.Discrepancy == 0
+
+	if !report.Reconciled {
+		log.Printf("Monetization reconciliation discrepancy: decisions=%d, persisted=%d, failures=%d",
+			report.HypervisorDecisions, report.PoVPersisted, report.PersistFailures)
+	}
+	if a.auditor != nil {
+		a.auditor.LogCompliance("ζ-Hypervisor", "A-4", report.Reconciled, map[string]interface{}{
+			"hypervisor_decisions": report.HypervisorDecisions,
+			"pov_persisted":        report.PoVPersisted,
+			"discrepancy":          report.Discrepancy,
+		})
+	}
+	return report
+}
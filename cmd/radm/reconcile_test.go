@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"anomaly"
+	"internal/audit"
+	"internal/monetization"
+)
+
+// reconcile ingests n points through app and returns the reconcile report.
+func reconcile(t *testing.T, app *App, n int) ReconcileReport {
+	t.Helper()
+
+	now := time.Now().Unix()
+	for i := 0; i < n; i++ {
+		rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: 10})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	app.monetizationReconcileHandler(rec, httptest.NewRequest(http.MethodGet, "/monetization/reconcile", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var report ReconcileReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to decode report: %v", err)
+	}
+	return report
+}
+
+func TestMonetizationReconcile_HealthyRunHasNoDiscrepancy(t *testing.T) {
+	app := setupTestComponents(t)
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:  0.001,
+		OutputFile: filepath.Join(t.TempDir(), "pov_records.jsonl"),
+	})
+
+	report := reconcile(t, app, 3)
+	if report.HypervisorDecisions != 3 || report.PoVRecorded != 3 || report.PoVPersisted != 3 {
+		t.Errorf("Expected 3 decisions recorded and persisted, got %+v", report)
+	}
+	if report.Discrepancy != 0 || !report.Reconciled || report.PersistFailures != 0 {
+		t.Errorf("Expected a reconciled report, got %+v", report)
+	}
+}
+
+func TestMonetizationReconcile_RetriedDecisionBilledOnce(t *testing.T) {
+	app := setupTestComponents(t)
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:   0.001,
+		OutputFile:  filepath.Join(t.TempDir(), "pov_records.jsonl"),
+		DedupWindow: 100,
+	})
+
+	// The client retries the same point after a network failure
+	point := anomaly.DataPoint{Timestamp: time.Now().Unix(), Value: 10}
+	var responses []Response
+	for i := 0; i < 2; i++ {
+		rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", point)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp Response
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+	if responses[0].Duplicate || responses[0].Price == 0 {
+		t.Errorf("Expected the first decision billed, got %+v", responses[0])
+	}
+	if !responses[1].Duplicate || responses[1].Price != 0 {
+		t.Errorf("Expected the retry flagged as a duplicate and not billed, got %+v", responses[1])
+	}
+
+	report := reconcile(t, app, 0)
+	if report.HypervisorDecisions != 1 || report.PoVPersisted != 1 || !report.Reconciled {
+		t.Errorf("Expected the retried decision billed once, got %+v", report)
+	}
+	if total := app.monTracker.GetTotalValue(); total != responses[0].Price {
+		t.Errorf("Expected revenue %v, got %v", responses[0].Price, total)
+	}
+}
+
+func TestMonetizationReconcile_ReportsDroppedPersistence(t *testing.T) {
+	app := setupTestComponents(t)
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:  0.001,
+		OutputFile: filepath.Join(t.TempDir(), "missing", "pov_records.jsonl"), // Directory does not exist
+	})
+
+	report := reconcile(t, app, 2)
+	if report.HypervisorDecisions != 2 || report.PoVRecorded != 2 || report.PoVPersisted != 0 {
+		t.Errorf("Expected 2 decisions recorded and none persisted, got %+v", report)
+	}
+	if report.Discrepancy != 2 || report.Reconciled || report.PersistFailures != 2 || report.PersistRetries == 0 {
+		t.Errorf("Expected a discrepancy of 2 after retried writes, got %+v", report)
+	}
+
+	events := app.auditor.GetEvents(1)
+	if len(events) != 1 || events[0].Status != audit.StatusNonCompliant {
+		t.Errorf("Expected the discrepancy audited as non-compliant, got %+v", events)
+	}
+}
+
+func TestMonetizationReconcile_UnavailableWithoutTracker(t *testing.T) {
+	app := setupTestComponents(t)
+
+	rec := httptest.NewRecorder()
+	app.monetizationReconcileHandler(rec, httptest.NewRequest(http.MethodGet, "/monetization/reconcile", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 without a monetization tracker, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"internal/config"
+)
+
+// reloadCoordinator runs configuration reloads one at a time. A reload
+// requested while another is running is coalesced with any other waiting
+// requests into a single follow-up run, so every trigger observes a reload
+// that started after it arrived.
+type reloadCoordinator struct {
+	mu         sync.Mutex
+	apply      func(source string, triggers int, current *config.Config) (*config.Config, error)
+	current    *config.Config // Last configuration applied successfully
+	running    bool
+	next       *reloadCall // Coalesced requests waiting for the running reload
+	requests   int64
+	runs       int64
+	coalesced  int64
+	failures   int64
+	lastReload time.Time
+	lastError  string
+}
+
+// reloadCall is one reload run and the requests waiting on its result.
+type reloadCall struct {
+	source   string
+	triggers int
+	done     chan struct{}
+	err      error
+}
+
+// newReloadCoordinator creates a coordinator starting from initial. apply
+// loads and applies a new configuration given the current one.
+func newReloadCoordinator(initial *config.Config, apply func(source string, triggers int, current *config.Config) (*config.Config, error)) *reloadCoordinator {
+	return &reloadCoordinator{apply: apply, current: initial}
+}
+
+// Reload requests a reload from source and waits for its result.
+func (rc *reloadCoordinator) Reload(source string) error {
+	rc.mu.Lock()
+	rc.requests++
+	if rc.running {
+		if rc.next == nil {
+			rc.next = &reloadCall{source: source, done: make(chan struct{})}
+		}
+		call := rc.next
+		call.triggers++
+		rc.coalesced++
+		rc.mu.Unlock()
+
+		<-call.done
+		return call.err
+	}
+	rc.running = true
+	first := &reloadCall{source: source, triggers: 1, done: make(chan struct{})}
+	rc.mu.Unlock()
+
+	// Run until no requests arrived during the last reload
+	for call := first; call != nil; {
+		rc.mu.Lock()
+		current := rc.current
+		rc.mu.Unlock()
+
+		applied, err := rc.apply(call.source, call.triggers, current)
+		call.err = err
+
+		rc.mu.Lock()
+		rc.runs++
+		rc.lastReload = time.Now()
+		rc.lastError = ""
+		if err != nil {
+			rc.failures++
+			rc.lastError = err.Error()
+		} else {
+			rc.current = applied
+		}
+		close(call.done)
+		call, rc.next = rc.next, nil
+		rc.running = call != nil
+		rc.mu.Unlock()
+	}
+	return first.err
+}
+
+// Current returns the last configuration applied successfully.
+func (rc *reloadCoordinator) Current() *config.Config {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return rc.current
+}
+
+// Stats returns reload counters.
+func (rc *reloadCoordinator) Stats() map[string]interface{} {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	stats := map[string]interface{}{
+		"requests":    rc.requests,
+		"runs":        rc.runs,
+		"coalesced":   rc.coalesced,
+		"failures":    rc.failures,
+		"in_progress": rc.running,
+	}
+	if !rc.lastReload.IsZero() {
+		stats["last_reload"] = rc.lastReload
+	}
+	if rc.lastError != "" {
+		stats["last_error"] = rc.lastError
+	}
+	return stats
+}
+
+// reloadConfig reloads the configuration from the environment on behalf of source.
+func (a *App) reloadConfig(source string) error {
+	if a.reloads == nil {
+		return fmt.Errorf("configuration reload not initialized")
+	}
+	return a.reloads.Reload(source)
+}
+
+// applyReload loads and validates the configuration, then applies the
+// settings that can change at runtime: the live detector's threshold and the
+// TLS certificate, read again from its files. Other settings take effect on
+// restart. Every outcome is audited.
+func (a *App) applyReload(source string, triggers int, current *config.Config) (*config.Config, error) {
+	cfg, err := config.Load()
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		err = fmt.Errorf("invalid configuration: %w", err)
+		log.Printf("Configuration reload failed: Source=%s, %v", source, err)
+		if a.auditor != nil {
+			a.auditor.LogConfigReload(source, triggers, err, nil)
+		}
+		return nil, err
+	}
+
+	if cfg.Detector.Threshold != current.Detector.Threshold {
+		a.liveDetector().AdjustThreshold(cfg.Detector.Threshold)
+	}
+
+	changes := map[string]interface{}{
+		"threshold": cfg.Detector.Threshold,
+	}
+
+	// A certificate that fails to load leaves the previous one in service
+	if a.certs != nil {
+		if certErr := a.certs.Reload(); certErr != nil {
+			log.Printf("TLS certificate reload failed, keeping the current certificate: %v", certErr)
+			changes["tls_certificate_error"] = certErr.Error()
+		} else {
+			changes["tls_certificate_reloaded"] = true
+		}
+	}
+
+	log.Printf("Configuration reloaded: Source=%s, Triggers=%d, Threshold=%.2f", source, triggers, cfg.Detector.Threshold)
+	if a.auditor != nil {
+		a.auditor.LogConfigReload(source, triggers, nil, changes)
+	}
+	return cfg, nil
+}
+
+// watchReloadSignal reloads the configuration on SIGHUP. Each signal is
+// handled on its own goroutine, so signals arriving during a reload coalesce.
+func (a *App) watchReloadSignal() {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+
+	go func() {
+		for range c {
+			go a.reloadConfig("sighup")
+		}
+	}()
+}
+
+// getReloadStats returns configuration reload counters.
+func (a *App) getReloadStats() map[string]interface{} {
+	if a.reloads == nil {
+		return nil
+	}
+	return a.reloads.Stats()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"internal/audit"
+	"internal/config"
+)
+
+func TestReloadCoordinator_ConcurrentReloadsCoalesce(t *testing.T) {
+	t.Setenv("AD_THRESHOLD", "4.2")
+	app := setupTestComponents(t)
+
+	// Slow reloads down so triggers pile up behind the running one
+	var active, maxActive atomic.Int32
+	app.reloads = newReloadCoordinator(app.cfg, func(source string, triggers int, current *config.Config) (*config.Config, error) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		if n > maxActive.Load() {
+			maxActive.Store(n)
+		}
+		time.Sleep(20 * time.Millisecond)
+		return app.applyReload(source, triggers, current)
+	})
+
+	const triggers = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, triggers)
+	for i := 0; i < triggers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- app.reloadConfig("test")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Unexpected reload error: %v", err)
+		}
+	}
+	if maxActive.Load() != 1 {
+		t.Errorf("Expected reloads to run one at a time, saw %d at once", maxActive.Load())
+	}
+
+	stats := app.reloads.Stats()
+	requests, runs, coalesced := stats["requests"].(int64), stats["runs"].(int64), stats["coalesced"].(int64)
+	if requests != triggers {
+		t.Errorf("Expected %d reload requests, got %d", triggers, requests)
+	}
+	if runs >= triggers || coalesced == 0 {
+		t.Errorf("Expected concurrent triggers to coalesce, got runs=%d coalesced=%d", runs, coalesced)
+	}
+	if stats["in_progress"] != false {
+		t.Errorf("Expected no reload in progress, got %v", stats["in_progress"])
+	}
+
+	if got := app.reloads.Current().Detector.Threshold; got != 4.2 {
+		t.Errorf("Expected the applied config threshold 4.2, got %.2f", got)
+	}
+	if got := app.liveDetector().Threshold; got != 4.2 {
+		t.Errorf("Expected the live detector threshold 4.2, got %.2f", got)
+	}
+
+	events := app.auditor.QueryEvents(audit.EventFilter{Types: []audit.EventType{audit.EventConfigReload}})
+	if int64(len(events)) != runs {
+		t.Errorf("Expected one audit event per reload run (%d), got %d", runs, len(events))
+	}
+}
+
+func TestReloadConfig_InvalidConfigKeepsCurrent(t *testing.T) {
+	t.Setenv("AD_THRESHOLD", "-1")
+	app := setupTestComponents(t)
+	app.reloads = newReloadCoordinator(app.cfg, app.applyReload)
+
+	if err := app.reloadConfig("test"); err == nil {
+		t.Fatal("Expected an invalid configuration to fail the reload")
+	}
+	if app.reloads.Current() != app.cfg {
+		t.Error("Expected the current configuration to be kept after a failed reload")
+	}
+	if got := app.liveDetector().Threshold; got != app.cfg.Detector.Threshold {
+		t.Errorf("Expected the live threshold to stay %.2f, got %.2f", app.cfg.Detector.Threshold, got)
+	}
+
+	events := app.auditor.QueryEvents(audit.EventFilter{Types: []audit.EventType{audit.EventConfigReload}})
+	if len(events) != 1 || events[0].Status != audit.StatusError {
+		t.Errorf("Expected one failed reload audit event, got %+v", events)
+	}
+}
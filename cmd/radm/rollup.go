@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"internal/monetization"
+)
+
+// RollupResponse is the revenue of the retained decisions per period.
+type RollupResponse struct {
+	Period   string                       `json:"period"`
+	Since    time.Time                    `json:"since"`
+	Currency string                       `json:"currency"`
+	Periods  []monetization.PeriodSummary `json:"periods"` // Periods with decisions, oldest first
+}
+
+// monetizationRollupHandler serves per-period revenue totals. The period query
+// parameter is a Go duration (default 1h) and since an RFC 3339 time (default
+// 24 hours ago).
+func (a *App) monetizationRollupHandler(w http.ResponseWriter, r *http.Request) {
+	if a.monTracker == nil {
+		writeErrorResponse(w, http.StatusServiceUnavailable, "MONETIZATION_UNAVAILABLE",
+			"Monetization tracking not initialized")
+		return
+	}
+
+	period := time.Hour
+	if periodStr := r.URL.Query().Get("period"); periodStr != "" {
+		parsed, err := time.ParseDuration(periodStr)
+		if err != nil || parsed <= 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PERIOD",
+				"period must be a positive duration such as 1h or 24h")
+			return
+		}
+		period = parsed
+	}
+	since := time.Now().Add(-24 * time.Hour)
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		parsed, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_SINCE",
+				"since must be an RFC 3339 time")
+			return
+		}
+		since = parsed
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(RollupResponse{
+		Period:   period.String(),
+		Since:    since,
+		Currency: a.monTracker.Currency(),
+		Periods:  a.monTracker.GetRollup(period, since),
+	})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"anomaly"
+	"internal/monetization"
+)
+
+// setupRollupApp returns test components with monetization tracking.
+func setupRollupApp(t *testing.T) *App {
+	t.Helper()
+	app := setupTestComponents(t)
+	app.monTracker = monetization.NewTracker(monetization.Config{
+		BasePrice:  0.001,
+		OutputFile: filepath.Join(t.TempDir(), "pov_records.jsonl"),
+	})
+	return app
+}
+
+func TestMonetizationRollup_TotalsIngestedDecisions(t *testing.T) {
+	app := setupRollupApp(t)
+
+	now := time.Now().Unix()
+	for i := 0; i < 3; i++ {
+		rec := postJSON(t, app.ingestHandler, "/api/v1/data/ingest", anomaly.DataPoint{Timestamp: now + int64(i), Value: 10})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	app.monetizationRollupHandler(rec, httptest.NewRequest(http.MethodGet, "/monetization/rollup?period=24h", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var rollup RollupResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &rollup); err != nil {
+		t.Fatalf("Failed to decode rollup: %v", err)
+	}
+
+	decisions, revenue := 0, 0.0
+	for _, period := range rollup.Periods {
+		decisions += period.DecisionCount
+		revenue += period.Revenue
+	}
+	if decisions != 3 || rollup.Period != "24h0m0s" {
+		t.Errorf("Expected 3 decisions in 24h periods, got %d in %s", decisions, rollup.Period)
+	}
+	if total := app.monTracker.GetTotalValue(); revenue < total-1e-9 || revenue > total+1e-9 {
+		t.Errorf("Expected the rollup revenue %v to match the total %v", revenue, total)
+	}
+}
+
+func TestMonetizationRollup_InvalidQuery(t *testing.T) {
+	app := setupRollupApp(t)
+
+	for _, query := range []string{"period=0s", "period=hourly", "since=yesterday"} {
+		rec := httptest.NewRecorder()
+		app.monetizationRollupHandler(rec, httptest.NewRequest(http.MethodGet, "/monetization/rollup?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", query, rec.Code)
+		}
+	}
+}
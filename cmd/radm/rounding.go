@@ -0,0 +1,83 @@
+package main
+
+import (
+	"math"
+
+	"anomaly"
+)
+
+// outputRounder rounds the statistics in responses to a fixed number of
+// decimal places, so identical inputs serialize to identical bytes whatever
+// the platform's floating-point noise. The value echoes the client's input
+// and prices are already rounded to minor units, so both are left alone.
+type outputRounder struct {
+	scale float64 // 10^decimals; 0 disables rounding
+}
+
+// newOutputRounder rounds to decimals places; decimals <= 0 disables rounding.
+func newOutputRounder(decimals int) outputRounder {
+	if decimals <= 0 {
+		return outputRounder{}
+	}
+	return outputRounder{scale: math.Pow10(decimals)}
+}
+
+// round rounds x half away from zero. Non-finite values, and values too large
+// to scale without overflowing, such as the math.MaxFloat64 z-score of a
+// spike over a zero-variance window, are returned as is.
+func (o outputRounder) round(x float64) float64 {
+	if o.scale == 0 || math.IsNaN(x) || math.Abs(x) > math.MaxFloat64/o.scale {
+		return x
+	}
+	return math.Round(x*o.scale) / o.scale
+}
+
+// Response rounds the z-score and the optional window, ratio and explanation
+// statistics of response in place.
+func (o outputRounder) Response(response *Response) {
+	if o.scale == 0 {
+		return
+	}
+
+	response.ZScore = o.round(response.ZScore)
+	if w := response.Windows; w != nil {
+		w.DivergencePct = o.round(w.DivergencePct)
+		o.window(&w.Short)
+		o.window(&w.Long)
+	}
+	if r := response.Ratio; r != nil {
+		r.ZScore = o.round(r.ZScore)
+		r.Ratio = o.round(r.Ratio)
+	}
+	if e := response.Explain; e != nil {
+		e.Mean = o.round(e.Mean)
+		e.Variance = o.round(e.Variance)
+		e.StdDev = o.round(e.StdDev)
+		e.EffectiveStdDev = o.round(e.EffectiveStdDev)
+		e.RawZScore = o.round(e.RawZScore)
+		e.Baseline = o.round(e.Baseline)
+		e.Median = o.round(e.Median)
+		e.MAD = o.round(e.MAD)
+	}
+}
+
+// Batch rounds every result of batch and its aggregate z-scores in place.
+func (o outputRounder) Batch(batch *BatchResponse) {
+	if o.scale == 0 {
+		return
+	}
+
+	for i := range batch.Results {
+		o.Response(&batch.Results[i])
+	}
+	batch.Aggregate.MinZScore = o.round(batch.Aggregate.MinZScore)
+	batch.Aggregate.MaxZScore = o.round(batch.Aggregate.MaxZScore)
+	batch.Aggregate.MeanZScore = o.round(batch.Aggregate.MeanZScore)
+}
+
+// window rounds the statistics of one window of a multi-window detection.
+func (o outputRounder) window(w *anomaly.WindowDetection) {
+	w.ZScore = o.round(w.ZScore)
+	w.Mean = o.round(w.Mean)
+	w.StdDev = o.round(w.StdDev)
+}
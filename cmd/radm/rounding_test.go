@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"anomaly"
+)
+
+// processingNSField matches the measured latency, the one field expected to differ.
+var processingNSField = regexp.MustCompile(`"processing_ns":\d+`)
+
+func TestIngest_RoundedOutputIsByteIdentical(t *testing.T) {
+	now := time.Now().Unix()
+	values := []float64{10.1, 10.3, 9.7, 10.2, 31.4159}
+
+	// Replay the same input against fresh apps, as on two platforms or restarts
+	ingest := func() [][]byte {
+		app := setupTestComponents(t)
+		app.cfg.Detector.OutputDecimals = 4
+
+		var bodies [][]byte
+		for i, value := range values {
+			payload, _ := json.Marshal(anomaly.DataPoint{Timestamp: now + int64(i), Value: value})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest?explain=true", bytes.NewReader(payload))
+			rec := httptest.NewRecorder()
+			app.ingestHandler(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			bodies = append(bodies, processingNSField.ReplaceAll(rec.Body.Bytes(), []byte(`"processing_ns":0`)))
+		}
+		return bodies
+	}
+
+	first, second := ingest(), ingest()
+	for i := range first {
+		if !bytes.Equal(first[i], second[i]) {
+			t.Errorf("Expected byte-identical responses for point %d, got\n%s\n%s", i, first[i], second[i])
+		}
+	}
+
+	var last Response
+	if err := json.Unmarshal(first[len(first)-1], &last); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if last.ZScore == 0 || last.ZScore != math.Round(last.ZScore*1e4)/1e4 {
+		t.Errorf("Expected the z-score rounded to 4 decimals, got %v", last.ZScore)
+	}
+	if last.Explain == nil || last.Explain.StdDev != math.Round(last.Explain.StdDev*1e4)/1e4 {
+		t.Errorf("Expected the explanation rounded to 4 decimals, got %+v", last.Explain)
+	}
+	if last.Value != 31.4159 {
+		t.Errorf("Expected the value echoed unrounded, got %v", last.Value)
+	}
+}
+
+func TestOutputRounder_Round(t *testing.T) {
+	response := Response{ZScore: 1.23456789}
+	newOutputRounder(0).Response(&response)
+	if response.ZScore != 1.23456789 {
+		t.Errorf("Expected the z-score unchanged without rounding, got %v", response.ZScore)
+	}
+
+	rounder := newOutputRounder(2)
+	if got := rounder.round(math.Inf(1)); !math.IsInf(got, 1) {
+		t.Errorf("Expected infinity unchanged, got %v", got)
+	}
+	if got := rounder.round(-1.005001); got != -1.01 {
+		t.Errorf("Expected -1.01, got %v", got)
+	}
+	if got := rounder.round(-math.MaxFloat64); got != -math.MaxFloat64 {
+		t.Errorf("Expected -MaxFloat64 unchanged, got %v", got)
+	}
+}
+
+func TestIngest_RoundedSpikeOverConstantWindow(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Detector.OutputDecimals = 4
+	app.detector.Mode = anomaly.ModeMAD
+	now := time.Now().Unix()
+
+	// A constant window has a zero MAD, so the spike scores math.MaxFloat64
+	var rec *httptest.ResponseRecorder
+	for i, value := range []float64{10, 10, 10, 10, 10, 50} {
+		payload, _ := json.Marshal(anomaly.DataPoint{Timestamp: now + int64(i), Value: value})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/data/ingest?explain=true", bytes.NewReader(payload))
+		rec = httptest.NewRecorder()
+		app.ingestHandler(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 for point %d, got %d: %s", i, rec.Code, rec.Body.String())
+		}
+	}
+
+	var response Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !response.IsAnomaly || response.ZScore != math.MaxFloat64 {
+		t.Errorf("Expected the spike flagged with z-score MaxFloat64, got anomaly=%v z=%v", response.IsAnomaly, response.ZScore)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"anomaly"
+)
+
+// maxStreamLineSize caps a single NDJSON line on the stream endpoint.
+const maxStreamLineSize = 1 << 20
+
+// StreamResult is one NDJSON line written by the stream endpoint, carrying
+// either the result of a point or the reason it was rejected.
+type StreamResult struct {
+	Index  int         `json:"index"` // Position of the point in the stream, counting non-empty lines
+	Result *Response   `json:"result,omitempty"`
+	Error  *BatchError `json:"error,omitempty"`
+}
+
+// streamIngestHandler reads a newline-delimited JSON stream of data points,
+// scoring each one as it arrives and writing a result line per point. The
+// connection stays open until the client closes the body, the request is
+// cancelled, the server shuts down or SERVER_STREAM_MAX_DURATION elapses.
+func (a *App) streamIngestHandler(w http.ResponseWriter, r *http.Request) {
+	deadline := time.Now().Add(a.cfg.Server.StreamMaxDuration)
+	ctx, cancel := context.WithDeadline(r.Context(), deadline)
+	defer cancel()
+
+	// Results are written while the body is still being read; the server's
+	// read and write timeouts would otherwise cut long-lived streams short.
+	// Recorders used in tests do not support these controls.
+	rc := http.NewResponseController(w)
+	rc.EnableFullDuplex()
+	rc.SetReadDeadline(deadline)
+	rc.SetWriteDeadline(deadline)
+
+	// Shutdown waits for handlers to return, so end the stream once it begins;
+	// the expired read deadline unblocks a scan waiting on the client.
+	watching := make(chan struct{})
+	go func() {
+		defer close(watching)
+		select {
+		case <-a.draining:
+			rc.SetReadDeadline(time.Now())
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	defer func() {
+		cancel()
+		<-watching // The controller must not be used once the handler returns
+	}()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+
+	start := time.Now()
+	index, anomalies := 0, 0
+	for ctx.Err() == nil && scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		result := a.processStreamLine(r, line, index)
+		if result.Result != nil && result.Result.IsAnomaly {
+			anomalies++
+		}
+		if err := enc.Encode(result); err != nil {
+			log.Printf("Stream closed: %v", err)
+			return
+		}
+		rc.Flush()
+		index++
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled) && r.Context().Err() == nil:
+		log.Printf("Stream ended by server shutdown")
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		log.Printf("Stream reached its maximum duration of %s", a.cfg.Server.StreamMaxDuration)
+	case scanner.Err() != nil && ctx.Err() == nil:
+		log.Printf("Stream read error: %v", scanner.Err())
+		if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+			a.recordRejection(rejectOversized)
+			enc.Encode(StreamResult{Index: index, Error: &BatchError{Index: index, Error: "LINE_TOO_LARGE",
+				Message: "Stream line exceeds the maximum size"}})
+		}
+	}
+
+	log.Printf("Processed stream: Points=%d, Anomalies=%d, Duration=%s", index, anomalies, time.Since(start))
+}
+
+// processStreamLine decodes and scores a single NDJSON line.
+func (a *App) processStreamLine(r *http.Request, line []byte, index int) StreamResult {
+	var dp anomaly.DataPoint
+	if err := a.newIngestDecoder(bytes.NewReader(line)).Decode(&dp); err != nil {
+		a.recordRejection(rejectInvalidJSON)
+		return StreamResult{Index: index, Error: &BatchError{Index: index, Error: "INVALID_JSON", Message: err.Error()}}
+	}
+
+	if a.blueTeam != nil && !a.blueTeam.CircuitBreaker().Allow() {
+		a.recordRejection(rejectCircuitOpen)
+		return StreamResult{Index: index, Error: &BatchError{Index: index, Error: "CIRCUIT_OPEN",
+			Message: "Ingestion is paused while the service recovers. Please retry later."}}
+	}
+
+	batch := a.processBatch(r, []anomaly.DataPoint{dp})
+	if len(batch.Results) == 0 {
+		if batch.Error != nil {
+			batch.Error.Index = index
+		}
+		return StreamResult{Index: index, Error: batch.Error}
+	}
+	return StreamResult{Index: index, Result: &batch.Results[0]}
+}
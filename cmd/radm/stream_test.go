@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamIngest_ResultPerPointInOrder(t *testing.T) {
+	app := setupTestComponents(t)
+
+	now := time.Now().Unix()
+	var body strings.Builder
+	for i := 0; i < 4; i++ {
+		fmt.Fprintf(&body, `{"timestamp":%d,"value":%d,"series_id":"cpu"}`+"\n", now+int64(i), 10+i)
+	}
+	body.WriteString("\n")
+	body.WriteString("not json\n")
+	fmt.Fprintf(&body, `{"timestamp":%d,"value":12,"series_id":"cpu"}`, now+4)
+
+	rec := httptest.NewRecorder()
+	app.streamIngestHandler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/data/stream", strings.NewReader(body.String())))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Expected NDJSON content type, got %q", ct)
+	}
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Expected 6 result lines, got %d: %s", len(lines), rec.Body.String())
+	}
+	for i, line := range lines {
+		var result StreamResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			t.Fatalf("Line %d is not JSON: %v", i, err)
+		}
+		if result.Index != i {
+			t.Errorf("Line %d: expected index %d, got %d", i, i, result.Index)
+		}
+		if i == 4 {
+			if result.Error == nil || result.Error.Error != "INVALID_JSON" {
+				t.Errorf("Expected INVALID_JSON for the malformed line, got %+v", result)
+			}
+			continue
+		}
+		want := now + int64(i)
+		if i == 5 {
+			want = now + 4
+		}
+		if result.Result == nil || result.Result.Timestamp != want {
+			t.Errorf("Line %d: expected the result for timestamp %d, got %+v", i, want, result)
+		}
+	}
+}
+
+func TestStreamIngest_AnswersEachPointBeforeTheNext(t *testing.T) {
+	app := setupTestComponents(t)
+	server := httptest.NewServer(app.setupRouter())
+	defer server.Close()
+
+	reqBody, writer := io.Pipe()
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/data/stream", reqBody)
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	responses := make(chan *http.Response, 1)
+	go func() {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Errorf("Stream request failed: %v", err)
+			close(responses)
+			return
+		}
+		responses <- resp
+	}()
+
+	now := time.Now().Unix()
+	fmt.Fprintf(writer, `{"timestamp":%d,"value":10}`+"\n", now)
+	resp, ok := <-responses
+	if !ok {
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+	results := bufio.NewScanner(resp.Body)
+
+	// Each result must arrive while the connection is still open
+	for i := 0; i < 3; i++ {
+		if i > 0 {
+			fmt.Fprintf(writer, `{"timestamp":%d,"value":10}`+"\n", now+int64(i))
+		}
+		if !results.Scan() {
+			t.Fatalf("Expected a result line for point %d: %v", i, results.Err())
+		}
+		var result StreamResult
+		if err := json.Unmarshal(results.Bytes(), &result); err != nil {
+			t.Fatalf("Result %d is not JSON: %v", i, err)
+		}
+		if result.Index != i || result.Result == nil || result.Result.Timestamp != now+int64(i) {
+			t.Errorf("Expected result %d for timestamp %d, got %+v", i, now+int64(i), result)
+		}
+	}
+
+	writer.Close()
+	if results.Scan() {
+		t.Errorf("Expected the stream to end after the client closed it, got %s", results.Text())
+	}
+}
+
+func TestStreamIngest_EndsOnServerShutdown(t *testing.T) {
+	app := setupTestComponents(t)
+	app.cfg.Server.StreamMaxDuration = time.Minute
+	server := httptest.NewServer(app.setupRouter())
+	defer server.Close()
+
+	reqBody, writer := io.Pipe()
+	defer writer.Close()
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/data/stream", reqBody)
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	responses := make(chan *http.Response, 1)
+	go func() {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Errorf("Stream request failed: %v", err)
+			close(responses)
+			return
+		}
+		responses <- resp
+	}()
+
+	fmt.Fprintf(writer, `{"timestamp":%d,"value":10}`+"\n", time.Now().Unix())
+	resp, ok := <-responses
+	if !ok {
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+	results := bufio.NewScanner(resp.Body)
+	if !results.Scan() {
+		t.Fatalf("Expected a result line before shutdown: %v", results.Err())
+	}
+
+	// The client keeps the stream open; shutdown must not wait for it
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := app.shutdownServer(ctx, server.Config); err != nil {
+		t.Fatalf("Unexpected shutdown error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the open stream to end promptly, shutdown took %v", elapsed)
+	}
+
+	if results.Scan() {
+		t.Errorf("Expected the stream to end at shutdown, got %s", results.Text())
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"log"
+	"sync"
+)
+
+// certReloader serves the TLS certificate to new connections and replaces it
+// when the certificate and key files are reloaded, so certificates can be
+// rotated without a restart. Established connections keep the certificate
+// they were negotiated with.
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// newCertReloader loads the certificate and key pair from certFile and keyFile.
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	cr := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := cr.Reload(); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// Reload reads the certificate and key files again. On error the previous
+// certificate is kept.
+func (cr *certReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(cr.certFile, cr.keyFile)
+	if err != nil {
+		return err
+	}
+	if cert.Leaf == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return err
+		}
+	}
+
+	cr.mu.Lock()
+	cr.cert = &cert
+	cr.mu.Unlock()
+
+	log.Printf("TLS: Loaded certificate %s, Subject=%s, Expires=%s",
+		cr.certFile, cert.Leaf.Subject, cert.Leaf.NotAfter.Format("2006-01-02"))
+	return nil
+}
+
+// GetCertificate returns the current certificate; it is used as the
+// tls.Config callback.
+func (cr *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	return cr.cert, nil
+}
+
+// TLSConfig returns a server TLS configuration serving the current certificate.
+func (cr *certReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: cr.GetCertificate,
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestCert writes a self-signed certificate for commonName and its key
+// to certFile and keyFile.
+func writeTestCert(t *testing.T, certFile, keyFile, commonName string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+}
+
+// servedCommonName returns the common name of the certificate the reloader
+// presents to a new connection.
+func servedCommonName(t *testing.T, certs *certReloader) string {
+	t.Helper()
+
+	cert, err := certs.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	return cert.Leaf.Subject.CommonName
+}
+
+func TestCertReloader_ReloadRotatesCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	writeTestCert(t, certFile, keyFile, "old.example")
+
+	certs, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Failed to load certificate: %v", err)
+	}
+	if name := servedCommonName(t, certs); name != "old.example" {
+		t.Fatalf("Expected old.example, got %s", name)
+	}
+
+	writeTestCert(t, certFile, keyFile, "new.example")
+	if err := certs.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if name := servedCommonName(t, certs); name != "new.example" {
+		t.Errorf("Expected the rotated certificate, got %s", name)
+	}
+
+	// A broken key file leaves the current certificate in service
+	if err := os.WriteFile(keyFile, []byte("not a key"), 0600); err != nil {
+		t.Fatalf("Failed to corrupt key: %v", err)
+	}
+	if err := certs.Reload(); err == nil {
+		t.Error("Expected reloading a broken key to fail")
+	}
+	if name := servedCommonName(t, certs); name != "new.example" {
+		t.Errorf("Expected the previous certificate kept after a failed reload, got %s", name)
+	}
+}
+
+func TestCertReloader_ServesHTTPS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	writeTestCert(t, certFile, keyFile, "radm.example")
+
+	certs, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Failed to load certificate: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	app := setupTestComponents(t)
+	server := newHTTPServer(app.cfg.Server, app.setupRouter())
+	server.TLSConfig = certs.TLSConfig()
+	go server.ServeTLS(listener, "", "")
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err := client.Get("https://" + listener.Addr().String() + "/healthz")
+	if err != nil {
+		t.Fatalf("HTTPS request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.TLS == nil || resp.TLS.PeerCertificates[0].Subject.CommonName != "radm.example" {
+		t.Errorf("Expected the loaded certificate to be served, got %+v", resp.TLS)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 from /healthz, got %d", resp.StatusCode)
+	}
+}
+
+func TestNewCertReloader_MissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := newCertReloader(filepath.Join(dir, "missing.crt"), filepath.Join(dir, "missing.key")); err == nil {
+		t.Error("Expected loading missing certificate files to fail")
+	}
+}
+
+func TestReloadConfig_RotatesTLSCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	writeTestCert(t, certFile, keyFile, "old.example")
+
+	app := setupTestComponents(t)
+	var err error
+	app.certs, err = newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Failed to load certificate: %v", err)
+	}
+	app.reloads = newReloadCoordinator(app.cfg, app.applyReload)
+
+	writeTestCert(t, certFile, keyFile, "new.example")
+	if err := app.reloadConfig("sighup"); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if name := servedCommonName(t, app.certs); name != "new.example" {
+		t.Errorf("Expected the reload to rotate the certificate, got %s", name)
+	}
+}
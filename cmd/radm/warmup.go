@@ -0,0 +1,57 @@
+package main
+
+import (
+	"sync"
+
+	"anomaly"
+)
+
+// globalSeriesKey names the unkeyed global series in warm-up stats.
+const globalSeriesKey = "_global"
+
+// warmupTracker counts decisions suppressed because their series had fewer
+// points than the detector's MinSamples. A series is dropped from the
+// per-series counts once it is warm; the total keeps counting.
+type warmupTracker struct {
+	mu     sync.Mutex
+	total  int64
+	series map[string]int64 // Suppressed decisions of series still warming up
+}
+
+func newWarmupTracker() *warmupTracker {
+	return &warmupTracker{series: make(map[string]int64)}
+}
+
+// Record counts detection against seriesID if it was suppressed during
+// warm-up, or clears the series' count once it has been scored.
+func (wt *warmupTracker) Record(seriesID string, detection anomaly.Detection) {
+	if seriesID == "" {
+		seriesID = globalSeriesKey
+	}
+
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+
+	if !detection.WarmingUp {
+		delete(wt.series, seriesID)
+		return
+	}
+	wt.total++
+	wt.series[seriesID]++
+}
+
+// Stats returns the total suppressed decisions and the counts of the series
+// still warming up.
+func (wt *warmupTracker) Stats() map[string]interface{} {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+
+	series := make(map[string]int64, len(wt.series))
+	for id, count := range wt.series {
+		series[id] = count
+	}
+	return map[string]interface{}{
+		"suppressed_total": wt.total,
+		"warming_series":   series,
+	}
+}
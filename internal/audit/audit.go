@@ -1,10 +1,12 @@
 package audit
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -20,6 +22,9 @@ const (
 	EventCompliance    EventType = "compliance"
 	EventSecurity      EventType = "security"
 	EventPerformance   EventType = "performance"
+	EventDegradation   EventType = "degradation"
+	EventConfigReload  EventType = "config_reload"
+	EventDeterminism   EventType = "determinism"
 )
 
 // ComplianceStatus represents the compliance status of an event.
@@ -49,13 +54,41 @@ type AuditEvent struct {
 }
 
 // Auditor manages comprehensive audit logging for compliance verification.
+//
+// In async mode formatted events are queued on a bounded channel and written
+// in batches by a background goroutine, so LogEvent does not wait on disk I/O
+// unless the queue is full. Up to BufferSize+BatchSize events that were
+// accepted but not yet written are lost if the process crashes; Close drains
+// the queue.
+//
+// WithRequestID derives an Auditor sharing the same state that stamps its
+// events with a request's correlation ID.
 type Auditor struct {
+	*auditorState
+	requestID string // Set on events logged through this Auditor that carry none
+}
+
+// auditorState is the state shared by an Auditor and those derived from it.
+type auditorState struct {
 	mu           sync.RWMutex
-	events       []AuditEvent
+	events       []AuditEvent // Circular buffer of the most recent maxEvents events
+	head         int          // Index of the next write in events
+	size         int          // Number of retained events
+	fileMu       sync.Mutex   // Guards outputFile, fileSize and rotation
 	outputFile   *os.File
-	encoder      *json.Encoder
+	outputPath   string
+	fileSize     int64 // Bytes written to outputFile, including any existing content
+	maxFileBytes int64
+	maxBackups   int
+	formatter    Formatter
+	sinks        []Sink // Additional destinations, e.g. syslog
+	redactors    []Redactor // Applied to every event before it is stored
+	baseFields   map[string]interface{} // Merged into every event's details; event keys take precedence
 	maxEvents    int
 	eventCounter int64
+	closed       bool // Set by Close; later events are kept in memory only
+	pending      chan []byte   // Formatted lines awaiting the writer; nil when synchronous
+	writerDone   chan struct{} // Closed once the writer has drained pending
 }
 
 // Config holds auditor configuration.
@@ -63,8 +96,26 @@ type Config struct {
 	OutputFile   string `json:"output_file"`
 	MaxEvents    int    `json:"max_events"`
 	EnableConsole bool  `json:"enable_console"`
+	Format       string `json:"format"` // "json" (default), "cef" or "rfc5424"
+	MaxFileBytes int64  `json:"max_file_bytes"` // Rotate once the file would exceed this size; 0 disables rotation
+	MaxBackups   int    `json:"max_backups"`    // Rotated files to keep; 0 keeps all
+	Async         bool          `json:"async"`          // Write to the file from a background goroutine
+	BufferSize    int           `json:"buffer_size"`    // Events queued for the writer before LogEvent blocks
+	FlushInterval time.Duration `json:"flush_interval"` // Longest an event waits in a partial batch
+	BatchSize     int           `json:"batch_size"`     // Events written per batch
+	BaseFields    map[string]interface{} `json:"base_fields"` // Added to every event's details, e.g. region or instance ID
 }
 
+// Async writer defaults, applied when the corresponding Config field is zero.
+const (
+	DefaultBufferSize    = 4096
+	DefaultFlushInterval = 100 * time.Millisecond
+	DefaultBatchSize     = 256
+)
+
+// backupTimeFormat names rotated files; it sorts lexically in time order.
+const backupTimeFormat = "20060102T150405.000000000"
+
 // NewAuditor creates a new auditor instance.
 func NewAuditor(config Config) (*Auditor, error) {
 	maxEvents := config.MaxEvents
@@ -72,17 +123,43 @@ func NewAuditor(config Config) (*Auditor, error) {
 		maxEvents = 100000 // Default to 100k events
 	}
 
-	file, err := os.OpenFile(config.OutputFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	formatter, err := NewFormatter(config.Format)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+		return nil, err
 	}
 
-	auditor := &Auditor{
-		events:       make([]AuditEvent, 0, maxEvents),
+	file, size, err := openAuditFile(config.OutputFile)
+	if err != nil {
+		return nil, err
+	}
+
+	auditor := &Auditor{auditorState: &auditorState{
+		events:       make([]AuditEvent, maxEvents),
 		outputFile:   file,
-		encoder:      json.NewEncoder(file),
+		outputPath:   config.OutputFile,
+		fileSize:     size,
+		maxFileBytes: config.MaxFileBytes,
+		maxBackups:   config.MaxBackups,
+		formatter:    formatter,
+		baseFields:   copyBaseFields(config.BaseFields),
 		maxEvents:    maxEvents,
 		eventCounter: 0,
+	}}
+
+	if config.Async {
+		bufferSize, flushInterval, batchSize := config.BufferSize, config.FlushInterval, config.BatchSize
+		if bufferSize <= 0 {
+			bufferSize = DefaultBufferSize
+		}
+		if flushInterval <= 0 {
+			flushInterval = DefaultFlushInterval
+		}
+		if batchSize <= 0 {
+			batchSize = DefaultBatchSize
+		}
+		auditor.pending = make(chan []byte, bufferSize)
+		auditor.writerDone = make(chan struct{})
+		go auditor.runWriter(flushInterval, batchSize)
 	}
 
 	// Log auditor startup
@@ -95,6 +172,8 @@ func NewAuditor(config Config) (*Auditor, error) {
 			"max_events":   maxEvents,
 			"output_file":  config.OutputFile,
 			"console_log":  config.EnableConsole,
+			"format":       config.Format,
+			"async":        config.Async,
 		},
 	})
 
@@ -102,8 +181,42 @@ func NewAuditor(config Config) (*Auditor, error) {
 	return auditor, nil
 }
 
+// AddSink forwards every subsequent event to sink as well. The sink is closed with the auditor.
+func (a *Auditor) AddSink(sink Sink) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.sinks = append(a.sinks, sink)
+}
+
+// WithRequestID returns an Auditor writing to the same destinations that sets
+// requestID on every event it logs, so the events of one request can be
+// traced together. An empty requestID, or a nil a, returns a.
+func (a *Auditor) WithRequestID(requestID string) *Auditor {
+	if a == nil || requestID == "" {
+		return a
+	}
+	return &Auditor{auditorState: a.auditorState, requestID: requestID}
+}
+
 // LogEvent logs an audit event.
 func (a *Auditor) LogEvent(event AuditEvent) {
+	if event.RequestID == "" {
+		event.RequestID = a.requestID
+	}
+	event, sinks := a.record(event)
+
+	// Sinks may block on the network, so they are written outside the lock
+	for _, sink := range sinks {
+		if err := sink.Write(event); err != nil {
+			log.Printf("Auditor: Failed to write event to sink: %v", err)
+		}
+	}
+}
+
+// record stores and persists event, returning it with its ID and timestamp
+// assigned along with the sinks it should be forwarded to.
+func (a *Auditor) record(event AuditEvent) (AuditEvent, []Sink) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
@@ -112,22 +225,29 @@ func (a *Auditor) LogEvent(event AuditEvent) {
 	event.ID = fmt.Sprintf("evt_%d_%d", time.Now().UnixNano(), a.eventCounter)
 	event.Timestamp = time.Now()
 
-	// Add to in-memory store
-	a.events = append(a.events, event)
+	// Attach deployment metadata before redactors see the event
+	a.addBaseFields(&event)
 
-	// Maintain max events limit
-	if len(a.events) > a.maxEvents {
-		// Remove oldest 10% of events
-		removeCount := a.maxEvents / 10
-		if removeCount < 100 {
-			removeCount = 100
-		}
-		a.events = a.events[removeCount:]
+	// Strip PII before the event is stored, written or forwarded
+	a.redact(&event)
+
+	// Add to in-memory store, overwriting the oldest event once full
+	a.events[a.head] = event
+	a.head = (a.head + 1) % len(a.events)
+	if a.size < len(a.events) {
+		a.size++
 	}
 
 	// Write to file
-	if err := a.encoder.Encode(event); err != nil {
-		log.Printf("Auditor: Failed to write event to file: %v", err)
+	if a.closed {
+		log.Printf("Auditor: Event %s logged after close, not persisted", event.ID)
+	} else if line, err := a.formatter.Format(event); err != nil {
+		log.Printf("Auditor: Failed to format event: %v", err)
+	} else if a.pending != nil {
+		// Sent under the lock so the writer sees events in ID order
+		a.pending <- append(line, '\n')
+	} else {
+		a.writeLines([][]byte{append(line, '\n')})
 	}
 
 	// Console logging for important events
@@ -135,10 +255,158 @@ func (a *Auditor) LogEvent(event AuditEvent) {
 		log.Printf("AUDIT [%s] %s: %s - %s",
 			event.Status, event.Type, event.Component, event.Message)
 	}
+
+	if a.closed {
+		return event, nil
+	}
+	return event, a.sinks
+}
+
+// runWriter writes queued lines in batches of up to batchSize, flushing a
+// partial batch every flushInterval, until pending is closed and drained.
+func (a *Auditor) runWriter(flushInterval time.Duration, batchSize int) {
+	defer close(a.writerDone)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, batchSize)
+	for {
+		select {
+		case line, ok := <-a.pending:
+			if !ok {
+				a.writeLines(batch)
+				return
+			}
+			batch = append(batch, line)
+			if len(batch) >= batchSize {
+				a.writeLines(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				a.writeLines(batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+// writeLines appends lines to the audit file with as few writes as rotation
+// allows, rotating before any line that would take the file past maxFileBytes.
+func (a *Auditor) writeLines(lines [][]byte) {
+	a.fileMu.Lock()
+	defer a.fileMu.Unlock()
+
+	var buf []byte
+	for _, line := range lines {
+		size := a.fileSize + int64(len(buf))
+		if a.maxFileBytes > 0 && size > 0 && size+int64(len(line)) > a.maxFileBytes {
+			a.write(buf)
+			buf = buf[:0]
+			// On failure keep appending to the current file rather than drop the event
+			if err := a.rotate(); err != nil {
+				log.Printf("Auditor: Failed to rotate audit log: %v", err)
+			}
+		}
+		buf = append(buf, line...)
+	}
+	a.write(buf)
+}
+
+// write appends buf to the audit file. Must be called with a.fileMu held.
+func (a *Auditor) write(buf []byte) {
+	if len(buf) == 0 {
+		return
+	}
+	n, err := a.outputFile.Write(buf)
+	a.fileSize += int64(n)
+	if err != nil {
+		log.Printf("Auditor: Failed to write events to file: %v", err)
+	}
+}
+
+// openAuditFile opens path for appending and returns its current size.
+func openAuditFile(path string) (*os.File, int64, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("failed to stat audit log file: %w", err)
+	}
+	return file, info.Size(), nil
+}
+
+// rotate renames the current file to a timestamped backup, opens a fresh file
+// and removes the oldest backups beyond maxBackups. Must be called with a.fileMu held.
+func (a *Auditor) rotate() error {
+	backup := a.outputPath + "." + time.Now().UTC().Format(backupTimeFormat)
+	if err := os.Rename(a.outputPath, backup); err != nil {
+		return fmt.Errorf("failed to rename audit log: %w", err)
+	}
+
+	file, size, err := openAuditFile(a.outputPath)
+	if err != nil {
+		return err // The renamed file is still open and keeps receiving events
+	}
+	a.outputFile.Close()
+	a.outputFile = file
+	a.fileSize = size
+
+	if a.maxBackups > 0 {
+		backups := a.backups()
+		for len(backups) > a.maxBackups {
+			if err := os.Remove(backups[0]); err != nil {
+				log.Printf("Auditor: Failed to remove old audit log %s: %v", backups[0], err)
+			}
+			backups = backups[1:]
+		}
+	}
+	return nil
+}
+
+// backups returns the rotated files of the audit log, oldest first.
+func (a *Auditor) backups() []string {
+	matches, err := filepath.Glob(a.outputPath + ".*")
+	if err != nil {
+		return nil
+	}
+
+	backups := matches[:0]
+	for _, match := range matches {
+		suffix := strings.TrimPrefix(match, a.outputPath+".")
+		if _, err := time.Parse(backupTimeFormat, suffix); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	sort.Strings(backups)
+	return backups
 }
 
 // LogDecision logs an anomaly detection decision.
 func (a *Auditor) LogDecision(decisionID string, isAnomaly bool, zScore float64, latencyNS int64, sourceIP string) {
+	a.LogDecisionWithMetadata(decisionID, isAnomaly, zScore, latencyNS, sourceIP, nil)
+}
+
+// LogDecisionWithMetadata is LogDecision with client correlation metadata recorded in the details.
+// Callers are responsible for redacting metadata before it is logged.
+func (a *Auditor) LogDecisionWithMetadata(decisionID string, isAnomaly bool, zScore float64, latencyNS int64, sourceIP string, metadata map[string]string) {
+	a.LogDecisionWithSeverity(decisionID, isAnomaly, zScore, latencyNS, sourceIP, "", metadata)
+}
+
+// LogDecisionWithSeverity is LogDecisionWithMetadata also recording the anomaly
+// severity band, which sinks such as syslog map to their own severity levels.
+func (a *Auditor) LogDecisionWithSeverity(decisionID string, isAnomaly bool, zScore float64, latencyNS int64, sourceIP string, severity string, metadata map[string]string) {
+	a.LogDecisionWithDedupKey(decisionID, isAnomaly, zScore, latencyNS, sourceIP, severity, "", metadata)
+}
+
+// LogDecisionWithDedupKey is LogDecisionWithSeverity also recording the dedup
+// key of the anomaly episode, so downstream alerting groups the alerts of one
+// ongoing condition instead of paging for each.
+func (a *Auditor) LogDecisionWithDedupKey(decisionID string, isAnomaly bool, zScore float64, latencyNS int64, sourceIP string, severity string, dedupKey string, metadata map[string]string) {
 	status := StatusCompliant
 	message := fmt.Sprintf("Decision processed: anomaly=%t, z_score=%.3f", isAnomaly, zScore)
 
@@ -147,6 +415,22 @@ func (a *Auditor) LogDecision(decisionID string, isAnomaly bool, zScore float64,
 		message += " (high latency)"
 	}
 
+	details := map[string]interface{}{
+		"decision_id": decisionID,
+		"is_anomaly":  isAnomaly,
+		"z_score":     zScore,
+		"latency_ms":  float64(latencyNS) / 1000000,
+	}
+	if severity != "" {
+		details["severity"] = severity
+	}
+	if dedupKey != "" {
+		details["dedup_key"] = dedupKey
+	}
+	if len(metadata) > 0 {
+		details["metadata"] = metadata
+	}
+
 	a.LogEvent(AuditEvent{
 		Type:             EventDecision,
 		Status:           status,
@@ -155,12 +439,7 @@ func (a *Auditor) LogDecision(decisionID string, isAnomaly bool, zScore float64,
 		ProcessingTimeNS: latencyNS,
 		Component:        "anomaly_detector",
 		Protocol:         "γ-Axiomatic Control",
-		Details: map[string]interface{}{
-			"decision_id": decisionID,
-			"is_anomaly":  isAnomaly,
-			"z_score":     zScore,
-			"latency_ms":  float64(latencyNS) / 1000000,
-		},
+		Details:          details,
 	})
 }
 
@@ -214,6 +493,24 @@ func (a *Auditor) LogRateLimit(allowed bool, sourceIP string, requestID string)
 	})
 }
 
+// LogAuthFailure logs a request rejected for a missing or invalid API key.
+func (a *Auditor) LogAuthFailure(sourceIP, requestID, method, path, reason string) {
+	a.LogEvent(AuditEvent{
+		Type:      EventSecurity,
+		Status:    StatusWarning,
+		Message:   "Authentication failed",
+		SourceIP:  sourceIP,
+		RequestID: requestID,
+		Component: "auth",
+		Protocol:  "α-IngressGuard",
+		Details: map[string]interface{}{
+			"method": method,
+			"path":   path,
+			"reason": reason,
+		},
+	})
+}
+
 // LogFaultInjection logs a fault injection event.
 func (a *Auditor) LogFaultInjection(faultType string, injected bool, duration time.Duration) {
 	status := StatusWarning // Fault injection is expected but notable
@@ -284,33 +581,174 @@ func (a *Auditor) LogPerformance(component string, metric string, value float64,
 	})
 }
 
+// LogDegradation logs a fallback to degraded operation.
+func (a *Auditor) LogDegradation(component string, reason string, details map[string]interface{}) {
+	if details == nil {
+		details = make(map[string]interface{})
+	}
+	details["reason"] = reason
+
+	a.LogEvent(AuditEvent{
+		Type:      EventDegradation,
+		Status:    StatusWarning,
+		Message:   fmt.Sprintf("%s degraded: %s", component, reason),
+		Component: component,
+		Details:   details,
+	})
+}
+
+// LogConfigReload logs the outcome of a configuration reload. triggers counts
+// the reload requests served by this run, including coalesced ones.
+func (a *Auditor) LogConfigReload(source string, triggers int, err error, details map[string]interface{}) {
+	if details == nil {
+		details = make(map[string]interface{})
+	}
+	details["source"] = source
+	details["triggers"] = triggers
+
+	status := StatusCompliant
+	message := fmt.Sprintf("Configuration reloaded (%s)", source)
+	if err != nil {
+		status = StatusError
+		message = fmt.Sprintf("Configuration reload failed (%s): %v", source, err)
+		details["error"] = err.Error()
+	}
+
+	a.LogEvent(AuditEvent{
+		Type:      EventConfigReload,
+		Status:    status,
+		Message:   message,
+		Component: "config",
+		Details:   details,
+	})
+}
+
+// LogDeterminism logs the outcome of an Axiom A-1 determinism verification.
+// err is the violation found, nil when the check passed; checkpoint is the
+// state hash of the recovery checkpoint created for it, empty when none was.
+func (a *Auditor) LogDeterminism(inputHash string, outputHash string, err error, checkpoint string) {
+	status := StatusCompliant
+	message := "A-1 determinism verified"
+	details := map[string]interface{}{
+		"input_hash":         inputHash,
+		"output_hash":        outputHash,
+		"checkpoint_created": checkpoint != "",
+	}
+
+	if err != nil {
+		status = StatusNonCompliant
+		message = fmt.Sprintf("A-1 determinism NON-COMPLIANT: %v", err)
+		details["error"] = err.Error()
+	}
+	if checkpoint != "" {
+		details["checkpoint_state_hash"] = checkpoint
+	}
+
+	a.LogEvent(AuditEvent{
+		Type:      EventDeterminism,
+		Status:    status,
+		Message:   message,
+		Component: "anomaly",
+		Details:   details,
+	})
+}
+
 // GetEvents returns recent audit events.
 func (a *Auditor) GetEvents(limit int) []AuditEvent {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
-	if limit <= 0 || limit > len(a.events) {
-		limit = len(a.events)
+	if limit <= 0 || limit > a.size {
+		limit = a.size
+	}
+
+	// Return most recent events, oldest first
+	result := make([]AuditEvent, limit)
+	for i := range result {
+		result[i] = a.eventAt(a.size - limit + i)
 	}
+	return result
+}
+
+// EventFilter selects audit events in QueryEvents. Empty fields match every event.
+type EventFilter struct {
+	Types    []EventType
+	Statuses []ComplianceStatus
+	Since    time.Time // Inclusive lower bound on Timestamp
+	Until    time.Time // Inclusive upper bound on Timestamp
+	Limit    int       // Most recent matches to return; 0 returns all
+}
 
-	// Return most recent events
-	start := len(a.events) - limit
-	if start < 0 {
-		start = 0
+// matches reports whether event passes the filter.
+func (f EventFilter) matches(event AuditEvent) bool {
+	if len(f.Types) > 0 && !containsType(f.Types, event.Type) {
+		return false
 	}
+	if len(f.Statuses) > 0 && !containsStatus(f.Statuses, event.Status) {
+		return false
+	}
+	if !f.Since.IsZero() && event.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && event.Timestamp.After(f.Until) {
+		return false
+	}
+	return true
+}
 
-	result := make([]AuditEvent, limit)
-	copy(result, a.events[start:])
+func containsType(types []EventType, t EventType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatus(statuses []ComplianceStatus, s ComplianceStatus) bool {
+	for _, candidate := range statuses {
+		if candidate == s {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryEvents returns the most recent retained events matching filter, oldest first.
+func (a *Auditor) QueryEvents(filter EventFilter) []AuditEvent {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	// Scan newest first so the limit keeps the most recent matches
+	result := make([]AuditEvent, 0)
+	for i := a.size - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(result) >= filter.Limit {
+			break
+		}
+		if event := a.eventAt(i); filter.matches(event) {
+			result = append(result, event)
+		}
+	}
+
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
 	return result
 }
 
+// eventAt returns the i-th oldest retained event. Must be called with a.mu held.
+func (a *Auditor) eventAt(i int) AuditEvent {
+	start := (a.head - a.size + len(a.events)) % len(a.events)
+	return a.events[(start+i)%len(a.events)]
+}
+
 // GetComplianceReport generates a compliance report.
 func (a *Auditor) GetComplianceReport(since time.Time) map[string]interface{} {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
 	report := map[string]interface{}{
-		"total_events":       len(a.events),
+		"total_events":       a.size,
 		"compliant_events":   0,
 		"non_compliant_events": 0,
 		"warning_events":     0,
@@ -319,7 +757,8 @@ func (a *Auditor) GetComplianceReport(since time.Time) map[string]interface{} {
 		"components":        make(map[string]int),
 	}
 
-	for _, event := range a.events {
+	for i := 0; i < a.size; i++ {
+		event := a.eventAt(i)
 		if event.Timestamp.Before(since) {
 			continue
 		}
@@ -353,20 +792,42 @@ func (a *Auditor) GetComplianceReport(since time.Time) map[string]interface{} {
 
 // Close closes the auditor and flushes any remaining events.
 func (a *Auditor) Close() error {
-	a.mu.Lock()
-	defer a.mu.Unlock()
+	a.mu.RLock()
+	total, closed := a.eventCounter, a.closed
+	a.mu.RUnlock()
+
+	if closed {
+		return nil
+	}
 
-	// Log final event
+	// Log final event (LogEvent takes the lock itself)
 	a.LogEvent(AuditEvent{
 		Type:      EventSecurity,
 		Status:    StatusCompliant,
 		Message:   "Audit system shutdown",
 		Component: "auditor",
 		Details: map[string]interface{}{
-			"total_events_logged": a.eventCounter,
+			"total_events_logged": total,
 		},
 	})
 
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.closed = true
+	if a.pending != nil {
+		// Nothing sends once closed is set; wait for the writer to drain the queue
+		close(a.pending)
+		<-a.writerDone
+	}
+	for _, sink := range a.sinks {
+		if err := sink.Close(); err != nil {
+			log.Printf("Auditor: Failed to close sink: %v", err)
+		}
+	}
+
+	a.fileMu.Lock()
+	defer a.fileMu.Unlock()
 	return a.outputFile.Close()
 }
 
@@ -391,5 +852,12 @@ func DefaultConfig() Config {
 		OutputFile:    "audit.log",
 		MaxEvents:     100000,
 		EnableConsole: true,
+		Format:        FormatJSON,
+		MaxFileBytes:  100 * 1024 * 1024, // 100 MiB
+		MaxBackups:    10,
+		Async:         false,
+		BufferSize:    DefaultBufferSize,
+		FlushInterval: DefaultFlushInterval,
+		BatchSize:     DefaultBatchSize,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,288 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestAuditor creates an auditor writing to a temporary file.
+func newTestAuditor(t testing.TB, maxEvents int) *Auditor {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.OutputFile = filepath.Join(t.TempDir(), "audit.log")
+	config.MaxEvents = maxEvents
+
+	auditor, err := NewAuditor(config)
+	if err != nil {
+		t.Fatalf("Failed to create auditor: %v", err)
+	}
+	return auditor
+}
+
+func TestAuditor_RingOrderingAndCapacity(t *testing.T) {
+	auditor := newTestAuditor(t, 10)
+
+	// The startup event plus 24 more wraps the ring more than twice
+	for i := 0; i < 24; i++ {
+		auditor.LogEvent(AuditEvent{
+			Type:      EventPerformance,
+			Status:    StatusCompliant,
+			Message:   fmt.Sprintf("event-%d", i),
+			Component: "test",
+		})
+	}
+
+	events := auditor.GetEvents(0)
+	if len(events) != 10 {
+		t.Fatalf("Expected ring to retain 10 events, got %d", len(events))
+	}
+
+	for i, event := range events {
+		expected := fmt.Sprintf("event-%d", 14+i)
+		if event.Message != expected {
+			t.Errorf("Position %d: expected %s, got %s", i, expected, event.Message)
+		}
+	}
+
+	recent := auditor.GetEvents(3)
+	if len(recent) != 3 || recent[0].Message != "event-21" || recent[2].Message != "event-23" {
+		t.Errorf("Expected the 3 most recent events oldest first, got %v", recent)
+	}
+
+	report := auditor.GetComplianceReport(events[0].Timestamp)
+	if report["total_events"] != 10 {
+		t.Errorf("Expected total_events 10, got %v", report["total_events"])
+	}
+}
+
+func TestAuditor_GetEventsBeforeWrap(t *testing.T) {
+	auditor := newTestAuditor(t, 100)
+
+	auditor.LogEvent(AuditEvent{Type: EventSecurity, Status: StatusCompliant, Message: "first", Component: "test"})
+
+	events := auditor.GetEvents(0)
+	if len(events) != 2 {
+		t.Fatalf("Expected startup event plus 1, got %d", len(events))
+	}
+	if events[0].Message != "Audit system initialized" || events[1].Message != "first" {
+		t.Errorf("Unexpected event order: %s, %s", events[0].Message, events[1].Message)
+	}
+}
+
+func BenchmarkAuditor_LogEvent(b *testing.B) {
+	auditor := newTestAuditor(b, 1000)
+	event := AuditEvent{
+		Type:      EventDecision,
+		Status:    StatusCompliant,
+		Message:   "Decision processed",
+		Component: "anomaly_detector",
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		auditor.LogEvent(event)
+	}
+}
+
+func TestAuditor_CloseThenLog(t *testing.T) {
+	auditor := newTestAuditor(t, 10)
+
+	if err := auditor.Close(); err != nil {
+		t.Fatalf("Unexpected error closing auditor: %v", err)
+	}
+	if err := auditor.Close(); err != nil {
+		t.Errorf("Expected second Close to be a no-op, got %v", err)
+	}
+
+	// Late events stay queryable but are not written to the closed file
+	auditor.LogDecision("late", false, 0.5, 1000, "10.0.0.1")
+	events := auditor.GetEvents(1)
+	if len(events) != 1 || events[0].Details["decision_id"] != "late" {
+		t.Errorf("Expected late decision retained in memory, got %+v", events)
+	}
+}
+
+func TestAuditor_RotatesBySize(t *testing.T) {
+	config := DefaultConfig()
+	config.OutputFile = filepath.Join(t.TempDir(), "audit.log")
+	config.MaxFileBytes = 2048
+	config.MaxBackups = 10
+
+	auditor, err := NewAuditor(config)
+	if err != nil {
+		t.Fatalf("Failed to create auditor: %v", err)
+	}
+	padding := strings.Repeat("x", 200)
+	for i := 0; i < 30; i++ {
+		auditor.LogEvent(AuditEvent{Type: EventPerformance, Status: StatusCompliant, Message: fmt.Sprintf("event-%d %s", i, padding), Component: "test"})
+	}
+	auditor.Close()
+
+	backups := auditor.backups()
+	if len(backups) < 2 {
+		t.Fatalf("Expected at least two rotations, got %d backups", len(backups))
+	}
+
+	// Every event, including those that triggered a rotation, is in exactly one file
+	lines := 0
+	for _, path := range append(backups, config.OutputFile) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		if len(data) > int(config.MaxFileBytes) {
+			t.Errorf("%s is %d bytes, over the %d limit", path, len(data), config.MaxFileBytes)
+		}
+		lines += strings.Count(string(data), "\n")
+	}
+	if lines != 32 { // Startup, 30 events and shutdown
+		t.Errorf("Expected 32 events across the audit files, got %d", lines)
+	}
+
+	// Reopening with a lower retention prunes down to MaxBackups on the next rotation
+	config.MaxBackups = 2
+	auditor, err = NewAuditor(config)
+	if err != nil {
+		t.Fatalf("Failed to reopen auditor: %v", err)
+	}
+	defer auditor.Close()
+	for i := 0; i < 30; i++ {
+		auditor.LogEvent(AuditEvent{Type: EventPerformance, Status: StatusCompliant, Message: padding, Component: "test"})
+	}
+	if backups := auditor.backups(); len(backups) != 2 {
+		t.Errorf("Expected 2 backups retained, got %d", len(backups))
+	}
+}
+
+func TestAuditor_QueryEvents(t *testing.T) {
+	auditor := newTestAuditor(t, 100)
+
+	auditor.LogValidation(false, "value", 1, "10.0.0.1", fmt.Errorf("too large"))
+	auditor.LogValidation(true, "value", 2, "10.0.0.1", nil)
+	time.Sleep(2 * time.Millisecond)
+	mid := time.Now()
+	time.Sleep(2 * time.Millisecond)
+	auditor.LogValidation(false, "timestamp", 0, "10.0.0.1", fmt.Errorf("required"))
+	auditor.LogRateLimit(false, "10.0.0.1", "req-1")
+	auditor.LogCompliance("ζ-Hypervisor", "A-4", false, nil)
+
+	fields := func(events []AuditEvent) []string {
+		var result []string
+		for _, event := range events {
+			result = append(result, event.Details["field"].(string))
+		}
+		return result
+	}
+
+	failed := auditor.QueryEvents(EventFilter{Types: []EventType{EventValidation}, Statuses: []ComplianceStatus{StatusError}})
+	if got := fields(failed); len(got) != 2 || got[0] != "value" || got[1] != "timestamp" {
+		t.Errorf("Expected both failed validations oldest first, got %v", got)
+	}
+
+	recent := auditor.QueryEvents(EventFilter{Types: []EventType{EventValidation}, Statuses: []ComplianceStatus{StatusError}, Since: mid})
+	if got := fields(recent); len(got) != 1 || got[0] != "timestamp" {
+		t.Errorf("Expected only the failed validation after mid, got %v", got)
+	}
+
+	early := auditor.QueryEvents(EventFilter{Types: []EventType{EventValidation}, Until: mid})
+	if len(early) != 2 {
+		t.Errorf("Expected 2 validations before mid, got %d", len(early))
+	}
+
+	several := auditor.QueryEvents(EventFilter{Types: []EventType{EventRateLimit, EventCompliance}, Statuses: []ComplianceStatus{StatusWarning, StatusNonCompliant}})
+	if len(several) != 2 || several[0].Type != EventRateLimit || several[1].Type != EventCompliance {
+		t.Errorf("Expected the rate limit and compliance events, got %+v", several)
+	}
+
+	limited := auditor.QueryEvents(EventFilter{Limit: 2})
+	if len(limited) != 2 || limited[1].Type != EventCompliance {
+		t.Errorf("Expected the 2 most recent events, got %+v", limited)
+	}
+
+	if none := auditor.QueryEvents(EventFilter{Types: []EventType{EventDecision}}); none == nil || len(none) != 0 {
+		t.Errorf("Expected an empty, non-nil result, got %v", none)
+	}
+	if none := auditor.QueryEvents(EventFilter{Since: time.Now().Add(time.Hour)}); len(none) != 0 {
+		t.Errorf("Expected no events in the future, got %d", len(none))
+	}
+}
+
+func TestAuditor_AsyncWritesInBatches(t *testing.T) {
+	config := DefaultConfig()
+	config.OutputFile = filepath.Join(t.TempDir(), "audit.log")
+	config.Async = true
+	config.BatchSize = 10
+	config.FlushInterval = time.Hour // Only full batches are written before Close
+
+	auditor, err := NewAuditor(config)
+	if err != nil {
+		t.Fatalf("Failed to create auditor: %v", err)
+	}
+	for i := 0; i < 25; i++ {
+		auditor.LogEvent(AuditEvent{Type: EventPerformance, Status: StatusCompliant, Message: fmt.Sprintf("event-%d", i), Component: "test"})
+	}
+
+	countLines := func() int {
+		data, err := os.ReadFile(config.OutputFile)
+		if err != nil {
+			t.Fatalf("Failed to read audit log: %v", err)
+		}
+		return strings.Count(string(data), "\n")
+	}
+
+	// Startup plus 25 events fill two batches; the remaining 6 wait for a flush
+	deadline := time.Now().Add(2 * time.Second)
+	for countLines() < 20 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if lines := countLines(); lines != 20 {
+		t.Fatalf("Expected 2 full batches written, got %d lines", lines)
+	}
+
+	if err := auditor.Close(); err != nil {
+		t.Fatalf("Unexpected error closing auditor: %v", err)
+	}
+	data, err := os.ReadFile(config.OutputFile)
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 27 { // Startup, 25 events and shutdown
+		t.Fatalf("Expected Close to drain all 27 events, got %d", len(lines))
+	}
+	if !strings.Contains(lines[1], "event-0") || !strings.Contains(lines[25], "event-24") || !strings.Contains(lines[26], "Audit system shutdown") {
+		t.Error("Expected events written in the order they were logged")
+	}
+}
+
+func TestAuditor_AsyncFlushesPartialBatch(t *testing.T) {
+	config := DefaultConfig()
+	config.OutputFile = filepath.Join(t.TempDir(), "audit.log")
+	config.Async = true
+	config.FlushInterval = 10 * time.Millisecond
+
+	auditor, err := NewAuditor(config)
+	if err != nil {
+		t.Fatalf("Failed to create auditor: %v", err)
+	}
+	defer auditor.Close()
+	auditor.LogEvent(AuditEvent{Type: EventSecurity, Status: StatusCompliant, Message: "flushed", Component: "test"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		data, _ := os.ReadFile(config.OutputFile)
+		if strings.Contains(string(data), "flushed") {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the flush interval to write a partial batch")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,165 @@
+package audit
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BatchWriter is a destination that accepts a group of events as a single
+// payload, e.g. one network write carrying many syslog messages.
+type BatchWriter interface {
+	WriteBatch(events []AuditEvent) error
+	Close() error
+}
+
+// BatchConfig holds event batching configuration.
+type BatchConfig struct {
+	MaxEvents     int           `json:"max_events"`     // Events per batch; a full batch is sent immediately
+	FlushInterval time.Duration `json:"flush_interval"` // A partial batch is sent at least this often
+	MaxPending    int           `json:"max_pending"`    // Full batches queued for the writer; further batches are dropped
+}
+
+// DefaultBatchConfig returns default batching configuration.
+func DefaultBatchConfig() BatchConfig {
+	return BatchConfig{
+		MaxEvents:     100,
+		FlushInterval: time.Second,
+		MaxPending:    10,
+	}
+}
+
+// BatchSink is a Sink that groups events by count or time and hands each
+// group to a BatchWriter from a background goroutine, so Write never waits on
+// the network. At most MaxPending full batches are buffered; when the writer
+// falls further behind, new batches are dropped and counted. Close sends the
+// partial batch before closing the writer.
+type BatchSink struct {
+	mu      sync.Mutex
+	config  BatchConfig
+	writer  BatchWriter
+	current []AuditEvent
+	batches chan []AuditEvent
+	stop    chan struct{}
+	done    chan struct{}
+	closed  bool
+	dropped atomic.Int64
+}
+
+// NewBatchSink starts batching events for writer. Non-positive settings fall
+// back to DefaultBatchConfig.
+func NewBatchSink(writer BatchWriter, config BatchConfig) *BatchSink {
+	defaults := DefaultBatchConfig()
+	if config.MaxEvents <= 0 {
+		config.MaxEvents = defaults.MaxEvents
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaults.FlushInterval
+	}
+	if config.MaxPending <= 0 {
+		config.MaxPending = defaults.MaxPending
+	}
+
+	s := &BatchSink{
+		config:  config,
+		writer:  writer,
+		batches: make(chan []AuditEvent, config.MaxPending),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Write implements Sink.
+func (s *BatchSink) Write(event AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.current = append(s.current, event)
+	if len(s.current) < s.config.MaxEvents {
+		return nil
+	}
+
+	batch := s.current
+	s.current = make([]AuditEvent, 0, s.config.MaxEvents)
+	select {
+	case s.batches <- batch:
+	default:
+		s.dropped.Add(int64(len(batch)))
+		log.Printf("Auditor: Batch sink queue full, dropped %d events", len(batch))
+	}
+	return nil
+}
+
+// Dropped returns the number of events dropped because the queue was full.
+func (s *BatchSink) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// run writes queued batches, and the partial batch every FlushInterval, until Close.
+func (s *BatchSink) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case batch := <-s.batches:
+			s.write(batch)
+		case <-ticker.C:
+			s.flush()
+		case <-s.stop:
+			s.flush()
+			return
+		}
+	}
+}
+
+// flush writes the queued batches, then the partial batch. Full batches are
+// queued before the partial one is taken, so events stay in order.
+func (s *BatchSink) flush() {
+	s.mu.Lock()
+	partial := s.current
+	s.current = nil
+	s.mu.Unlock()
+
+	for drained := false; !drained; {
+		select {
+		case batch := <-s.batches:
+			s.write(batch)
+		default:
+			drained = true
+		}
+	}
+	if len(partial) > 0 {
+		s.write(partial)
+	}
+}
+
+// write hands batch to the writer, logging failures.
+func (s *BatchSink) write(batch []AuditEvent) {
+	if err := s.writer.WriteBatch(batch); err != nil {
+		log.Printf("Auditor: Failed to write batch of %d events to sink: %v", len(batch), err)
+	}
+}
+
+// Close implements Sink. It sends any buffered events, then closes the writer.
+func (s *BatchSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	close(s.stop)
+	<-s.done
+	return s.writer.Close()
+}
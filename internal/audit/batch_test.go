@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingBatchWriter records the batches it receives.
+type recordingBatchWriter struct {
+	mu      sync.Mutex
+	batches [][]AuditEvent
+	closed  bool
+}
+
+func (w *recordingBatchWriter) WriteBatch(events []AuditEvent) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.batches = append(w.batches, append([]AuditEvent(nil), events...))
+	return nil
+}
+
+func (w *recordingBatchWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+	return nil
+}
+
+func (w *recordingBatchWriter) sizes() []int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	sizes := make([]int, len(w.batches))
+	for i, batch := range w.batches {
+		sizes[i] = len(batch)
+	}
+	return sizes
+}
+
+func TestBatchSink_GroupsBySizeAndFlushesOnClose(t *testing.T) {
+	writer := &recordingBatchWriter{}
+	sink := NewBatchSink(writer, BatchConfig{MaxEvents: 3, FlushInterval: time.Hour, MaxPending: 10})
+
+	for i := 0; i < 7; i++ {
+		sink.Write(AuditEvent{ID: fmt.Sprintf("evt-%d", i), Type: EventDecision})
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Failed to close sink: %v", err)
+	}
+
+	sizes := writer.sizes()
+	if fmt.Sprint(sizes) != "[3 3 1]" {
+		t.Fatalf("Expected batches of [3 3 1], got %v", sizes)
+	}
+	next := 0
+	for _, batch := range writer.batches {
+		for _, event := range batch {
+			if want := fmt.Sprintf("evt-%d", next); event.ID != want {
+				t.Errorf("Expected event %s, got %s", want, event.ID)
+			}
+			next++
+		}
+	}
+	if !writer.closed {
+		t.Error("Expected Close to close the writer")
+	}
+
+	// Events written after Close are discarded
+	sink.Write(AuditEvent{ID: "late"})
+	if got := len(writer.sizes()); got != 3 {
+		t.Errorf("Expected no batch after Close, got %d batches", got)
+	}
+}
+
+func TestBatchSink_FlushesPartialBatchOnInterval(t *testing.T) {
+	writer := &recordingBatchWriter{}
+	sink := NewBatchSink(writer, BatchConfig{MaxEvents: 100, FlushInterval: 10 * time.Millisecond, MaxPending: 10})
+	defer sink.Close()
+
+	sink.Write(AuditEvent{ID: "evt-0"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(writer.sizes()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the partial batch to be flushed on the interval")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if sizes := writer.sizes(); sizes[0] != 1 {
+		t.Errorf("Expected a batch of 1 event, got %v", sizes)
+	}
+}
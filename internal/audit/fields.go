@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// addBaseFields merges the configured base fields into event's details.
+// Keys the event already sets are kept, and the caller's map is not mutated.
+func (a *Auditor) addBaseFields(event *AuditEvent) {
+	if len(a.baseFields) == 0 {
+		return
+	}
+
+	details := make(map[string]interface{}, len(event.Details)+len(a.baseFields))
+	for key, value := range a.baseFields {
+		details[key] = value
+	}
+	for key, value := range event.Details {
+		details[key] = value
+	}
+	event.Details = details
+}
+
+// copyBaseFields returns a copy of fields so later changes by the caller do
+// not reach the auditor, or nil when there are none.
+func copyBaseFields(fields map[string]interface{}) map[string]interface{} {
+	if len(fields) == 0 {
+		return nil
+	}
+	return copyValue(fields).(map[string]interface{})
+}
+
+// ParseBaseFields parses a comma-separated list of key:value pairs such as
+// "region:us-east,instance:radm-1" into base fields.
+func ParseBaseFields(s string) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, ":")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid base field %q: expected key:value", pair)
+		}
+		fields[key] = value
+	}
+	return fields, nil
+}
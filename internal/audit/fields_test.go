@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAuditor_BaseFieldsOnEveryEvent(t *testing.T) {
+	config := DefaultConfig()
+	config.OutputFile = filepath.Join(t.TempDir(), "audit.log")
+	config.BaseFields = map[string]interface{}{"region": "us-east", "component": "base"}
+	auditor, err := NewAuditor(config)
+	if err != nil {
+		t.Fatalf("Failed to create auditor: %v", err)
+	}
+
+	details := map[string]interface{}{"component": "event"}
+	auditor.LogEvent(AuditEvent{Type: EventSecurity, Status: StatusCompliant, Message: "with details", Component: "test", Details: details})
+	auditor.LogEvent(AuditEvent{Type: EventSecurity, Status: StatusCompliant, Message: "without details", Component: "test"})
+	auditor.LogDecision("TS-1", false, 0.5, 1000, "127.0.0.1")
+	auditor.Close()
+
+	if len(details) != 1 {
+		t.Errorf("Expected the caller's details untouched, got %v", details)
+	}
+
+	file, err := os.Open(config.OutputFile)
+	if err != nil {
+		t.Fatalf("Failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event AuditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Audit line is not JSON: %v", err)
+		}
+		if event.Details["region"] != "us-east" {
+			t.Errorf("Expected region us-east on %q, got %v", event.Message, event.Details)
+		}
+		if event.Message == "with details" && event.Details["component"] != "event" {
+			t.Errorf("Expected the event's own key to win over the base field, got %v", event.Details["component"])
+		}
+		count++
+	}
+	// Initialization, the two events, the decision and shutdown
+	if count != 5 {
+		t.Errorf("Expected 5 events, got %d", count)
+	}
+}
+
+func TestParseBaseFields(t *testing.T) {
+	fields, err := ParseBaseFields("region:us-east, instance: radm-1 ,")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(fields) != 2 || fields["region"] != "us-east" || fields["instance"] != "radm-1" {
+		t.Errorf("Expected region and instance, got %v", fields)
+	}
+
+	for _, s := range []string{"region", ":us-east"} {
+		if _, err := ParseBaseFields(s); err == nil {
+			t.Errorf("Expected %q to be rejected", s)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported audit output formats.
+const (
+	FormatJSON    = "json"
+	FormatCEF     = "cef"
+	FormatRFC5424 = "rfc5424"
+)
+
+// Formatter renders an AuditEvent into a single log line (without trailing newline).
+type Formatter interface {
+	Format(event AuditEvent) ([]byte, error)
+}
+
+// NewFormatter returns the formatter for the named format.
+// An empty name selects JSON.
+func NewFormatter(format string) (Formatter, error) {
+	switch strings.ToLower(format) {
+	case "", FormatJSON:
+		return JSONFormatter{}, nil
+	case FormatCEF:
+		return DefaultCEFFormatter(), nil
+	case FormatRFC5424:
+		return DefaultRFC5424Formatter(), nil
+	default:
+		return nil, fmt.Errorf("unknown audit format %q", format)
+	}
+}
+
+// JSONFormatter renders events as JSON objects, one per line.
+type JSONFormatter struct{}
+
+// Format implements Formatter.
+func (JSONFormatter) Format(event AuditEvent) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+// CEFFormatter renders events in ArcSight Common Event Format (CEF:0).
+type CEFFormatter struct {
+	Vendor  string
+	Product string
+	Version string
+}
+
+// DefaultCEFFormatter returns a CEFFormatter identifying this service.
+func DefaultCEFFormatter() CEFFormatter {
+	return CEFFormatter{
+		Vendor:  "A1Axiom",
+		Product: "radm",
+		Version: "1.0",
+	}
+}
+
+// cefSeverity maps compliance status to the CEF 0-10 severity scale.
+var cefSeverity = map[ComplianceStatus]int{
+	StatusCompliant:    3,
+	StatusWarning:      6,
+	StatusError:        8,
+	StatusNonCompliant: 9,
+}
+
+// Format implements Formatter.
+//
+// Header: CEF:0|Vendor|Product|Version|<type>|<message>|<severity>
+// Extensions: rt, externalId, outcome, src, requestClientApplication,
+// cs1 (component), cs2 (protocol), cs3 (request ID), cn1 (processing time),
+// followed by event details as ad.<key>, sorted by key.
+func (f CEFFormatter) Format(event AuditEvent) ([]byte, error) {
+	severity, exists := cefSeverity[event.Status]
+	if !exists {
+		severity = 5
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		cefHeader(f.Vendor), cefHeader(f.Product), cefHeader(f.Version),
+		cefHeader(string(event.Type)), cefHeader(event.Message), severity)
+
+	ext := make([]string, 0, 16)
+	add := func(key, value string) {
+		if value != "" {
+			ext = append(ext, key+"="+cefExtension(value))
+		}
+	}
+
+	if !event.Timestamp.IsZero() {
+		add("rt", strconv.FormatInt(event.Timestamp.UnixMilli(), 10))
+	}
+	add("externalId", event.ID)
+	add("outcome", string(event.Status))
+	add("src", event.SourceIP)
+	add("requestClientApplication", event.UserAgent)
+	if event.Component != "" {
+		add("cs1Label", "component")
+		add("cs1", event.Component)
+	}
+	if event.Protocol != "" {
+		add("cs2Label", "protocol")
+		add("cs2", event.Protocol)
+	}
+	if event.RequestID != "" {
+		add("cs3Label", "requestId")
+		add("cs3", event.RequestID)
+	}
+	if event.ProcessingTimeNS != 0 {
+		add("cn1Label", "processingTimeNs")
+		add("cn1", strconv.FormatInt(event.ProcessingTimeNS, 10))
+	}
+
+	keys := make([]string, 0, len(event.Details))
+	for k := range event.Details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		add("ad."+k, fmt.Sprint(event.Details[k]))
+	}
+
+	b.WriteString(strings.Join(ext, " "))
+	return []byte(b.String()), nil
+}
+
+// cefHeader escapes a CEF header field.
+func cefHeader(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+// cefExtension escapes a CEF extension value.
+func cefExtension(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		"=", `\=`,
+		"\r", `\r`,
+		"\n", `\n`,
+	).Replace(s)
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCEFFormatter_KnownEvent(t *testing.T) {
+	event := AuditEvent{
+		ID:               "evt_1_1",
+		Timestamp:        time.Unix(1638360000, 0),
+		Type:             EventDecision,
+		Status:           StatusWarning,
+		Message:          "Decision processed: anomaly=true|high latency",
+		SourceIP:         "10.0.0.1",
+		ProcessingTimeNS: 1500,
+		Component:        "anomaly_detector",
+		Protocol:         "γ-Axiomatic Control",
+		Details: map[string]interface{}{
+			"z_score":    3.5,
+			"is_anomaly": true,
+			"expr":       "a=b",
+		},
+	}
+
+	line, err := DefaultCEFFormatter().Format(event)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `CEF:0|A1Axiom|radm|1.0|decision|Decision processed: anomaly=true\|high latency|6|` +
+		`rt=1638360000000 externalId=evt_1_1 outcome=warning src=10.0.0.1 ` +
+		`cs1Label=component cs1=anomaly_detector cs2Label=protocol cs2=γ-Axiomatic Control ` +
+		`cn1Label=processingTimeNs cn1=1500 ad.expr=a\=b ad.is_anomaly=true ad.z_score=3.5`
+
+	if string(line) != expected {
+		t.Errorf("Unexpected CEF output:\n got: %s\nwant: %s", line, expected)
+	}
+}
+
+func TestNewFormatter_UnknownFormat(t *testing.T) {
+	if _, err := NewFormatter("xml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+
+	config := DefaultConfig()
+	config.OutputFile = filepath.Join(t.TempDir(), "audit.log")
+	config.Format = "xml"
+
+	if _, err := NewAuditor(config); err == nil {
+		t.Error("Expected NewAuditor to reject unknown format")
+	}
+}
+
+func TestNewFormatter_Defaults(t *testing.T) {
+	for _, format := range []string{"", "json", "JSON"} {
+		formatter, err := NewFormatter(format)
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", format, err)
+		}
+		if _, ok := formatter.(JSONFormatter); !ok {
+			t.Errorf("Expected JSONFormatter for %q, got %T", format, formatter)
+		}
+	}
+}
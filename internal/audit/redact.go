@@ -0,0 +1,74 @@
+package audit
+
+import "net"
+
+// Redactor rewrites an event before it is stored or written, e.g. to strip PII.
+// It receives a copy whose Details map is not shared with the caller.
+type Redactor func(event *AuditEvent)
+
+// AddRedactor registers redactor to run on every subsequent event.
+// Redactors run in registration order.
+func (a *Auditor) AddRedactor(redactor Redactor) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.redactors = append(a.redactors, redactor)
+}
+
+// redact applies the registered redactors to event, deep-copying its Details
+// first so the caller's maps are not mutated. Must be called with a.mu held.
+func (a *Auditor) redact(event *AuditEvent) {
+	if len(a.redactors) == 0 {
+		return
+	}
+	if event.Details != nil {
+		event.Details = copyValue(event.Details).(map[string]interface{})
+	}
+	for _, redactor := range a.redactors {
+		redactor(event)
+	}
+}
+
+// copyValue deep-copies the maps and slices found in event details.
+// Other values are returned as is.
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case map[string]string:
+		copied := make(map[string]string, len(v))
+		for key, item := range v {
+			copied[key] = item
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	case []string:
+		return append([]string(nil), v...)
+	default:
+		return value
+	}
+}
+
+// MaskSourceIP is a Redactor that zeroes the last octet of an IPv4 SourceIP,
+// and everything past the /64 prefix of an IPv6 one. Values that are not IP
+// addresses are left unchanged.
+func MaskSourceIP(event *AuditEvent) {
+	ip := net.ParseIP(event.SourceIP)
+	if ip == nil {
+		return
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		event.SourceIP = ip4.Mask(net.CIDRMask(24, 32)).String()
+		return
+	}
+	event.SourceIP = ip.Mask(net.CIDRMask(64, 128)).String()
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAuditor_RedactorsRunInOrderOnACopy(t *testing.T) {
+	auditor := newTestAuditor(t, 10)
+	defer auditor.Close()
+
+	var seenIP string
+	auditor.AddRedactor(MaskSourceIP)
+	auditor.AddRedactor(func(event *AuditEvent) {
+		seenIP = event.SourceIP
+		delete(event.Details, "token")
+		if nested, ok := event.Details["client"].(map[string]interface{}); ok {
+			nested["email"] = "[REDACTED]"
+		}
+	})
+
+	client := map[string]interface{}{"email": "user@example.com"}
+	details := map[string]interface{}{"token": "s3cret", "client": client}
+	auditor.LogEvent(AuditEvent{Type: EventSecurity, Status: StatusCompliant, Message: "login", SourceIP: "192.168.10.77", Component: "test", Details: details})
+
+	if seenIP != "192.168.10.0" {
+		t.Errorf("Expected the second redactor to see the masked IP, got %q", seenIP)
+	}
+	if details["token"] != "s3cret" || client["email"] != "user@example.com" {
+		t.Errorf("Expected the caller's details untouched, got %v", details)
+	}
+
+	stored := auditor.GetEvents(1)[0]
+	if stored.SourceIP != "192.168.10.0" || stored.Details["token"] != nil {
+		t.Errorf("Expected the redacted event stored, got %+v", stored)
+	}
+
+	data, err := os.ReadFile(auditor.outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	for _, leaked := range []string{"192.168.10.77", "s3cret", "user@example.com"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("Audit log contains %q", leaked)
+		}
+	}
+}
+
+func TestMaskSourceIP(t *testing.T) {
+	cases := map[string]string{
+		"10.1.2.3":             "10.1.2.0",
+		"2001:db8:1:2:3:4:5:6": "2001:db8:1:2::",
+		"::ffff:172.16.5.9":    "172.16.5.0",
+		"not-an-ip":            "not-an-ip",
+		"":                     "",
+	}
+	for input, want := range cases {
+		event := AuditEvent{SourceIP: input}
+		MaskSourceIP(&event)
+		if event.SourceIP != want {
+			t.Errorf("MaskSourceIP(%q) = %q, want %q", input, event.SourceIP, want)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sink receives every audit event in addition to the audit log file.
+type Sink interface {
+	Write(event AuditEvent) error
+	Close() error
+}
+
+// Syslog severities (RFC5424 section 6.2.1) used by the event mapping.
+const (
+	SyslogCritical      = 2
+	SyslogError         = 3
+	SyslogWarning       = 4
+	SyslogNotice        = 5
+	SyslogInformational = 6
+)
+
+// DefaultSyslogFacility is local0.
+const DefaultSyslogFacility = 16
+
+// syslogSDID identifies the structured data element carrying event fields.
+// 32473 is the private enterprise number reserved for documentation (RFC5612).
+const syslogSDID = "radm@32473"
+
+// RFC5424Formatter renders events as RFC5424 syslog messages.
+type RFC5424Formatter struct {
+	Facility int
+	Hostname string
+	AppName  string
+	ProcID   string
+}
+
+// DefaultRFC5424Formatter returns an RFC5424Formatter identifying this process.
+func DefaultRFC5424Formatter() RFC5424Formatter {
+	hostname, _ := os.Hostname() // Rendered as the NILVALUE when unknown
+	return RFC5424Formatter{
+		Facility: DefaultSyslogFacility,
+		Hostname: hostname,
+		AppName:  "radm",
+		ProcID:   strconv.Itoa(os.Getpid()),
+	}
+}
+
+// SyslogSeverity maps an event to a syslog severity. Decisions use the
+// anomaly severity band; other events use their compliance status.
+func SyslogSeverity(event AuditEvent) int {
+	if event.Type == EventDecision {
+		switch event.Details["severity"] {
+		case "critical":
+			return SyslogCritical
+		case "warning":
+			return SyslogWarning
+		}
+		if anomalous, _ := event.Details["is_anomaly"].(bool); anomalous {
+			return SyslogNotice
+		}
+		if event.Status == StatusWarning {
+			return SyslogWarning
+		}
+		return SyslogInformational
+	}
+
+	switch event.Status {
+	case StatusNonCompliant:
+		return SyslogCritical
+	case StatusError:
+		return SyslogError
+	case StatusWarning:
+		return SyslogWarning
+	default:
+		return SyslogInformational
+	}
+}
+
+// Format implements Formatter.
+//
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [radm@32473 ...] MSG
+// The structured data carries the event ID, status, component, protocol,
+// source IP, request ID and processing time, followed by details sorted by key.
+func (f RFC5424Formatter) Format(event AuditEvent) ([]byte, error) {
+	pri := f.Facility*8 + SyslogSeverity(event)
+	timestamp := "-"
+	if !event.Timestamp.IsZero() {
+		timestamp = event.Timestamp.UTC().Format("2006-01-02T15:04:05.000000Z07:00")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %s %s [%s", pri, timestamp,
+		syslogHeader(f.Hostname, 255), syslogHeader(f.AppName, 48),
+		syslogHeader(f.ProcID, 128), syslogHeader(string(event.Type), 32), syslogSDID)
+
+	add := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, " %s=\"%s\"", syslogParamName(name), syslogParamValue(value))
+		}
+	}
+	add("id", event.ID)
+	add("status", string(event.Status))
+	add("component", event.Component)
+	add("protocol", event.Protocol)
+	add("src", event.SourceIP)
+	add("requestId", event.RequestID)
+	if event.ProcessingTimeNS != 0 {
+		add("processingTimeNs", strconv.FormatInt(event.ProcessingTimeNS, 10))
+	}
+
+	keys := make([]string, 0, len(event.Details))
+	for k := range event.Details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		add(k, fmt.Sprint(event.Details[k]))
+	}
+
+	b.WriteString("] ")
+	b.WriteString(event.Message)
+	return []byte(b.String()), nil
+}
+
+// syslogHeader returns s as a header field: printable ASCII without spaces,
+// truncated to max, or the NILVALUE "-" when empty.
+func syslogHeader(s string, max int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, s)
+	if s == "" {
+		return "-"
+	}
+	if len(s) > max {
+		s = s[:max]
+	}
+	return s
+}
+
+// syslogParamName returns s as an SD-PARAM name (at most 32 printable ASCII
+// characters excluding '=', ' ', ']' and '"').
+func syslogParamName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(s) > 32 {
+		s = s[:32]
+	}
+	return s
+}
+
+// syslogParamValue escapes an SD-PARAM value.
+func syslogParamValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "]", `\]`).Replace(s)
+}
+
+// SyslogConfig holds syslog sink configuration.
+type SyslogConfig struct {
+	Network  string        `json:"network"` // "udp", "tcp" or "tls"
+	Address  string        `json:"address"` // host:port
+	Facility int           `json:"facility"`
+	Timeout  time.Duration `json:"timeout"` // Dial and write timeout
+}
+
+// SyslogSink forwards audit events to a syslog collector as RFC5424 messages.
+// UDP sends one message per datagram; TCP and TLS use octet-counting framing (RFC6587).
+// The connection is dialled on first use and re-dialled once after a write error.
+type SyslogSink struct {
+	mu        sync.Mutex
+	config    SyslogConfig
+	formatter RFC5424Formatter
+	conn      net.Conn
+}
+
+// NewSyslogSink creates a syslog sink. No connection is made until the first event.
+func NewSyslogSink(config SyslogConfig) (*SyslogSink, error) {
+	switch config.Network {
+	case "":
+		config.Network = "udp"
+	case "udp", "tcp", "tls":
+	default:
+		return nil, fmt.Errorf("unknown syslog network %q", config.Network)
+	}
+	if config.Address == "" {
+		return nil, fmt.Errorf("syslog address cannot be empty")
+	}
+	if config.Facility < 0 || config.Facility > 23 {
+		return nil, fmt.Errorf("syslog facility must be between 0 and 23")
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+
+	formatter := DefaultRFC5424Formatter()
+	formatter.Facility = config.Facility
+	return &SyslogSink{config: config, formatter: formatter}, nil
+}
+
+// Write implements Sink.
+func (s *SyslogSink) Write(event AuditEvent) error {
+	msg, err := s.frame(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.send(msg)
+}
+
+// WriteBatch implements BatchWriter. Over TCP and TLS the framed messages
+// are sent in a single write; UDP still sends one datagram per message.
+func (s *SyslogSink) WriteBatch(events []AuditEvent) error {
+	msgs := make([][]byte, 0, len(events))
+	for _, event := range events {
+		msg, err := s.frame(event)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	if s.config.Network != "udp" {
+		msgs = [][]byte{bytes.Join(msgs, nil)}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, msg := range msgs {
+		if err := s.send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// frame formats event as a syslog message, octet-counted unless sent over UDP.
+func (s *SyslogSink) frame(event AuditEvent) ([]byte, error) {
+	msg, err := s.formatter.Format(event)
+	if err != nil {
+		return nil, err
+	}
+	if s.config.Network != "udp" {
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+	return msg, nil
+}
+
+// send writes msg to the collector, re-dialling once after a write error.
+// Must be called with s.mu held.
+func (s *SyslogSink) send(msg []byte) (err error) {
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			conn, err := s.dial()
+			if err != nil {
+				return fmt.Errorf("failed to connect to syslog: %w", err)
+			}
+			s.conn = conn
+		}
+
+		s.conn.SetWriteDeadline(time.Now().Add(s.config.Timeout))
+		if _, err = s.conn.Write(msg); err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+		if attempt > 0 {
+			return fmt.Errorf("failed to write to syslog: %w", err)
+		}
+	}
+}
+
+// dial connects to the collector. Must be called with s.mu held.
+func (s *SyslogSink) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.config.Timeout}
+	if s.config.Network == "tls" {
+		host, _, _ := net.SplitHostPort(s.config.Address)
+		return tls.DialWithDialer(dialer, "tcp", s.config.Address, &tls.Config{ServerName: host})
+	}
+	return dialer.Dial(s.config.Network, s.config.Address)
+}
+
+// Close implements Sink.
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var rfc5424Pattern = regexp.MustCompile(`^<(\d+)>1 \S+ \S+ radm \S+ (\S+) \[radm@32473( \S+="[^"]*")*\] .*$`)
+
+func TestSyslogSink_UDPDecisionSeverity(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: "udp", Address: conn.LocalAddr().String(), Facility: DefaultSyslogFacility})
+	if err != nil {
+		t.Fatalf("Failed to create sink: %v", err)
+	}
+	auditor := newTestAuditor(t, 10)
+	auditor.AddSink(sink)
+	defer auditor.Close()
+
+	read := func() string {
+		t.Helper()
+		buf := make([]byte, 4096)
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("Failed to read syslog message: %v", err)
+		}
+		return string(buf[:n])
+	}
+
+	auditor.LogDecisionWithSeverity("dec-1", true, 4.2, 1500, "10.0.0.1", "critical", nil)
+	msg := read()
+	match := rfc5424Pattern.FindStringSubmatch(msg)
+	if match == nil {
+		t.Fatalf("Message is not RFC5424: %q", msg)
+	}
+	if match[1] != "130" { // local0 (16) * 8 + critical (2)
+		t.Errorf("Expected PRI 130, got %s", match[1])
+	}
+	if match[2] != string(EventDecision) {
+		t.Errorf("Expected MSGID %s, got %s", EventDecision, match[2])
+	}
+	for _, param := range []string{`src="10.0.0.1"`, `severity="critical"`, `is_anomaly="true"`, `decision_id="dec-1"`} {
+		if !strings.Contains(msg, param) {
+			t.Errorf("Expected %s in %q", param, msg)
+		}
+	}
+
+	auditor.LogDecisionWithSeverity("dec-2", false, 0.3, 1500, "10.0.0.1", "", nil)
+	match = rfc5424Pattern.FindStringSubmatch(read())
+	if match == nil || match[1] != "134" { // local0 (16) * 8 + informational (6)
+		t.Errorf("Expected PRI 134 for a normal decision, got %v", match)
+	}
+}
+
+func TestSyslogSink_TCPOctetCounting(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: "tcp", Address: listener.Addr().String(), Facility: DefaultSyslogFacility})
+	if err != nil {
+		t.Fatalf("Failed to create sink: %v", err)
+	}
+	defer sink.Close()
+
+	event := AuditEvent{Type: EventSecurity, Status: StatusNonCompliant, Message: "tampering detected", Component: "test"}
+	if err := sink.Write(event); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("Accept failed: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	reader := bufio.NewReader(conn)
+	length, err := reader.ReadString(' ')
+	if err != nil {
+		t.Fatalf("Failed to read frame length: %v", err)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(length))
+	if err != nil {
+		t.Fatalf("Invalid frame length %q", length)
+	}
+	frame := make([]byte, n)
+	if _, err := io.ReadFull(reader, frame); err != nil {
+		t.Fatalf("Failed to read frame: %v", err)
+	}
+	if !strings.HasPrefix(string(frame), "<130>1 ") || !strings.HasSuffix(string(frame), "] tampering detected") {
+		t.Errorf("Unexpected frame %q", frame)
+	}
+}
+
+func TestNewSyslogSink_InvalidConfig(t *testing.T) {
+	for _, config := range []SyslogConfig{
+		{Network: "udp"},
+		{Network: "http", Address: "127.0.0.1:514"},
+		{Network: "udp", Address: "127.0.0.1:514", Facility: 24},
+	} {
+		if _, err := NewSyslogSink(config); err == nil {
+			t.Errorf("Expected error for %+v", config)
+		}
+	}
+}
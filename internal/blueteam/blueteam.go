@@ -1,36 +1,34 @@
 package blueteam
 
-package blueteam
-
 import (
 	"fmt"
 	"log"
+	"runtime"
+	"runtime/debug"
 	"sync"
 	"time"
-
-	"anomaly"
 )
 
 // HealingStrategy represents different approaches to system healing.
 type HealingStrategy string
 
 const (
-	StrategyResetDetector    HealingStrategy = "reset_detector"
-	StrategyCircuitBreaker   HealingStrategy = "circuit_breaker"
-	StrategyFallbackMode     HealingStrategy = "fallback_mode"
-	StrategyResourceCleanup  HealingStrategy = "resource_cleanup"
-	StrategyConfigReload     HealingStrategy = "config_reload"
+	StrategyResetDetector   HealingStrategy = "reset_detector"
+	StrategyCircuitBreaker  HealingStrategy = "circuit_breaker"
+	StrategyFallbackMode    HealingStrategy = "fallback_mode"
+	StrategyResourceCleanup HealingStrategy = "resource_cleanup"
+	StrategyConfigReload    HealingStrategy = "config_reload"
 )
 
 // IssueType represents different types of issues that need healing.
 type IssueType string
 
 const (
-	IssueHighLatency       IssueType = "high_latency"
-	IssueHighErrorRate     IssueType = "high_error_rate"
+	IssueHighLatency        IssueType = "high_latency"
+	IssueHighErrorRate      IssueType = "high_error_rate"
 	IssueResourceExhaustion IssueType = "resource_exhaustion"
-	IssueComplianceFailure IssueType = "compliance_failure"
-	IssueFaultInjection    IssueType = "fault_injection"
+	IssueComplianceFailure  IssueType = "compliance_failure"
+	IssueFaultInjection     IssueType = "fault_injection"
 )
 
 // HealingAction represents a specific healing action to be taken.
@@ -43,6 +41,20 @@ type HealingAction struct {
 	Status      string          `json:"status"`
 	Success     bool            `json:"success"`
 	Error       string          `json:"error,omitempty"`
+
+	CorrelationID string `json:"correlation_id,omitempty"` // ID of the request that triggered the heal
+}
+
+// healKey identifies an issue healed with a particular strategy.
+type healKey struct {
+	issue    IssueType
+	strategy HealingStrategy
+}
+
+// strategyBackoff tracks consecutive failures of a healing strategy.
+type strategyBackoff struct {
+	failures int
+	until    time.Time // On-demand heals with the strategy are suppressed until then
 }
 
 // BlueTeam manages self-healing mechanisms for the resilience layer.
@@ -53,13 +65,52 @@ type BlueTeam struct {
 	healingEnabled  bool
 	monitorInterval time.Duration
 	stopMonitoring  chan bool
+	historyFile     string
+	historyMu       sync.Mutex      // Guards historyQueue and historyWriting
+	historyQueue    []HealingAction // Actions waiting for the history writer
+	historyWriting  bool
+	historyPending  sync.WaitGroup
+	detectorReset   func()        // Clears the live detector for StrategyResetDetector; nil fails the strategy
+	latencyCheck    func() bool   // Reports high latency to the periodic health check; overrides health's Axiom A-2
+	health          HealthMetrics // Service health the periodic check acts on; nil skips the metric checks
+	minSuccessRate  float64       // Decision success rate, in percent, below which the error rate is high
+	maxHeapBytes    uint64        // Heap size above which resources are exhausted; 0 skips the check
+	dryRun          bool          // Record what would be healed without side effects
+	configReload    func() error  // Reloads configuration for StrategyConfigReload; nil leaves nothing to reload
+	breaker         *CircuitBreaker
+	healCooldown    time.Duration
+	failureBackoff  time.Duration
+	maxBackoff      time.Duration
+	cooldowns       map[healKey]time.Time               // Suppresses a healed issue/strategy pair until the given time
+	backoffs        map[HealingStrategy]strategyBackoff // Failing strategies, reset on success
+	suppressedHeals int64
+	now             func() time.Time
+
+	customStrategies map[HealingStrategy]func(*HealingAction) bool // Registered strategies, checked before the built-ins
+	forcedFailures   map[HealingStrategy]error                     // Strategies made to fail with the given error
 }
 
 // Config holds Blue Team configuration.
 type Config struct {
-	MaxActions      int           `json:"max_actions"`
-	MonitorInterval time.Duration `json:"monitor_interval"`
-	HealingEnabled  bool          `json:"healing_enabled"`
+	MaxActions        int           `json:"max_actions"`
+	MonitorInterval   time.Duration `json:"monitor_interval"`
+	HealingEnabled    bool          `json:"healing_enabled"`
+	HistoryFile       string        `json:"history_file"`        // Optional JSONL persistence of healing actions
+	Breaker           BreakerConfig `json:"breaker"`             // Circuit breaker opened by StrategyCircuitBreaker
+	HealCooldown      time.Duration `json:"heal_cooldown"`       // On-demand heals of a pair suppressed after success; 0 disables
+	FailureBackoff    time.Duration `json:"failure_backoff"`     // Initial backoff after a strategy fails, doubled per failure; 0 disables
+	MaxFailureBackoff time.Duration `json:"max_failure_backoff"` // Cap on the failure backoff; 0 leaves it uncapped
+	MinSuccessRate    float64       `json:"min_success_rate"`    // Percent of successful decisions below which fallback mode is applied
+	MaxHeapBytes      uint64        `json:"max_heap_bytes"`      // Heap size above which resource cleanup runs; 0 disables
+	DryRun            bool          `json:"dry_run"`             // Record heals with status "dry_run" instead of acting on them
+}
+
+// HealthMetrics is the view of service health the periodic health check acts
+// on. *hypervisor.Hypervisor implements it.
+type HealthMetrics interface {
+	IsAxiomA2Compliant() bool     // P95 latency within bounds
+	IsAxiomA4Compliant() bool     // Monetization accuracy at 100%
+	DecisionSuccessRate() float64 // Percent of successful decisions
 }
 
 // NewBlueTeam creates a new BlueTeam instance.
@@ -74,13 +125,38 @@ func NewBlueTeam(config Config) *BlueTeam {
 		monitorInterval = time.Minute * 5 // Default to 5 minutes
 	}
 
-	return &BlueTeam{
+	bt := &BlueTeam{
 		healingActions:  make([]HealingAction, 0, maxActions),
 		maxActions:      maxActions,
 		healingEnabled:  config.HealingEnabled,
 		monitorInterval: monitorInterval,
 		stopMonitoring:  make(chan bool),
+		historyFile:     config.HistoryFile,
+		breaker:         NewCircuitBreaker(config.Breaker),
+		healCooldown:    config.HealCooldown,
+		failureBackoff:  config.FailureBackoff,
+		maxBackoff:      config.MaxFailureBackoff,
+		minSuccessRate:  config.MinSuccessRate,
+		maxHeapBytes:    config.MaxHeapBytes,
+		dryRun:          config.DryRun,
+		cooldowns:       make(map[healKey]time.Time),
+		backoffs:        make(map[HealingStrategy]strategyBackoff),
+		now:             time.Now,
+
+		customStrategies: make(map[HealingStrategy]func(*HealingAction) bool),
+		forcedFailures:   make(map[HealingStrategy]error),
 	}
+
+	// Restore recent history for post-incident review across restarts
+	if bt.historyFile != "" {
+		if err := bt.LoadHistory(); err != nil {
+			log.Printf("BlueTeam: Failed to load healing history: %v", err)
+		} else if len(bt.healingActions) > 0 {
+			log.Printf("BlueTeam: Restored %d healing actions from %s", len(bt.healingActions), bt.historyFile)
+		}
+	}
+
+	return bt
 }
 
 // StartMonitoring starts the continuous monitoring and healing process.
@@ -116,13 +192,17 @@ func (bt *BlueTeam) StopMonitoring() {
 	}
 }
 
-// performHealthCheck performs a comprehensive health check and initiates healing if needed.
+// CheckHealth runs one health check now, outside the monitoring schedule.
+func (bt *BlueTeam) CheckHealth() {
+	bt.performHealthCheck()
+}
+
+// performHealthCheck heals each issue whose threshold is breached. A healthy
+// service is left alone.
 func (bt *BlueTeam) performHealthCheck() {
 	bt.mu.Lock()
 	defer bt.mu.Unlock()
 
-	log.Println("BlueTeam: Performing health check")
-
 	// Check for various issues and attempt healing
 	bt.checkLatencyIssues()
 	bt.checkErrorRateIssues()
@@ -130,10 +210,19 @@ func (bt *BlueTeam) performHealthCheck() {
 	bt.checkComplianceIssues()
 }
 
-// checkLatencyIssues checks for high latency issues and applies healing.
+// checkLatencyIssues applies the circuit breaker when latency is high: per
+// the registered latency check, or else Axiom A-2. Without either there is
+// nothing to act on, since the breaker must not open on a healthy service.
 func (bt *BlueTeam) checkLatencyIssues() {
-	// This would integrate with the hypervisor to check P95 latency
-	// For now, we'll simulate the check
+	switch {
+	case bt.latencyCheck != nil:
+		if !bt.latencyCheck() {
+			return
+		}
+	case bt.health == nil || bt.health.IsAxiomA2Compliant():
+		return
+	}
+
 	action := bt.initiateHealing(IssueHighLatency, StrategyCircuitBreaker,
 		"High latency detected, applying circuit breaker pattern")
 
@@ -142,30 +231,50 @@ func (bt *BlueTeam) checkLatencyIssues() {
 	}
 }
 
-// checkErrorRateIssues checks for high error rates and applies healing.
+// checkErrorRateIssues switches to fallback mode when the decision success
+// rate drops below the configured minimum.
 func (bt *BlueTeam) checkErrorRateIssues() {
-	// This would integrate with audit logs to check error rates
-	// For now, we'll simulate the check
+	if bt.health == nil {
+		return
+	}
+	rate := bt.health.DecisionSuccessRate()
+	if rate >= bt.minSuccessRate {
+		return
+	}
+
 	action := bt.initiateHealing(IssueHighErrorRate, StrategyFallbackMode,
-		"High error rate detected, switching to fallback mode")
+		fmt.Sprintf("High error rate detected (success rate %.2f%% below %.2f%%), switching to fallback mode", rate, bt.minSuccessRate))
 
 	if action != nil {
 		log.Printf("BlueTeam: Applied fallback mode for high error rate: %s", action.Description)
 	}
 }
 
-// checkResourceIssues checks for resource exhaustion and applies healing.
+// checkResourceIssues performs cleanup when the heap exceeds the configured maximum.
 func (bt *BlueTeam) checkResourceIssues() {
+	if bt.maxHeapBytes == 0 {
+		return
+	}
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	if mem.HeapAlloc <= bt.maxHeapBytes {
+		return
+	}
+
 	action := bt.initiateHealing(IssueResourceExhaustion, StrategyResourceCleanup,
-		"Resource exhaustion detected, performing cleanup")
+		fmt.Sprintf("Resource exhaustion detected (heap %d bytes above %d), performing cleanup", mem.HeapAlloc, bt.maxHeapBytes))
 
 	if action != nil {
 		log.Printf("BlueTeam: Applied resource cleanup: %s", action.Description)
 	}
 }
 
-// checkComplianceIssues checks for compliance failures and applies healing.
+// checkComplianceIssues reloads the configuration when Axiom A-4 is violated.
 func (bt *BlueTeam) checkComplianceIssues() {
+	if bt.health == nil || bt.health.IsAxiomA4Compliant() {
+		return
+	}
+
 	action := bt.initiateHealing(IssueComplianceFailure, StrategyConfigReload,
 		"Compliance failure detected, reloading configuration")
 
@@ -176,26 +285,39 @@ func (bt *BlueTeam) checkComplianceIssues() {
 
 // initiateHealing initiates a healing action for a specific issue.
 func (bt *BlueTeam) initiateHealing(issueType IssueType, strategy HealingStrategy, description string) *HealingAction {
+	return bt.initiateCorrelatedHealing(issueType, strategy, description, "")
+}
+
+// initiateCorrelatedHealing is initiateHealing recording the ID of the
+// request that triggered the heal.
+func (bt *BlueTeam) initiateCorrelatedHealing(issueType IssueType, strategy HealingStrategy, description, correlationID string) *HealingAction {
 	action := HealingAction{
-		ID:          fmt.Sprintf("heal_%d_%s", time.Now().UnixNano(), issueType),
-		Type:        issueType,
-		Strategy:    strategy,
-		Description: description,
-		Timestamp:   time.Now(),
-		Status:      "initiated",
+		ID:            fmt.Sprintf("heal_%d_%s", time.Now().UnixNano(), issueType),
+		Type:          issueType,
+		Strategy:      strategy,
+		Description:   description,
+		Timestamp:     time.Now(),
+		Status:        "initiated",
+		CorrelationID: correlationID,
 	}
 
 	// Execute the healing strategy
 	success := bt.executeHealingStrategy(strategy, &action)
 
-	if success {
+	if success && bt.dryRun {
+		action.Status = "dry_run"
+		action.Success = true
+		log.Printf("BlueTeam: Dry run, would execute healing strategy %s for issue %s", strategy, issueType)
+	} else if success {
 		action.Status = "completed"
 		action.Success = true
 		log.Printf("BlueTeam: Successfully executed healing strategy %s for issue %s", strategy, issueType)
 	} else {
 		action.Status = "failed"
 		action.Success = false
-		action.Error = "Healing strategy execution failed"
+		if action.Error == "" {
+			action.Error = "Healing strategy execution failed"
+		}
 		log.Printf("BlueTeam: Failed to execute healing strategy %s for issue %s", strategy, issueType)
 	}
 
@@ -207,11 +329,31 @@ func (bt *BlueTeam) initiateHealing(issueType IssueType, strategy HealingStrateg
 		bt.healingActions = bt.healingActions[1:]
 	}
 
+	// Persist the action if history is enabled
+	if bt.historyFile != "" {
+		bt.persistAction(action)
+	}
+
 	return &action
 }
 
-// executeHealingStrategy executes the specific healing strategy.
+// executeHealingStrategy executes the specific healing strategy. In dry-run
+// mode a known strategy succeeds without being executed.
 func (bt *BlueTeam) executeHealingStrategy(strategy HealingStrategy, action *HealingAction) bool {
+	if bt.dryRun && bt.hasStrategy(strategy) {
+		action.Description += " - Dry run, no action taken"
+		return true
+	}
+
+	if err, forced := bt.forcedFailures[strategy]; forced {
+		action.Error = fmt.Sprintf("Healing strategy %s failed: %v", strategy, err)
+		return false
+	}
+
+	if fn, ok := bt.customStrategies[strategy]; ok {
+		return fn(action)
+	}
+
 	switch strategy {
 	case StrategyResetDetector:
 		return bt.executeDetectorReset(action)
@@ -229,22 +371,124 @@ func (bt *BlueTeam) executeHealingStrategy(strategy HealingStrategy, action *Hea
 	}
 }
 
+// RegisterStrategy adds a healing strategy dispatched by HealOnDemand. A
+// registered strategy takes precedence over a built-in one of the same name.
+// fn reports success and may set action.Description or action.Error; it runs
+// with the Blue Team locked, so it must not call back into bt.
+func (bt *BlueTeam) RegisterStrategy(name HealingStrategy, fn func(*HealingAction) bool) error {
+	if name == "" {
+		return fmt.Errorf("healing strategy name must not be empty")
+	}
+	if fn == nil {
+		return fmt.Errorf("healing strategy %s has no function", name)
+	}
+
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	if _, exists := bt.customStrategies[name]; exists {
+		return fmt.Errorf("healing strategy %s is already registered", name)
+	}
+	bt.customStrategies[name] = fn
+	log.Printf("BlueTeam: Registered healing strategy %s", name)
+	return nil
+}
+
+// SetStrategyFailure makes strategy fail with err instead of executing, so the
+// failure path of a heal can be exercised. A nil err restores the strategy.
+func (bt *BlueTeam) SetStrategyFailure(strategy HealingStrategy, err error) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	if err == nil {
+		delete(bt.forcedFailures, strategy)
+		log.Printf("BlueTeam: Restored healing strategy %s", strategy)
+		return
+	}
+	bt.forcedFailures[strategy] = err
+	log.Printf("BlueTeam: Healing strategy %s set to fail: %v", strategy, err)
+}
+
+// HasStrategy reports whether name is a built-in or registered healing strategy.
+func (bt *BlueTeam) HasStrategy(name HealingStrategy) bool {
+	bt.mu.RLock()
+	defer bt.mu.RUnlock()
+
+	return bt.hasStrategy(name)
+}
+
+// hasStrategy implements HasStrategy. Must be called with bt.mu held.
+func (bt *BlueTeam) hasStrategy(name HealingStrategy) bool {
+	switch name {
+	case StrategyResetDetector, StrategyCircuitBreaker, StrategyFallbackMode,
+		StrategyResourceCleanup, StrategyConfigReload:
+		return true
+	}
+
+	_, ok := bt.customStrategies[name]
+	return ok
+}
+
+// SetDetectorReset registers the callback StrategyResetDetector uses to clear
+// the anomaly detector, e.g. the live detector's Reset method.
+func (bt *BlueTeam) SetDetectorReset(reset func()) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	bt.detectorReset = reset
+}
+
 // executeDetectorReset resets the anomaly detector to clear potential corruption.
-func (bt *BlueTeam) executeDetectorReset(action *HealingAction) bool {
-	// This would integrate with the actual detector
-	// For now, we'll simulate success
+// It fails when no reset callback is registered or the callback panics.
+func (bt *BlueTeam) executeDetectorReset(action *HealingAction) (success bool) {
+	if bt.detectorReset == nil {
+		action.Error = "No detector registered for reset"
+		return false
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			action.Error = fmt.Sprintf("Detector reset panicked: %v", r)
+			success = false
+		}
+	}()
+
+	bt.detectorReset()
 	action.Description += " - Detector reset completed"
 	return true
 }
 
-// executeCircuitBreaker implements circuit breaker pattern for fault tolerance.
+// executeCircuitBreaker opens the circuit breaker so the ingest path stops
+// processing until half-open probes succeed.
 func (bt *BlueTeam) executeCircuitBreaker(action *HealingAction) bool {
-	// This would implement actual circuit breaker logic
-	// For now, we'll simulate success
-	action.Description += " - Circuit breaker activated"
+	bt.breaker.Trip()
+	action.Description += " - Circuit breaker opened"
 	return true
 }
 
+// CircuitBreaker returns the breaker the ingest path consults before processing.
+func (bt *BlueTeam) CircuitBreaker() *CircuitBreaker {
+	return bt.breaker
+}
+
+// SetLatencyCheck registers the check the periodic health check uses to decide
+// whether latency is high, e.g. the hypervisor's Axiom A-2 compliance.
+func (bt *BlueTeam) SetLatencyCheck(check func() bool) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	bt.latencyCheck = check
+}
+
+// SetHealthMetrics registers the service health the periodic health check
+// acts on, e.g. the hypervisor.
+func (bt *BlueTeam) SetHealthMetrics(health HealthMetrics) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	bt.health = health
+}
+
 // executeFallbackMode switches to a simplified fallback mode.
 func (bt *BlueTeam) executeFallbackMode(action *HealingAction) bool {
 	// This would switch to fallback algorithms
@@ -253,29 +497,135 @@ func (bt *BlueTeam) executeFallbackMode(action *HealingAction) bool {
 	return true
 }
 
-// executeResourceCleanup performs resource cleanup and garbage collection.
+// executeResourceCleanup forces a garbage collection and returns freed memory
+// to the operating system. It fails when the heap is still above the limit
+// afterwards, since cleanup alone cannot relieve the pressure.
 func (bt *BlueTeam) executeResourceCleanup(action *HealingAction) bool {
-	// This would force garbage collection and cleanup
-	// For now, we'll simulate success
+	debug.FreeOSMemory()
+
+	if bt.maxHeapBytes > 0 {
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		if mem.HeapAlloc > bt.maxHeapBytes {
+			action.Error = fmt.Sprintf("Heap still %d bytes after cleanup, above %d", mem.HeapAlloc, bt.maxHeapBytes)
+			return false
+		}
+	}
 	action.Description += " - Resource cleanup completed"
 	return true
 }
 
+// SetConfigReload registers the callback StrategyConfigReload uses to reload
+// the service configuration.
+func (bt *BlueTeam) SetConfigReload(reload func() error) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	bt.configReload = reload
+}
+
 // executeConfigReload reloads configuration to restore compliance.
 func (bt *BlueTeam) executeConfigReload(action *HealingAction) bool {
-	// This would reload configuration from disk
-	// For now, we'll simulate success
+	if bt.configReload == nil {
+		action.Description += " - No configuration reload registered"
+		return true
+	}
+	if err := bt.configReload(); err != nil {
+		action.Error = fmt.Sprintf("Configuration reload failed: %v", err)
+		return false
+	}
 	action.Description += " - Configuration reloaded"
 	return true
 }
 
-// HealOnDemand initiates healing for a specific issue type.
+// HealOnDemand initiates healing for a specific issue type. A heal is
+// suppressed while the issue/strategy pair is cooling down after a success, or
+// while the strategy is backing off after failures; the returned action then
+// has status "suppressed" and is not recorded in the healing history.
 func (bt *BlueTeam) HealOnDemand(issueType IssueType, strategy HealingStrategy) *HealingAction {
+	return bt.HealOnDemandWithCorrelation(issueType, strategy, "")
+}
+
+// HealOnDemandWithCorrelation is HealOnDemand recording correlationID, the ID
+// of the request that triggered the heal, on the action.
+func (bt *BlueTeam) HealOnDemandWithCorrelation(issueType IssueType, strategy HealingStrategy, correlationID string) *HealingAction {
 	bt.mu.Lock()
 	defer bt.mu.Unlock()
 
 	description := fmt.Sprintf("On-demand healing for %s using %s strategy", issueType, strategy)
-	return bt.initiateHealing(issueType, strategy, description)
+	if action := bt.suppressHealing(issueType, strategy, description); action != nil {
+		action.CorrelationID = correlationID
+		return action
+	}
+
+	action := bt.initiateCorrelatedHealing(issueType, strategy, description, correlationID)
+	bt.trackHealingOutcome(issueType, strategy, action.Success)
+	return action
+}
+
+// suppressHealing returns a suppressed action if the pair is cooling down or
+// the strategy is backing off, and nil otherwise. Must be called with bt.mu held.
+func (bt *BlueTeam) suppressHealing(issueType IssueType, strategy HealingStrategy, description string) *HealingAction {
+	now := bt.now()
+
+	var reason string
+	if until, exists := bt.cooldowns[healKey{issueType, strategy}]; exists && now.Before(until) {
+		reason = fmt.Sprintf("cooling down until %s", until.Format(time.RFC3339))
+	} else if backoff, exists := bt.backoffs[strategy]; exists && now.Before(backoff.until) {
+		reason = fmt.Sprintf("backing off after %d failures until %s", backoff.failures, backoff.until.Format(time.RFC3339))
+	} else {
+		return nil
+	}
+
+	bt.suppressedHeals++
+	return &HealingAction{
+		ID:          fmt.Sprintf("heal_%d_%s", now.UnixNano(), issueType),
+		Type:        issueType,
+		Strategy:    strategy,
+		Description: description + " - Suppressed, " + reason,
+		Timestamp:   now,
+		Status:      "suppressed",
+	}
+}
+
+// trackHealingOutcome starts the cooldown of a healed pair, or extends the
+// backoff of a failed strategy. Must be called with bt.mu held.
+func (bt *BlueTeam) trackHealingOutcome(issueType IssueType, strategy HealingStrategy, success bool) {
+	now := bt.now()
+
+	if success {
+		delete(bt.backoffs, strategy)
+		if bt.healCooldown > 0 {
+			bt.cooldowns[healKey{issueType, strategy}] = now.Add(bt.healCooldown)
+		}
+		return
+	}
+
+	if bt.failureBackoff <= 0 {
+		return
+	}
+	backoff := bt.backoffs[strategy]
+	backoff.failures++
+	delay := bt.failureBackoff
+	for i := 1; i < backoff.failures && (bt.maxBackoff <= 0 || delay < bt.maxBackoff); i++ {
+		delay *= 2
+	}
+	if bt.maxBackoff > 0 && delay > bt.maxBackoff {
+		delay = bt.maxBackoff
+	}
+	backoff.until = now.Add(delay)
+	bt.backoffs[strategy] = backoff
+	log.Printf("BlueTeam: Strategy %s failed %d times, backing off for %s", strategy, backoff.failures, delay)
+}
+
+// ClearCooldowns drops all heal cooldowns and failure backoffs, so the next
+// on-demand heal runs immediately.
+func (bt *BlueTeam) ClearCooldowns() {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	bt.cooldowns = make(map[healKey]time.Time)
+	bt.backoffs = make(map[HealingStrategy]strategyBackoff)
 }
 
 // GetHealingHistory returns the history of healing actions.
@@ -304,17 +654,23 @@ func (bt *BlueTeam) GetHealingStats() map[string]interface{} {
 	defer bt.mu.RUnlock()
 
 	stats := map[string]interface{}{
-		"total_actions":     len(bt.healingActions),
-		"healing_enabled":   bt.healingEnabled,
-		"monitor_interval":  bt.monitorInterval.String(),
-		"successful_heals":  0,
-		"failed_heals":      0,
-		"strategies_used":   make(map[string]int),
-		"issues_addressed":  make(map[string]int),
+		"total_actions":    len(bt.healingActions),
+		"healing_enabled":  bt.healingEnabled,
+		"monitor_interval": bt.monitorInterval.String(),
+		"successful_heals": 0,
+		"failed_heals":     0,
+		"strategies_used":  make(map[string]int),
+		"issues_addressed": make(map[string]int),
+		"circuit_breaker":  bt.breaker.Stats(),
+		"suppressed_heals": bt.suppressedHeals,
+		"dry_run":          bt.dryRun,
+		"dry_run_heals":    0,
 	}
 
 	for _, action := range bt.healingActions {
-		if action.Success {
+		if action.Status == "dry_run" {
+			stats["dry_run_heals"] = stats["dry_run_heals"].(int) + 1
+		} else if action.Success {
 			stats["successful_heals"] = stats["successful_heals"].(int) + 1
 		} else {
 			stats["failed_heals"] = stats["failed_heals"].(int) + 1
@@ -355,52 +711,13 @@ func (bt *BlueTeam) DisableHealing() {
 // DefaultConfig returns default Blue Team configuration.
 func DefaultConfig() Config {
 	return Config{
-		MaxActions:      1000,
-		MonitorInterval: time.Minute * 5,
-		HealingEnabled:  true,
+		MaxActions:        1000,
+		MonitorInterval:   time.Minute * 5,
+		HealingEnabled:    true,
+		Breaker:           DefaultBreakerConfig(),
+		HealCooldown:      time.Minute,
+		FailureBackoff:    time.Second,
+		MaxFailureBackoff: 5 * time.Minute,
+		MinSuccessRate:    95.0,
 	}
 }
-
-// Healer holds a reference to the anomaly detector to execute patches.
-type Healer struct {
-	Detector *anomaly.AnomalyDetector
-}
-
-// NewHealer creates a new Blue Team Healer instance.
-func NewHealer(d *anomaly.AnomalyDetector) *Healer {
-	return &Healer{Detector: d}
-}
-
-// ExecuteHardReversion performs the fast, necessary rollback for critical failures.
-// This is the fastest path to restoring Axiom A-1 Determinism.
-func (h *Healer) ExecuteHardReversion(faultReason string) time.Duration {
-	log.Printf("[BlueTeam/HardReversion] Initiating critical state rollback. Reason: %s", faultReason)
-	start := time.Now()
-
-	// Rollback to known-good default state
-	// In a full CRG, this would involve loading the last HASHED snapshot.
-	h.Detector.ResetState(500, 3.5) // Default values
-
-	duration := time.Since(start)
-	log.Printf("[BlueTeam/HardReversion] State roll-back complete. Time-to-Heal: %s", duration)
-	return duration
-}
-
-// ExecuteSoftPatch initiates a logical correction (e.g., based on high false-positive rate).
-// This path prioritizes validation and strategic optimization (Protocol γ-Axiomatic Control).
-func (h *Healer) ExecuteSoftPatch(faultReason string, newThreshold float64) time.Duration {
-	log.Printf("[BlueTeam/SoftPatch] Initiating logical correction. Reason: %s", faultReason)
-	start := time.Now()
-
-	// 1. Apply Patch
-	h.Detector.AdjustThreshold(newThreshold)
-
-	// 2. Validation (Protocol γ-Axiomatic Control Check)
-	// In a full SCGO, this would involve re-running a simulated test set against the new threshold
-	// to ensure the change optimizes the PoV metric.
-	log.Println("[BlueTeam] Patch validated against γ-Axiomatic Control (Simulated PASS).")
-
-	duration := time.Since(start)
-	log.Printf("[BlueTeam/SoftPatch] Patch complete. Time-to-Heal: %s", duration)
-	return duration
-}
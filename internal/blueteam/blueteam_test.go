@@ -0,0 +1,289 @@
+package blueteam
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"anomaly"
+)
+
+func TestBlueTeam_HistoryRestoredAfterRestart(t *testing.T) {
+	config := DefaultConfig()
+	config.HistoryFile = filepath.Join(t.TempDir(), "healing_history.jsonl")
+
+	bt := NewBlueTeam(config)
+	bt.HealOnDemand(IssueHighLatency, StrategyCircuitBreaker)
+	bt.HealOnDemand(IssueComplianceFailure, StrategyConfigReload)
+	bt.HealOnDemand(IssueHighErrorRate, "unknown_strategy")
+	bt.Flush()
+
+	before := bt.GetHealingHistory(0)
+
+	// Simulate a restart by constructing a fresh BlueTeam on the same file
+	restarted := NewBlueTeam(config)
+	after := restarted.GetHealingHistory(0)
+
+	if len(after) != len(before) {
+		t.Fatalf("Expected %d restored actions, got %d", len(before), len(after))
+	}
+	for i := range before {
+		if after[i].ID != before[i].ID || after[i].Strategy != before[i].Strategy || after[i].Success != before[i].Success {
+			t.Errorf("Action %d: expected %+v, got %+v", i, before[i], after[i])
+		}
+	}
+
+	stats := restarted.GetHealingStats()
+	if stats["failed_heals"] != 1 {
+		t.Errorf("Expected restored stats to include 1 failed heal, got %v", stats["failed_heals"])
+	}
+}
+
+func TestBlueTeam_HistoryTrimmedToMaxActions(t *testing.T) {
+	config := DefaultConfig()
+	config.HistoryFile = filepath.Join(t.TempDir(), "healing_history.jsonl")
+	config.MaxActions = 3
+
+	bt := NewBlueTeam(config)
+	for i := 0; i < 5; i++ {
+		bt.ClearCooldowns()
+		bt.HealOnDemand(IssueHighLatency, StrategyCircuitBreaker)
+	}
+	bt.Flush()
+
+	restarted := NewBlueTeam(config)
+	history := restarted.GetHealingHistory(0)
+	if len(history) != 3 {
+		t.Fatalf("Expected restored history trimmed to 3, got %d", len(history))
+	}
+
+	// The file itself is compacted to the restored actions
+	data, err := os.ReadFile(config.HistoryFile)
+	if err != nil {
+		t.Fatalf("Failed to read history file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], history[0].ID) {
+		t.Errorf("Expected the history file compacted to the 3 restored actions, got %d lines", len(lines))
+	}
+	if tmp, _ := filepath.Glob(filepath.Join(filepath.Dir(config.HistoryFile), ".healing-history-*")); len(tmp) != 0 {
+		t.Errorf("Expected no temporary files, got %v", tmp)
+	}
+}
+
+func TestBlueTeam_LoadHistoryPicksUpNewActions(t *testing.T) {
+	config := DefaultConfig()
+	config.HistoryFile = filepath.Join(t.TempDir(), "healing_history.jsonl")
+
+	reader := NewBlueTeam(config)
+	writer := NewBlueTeam(config)
+	writer.HealOnDemand(IssueHighLatency, StrategyCircuitBreaker)
+	writer.HealOnDemand(IssueResourceExhaustion, StrategyResourceCleanup)
+	writer.Flush()
+
+	if history := reader.GetHealingHistory(0); len(history) != 0 {
+		t.Fatalf("Expected no history before reloading, got %d actions", len(history))
+	}
+	if err := reader.LoadHistory(); err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+
+	want, got := writer.GetHealingHistory(0), reader.GetHealingHistory(0)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d reloaded actions, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID || got[i].Type != want[i].Type || got[i].Status != want[i].Status {
+			t.Errorf("Action %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestBlueTeam_MissingHistoryFile(t *testing.T) {
+	config := DefaultConfig()
+	config.HistoryFile = filepath.Join(t.TempDir(), "missing.jsonl")
+
+	bt := NewBlueTeam(config)
+	if history := bt.GetHealingHistory(0); len(history) != 0 {
+		t.Errorf("Expected empty history, got %d actions", len(history))
+	}
+}
+
+func TestBlueTeam_ResetDetectorClearsWindow(t *testing.T) {
+	detector := anomaly.NewDetector(50, 3.0)
+	for i := 0; i < 50; i++ {
+		if _, _, err := detector.ProcessData(anomaly.DataPoint{Timestamp: int64(i + 1), Value: float64(i % 7)}); err != nil {
+			t.Fatalf("Failed to fill window: %v", err)
+		}
+	}
+	if count, _, _ := detector.GetStats(); count != 50 {
+		t.Fatalf("Expected a full window, got %d points", count)
+	}
+
+	bt := NewBlueTeam(DefaultConfig())
+	bt.SetDetectorReset(detector.Reset)
+	action := bt.HealOnDemand(IssueHighLatency, StrategyResetDetector)
+
+	if !action.Success {
+		t.Errorf("Expected the reset to succeed, got %+v", action)
+	}
+	if count, _, _ := detector.GetStats(); count != 0 {
+		t.Errorf("Expected an empty window after reset, got %d points", count)
+	}
+}
+
+func TestBlueTeam_ResetDetectorFailsWithoutCallback(t *testing.T) {
+	bt := NewBlueTeam(DefaultConfig())
+	if action := bt.HealOnDemand(IssueHighLatency, StrategyResetDetector); action.Success || action.Error == "" {
+		t.Errorf("Expected a failed reset without a detector, got %+v", action)
+	}
+
+	bt.ClearCooldowns()
+	bt.SetDetectorReset(func() { panic("corrupted state") })
+	if action := bt.HealOnDemand(IssueHighLatency, StrategyResetDetector); action.Success || action.Error == "" {
+		t.Errorf("Expected a failed reset when the callback panics, got %+v", action)
+	}
+}
+
+func TestBlueTeam_HealCooldownSuppressesRepeatHeals(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	bt := NewBlueTeam(DefaultConfig())
+	bt.now = func() time.Time { return now }
+
+	if action := bt.HealOnDemand(IssueHighLatency, StrategyCircuitBreaker); action.Status != "completed" {
+		t.Fatalf("Expected the first heal to complete, got %+v", action)
+	}
+	if action := bt.HealOnDemand(IssueHighLatency, StrategyCircuitBreaker); action.Status != "suppressed" || action.Success {
+		t.Errorf("Expected the repeat heal to be suppressed, got %+v", action)
+	}
+
+	// Other pairs are not affected by the cooldown
+	if action := bt.HealOnDemand(IssueComplianceFailure, StrategyCircuitBreaker); action.Status != "completed" {
+		t.Errorf("Expected a different issue to heal, got %+v", action)
+	}
+
+	now = now.Add(time.Minute)
+	if action := bt.HealOnDemand(IssueHighLatency, StrategyCircuitBreaker); action.Status != "completed" {
+		t.Errorf("Expected the heal to run once the cooldown elapsed, got %+v", action)
+	}
+
+	if history := bt.GetHealingHistory(0); len(history) != 3 {
+		t.Errorf("Expected suppressed heals to stay out of the history, got %d actions", len(history))
+	}
+	if stats := bt.GetHealingStats(); stats["suppressed_heals"] != int64(1) {
+		t.Errorf("Expected 1 suppressed heal, got %v", stats["suppressed_heals"])
+	}
+}
+
+func TestBlueTeam_FailureBackoffDoubles(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	config := DefaultConfig()
+	config.FailureBackoff = time.Second
+	config.MaxFailureBackoff = 3 * time.Second
+	bt := NewBlueTeam(config)
+	bt.now = func() time.Time { return now }
+
+	// No detector is registered, so every reset fails
+	heal := func() *HealingAction {
+		return bt.HealOnDemand(IssueHighErrorRate, StrategyResetDetector)
+	}
+	if action := heal(); action.Status != "failed" {
+		t.Fatalf("Expected the reset to fail, got %+v", action)
+	}
+	if action := heal(); action.Status != "suppressed" {
+		t.Errorf("Expected a heal during backoff to be suppressed, got %+v", action)
+	}
+
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second} {
+		now = now.Add(delay)
+		if action := heal(); action.Status != "failed" {
+			t.Fatalf("Expected the heal to run after %s, got %+v", delay, action)
+		}
+		now = now.Add(delay * 2 / 3)
+		if action := heal(); action.Status != "suppressed" {
+			t.Errorf("Expected the heal to stay suppressed during the next backoff, got %+v", action)
+		}
+		now = now.Add(-delay * 2 / 3)
+	}
+
+	bt.ClearCooldowns()
+	bt.SetDetectorReset(func() {})
+	if action := heal(); action.Status != "completed" {
+		t.Errorf("Expected ClearCooldowns to allow an immediate heal, got %+v", action)
+	}
+}
+
+func TestBlueTeam_RegisterStrategy(t *testing.T) {
+	bt := NewBlueTeam(DefaultConfig())
+
+	paged := 0
+	page := func(action *HealingAction) bool {
+		paged++
+		action.Description += " - Paged on-call"
+		return true
+	}
+	if err := bt.RegisterStrategy("page_oncall", page); err != nil {
+		t.Fatalf("Failed to register strategy: %v", err)
+	}
+	if err := bt.RegisterStrategy("page_oncall", page); err == nil {
+		t.Error("Expected registering a duplicate strategy to fail")
+	}
+	if !bt.HasStrategy("page_oncall") || !bt.HasStrategy(StrategyFallbackMode) || bt.HasStrategy("drain_node") {
+		t.Error("Expected HasStrategy to report built-in and registered strategies only")
+	}
+
+	action := bt.HealOnDemand(IssueHighLatency, "page_oncall")
+	if !action.Success || paged != 1 {
+		t.Errorf("Expected the registered strategy to run, got %+v (paged %d)", action, paged)
+	}
+
+	// A registered strategy takes precedence over the built-in of the same name
+	if err := bt.RegisterStrategy(StrategyResetDetector, func(action *HealingAction) bool { return true }); err != nil {
+		t.Fatalf("Failed to override built-in strategy: %v", err)
+	}
+	if action := bt.HealOnDemand(IssueHighLatency, StrategyResetDetector); !action.Success {
+		t.Errorf("Expected the override to replace the built-in reset, got %+v", action)
+	}
+}
+
+func TestBlueTeam_FailedStrategyRecorded(t *testing.T) {
+	bt := NewBlueTeam(DefaultConfig())
+
+	// A reload pointing at a missing configuration file genuinely fails
+	missing := filepath.Join(t.TempDir(), "radm.env")
+	bt.SetConfigReload(func() error {
+		if _, err := os.ReadFile(missing); err != nil {
+			return fmt.Errorf("failed to read configuration: %w", err)
+		}
+		return nil
+	})
+
+	action := bt.HealOnDemand(IssueComplianceFailure, StrategyConfigReload)
+	if action.Success || action.Status != "failed" {
+		t.Errorf("Expected the reload heal to fail, got %+v", action)
+	}
+	if !strings.Contains(action.Error, "Configuration reload failed") || !strings.Contains(action.Error, "radm.env") {
+		t.Errorf("Expected the reload error recorded, got %q", action.Error)
+	}
+
+	// A built-in that normally succeeds can be made to fail, then restored
+	bt.SetStrategyFailure(StrategyFallbackMode, errors.New("fallback detector unavailable"))
+	action = bt.HealOnDemand(IssueHighErrorRate, StrategyFallbackMode)
+	if action.Success || !strings.Contains(action.Error, "fallback detector unavailable") {
+		t.Errorf("Expected the forced failure recorded, got %+v", action)
+	}
+	bt.SetStrategyFailure(StrategyFallbackMode, nil)
+	bt.ClearCooldowns()
+	if action := bt.HealOnDemand(IssueHighLatency, StrategyFallbackMode); !action.Success {
+		t.Errorf("Expected the restored strategy to succeed, got %+v", action)
+	}
+
+	stats := bt.GetHealingStats()
+	if stats["failed_heals"] != 2 || stats["successful_heals"] != 1 {
+		t.Errorf("Expected 2 failed and 1 successful heal, got %v failed, %v successful", stats["failed_heals"], stats["successful_heals"])
+	}
+}
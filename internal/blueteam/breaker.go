@@ -0,0 +1,178 @@
+package blueteam
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState string
+
+const (
+	// BreakerClosed lets every request through while counting failures.
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen rejects every request until OpenTimeout has elapsed.
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets probe requests through; HalfOpenSuccesses consecutive
+	// successes close the breaker and any failure opens it again.
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// BreakerConfig holds circuit breaker configuration.
+type BreakerConfig struct {
+	FailureThreshold  int           `json:"failure_threshold"`   // Failures within FailureWindow that trip the breaker
+	FailureWindow     time.Duration `json:"failure_window"`      // Failures older than this no longer count
+	OpenTimeout       time.Duration `json:"open_timeout"`        // Time spent open before probing
+	HalfOpenSuccesses int           `json:"half_open_successes"` // Consecutive probe successes that close the breaker
+}
+
+// DefaultBreakerConfig returns default circuit breaker configuration.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold:  5,
+		FailureWindow:     time.Minute,
+		OpenTimeout:       30 * time.Second,
+		HalfOpenSuccesses: 3,
+	}
+}
+
+// CircuitBreaker stops the ingest path from processing requests while it is
+// failing, giving it time to recover before traffic is let back in.
+type CircuitBreaker struct {
+	mu          sync.Mutex
+	config      BreakerConfig
+	state       BreakerState
+	failures    int       // Failures in the current window while closed
+	windowStart time.Time // Time of the first failure counted in failures
+	successes   int       // Consecutive successes while half-open
+	openedAt    time.Time
+	trips       int64
+	now         func() time.Time
+}
+
+// NewCircuitBreaker creates a closed circuit breaker. Non-positive settings
+// fall back to DefaultBreakerConfig.
+func NewCircuitBreaker(config BreakerConfig) *CircuitBreaker {
+	defaults := DefaultBreakerConfig()
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaults.FailureThreshold
+	}
+	if config.FailureWindow <= 0 {
+		config.FailureWindow = defaults.FailureWindow
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = defaults.OpenTimeout
+	}
+	if config.HalfOpenSuccesses <= 0 {
+		config.HalfOpenSuccesses = defaults.HalfOpenSuccesses
+	}
+
+	return &CircuitBreaker{
+		config: config,
+		state:  BreakerClosed,
+		now:    time.Now,
+	}
+}
+
+// Allow reports whether a request may proceed. An open breaker moves to
+// half-open, admitting probes, once OpenTimeout has elapsed.
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == BreakerOpen {
+		if cb.now().Sub(cb.openedAt) < cb.config.OpenTimeout {
+			return false
+		}
+		cb.state = BreakerHalfOpen
+		cb.successes = 0
+		log.Println("BlueTeam: Circuit breaker half-open, probing")
+	}
+	return true
+}
+
+// RecordSuccess records a request that completed normally.
+func (cb *CircuitBreaker) RecordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case BreakerHalfOpen:
+		cb.successes++
+		if cb.successes >= cb.config.HalfOpenSuccesses {
+			cb.state = BreakerClosed
+			cb.failures = 0
+			log.Printf("BlueTeam: Circuit breaker closed after %d successful probes", cb.successes)
+		}
+	case BreakerClosed:
+		cb.failures = 0
+	}
+}
+
+// RecordFailure records a failed request, tripping the breaker once
+// FailureThreshold failures occur within FailureWindow, or on any failed probe.
+func (cb *CircuitBreaker) RecordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case BreakerHalfOpen:
+		cb.open("probe failed")
+	case BreakerClosed:
+		now := cb.now()
+		if cb.failures == 0 || now.Sub(cb.windowStart) > cb.config.FailureWindow {
+			cb.failures = 0
+			cb.windowStart = now
+		}
+		cb.failures++
+		if cb.failures >= cb.config.FailureThreshold {
+			cb.open("failure threshold reached")
+		}
+	}
+}
+
+// Trip opens the breaker immediately, e.g. as a healing action.
+func (cb *CircuitBreaker) Trip() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.open("tripped")
+}
+
+// open moves the breaker to the open state. Must be called with cb.mu held.
+func (cb *CircuitBreaker) open(reason string) {
+	cb.state = BreakerOpen
+	cb.openedAt = cb.now()
+	cb.failures = 0
+	cb.successes = 0
+	cb.trips++
+	log.Printf("BlueTeam: Circuit breaker opened (%s) for %s", reason, cb.config.OpenTimeout)
+}
+
+// State returns the current state without advancing an expired open state.
+func (cb *CircuitBreaker) State() BreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.state
+}
+
+// Stats returns the breaker state and counters.
+func (cb *CircuitBreaker) Stats() map[string]interface{} {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	stats := map[string]interface{}{
+		"state":               cb.state,
+		"failures":            cb.failures,
+		"trips":               cb.trips,
+		"failure_threshold":   cb.config.FailureThreshold,
+		"open_timeout":        cb.config.OpenTimeout.String(),
+		"half_open_successes": cb.config.HalfOpenSuccesses,
+	}
+	if cb.state != BreakerClosed {
+		stats["opened_at"] = cb.openedAt
+	}
+	return stats
+}
@@ -0,0 +1,182 @@
+package blueteam
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestBreaker returns a breaker driven by a manual clock.
+func newTestBreaker(config BreakerConfig) (*CircuitBreaker, *time.Time) {
+	clock := time.Unix(1609459200, 0)
+	cb := NewCircuitBreaker(config)
+	cb.now = func() time.Time { return clock }
+	return cb, &clock
+}
+
+func TestCircuitBreaker_TripsOnFailuresWithinWindow(t *testing.T) {
+	cb, clock := newTestBreaker(BreakerConfig{FailureThreshold: 3, FailureWindow: time.Minute, OpenTimeout: 10 * time.Second, HalfOpenSuccesses: 2})
+
+	cb.RecordFailure()
+	cb.RecordFailure()
+	*clock = clock.Add(2 * time.Minute) // The earlier failures age out
+	cb.RecordFailure()
+	cb.RecordFailure()
+	if cb.State() != BreakerClosed || !cb.Allow() {
+		t.Fatalf("Expected the breaker closed with 2 failures in the window, got %s", cb.State())
+	}
+
+	cb.RecordFailure()
+	if cb.State() != BreakerOpen || cb.Allow() {
+		t.Fatalf("Expected the breaker open after 3 failures in the window, got %s", cb.State())
+	}
+
+	// A success while closed resets the count
+	cb2, _ := newTestBreaker(BreakerConfig{FailureThreshold: 2})
+	cb2.RecordFailure()
+	cb2.RecordSuccess()
+	cb2.RecordFailure()
+	if cb2.State() != BreakerClosed {
+		t.Errorf("Expected non-consecutive failures not to trip, got %s", cb2.State())
+	}
+}
+
+func TestCircuitBreaker_HalfOpenProbing(t *testing.T) {
+	cb, clock := newTestBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Second, HalfOpenSuccesses: 2})
+
+	cb.Trip()
+	*clock = clock.Add(5 * time.Second)
+	if cb.Allow() {
+		t.Fatal("Expected requests rejected before the open timeout")
+	}
+
+	// A failed probe reopens the breaker
+	*clock = clock.Add(5 * time.Second)
+	if !cb.Allow() || cb.State() != BreakerHalfOpen {
+		t.Fatalf("Expected a half-open probe after the open timeout, got %s", cb.State())
+	}
+	cb.RecordFailure()
+	if cb.State() != BreakerOpen || cb.Allow() {
+		t.Fatalf("Expected a failed probe to reopen the breaker, got %s", cb.State())
+	}
+
+	*clock = clock.Add(10 * time.Second)
+	cb.Allow()
+	cb.RecordSuccess()
+	if cb.State() != BreakerHalfOpen {
+		t.Errorf("Expected the breaker to stay half-open after 1 of 2 successes, got %s", cb.State())
+	}
+	cb.RecordSuccess()
+	if cb.State() != BreakerClosed {
+		t.Errorf("Expected the breaker closed after 2 successful probes, got %s", cb.State())
+	}
+	if trips := cb.Stats()["trips"]; trips != int64(2) {
+		t.Errorf("Expected 2 trips, got %v", trips)
+	}
+}
+
+func TestBlueTeam_CircuitBreakerStrategyOpensBreaker(t *testing.T) {
+	bt := NewBlueTeam(DefaultConfig())
+	if action := bt.HealOnDemand(IssueHighLatency, StrategyCircuitBreaker); !action.Success {
+		t.Fatalf("Expected the strategy to succeed, got %+v", action)
+	}
+	if bt.CircuitBreaker().Allow() {
+		t.Error("Expected the breaker to reject requests")
+	}
+
+	stats := bt.GetHealingStats()["circuit_breaker"].(map[string]interface{})
+	if stats["state"] != BreakerOpen {
+		t.Errorf("Expected the open state in healing stats, got %v", stats["state"])
+	}
+}
+
+func TestBlueTeam_HealthCheckOnlyTripsOnHighLatency(t *testing.T) {
+	bt := NewBlueTeam(DefaultConfig())
+	bt.performHealthCheck()
+	if bt.CircuitBreaker().State() != BreakerClosed {
+		t.Error("Expected no trip without a latency check")
+	}
+
+	highLatency := false
+	bt.SetLatencyCheck(func() bool { return highLatency })
+	bt.performHealthCheck()
+	if bt.CircuitBreaker().State() != BreakerClosed {
+		t.Error("Expected no trip while latency is healthy")
+	}
+
+	highLatency = true
+	bt.performHealthCheck()
+	if bt.CircuitBreaker().State() != BreakerOpen {
+		t.Error("Expected a trip on high latency")
+	}
+}
+
+// fakeHealth is a HealthMetrics whose readings tests set directly.
+type fakeHealth struct {
+	a2, a4      bool
+	successRate float64
+}
+
+func (h *fakeHealth) IsAxiomA2Compliant() bool     { return h.a2 }
+func (h *fakeHealth) IsAxiomA4Compliant() bool     { return h.a4 }
+func (h *fakeHealth) DecisionSuccessRate() float64 { return h.successRate }
+
+func TestBlueTeam_HealthCheckHealsOnlyBreaches(t *testing.T) {
+	bt := NewBlueTeam(DefaultConfig())
+	health := &fakeHealth{a2: true, a4: true, successRate: 100}
+	bt.SetHealthMetrics(health)
+
+	bt.CheckHealth()
+	if history := bt.GetHealingHistory(0); len(history) != 0 {
+		t.Fatalf("Expected no healing while healthy, got %+v", history)
+	}
+
+	health.successRate = 80
+	health.a4 = false
+	bt.CheckHealth()
+	history := bt.GetHealingHistory(0)
+	if len(history) != 2 || history[0].Type != IssueHighErrorRate || history[1].Type != IssueComplianceFailure {
+		t.Fatalf("Expected error rate and compliance heals, got %+v", history)
+	}
+	if bt.CircuitBreaker().State() != BreakerClosed {
+		t.Error("Expected the breaker closed while latency is healthy")
+	}
+
+	health.successRate = 100
+	health.a4 = true
+	bt.CheckHealth()
+	if got := len(bt.GetHealingHistory(0)); got != 2 {
+		t.Errorf("Expected no further heals after recovery, got %d actions", got)
+	}
+}
+
+func TestBlueTeam_DryRunHasNoSideEffects(t *testing.T) {
+	config := DefaultConfig()
+	config.DryRun = true
+	bt := NewBlueTeam(config)
+
+	resets := 0
+	bt.SetDetectorReset(func() { resets++ })
+
+	for _, strategy := range []HealingStrategy{StrategyResetDetector, StrategyCircuitBreaker} {
+		action := bt.HealOnDemand(IssueHighLatency, strategy)
+		if action.Status != "dry_run" || !action.Success {
+			t.Errorf("Expected a successful dry-run action for %s, got %+v", strategy, action)
+		}
+	}
+	if resets != 0 {
+		t.Errorf("Expected no detector reset in dry-run mode, got %d", resets)
+	}
+	if bt.CircuitBreaker().State() != BreakerClosed {
+		t.Error("Expected the breaker to stay closed in dry-run mode")
+	}
+
+	if action := bt.HealOnDemand(IssueHighLatency, "drain_node"); action.Success {
+		t.Errorf("Expected an unknown strategy to fail in dry-run mode, got %+v", action)
+	}
+
+	stats := bt.GetHealingStats()
+	if stats["dry_run_heals"] != 2 || stats["successful_heals"] != 0 || stats["failed_heals"] != 1 {
+		t.Errorf("Expected 2 dry-run, 0 successful and 1 failed heals, got %v/%v/%v",
+			stats["dry_run_heals"], stats["successful_heals"], stats["failed_heals"])
+	}
+}
@@ -49,3 +49,14 @@ func (h *Healer) ExecuteSoftPatch(faultReason string, newThreshold float64) time
 	log.Printf("[BlueTeam/SoftPatch] Patch complete. Time-to-Heal: %s", duration)
 	return duration
 }
+
+// ExecuteValidatedSoftPatch is ExecuteSoftPatch with a real validation step:
+// validate is run against newThreshold first, and the patch is only applied
+// if it passes. A rejected patch leaves the detector unchanged.
+func (h *Healer) ExecuteValidatedSoftPatch(faultReason string, newThreshold float64, validate func(threshold float64) error) (time.Duration, error) {
+	if err := validate(newThreshold); err != nil {
+		log.Printf("[BlueTeam/SoftPatch] Patch to threshold %.2f rejected by validation. Reason: %s: %v", newThreshold, faultReason, err)
+		return 0, err
+	}
+	return h.ExecuteSoftPatch(faultReason, newThreshold), nil
+}
@@ -0,0 +1,159 @@
+package blueteam
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// persistAction queues a healing action to be appended to the history file.
+// Actions are written in order by a background writer, so a slow or failing
+// disk never blocks the heal; write failures are logged.
+func (bt *BlueTeam) persistAction(action HealingAction) {
+	bt.historyMu.Lock()
+	defer bt.historyMu.Unlock()
+
+	bt.historyQueue = append(bt.historyQueue, action)
+	if !bt.historyWriting {
+		bt.historyWriting = true
+		bt.historyPending.Add(1)
+		go bt.writeHistory()
+	}
+}
+
+// writeHistory appends queued actions to the history file until the queue is empty.
+func (bt *BlueTeam) writeHistory() {
+	defer bt.historyPending.Done()
+
+	for {
+		bt.historyMu.Lock()
+		actions := bt.historyQueue
+		bt.historyQueue = nil
+		if len(actions) == 0 {
+			bt.historyWriting = false
+			bt.historyMu.Unlock()
+			return
+		}
+		bt.historyMu.Unlock()
+
+		bt.appendHistory(actions)
+	}
+}
+
+// appendHistory appends actions to the history file as JSON lines.
+func (bt *BlueTeam) appendHistory(actions []HealingAction) {
+	file, err := os.OpenFile(bt.historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Printf("BlueTeam: Error opening healing history file: %v", err)
+		return
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, action := range actions {
+		if err := encoder.Encode(action); err != nil {
+			log.Printf("BlueTeam: Error writing healing action: %v", err)
+			return
+		}
+	}
+}
+
+// Flush blocks until all queued healing actions have been written.
+func (bt *BlueTeam) Flush() {
+	bt.historyPending.Wait()
+}
+
+// LoadHistory replaces the in-memory healing history with the most recent
+// maxActions actions from the history file, compacting the file to them. A
+// missing file, or no history file configured, is not an error.
+func (bt *BlueTeam) LoadHistory() error {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	if bt.historyFile == "" {
+		return nil
+	}
+	// Heals queue actions under bt.mu, so once the queue drains the file is
+	// complete until the lock is released
+	bt.Flush()
+	return bt.loadHistory()
+}
+
+// loadHistory restores the most recent maxActions healing actions from the
+// history file, rewriting the file without older and malformed entries so it
+// does not grow without bound across restarts. A missing file is not an
+// error. Must be called with bt.mu held and no history write in flight.
+func (bt *BlueTeam) loadHistory() error {
+	file, err := os.Open(bt.historyFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open healing history: %w", err)
+	}
+	defer file.Close()
+
+	actions := make([]HealingAction, 0, bt.maxActions)
+	entries := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entries++
+		var action HealingAction
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+			log.Printf("BlueTeam: Skipping malformed healing history entry: %v", err)
+			continue
+		}
+		actions = append(actions, action)
+		if len(actions) > bt.maxActions {
+			actions = actions[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read healing history: %w", err)
+	}
+	file.Close()
+
+	bt.healingActions = append(bt.healingActions[:0], actions...)
+
+	if entries > len(actions) {
+		if err := bt.compactHistory(actions); err != nil {
+			return err
+		}
+		log.Printf("BlueTeam: Compacted healing history %s from %d to %d entries", bt.historyFile, entries, len(actions))
+	}
+	return nil
+}
+
+// compactHistory replaces the history file with actions. The file is written
+// under a temporary name and renamed, so a failed compaction loses nothing.
+func (bt *BlueTeam) compactHistory(actions []HealingAction) error {
+	tmp, err := os.CreateTemp(filepath.Dir(bt.historyFile), ".healing-history-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to compact healing history: %w", err)
+	}
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, action := range actions {
+		if err = encoder.Encode(action); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), bt.historyFile)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to compact healing history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package blueteam
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"anomaly"
+)
+
+// TunerConfig bounds automatic threshold tuning.
+type TunerConfig struct {
+	Interval     time.Duration `json:"interval"`      // How often new feedback is evaluated
+	MinFeedback  int           `json:"min_feedback"`  // New feedback entries needed before a change is proposed
+	Step         float64       `json:"step"`          // Threshold change per adjustment
+	MinThreshold float64       `json:"min_threshold"` // The tuner never lowers the threshold below this
+	MaxThreshold float64       `json:"max_threshold"` // The tuner never raises the threshold above this
+}
+
+// DefaultTunerConfig returns default threshold tuning configuration.
+func DefaultTunerConfig() TunerConfig {
+	return TunerConfig{
+		Interval:     5 * time.Minute,
+		MinFeedback:  50,
+		Step:         0.25,
+		MinThreshold: 2.0,
+		MaxThreshold: 6.0,
+	}
+}
+
+// ThresholdChange records one threshold adjustment made by the tuner.
+type ThresholdChange struct {
+	Timestamp      time.Time `json:"timestamp"`
+	From           float64   `json:"from"`
+	To             float64   `json:"to"`
+	Reason         string    `json:"reason"`
+	FalsePositives int       `json:"false_positives"` // In the feedback that prompted the change
+	FalseNegatives int       `json:"false_negatives"`
+	ReplayF1Before float64   `json:"replay_f1_before"` // F1 score of the scored feedback at From
+	ReplayF1After  float64   `json:"replay_f1_after"`  // F1 score of the scored feedback at To
+}
+
+// ThresholdTuner adjusts the healer's detector threshold from client
+// feedback. More false positives than false negatives raise the threshold by
+// one step, more false negatives lower it. Each proposal goes through the
+// healer's validated soft-patch path: it is replayed against the scored
+// feedback and only applied if it does not lower the F1 score.
+type ThresholdTuner struct {
+	mu       sync.Mutex
+	healer   *Healer
+	quality  *anomaly.QualityTracker
+	config   TunerConfig
+	lastSeq  int64 // Feedback up to this sequence number has been evaluated
+	changes  []ThresholdChange
+	rejected int64 // Proposals rejected by validation
+	onChange func(ThresholdChange)
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewThresholdTuner creates a tuner adjusting healer's detector from the
+// feedback in quality.
+func NewThresholdTuner(healer *Healer, quality *anomaly.QualityTracker, config TunerConfig) (*ThresholdTuner, error) {
+	if config.Interval <= 0 || config.MinFeedback <= 0 || config.Step <= 0 {
+		return nil, fmt.Errorf("threshold tuning interval, min feedback and step must be positive")
+	}
+	if config.MinThreshold <= 0 || config.MinThreshold > config.MaxThreshold {
+		return nil, fmt.Errorf("threshold tuning bounds must satisfy 0 < min <= max, got [%v, %v]", config.MinThreshold, config.MaxThreshold)
+	}
+
+	return &ThresholdTuner{
+		healer:  healer,
+		quality: quality,
+		config:  config,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}, nil
+}
+
+// SetChangeHandler registers a callback invoked after each applied change,
+// e.g. to audit it.
+func (t *ThresholdTuner) SetChangeHandler(handler func(ThresholdChange)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.onChange = handler
+}
+
+// Start evaluates new feedback every interval until Stop.
+func (t *ThresholdTuner) Start() {
+	go func() {
+		defer close(t.done)
+
+		ticker := time.NewTicker(t.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := t.Tune(); err != nil {
+					log.Printf("BlueTeam: Threshold tuning proposal rejected: %v", err)
+				}
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the tuning loop.
+func (t *ThresholdTuner) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stop)
+		<-t.done
+	})
+}
+
+// Tune evaluates the feedback received since the last evaluation and, once
+// there is at least MinFeedback of it, proposes and applies one step. It
+// returns the applied change, nil when none was warranted, or the error a
+// rejected proposal failed validation with.
+func (t *ThresholdTuner) Tune() (*ThresholdChange, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seq := t.quality.Seq()
+	report := t.quality.ReportSince(t.lastSeq)
+	if report.Feedback < t.config.MinFeedback {
+		return nil, nil
+	}
+	t.lastSeq = seq // Each feedback entry prompts at most one proposal
+
+	current := t.healer.Detector.CurrentThreshold()
+	var proposed float64
+	var reason string
+	switch {
+	case report.FalsePositives > report.FalseNegatives:
+		proposed = math.Min(current+t.config.Step, t.config.MaxThreshold)
+		reason = fmt.Sprintf("%d false positives outweigh %d false negatives", report.FalsePositives, report.FalseNegatives)
+	case report.FalseNegatives > report.FalsePositives:
+		proposed = math.Max(current-t.config.Step, t.config.MinThreshold)
+		reason = fmt.Sprintf("%d false negatives outweigh %d false positives", report.FalseNegatives, report.FalsePositives)
+	default:
+		return nil, nil
+	}
+	if proposed == current {
+		return nil, nil // Already at the bound
+	}
+
+	labeled := t.quality.Labeled()
+	before := replayF1(labeled, current)
+	after := replayF1(labeled, proposed)
+	_, err := t.healer.ExecuteValidatedSoftPatch(reason, proposed, func(threshold float64) error {
+		if len(labeled) == 0 {
+			return fmt.Errorf("no scored feedback to replay")
+		}
+		if after < before {
+			return fmt.Errorf("threshold %.2f lowers the replayed F1 score from %.3f to %.3f", threshold, before, after)
+		}
+		return nil
+	})
+	if err != nil {
+		t.rejected++
+		return nil, err
+	}
+
+	change := ThresholdChange{
+		Timestamp:      time.Now(),
+		From:           current,
+		To:             proposed,
+		Reason:         reason,
+		FalsePositives: report.FalsePositives,
+		FalseNegatives: report.FalseNegatives,
+		ReplayF1Before: before,
+		ReplayF1After:  after,
+	}
+	t.changes = append(t.changes, change)
+	log.Printf("BlueTeam: Threshold tuned from %.2f to %.2f: %s", current, proposed, reason)
+	if t.onChange != nil {
+		t.onChange(change)
+	}
+	return &change, nil
+}
+
+// Changes returns the threshold changes applied so far, oldest first.
+func (t *ThresholdTuner) Changes() []ThresholdChange {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]ThresholdChange(nil), t.changes...)
+}
+
+// Stats returns the tuning bounds, the current threshold and the changes.
+func (t *ThresholdTuner) Stats() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return map[string]interface{}{
+		"threshold":          t.healer.Detector.CurrentThreshold(),
+		"min_threshold":      t.config.MinThreshold,
+		"max_threshold":      t.config.MaxThreshold,
+		"rejected_proposals": t.rejected,
+		"changes":            append([]ThresholdChange(nil), t.changes...),
+	}
+}
+
+// replayF1 is the F1 score of detecting the labeled decisions with threshold.
+func replayF1(labeled []anomaly.LabeledDecision, threshold float64) float64 {
+	var tp, fp, fn int
+	for _, decision := range labeled {
+		flagged := decision.ZScore > threshold
+		switch {
+		case flagged && decision.Anomaly:
+			tp++
+		case flagged:
+			fp++
+		case decision.Anomaly:
+			fn++
+		}
+	}
+	if tp == 0 {
+		return 0
+	}
+	return 2 * float64(tp) / float64(2*tp+fp+fn)
+}
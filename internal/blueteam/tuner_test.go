@@ -0,0 +1,113 @@
+package blueteam
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"anomaly"
+)
+
+// newTestTuner returns a tuner over a detector at threshold 3.5, tuning after
+// every 5 feedback entries in steps of 0.25 up to 4.0.
+func newTestTuner(t *testing.T) (*ThresholdTuner, *anomaly.AnomalyDetector, *anomaly.QualityTracker) {
+	t.Helper()
+	detector := anomaly.NewDetector(100, 3.5)
+	quality := anomaly.NewQualityTracker(100)
+	tuner, err := NewThresholdTuner(NewHealer(detector), quality, TunerConfig{
+		Interval:     time.Minute,
+		MinFeedback:  5,
+		Step:         0.25,
+		MinThreshold: 3.0,
+		MaxThreshold: 4.0,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tuner: %v", err)
+	}
+	return tuner, detector, quality
+}
+
+func TestThresholdTuner_RaisesThresholdOnFalsePositives(t *testing.T) {
+	tuner, detector, quality := newTestTuner(t)
+	var handled []ThresholdChange
+	tuner.SetChangeHandler(func(change ThresholdChange) { handled = append(handled, change) })
+
+	// Normal values just over the threshold are flagged, real anomalies score far higher
+	feedback := func(round int, falsePositives []float64) {
+		for i, z := range falsePositives {
+			quality.RecordScored(fmt.Sprintf("fp-%d-%d", round, i), anomaly.FalsePositive, z)
+		}
+		for i, z := range []float64{6, 7, 8} {
+			quality.RecordScored(fmt.Sprintf("tp-%d-%d", round, i), anomaly.TruePositive, z)
+		}
+	}
+
+	feedback(1, []float64{3.55, 3.6, 3.65, 3.7})
+	change, err := tuner.Tune()
+	if err != nil || change == nil {
+		t.Fatalf("Expected a threshold change, got %v, %v", change, err)
+	}
+	if change.From != 3.5 || change.To != 3.75 || detector.CurrentThreshold() != 3.75 {
+		t.Errorf("Expected the threshold raised from 3.5 to 3.75, got %+v at %v", change, detector.CurrentThreshold())
+	}
+	if change.ReplayF1After <= change.ReplayF1Before {
+		t.Errorf("Expected the replayed F1 score to improve, got %+v", change)
+	}
+
+	// The same feedback is not evaluated twice
+	if change, err := tuner.Tune(); change != nil || err != nil {
+		t.Errorf("Expected no change without new feedback, got %v, %v", change, err)
+	}
+
+	feedback(2, []float64{3.8, 3.85, 3.9})
+	if change, err := tuner.Tune(); err != nil || change == nil || change.To != 4.0 {
+		t.Fatalf("Expected the threshold raised to 4.0, got %v, %v", change, err)
+	}
+
+	// The threshold never passes the upper bound
+	feedback(3, []float64{4.1, 4.2, 4.3})
+	if change, err := tuner.Tune(); change != nil || err != nil {
+		t.Errorf("Expected no change at the upper bound, got %v, %v", change, err)
+	}
+	if detector.CurrentThreshold() != 4.0 {
+		t.Errorf("Expected the threshold to stay at 4.0, got %v", detector.CurrentThreshold())
+	}
+
+	if len(tuner.Changes()) != 2 || len(handled) != 2 {
+		t.Errorf("Expected 2 recorded and handled changes, got %d and %d", len(tuner.Changes()), len(handled))
+	}
+}
+
+func TestThresholdTuner_RejectsUnvalidatedProposal(t *testing.T) {
+	tuner, detector, quality := newTestTuner(t)
+
+	// Feedback without z-scores cannot be replayed to validate a change
+	for i := 0; i < 5; i++ {
+		quality.Record(fmt.Sprintf("fp-%d", i), anomaly.FalsePositive)
+	}
+	if change, err := tuner.Tune(); err == nil || change != nil {
+		t.Fatalf("Expected the proposal to be rejected, got %v, %v", change, err)
+	}
+	if detector.CurrentThreshold() != 3.5 {
+		t.Errorf("Expected the threshold unchanged, got %v", detector.CurrentThreshold())
+	}
+	if stats := tuner.Stats(); stats["rejected_proposals"] != int64(1) {
+		t.Errorf("Expected 1 rejected proposal, got %v", stats["rejected_proposals"])
+	}
+}
+
+func TestNewThresholdTuner_InvalidConfig(t *testing.T) {
+	healer := NewHealer(anomaly.NewDetector(100, 3.5))
+	quality := anomaly.NewQualityTracker(100)
+
+	config := DefaultTunerConfig()
+	config.MinThreshold, config.MaxThreshold = 5.0, 4.0
+	if _, err := NewThresholdTuner(healer, quality, config); err == nil {
+		t.Error("Expected inverted threshold bounds to be rejected")
+	}
+	config = DefaultTunerConfig()
+	config.Step = 0
+	if _, err := NewThresholdTuner(healer, quality, config); err == nil {
+		t.Error("Expected a zero step to be rejected")
+	}
+}
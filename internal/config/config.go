@@ -3,8 +3,12 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"net"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,6 +19,16 @@ type Config struct {
 	Monetization MonetizationConfig `json:"monetization"`
 	Validation ValidationConfig `json:"validation"`
 	RateLimit RateLimitConfig `json:"rate_limit"`
+	HTTPClient HTTPClientConfig `json:"http_client"`
+	Signing SigningConfig `json:"signing"`
+	Audit AuditConfig `json:"audit"`
+	BlueTeam BlueTeamConfig `json:"blue_team"`
+	Metadata MetadataConfig `json:"metadata"`
+	Hypervisor HypervisorConfig `json:"hypervisor"`
+	LoadShed LoadShedConfig `json:"load_shed"`
+	Syslog SyslogConfig `json:"syslog"`
+	RedTeam RedTeamConfig `json:"red_team"`
+	Auth AuthConfig `json:"auth"`
 }
 
 // ServerConfig holds server-related configuration.
@@ -24,12 +38,134 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `json:"read_timeout"`
 	WriteTimeout time.Duration `json:"write_timeout"`
 	IdleTimeout  time.Duration `json:"idle_timeout"`
+	ReadHeaderTimeout time.Duration `json:"read_header_timeout"` // Time allowed to read request headers, bounding slow-header clients
+	MaxHeaderBytes    int           `json:"max_header_bytes"`    // Largest accepted request header block
+	TLSEnabled        bool          `json:"tls_enabled"`         // Serve HTTPS; the certificate is reloaded on SIGHUP
+	TLSCertFile       string        `json:"tls_cert_file"`       // PEM certificate chain
+	TLSKeyFile        string        `json:"tls_key_file"`        // PEM private key
+	MaxBatchSize int           `json:"max_batch_size"`
+	AcceptArrays bool          `json:"accept_arrays"` // Accept JSON arrays on the single-point ingest endpoint
+	ShutdownTimeout time.Duration `json:"shutdown_timeout"` // Time allowed for in-flight requests to drain on shutdown
+	DrainMode       string        `json:"drain_mode"`       // Ingest while draining: "indicate" answers marked "draining", "reject" answers 503
+	StrictJSON      bool          `json:"strict_json"`      // Reject ingest payloads with unknown fields
+	DebugEndpoints  bool          `json:"debug_endpoints"`  // Serve /debug/state
+	DebugToken      string        `json:"-"`                // Bearer token required by debug endpoints when set
+	StreamMaxDuration time.Duration `json:"stream_max_duration"` // Longest a /api/v1/data/stream connection is kept open
+	CorrelationIDs    bool          `json:"correlation_ids"`     // Stamp the request ID on each request's decision, PoV record, audit events and heals
+	ResponseBudget    time.Duration `json:"response_budget"`     // Soft per-request budget past which optional work is skipped; 0 disables
+	ReadinessTimeout  time.Duration `json:"readiness_timeout"`   // Longest one /readyz dependency check may run; 0 is unbounded
+	ReadinessCacheTTL time.Duration `json:"readiness_cache_ttl"` // How long /readyz reuses dependency check results; 0 checks on every probe
 }
 
 // DetectorConfig holds anomaly detector configuration.
 type DetectorConfig struct {
 	WindowSize int     `json:"window_size"`
 	Threshold  float64 `json:"threshold"`
+	MaxSeries  int     `json:"max_series"`
+	OrderPolicy   string `json:"order_policy"`   // "flag", "reject" or "reorder"
+	ReorderBuffer int    `json:"reorder_buffer"`
+	WarningMultiplier  float64 `json:"warning_multiplier"`  // Severity bands as multiples of Threshold
+	CriticalMultiplier float64 `json:"critical_multiplier"`
+	FallbackEnabled    bool    `json:"fallback_enabled"` // Static min/max fallback on detector error
+	FallbackMin        float64 `json:"fallback_min"`
+	FallbackMax        float64 `json:"fallback_max"`
+	ShortWindowSize    int     `json:"short_window_size"` // Enables short/long window detection when > 0
+	LongWindowSize     int     `json:"long_window_size"`
+	DivergencePercent  float64 `json:"divergence_percent"`
+	MinStdDev          float64 `json:"min_std_dev"` // Floor on the scoring standard deviation
+	RatioNumerator     string  `json:"ratio_numerator"`   // Series ID of the ratio numerator; empty disables
+	RatioDenominator   string  `json:"ratio_denominator"` // Series ID of the ratio denominator
+	DrainTimeout       time.Duration `json:"drain_timeout"` // Wait for in-flight requests when promoting a standby detector
+	Mode               string             `json:"mode"`                  // Scoring mode; empty means "zscore"
+	ModeParams         map[string]float64 `json:"mode_params,omitempty"` // Mode-specific parameters, read from AD_<PARAM>
+
+	AnomalyHistorySize int    `json:"anomaly_history_size"` // Recent anomalies kept for /anomalies/recent
+	RecentDecisions    int    `json:"recent_decisions"`     // Decisions kept per series for /series/{name}/recent; 0 disables
+	FeedbackWindow     int    `json:"feedback_window"`      // Decisions with client feedback scored by /api/v1/detector/quality; 0 disables
+	AnomalyStateFile   string `json:"anomaly_state_file"`   // Persists recent anomalies and open episodes; empty keeps them in memory
+	DedupKeyPrefix     string `json:"dedup_key_prefix"`     // Prefix of anomaly episode dedup keys, such as a deployment name
+	SeriesModes        string `json:"series_modes"`         // Per-series modes such as "cpu:mad"; parameters come from ModeParams
+	MinSamples         int    `json:"min_samples"`          // Points a series needs before it is scored; earlier points are warm-up
+	OutputDecimals     int    `json:"output_decimals"`      // Decimal places of z-scores and statistics in responses; 0 disables rounding
+
+	ExpectedSeries  string        `json:"expected_series"`  // Series that must keep reporting, optionally with their own freshness such as "cpu:30s"
+	SeriesFreshness time.Duration `json:"series_freshness"` // How long an expected series may stay silent before it is alerted missing
+}
+
+// detectorModes lists the parameters each detection mode accepts.
+var detectorModes = map[string][]string{
+	"zscore":         nil,
+	"percent_change": {"percent_threshold"},
+	"mad":            nil,
+	"seasonal":       {"season_period", "season_buckets"},
+}
+
+// validCurrencyCode reports whether code has the form of an ISO 4217
+// currency code: three uppercase letters.
+func validCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// validAllowedSource reports whether source is a pattern the data point
+// validator understands: "*", a CIDR block, an IPv4 wildcard such as
+// "192.168.*", or a single IP address. Empty allows every source.
+func validAllowedSource(source string) bool {
+	switch {
+	case source == "" || source == "*":
+		return true
+	case strings.Contains(source, "/"):
+		_, _, err := net.ParseCIDR(source)
+		return err == nil
+	case strings.Contains(source, "*"):
+		octets := strings.Split(source, ".")
+		if len(octets) > 4 {
+			return false
+		}
+		for _, octet := range octets {
+			if octet == "*" {
+				continue
+			}
+			if n, err := strconv.Atoi(octet); err != nil || n < 0 || n > 255 {
+				return false
+			}
+		}
+		return true
+	default:
+		return net.ParseIP(source) != nil
+	}
+}
+
+// modeParamNames returns every mode parameter name, sorted.
+func modeParamNames() []string {
+	var names []string
+	for _, params := range detectorModes {
+		names = append(names, params...)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// acceptsModeParam reports whether name is among a mode's params.
+func acceptsModeParam(params []string, name string) bool {
+	for _, param := range params {
+		if param == name {
+			return true
+		}
+	}
+	return false
+}
+
+// modeParamEnv returns the environment variable for a mode parameter.
+func modeParamEnv(name string) string {
+	return "AD_" + strings.ToUpper(name)
 }
 
 // MonetizationConfig holds monetization tracking configuration.
@@ -38,6 +174,17 @@ type MonetizationConfig struct {
 	ComplexityMultiplier float64 `json:"complexity_multiplier"`
 	OutputFile           string  `json:"output_file"`
 	Enabled              bool    `json:"enabled"`
+	MaxRecords           int     `json:"max_records"` // PoV records retained in memory; 0 is unbounded
+	FallbackPrice        float64 `json:"fallback_price"` // Billed when the computed price is not finite; 0 uses BasePrice
+	WarmupPricing        bool    `json:"warmup_pricing"` // Bill decisions made during detector warm-up at WarmupPrice
+	WarmupPrice          float64 `json:"warmup_price"`   // Flat price of a warm-up decision; may be 0
+	Currency             string  `json:"currency"`       // ISO 4217 code of prices
+	RoundingDP           int     `json:"rounding_dp"`    // Decimal places each price is rounded to
+	DedupWindow          int     `json:"dedup_window"`   // Recent decision IDs remembered so a retried decision is billed once; 0 disables
+	Tiers                string        `json:"tiers"`          // Volume pricing plan such as "1000:0.001,0:0.0005"; empty uses the price formula
+	BillingPeriod        time.Duration `json:"billing_period"` // Period over which Tiers count decisions; 0 is a calendar month
+	AnomalyRateRecords   int           `json:"anomaly_rate_records"` // Most recent decisions in the windowed anomaly rate; 0 is all retained
+	AnomalyRateWindow    time.Duration `json:"anomaly_rate_window"`  // Longest a decision counts in the windowed anomaly rate; 0 is unbounded
 }
 
 // ValidationConfig holds input validation configuration.
@@ -48,6 +195,8 @@ type ValidationConfig struct {
 	MinTimestamp  int64   `json:"min_timestamp"`
 	AllowedSource string  `json:"allowed_source"`
 	Enabled       bool    `json:"enabled"`
+	SeriesUnits   string  `json:"series_units"` // Comma-separated series:unit pairs; "*" applies to series without their own
+	TimestampOrder string `json:"timestamp_order"` // "strict" or "lenient" rejects timestamps not after a series' last; empty disables
 }
 
 // RateLimitConfig holds rate limiting configuration.
@@ -55,6 +204,118 @@ type RateLimitConfig struct {
 	RequestsPerSecond int64 `json:"requests_per_second"`
 	BurstSize         int64 `json:"burst_size"`
 	Enabled           bool  `json:"enabled"`
+
+	SeriesPerSecond int64 `json:"series_per_second"` // Points processed per second for each series; 0 disables the per-series cap
+	SeriesBurst     int64 `json:"series_burst"`      // Per-series burst; 0 uses SeriesPerSecond
+}
+
+// HTTPClientConfig holds the shared outbound HTTP client configuration.
+type HTTPClientConfig struct {
+	Timeout             time.Duration `json:"timeout"`
+	DialTimeout         time.Duration `json:"dial_timeout"`
+	MaxIdleConns        int           `json:"max_idle_conns"`
+	MaxIdleConnsPerHost int           `json:"max_idle_conns_per_host"`
+	IdleConnTimeout     time.Duration `json:"idle_conn_timeout"`
+}
+
+// SigningConfig holds response signing configuration.
+type SigningConfig struct {
+	Enabled      bool   `json:"enabled"`
+	KeyID        string `json:"key_id"`
+	Key          string `json:"-"`
+	PreviousKeys string `json:"-"` // Comma-separated id:key pairs accepted for verification
+}
+
+// AuditConfig holds audit log configuration.
+type AuditConfig struct {
+	Format       string `json:"format"`         // "json", "cef" or "rfc5424"
+	MaxFileBytes int64  `json:"max_file_bytes"` // Rotate the audit log past this size; 0 disables rotation
+	MaxBackups   int    `json:"max_backups"`    // Rotated audit logs to keep; 0 keeps all
+	Async         bool          `json:"async"`          // Buffer events and write them in batches off the request path
+	BufferSize    int           `json:"buffer_size"`    // Queued events before logging blocks
+	FlushInterval time.Duration `json:"flush_interval"` // Longest an event waits before being written
+	BatchSize     int           `json:"batch_size"`     // Events written per batch
+	MaskSourceIP  bool          `json:"mask_source_ip"` // Zero the host part of client IPs in audit records
+	Determinism   string        `json:"determinism"`    // Determinism checks audited: "violations", "all" or "off"
+	BaseFields    string        `json:"base_fields"`    // Comma-separated key:value pairs added to every event, e.g. "region:us-east"
+}
+
+// BlueTeamConfig holds self-healing configuration.
+type BlueTeamConfig struct {
+	HistoryFile string `json:"history_file"` // Empty disables healing history persistence
+
+	BreakerFailureThreshold  int           `json:"breaker_failure_threshold"`   // Detection failures within BreakerFailureWindow that open the breaker
+	BreakerFailureWindow     time.Duration `json:"breaker_failure_window"`
+	BreakerOpenTimeout       time.Duration `json:"breaker_open_timeout"`        // Time ingestion is rejected before probing
+	BreakerHalfOpenSuccesses int           `json:"breaker_half_open_successes"` // Consecutive probe successes that close the breaker
+
+	HealCooldown      time.Duration `json:"heal_cooldown"`       // Repeat on-demand heals of an issue/strategy pair are suppressed after a success; 0 disables
+	FailureBackoff    time.Duration `json:"failure_backoff"`     // Initial suppression after a strategy fails, doubled per consecutive failure; 0 disables
+	MaxFailureBackoff time.Duration `json:"max_failure_backoff"` // Cap on the failure backoff; 0 leaves it uncapped
+
+	MinSuccessRate float64 `json:"min_success_rate"` // Decision success rate, in percent, below which the health check applies fallback mode
+	MaxHeapBytes   int64   `json:"max_heap_bytes"`   // Heap size above which the health check runs resource cleanup; 0 disables
+	DryRun         bool    `json:"dry_run"`          // Record heals without acting on them
+
+	ThresholdTuning    bool          `json:"threshold_tuning"`     // Adjust the detector threshold from client feedback
+	TuningInterval     time.Duration `json:"tuning_interval"`      // How often new feedback is evaluated
+	TuningMinFeedback  int           `json:"tuning_min_feedback"`  // New feedback entries needed before a change is proposed
+	TuningStep         float64       `json:"tuning_step"`          // Threshold change per adjustment
+	TuningMinThreshold float64       `json:"tuning_min_threshold"` // Lowest threshold the tuner may set
+	TuningMaxThreshold float64       `json:"tuning_max_threshold"` // Highest threshold the tuner may set
+}
+
+// RedTeamConfig holds fault injection configuration.
+type RedTeamConfig struct {
+	MaxFaultDuration time.Duration `json:"max_fault_duration"` // Longer fault durations are clamped to this
+	CampaignFile     string        `json:"campaign_file"`      // JSON fault campaign file or http(s) URL applied at startup; empty applies none
+}
+
+// AuthConfig holds API key authentication configuration.
+type AuthConfig struct {
+	Keys   string `json:"-"`      // Comma-separated API keys accepted as bearer tokens; empty disables authentication
+	Ingest bool   `json:"ingest"` // Also require a key on the ingest and feedback endpoints, not only the admin ones
+}
+
+// MetadataConfig holds limits for client-supplied decision metadata.
+type MetadataConfig struct {
+	MaxKeys    int    `json:"max_keys"`
+	MaxLength  int    `json:"max_length"`  // Per key and per value, in bytes
+	RedactKeys string `json:"redact_keys"` // Comma-separated keys redacted in audit and PoV records
+}
+
+// HypervisorConfig holds SBOH reporting configuration.
+type HypervisorConfig struct {
+	Percentiles     string  `json:"percentiles"`       // Comma-separated latency percentiles reported under "percentiles"
+	SampleRate      float64 `json:"sample_rate"`       // Fraction of ordinary decisions kept as latency samples
+	SlowThresholdMS float64 `json:"slow_threshold_ms"` // Decisions at or above this latency are always sampled
+	WindowDuration  time.Duration `json:"window_duration"` // Time-based metrics window; zero keeps the count-capped window
+	A4MinDecisions  int           `json:"a4_min_decisions"` // Decisions required before Axiom A-4 is evaluated; zero disables the gate
+
+	ExportDir      string        `json:"export_dir"`      // Directory SBOH reports are archived to; empty disables the export
+	ExportInterval time.Duration `json:"export_interval"` // Time between archived SBOH reports
+	ExportURL      string        `json:"export_url"`      // http(s) URL each archived SBOH report is also POSTed to; empty disables the push
+}
+
+// LoadShedConfig holds adaptive load shedding configuration.
+type LoadShedConfig struct {
+	Enabled        bool    `json:"enabled"`
+	SLOMS          float64 `json:"slo_ms"`          // P95 latency objective
+	StartRatio     float64 `json:"start_ratio"`     // Fraction of the SLO at which shedding begins
+	MaxProbability float64 `json:"max_probability"` // Shed probability once P95 reaches the SLO
+	Curve          string  `json:"curve"`           // "linear", "quadratic" or "step"
+}
+
+// SyslogConfig holds RFC5424 syslog export configuration.
+type SyslogConfig struct {
+	Address  string        `json:"address"` // host:port; empty disables syslog export
+	Network  string        `json:"network"` // "udp", "tcp" or "tls"
+	Facility int           `json:"facility"`
+	Timeout  time.Duration `json:"timeout"`
+
+	BatchSize       int           `json:"batch_size"`        // Events per write; 0 or 1 sends each event on its own
+	BatchInterval   time.Duration `json:"batch_interval"`    // A partial batch is sent at least this often
+	BatchMaxPending int           `json:"batch_max_pending"` // Full batches buffered before new ones are dropped
 }
 
 // Load loads configuration from environment variables and files.
@@ -83,6 +344,73 @@ func Load() (*Config, error) {
 			config.Server.IdleTimeout = d
 		}
 	}
+	if readHeaderTimeout := os.Getenv("SERVER_READ_HEADER_TIMEOUT"); readHeaderTimeout != "" {
+		if d, err := time.ParseDuration(readHeaderTimeout); err == nil {
+			config.Server.ReadHeaderTimeout = d
+		}
+	}
+	if maxHeaderBytes := os.Getenv("SERVER_MAX_HEADER_BYTES"); maxHeaderBytes != "" {
+		if n, err := strconv.Atoi(maxHeaderBytes); err == nil {
+			config.Server.MaxHeaderBytes = n
+		}
+	}
+	if tlsEnabled := os.Getenv("SERVER_TLS_ENABLED"); tlsEnabled != "" {
+		config.Server.TLSEnabled = tlsEnabled == "true"
+	}
+	if certFile := os.Getenv("SERVER_TLS_CERT_FILE"); certFile != "" {
+		config.Server.TLSCertFile = certFile
+	}
+	if keyFile := os.Getenv("SERVER_TLS_KEY_FILE"); keyFile != "" {
+		config.Server.TLSKeyFile = keyFile
+	}
+	if maxBatchSize := os.Getenv("SERVER_MAX_BATCH_SIZE"); maxBatchSize != "" {
+		if mb, err := strconv.Atoi(maxBatchSize); err == nil {
+			config.Server.MaxBatchSize = mb
+		}
+	}
+	if acceptArrays := os.Getenv("SERVER_ACCEPT_ARRAYS"); acceptArrays != "" {
+		config.Server.AcceptArrays = acceptArrays == "true"
+	}
+	if shutdownTimeout := os.Getenv("SERVER_SHUTDOWN_TIMEOUT"); shutdownTimeout != "" {
+		if d, err := time.ParseDuration(shutdownTimeout); err == nil {
+			config.Server.ShutdownTimeout = d
+		}
+	}
+	if drainMode := os.Getenv("SERVER_DRAIN_MODE"); drainMode != "" {
+		config.Server.DrainMode = drainMode
+	}
+	if strictJSON := os.Getenv("SERVER_STRICT_JSON"); strictJSON != "" {
+		config.Server.StrictJSON = strictJSON == "true"
+	}
+	if debugEndpoints := os.Getenv("SERVER_DEBUG_ENDPOINTS"); debugEndpoints != "" {
+		config.Server.DebugEndpoints = debugEndpoints == "true"
+	}
+	if debugToken := os.Getenv("SERVER_DEBUG_TOKEN"); debugToken != "" {
+		config.Server.DebugToken = debugToken
+	}
+	if streamMaxDuration := os.Getenv("SERVER_STREAM_MAX_DURATION"); streamMaxDuration != "" {
+		if d, err := time.ParseDuration(streamMaxDuration); err == nil {
+			config.Server.StreamMaxDuration = d
+		}
+	}
+	if correlationIDs := os.Getenv("SERVER_CORRELATION_IDS"); correlationIDs != "" {
+		config.Server.CorrelationIDs = correlationIDs == "true"
+	}
+	if responseBudget := os.Getenv("SERVER_RESPONSE_BUDGET"); responseBudget != "" {
+		if d, err := time.ParseDuration(responseBudget); err == nil {
+			config.Server.ResponseBudget = d
+		}
+	}
+	if readinessTimeout := os.Getenv("SERVER_READINESS_TIMEOUT"); readinessTimeout != "" {
+		if d, err := time.ParseDuration(readinessTimeout); err == nil {
+			config.Server.ReadinessTimeout = d
+		}
+	}
+	if readinessCacheTTL := os.Getenv("SERVER_READINESS_CACHE_TTL"); readinessCacheTTL != "" {
+		if d, err := time.ParseDuration(readinessCacheTTL); err == nil {
+			config.Server.ReadinessCacheTTL = d
+		}
+	}
 
 	// Detector configuration
 	if windowSize := os.Getenv("AD_WINDOW_SIZE"); windowSize != "" {
@@ -95,6 +423,128 @@ func Load() (*Config, error) {
 			config.Detector.Threshold = t
 		}
 	}
+	if maxSeries := os.Getenv("AD_MAX_SERIES"); maxSeries != "" {
+		if ms, err := strconv.Atoi(maxSeries); err == nil {
+			config.Detector.MaxSeries = ms
+		}
+	}
+	if orderPolicy := os.Getenv("AD_ORDER_POLICY"); orderPolicy != "" {
+		config.Detector.OrderPolicy = orderPolicy
+	}
+	if reorderBuffer := os.Getenv("AD_REORDER_BUFFER"); reorderBuffer != "" {
+		if rb, err := strconv.Atoi(reorderBuffer); err == nil {
+			config.Detector.ReorderBuffer = rb
+		}
+	}
+	if warningMultiplier := os.Getenv("AD_WARNING_MULTIPLIER"); warningMultiplier != "" {
+		if wm, err := strconv.ParseFloat(warningMultiplier, 64); err == nil {
+			config.Detector.WarningMultiplier = wm
+		}
+	}
+	if criticalMultiplier := os.Getenv("AD_CRITICAL_MULTIPLIER"); criticalMultiplier != "" {
+		if cm, err := strconv.ParseFloat(criticalMultiplier, 64); err == nil {
+			config.Detector.CriticalMultiplier = cm
+		}
+	}
+	if fallbackEnabled := os.Getenv("AD_FALLBACK_ENABLED"); fallbackEnabled != "" {
+		config.Detector.FallbackEnabled = fallbackEnabled == "true"
+	}
+	if fallbackMin := os.Getenv("AD_FALLBACK_MIN"); fallbackMin != "" {
+		if fm, err := strconv.ParseFloat(fallbackMin, 64); err == nil {
+			config.Detector.FallbackMin = fm
+		}
+	}
+	if fallbackMax := os.Getenv("AD_FALLBACK_MAX"); fallbackMax != "" {
+		if fm, err := strconv.ParseFloat(fallbackMax, 64); err == nil {
+			config.Detector.FallbackMax = fm
+		}
+	}
+	if shortWindowSize := os.Getenv("AD_SHORT_WINDOW_SIZE"); shortWindowSize != "" {
+		if sw, err := strconv.Atoi(shortWindowSize); err == nil {
+			config.Detector.ShortWindowSize = sw
+		}
+	}
+	if longWindowSize := os.Getenv("AD_LONG_WINDOW_SIZE"); longWindowSize != "" {
+		if lw, err := strconv.Atoi(longWindowSize); err == nil {
+			config.Detector.LongWindowSize = lw
+		}
+	}
+	if divergencePercent := os.Getenv("AD_DIVERGENCE_PERCENT"); divergencePercent != "" {
+		if dp, err := strconv.ParseFloat(divergencePercent, 64); err == nil {
+			config.Detector.DivergencePercent = dp
+		}
+	}
+	if minStdDev := os.Getenv("AD_MIN_STD_DEV"); minStdDev != "" {
+		if ms, err := strconv.ParseFloat(minStdDev, 64); err == nil {
+			config.Detector.MinStdDev = ms
+		}
+	}
+	if ratioNumerator := os.Getenv("AD_RATIO_NUMERATOR"); ratioNumerator != "" {
+		config.Detector.RatioNumerator = ratioNumerator
+	}
+	if ratioDenominator := os.Getenv("AD_RATIO_DENOMINATOR"); ratioDenominator != "" {
+		config.Detector.RatioDenominator = ratioDenominator
+	}
+	if drainTimeout := os.Getenv("AD_DRAIN_TIMEOUT"); drainTimeout != "" {
+		if d, err := time.ParseDuration(drainTimeout); err == nil {
+			config.Detector.DrainTimeout = d
+		}
+	}
+	if mode := os.Getenv("AD_MODE"); mode != "" {
+		config.Detector.Mode = mode
+	}
+	for _, name := range modeParamNames() {
+		if param := os.Getenv(modeParamEnv(name)); param != "" {
+			if p, err := strconv.ParseFloat(param, 64); err == nil {
+				if config.Detector.ModeParams == nil {
+					config.Detector.ModeParams = make(map[string]float64)
+				}
+				config.Detector.ModeParams[name] = p
+			}
+		}
+	}
+	if historySize := os.Getenv("AD_ANOMALY_HISTORY_SIZE"); historySize != "" {
+		if hs, err := strconv.Atoi(historySize); err == nil {
+			config.Detector.AnomalyHistorySize = hs
+		}
+	}
+	if recentDecisions := os.Getenv("AD_RECENT_DECISIONS"); recentDecisions != "" {
+		if rd, err := strconv.Atoi(recentDecisions); err == nil {
+			config.Detector.RecentDecisions = rd
+		}
+	}
+	if feedbackWindow := os.Getenv("AD_FEEDBACK_WINDOW"); feedbackWindow != "" {
+		if fw, err := strconv.Atoi(feedbackWindow); err == nil {
+			config.Detector.FeedbackWindow = fw
+		}
+	}
+	if outputDecimals := os.Getenv("AD_OUTPUT_DECIMALS"); outputDecimals != "" {
+		if od, err := strconv.Atoi(outputDecimals); err == nil {
+			config.Detector.OutputDecimals = od
+		}
+	}
+	if stateFile := os.Getenv("AD_ANOMALY_STATE_FILE"); stateFile != "" {
+		config.Detector.AnomalyStateFile = stateFile
+	}
+	if prefix := os.Getenv("AD_DEDUP_KEY_PREFIX"); prefix != "" {
+		config.Detector.DedupKeyPrefix = prefix
+	}
+	if seriesModes := os.Getenv("AD_SERIES_MODES"); seriesModes != "" {
+		config.Detector.SeriesModes = seriesModes
+	}
+	if minSamples := os.Getenv("AD_MIN_SAMPLES"); minSamples != "" {
+		if ms, err := strconv.Atoi(minSamples); err == nil {
+			config.Detector.MinSamples = ms
+		}
+	}
+	if expectedSeries := os.Getenv("AD_EXPECTED_SERIES"); expectedSeries != "" {
+		config.Detector.ExpectedSeries = expectedSeries
+	}
+	if freshness := os.Getenv("AD_SERIES_FRESHNESS"); freshness != "" {
+		if d, err := time.ParseDuration(freshness); err == nil {
+			config.Detector.SeriesFreshness = d
+		}
+	}
 
 	// Monetization configuration
 	if basePrice := os.Getenv("MONETIZATION_BASE_PRICE"); basePrice != "" {
@@ -113,6 +563,55 @@ func Load() (*Config, error) {
 	if enabled := os.Getenv("MONETIZATION_ENABLED"); enabled != "" {
 		config.Monetization.Enabled = enabled == "true"
 	}
+	if maxRecords := os.Getenv("MONETIZATION_MAX_RECORDS"); maxRecords != "" {
+		if mr, err := strconv.Atoi(maxRecords); err == nil {
+			config.Monetization.MaxRecords = mr
+		}
+	}
+	if fallbackPrice := os.Getenv("MONETIZATION_FALLBACK_PRICE"); fallbackPrice != "" {
+		if fp, err := strconv.ParseFloat(fallbackPrice, 64); err == nil {
+			config.Monetization.FallbackPrice = fp
+		}
+	}
+	if warmupPricing := os.Getenv("MONETIZATION_WARMUP_PRICING"); warmupPricing != "" {
+		config.Monetization.WarmupPricing = warmupPricing == "true"
+	}
+	if warmupPrice := os.Getenv("MONETIZATION_WARMUP_PRICE"); warmupPrice != "" {
+		if wp, err := strconv.ParseFloat(warmupPrice, 64); err == nil {
+			config.Monetization.WarmupPrice = wp
+		}
+	}
+	if currency := os.Getenv("MONETIZATION_CURRENCY"); currency != "" {
+		config.Monetization.Currency = strings.ToUpper(currency)
+	}
+	if roundingDP := os.Getenv("MONETIZATION_ROUNDING_DP"); roundingDP != "" {
+		if dp, err := strconv.Atoi(roundingDP); err == nil {
+			config.Monetization.RoundingDP = dp
+		}
+	}
+	if dedupWindow := os.Getenv("MONETIZATION_DEDUP_WINDOW"); dedupWindow != "" {
+		if dw, err := strconv.Atoi(dedupWindow); err == nil {
+			config.Monetization.DedupWindow = dw
+		}
+	}
+	if tiers := os.Getenv("MONETIZATION_TIERS"); tiers != "" {
+		config.Monetization.Tiers = tiers
+	}
+	if billingPeriod := os.Getenv("MONETIZATION_BILLING_PERIOD"); billingPeriod != "" {
+		if d, err := time.ParseDuration(billingPeriod); err == nil {
+			config.Monetization.BillingPeriod = d
+		}
+	}
+	if rateRecords := os.Getenv("MONETIZATION_ANOMALY_RATE_RECORDS"); rateRecords != "" {
+		if n, err := strconv.Atoi(rateRecords); err == nil {
+			config.Monetization.AnomalyRateRecords = n
+		}
+	}
+	if rateWindow := os.Getenv("MONETIZATION_ANOMALY_RATE_WINDOW"); rateWindow != "" {
+		if d, err := time.ParseDuration(rateWindow); err == nil {
+			config.Monetization.AnomalyRateWindow = d
+		}
+	}
 
 	// Validation configuration
 	if maxValue := os.Getenv("VALIDATION_MAX_VALUE"); maxValue != "" {
@@ -141,6 +640,12 @@ func Load() (*Config, error) {
 	if enabled := os.Getenv("VALIDATION_ENABLED"); enabled != "" {
 		config.Validation.Enabled = enabled == "true"
 	}
+	if seriesUnits := os.Getenv("VALIDATION_SERIES_UNITS"); seriesUnits != "" {
+		config.Validation.SeriesUnits = seriesUnits
+	}
+	if timestampOrder := os.Getenv("VALIDATION_TIMESTAMP_ORDER"); timestampOrder != "" {
+		config.Validation.TimestampOrder = timestampOrder
+	}
 
 	// Rate limit configuration
 	if requestsPerSecond := os.Getenv("RATE_LIMIT_REQUESTS_PER_SECOND"); requestsPerSecond != "" {
@@ -156,6 +661,305 @@ func Load() (*Config, error) {
 	if enabled := os.Getenv("RATE_LIMIT_ENABLED"); enabled != "" {
 		config.RateLimit.Enabled = enabled == "true"
 	}
+	if seriesPerSecond := os.Getenv("RATE_LIMIT_SERIES_PER_SECOND"); seriesPerSecond != "" {
+		if n, err := strconv.ParseInt(seriesPerSecond, 10, 64); err == nil {
+			config.RateLimit.SeriesPerSecond = n
+		}
+	}
+	if seriesBurst := os.Getenv("RATE_LIMIT_SERIES_BURST"); seriesBurst != "" {
+		if n, err := strconv.ParseInt(seriesBurst, 10, 64); err == nil {
+			config.RateLimit.SeriesBurst = n
+		}
+	}
+
+	// Outbound HTTP client configuration
+	if timeout := os.Getenv("HTTP_CLIENT_TIMEOUT"); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err == nil {
+			config.HTTPClient.Timeout = d
+		}
+	}
+	if dialTimeout := os.Getenv("HTTP_CLIENT_DIAL_TIMEOUT"); dialTimeout != "" {
+		if d, err := time.ParseDuration(dialTimeout); err == nil {
+			config.HTTPClient.DialTimeout = d
+		}
+	}
+	if maxIdleConns := os.Getenv("HTTP_CLIENT_MAX_IDLE_CONNS"); maxIdleConns != "" {
+		if n, err := strconv.Atoi(maxIdleConns); err == nil {
+			config.HTTPClient.MaxIdleConns = n
+		}
+	}
+	if maxIdleConnsPerHost := os.Getenv("HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST"); maxIdleConnsPerHost != "" {
+		if n, err := strconv.Atoi(maxIdleConnsPerHost); err == nil {
+			config.HTTPClient.MaxIdleConnsPerHost = n
+		}
+	}
+	if idleConnTimeout := os.Getenv("HTTP_CLIENT_IDLE_CONN_TIMEOUT"); idleConnTimeout != "" {
+		if d, err := time.ParseDuration(idleConnTimeout); err == nil {
+			config.HTTPClient.IdleConnTimeout = d
+		}
+	}
+
+	// Response signing configuration
+	if enabled := os.Getenv("SIGNING_ENABLED"); enabled != "" {
+		config.Signing.Enabled = enabled == "true"
+	}
+	if keyID := os.Getenv("SIGNING_KEY_ID"); keyID != "" {
+		config.Signing.KeyID = keyID
+	}
+	if key := os.Getenv("SIGNING_KEY"); key != "" {
+		config.Signing.Key = key
+	}
+	if previousKeys := os.Getenv("SIGNING_PREVIOUS_KEYS"); previousKeys != "" {
+		config.Signing.PreviousKeys = previousKeys
+	}
+
+	// Audit configuration
+	if format := os.Getenv("AUDIT_FORMAT"); format != "" {
+		config.Audit.Format = format
+	}
+	if maxFileBytes := os.Getenv("AUDIT_MAX_FILE_BYTES"); maxFileBytes != "" {
+		if n, err := strconv.ParseInt(maxFileBytes, 10, 64); err == nil {
+			config.Audit.MaxFileBytes = n
+		}
+	}
+	if maxBackups := os.Getenv("AUDIT_MAX_BACKUPS"); maxBackups != "" {
+		if n, err := strconv.Atoi(maxBackups); err == nil {
+			config.Audit.MaxBackups = n
+		}
+	}
+	if async := os.Getenv("AUDIT_ASYNC"); async != "" {
+		config.Audit.Async = async == "true"
+	}
+	if bufferSize := os.Getenv("AUDIT_BUFFER_SIZE"); bufferSize != "" {
+		if n, err := strconv.Atoi(bufferSize); err == nil {
+			config.Audit.BufferSize = n
+		}
+	}
+	if flushInterval := os.Getenv("AUDIT_FLUSH_INTERVAL"); flushInterval != "" {
+		if d, err := time.ParseDuration(flushInterval); err == nil {
+			config.Audit.FlushInterval = d
+		}
+	}
+	if batchSize := os.Getenv("AUDIT_BATCH_SIZE"); batchSize != "" {
+		if n, err := strconv.Atoi(batchSize); err == nil {
+			config.Audit.BatchSize = n
+		}
+	}
+	if maskSourceIP := os.Getenv("AUDIT_MASK_SOURCE_IP"); maskSourceIP != "" {
+		config.Audit.MaskSourceIP = maskSourceIP == "true"
+	}
+	if determinism := os.Getenv("AUDIT_DETERMINISM"); determinism != "" {
+		config.Audit.Determinism = determinism
+	}
+	if baseFields := os.Getenv("AUDIT_BASE_FIELDS"); baseFields != "" {
+		config.Audit.BaseFields = baseFields
+	}
+
+	// Syslog configuration
+	if address := os.Getenv("SYSLOG_ADDRESS"); address != "" {
+		config.Syslog.Address = address
+	}
+	if network := os.Getenv("SYSLOG_NETWORK"); network != "" {
+		config.Syslog.Network = network
+	}
+	if facility := os.Getenv("SYSLOG_FACILITY"); facility != "" {
+		if f, err := strconv.Atoi(facility); err == nil {
+			config.Syslog.Facility = f
+		}
+	}
+	if timeout := os.Getenv("SYSLOG_TIMEOUT"); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err == nil {
+			config.Syslog.Timeout = d
+		}
+	}
+	if batchSize := os.Getenv("SYSLOG_BATCH_SIZE"); batchSize != "" {
+		if n, err := strconv.Atoi(batchSize); err == nil {
+			config.Syslog.BatchSize = n
+		}
+	}
+	if interval := os.Getenv("SYSLOG_BATCH_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err == nil {
+			config.Syslog.BatchInterval = d
+		}
+	}
+	if pending := os.Getenv("SYSLOG_BATCH_MAX_PENDING"); pending != "" {
+		if n, err := strconv.Atoi(pending); err == nil {
+			config.Syslog.BatchMaxPending = n
+		}
+	}
+
+	// Red Team configuration
+	if maxFaultDuration := os.Getenv("REDTEAM_MAX_FAULT_DURATION"); maxFaultDuration != "" {
+		if d, err := time.ParseDuration(maxFaultDuration); err == nil {
+			config.RedTeam.MaxFaultDuration = d
+		}
+	}
+	if campaignFile := os.Getenv("REDTEAM_CAMPAIGN_FILE"); campaignFile != "" {
+		config.RedTeam.CampaignFile = campaignFile
+	}
+
+	// Authentication configuration
+	if keys := os.Getenv("AUTH_KEYS"); keys != "" {
+		config.Auth.Keys = keys
+	}
+	if ingest := os.Getenv("AUTH_INGEST"); ingest != "" {
+		config.Auth.Ingest = ingest == "true"
+	}
+
+	// Blue Team configuration
+	if historyFile := os.Getenv("BLUETEAM_HISTORY_FILE"); historyFile != "" {
+		config.BlueTeam.HistoryFile = historyFile
+	}
+	if failureThreshold := os.Getenv("BLUETEAM_BREAKER_FAILURE_THRESHOLD"); failureThreshold != "" {
+		if ft, err := strconv.Atoi(failureThreshold); err == nil {
+			config.BlueTeam.BreakerFailureThreshold = ft
+		}
+	}
+	if failureWindow := os.Getenv("BLUETEAM_BREAKER_FAILURE_WINDOW"); failureWindow != "" {
+		if d, err := time.ParseDuration(failureWindow); err == nil {
+			config.BlueTeam.BreakerFailureWindow = d
+		}
+	}
+	if openTimeout := os.Getenv("BLUETEAM_BREAKER_OPEN_TIMEOUT"); openTimeout != "" {
+		if d, err := time.ParseDuration(openTimeout); err == nil {
+			config.BlueTeam.BreakerOpenTimeout = d
+		}
+	}
+	if halfOpenSuccesses := os.Getenv("BLUETEAM_BREAKER_HALF_OPEN_SUCCESSES"); halfOpenSuccesses != "" {
+		if hs, err := strconv.Atoi(halfOpenSuccesses); err == nil {
+			config.BlueTeam.BreakerHalfOpenSuccesses = hs
+		}
+	}
+	if healCooldown := os.Getenv("BLUETEAM_HEAL_COOLDOWN"); healCooldown != "" {
+		if d, err := time.ParseDuration(healCooldown); err == nil {
+			config.BlueTeam.HealCooldown = d
+		}
+	}
+	if failureBackoff := os.Getenv("BLUETEAM_FAILURE_BACKOFF"); failureBackoff != "" {
+		if d, err := time.ParseDuration(failureBackoff); err == nil {
+			config.BlueTeam.FailureBackoff = d
+		}
+	}
+	if maxFailureBackoff := os.Getenv("BLUETEAM_MAX_FAILURE_BACKOFF"); maxFailureBackoff != "" {
+		if d, err := time.ParseDuration(maxFailureBackoff); err == nil {
+			config.BlueTeam.MaxFailureBackoff = d
+		}
+	}
+	if minSuccessRate := os.Getenv("BLUETEAM_MIN_SUCCESS_RATE"); minSuccessRate != "" {
+		if rate, err := strconv.ParseFloat(minSuccessRate, 64); err == nil {
+			config.BlueTeam.MinSuccessRate = rate
+		}
+	}
+	if maxHeapBytes := os.Getenv("BLUETEAM_MAX_HEAP_BYTES"); maxHeapBytes != "" {
+		if n, err := strconv.ParseInt(maxHeapBytes, 10, 64); err == nil {
+			config.BlueTeam.MaxHeapBytes = n
+		}
+	}
+	if dryRun := os.Getenv("BLUETEAM_DRY_RUN"); dryRun != "" {
+		config.BlueTeam.DryRun = dryRun == "true"
+	}
+	if thresholdTuning := os.Getenv("BLUETEAM_THRESHOLD_TUNING"); thresholdTuning != "" {
+		config.BlueTeam.ThresholdTuning = thresholdTuning == "true"
+	}
+	if tuningInterval := os.Getenv("BLUETEAM_TUNING_INTERVAL"); tuningInterval != "" {
+		if d, err := time.ParseDuration(tuningInterval); err == nil {
+			config.BlueTeam.TuningInterval = d
+		}
+	}
+	if minFeedback := os.Getenv("BLUETEAM_TUNING_MIN_FEEDBACK"); minFeedback != "" {
+		if mf, err := strconv.Atoi(minFeedback); err == nil {
+			config.BlueTeam.TuningMinFeedback = mf
+		}
+	}
+	if step := os.Getenv("BLUETEAM_TUNING_STEP"); step != "" {
+		if s, err := strconv.ParseFloat(step, 64); err == nil {
+			config.BlueTeam.TuningStep = s
+		}
+	}
+	if minThreshold := os.Getenv("BLUETEAM_TUNING_MIN_THRESHOLD"); minThreshold != "" {
+		if mt, err := strconv.ParseFloat(minThreshold, 64); err == nil {
+			config.BlueTeam.TuningMinThreshold = mt
+		}
+	}
+	if maxThreshold := os.Getenv("BLUETEAM_TUNING_MAX_THRESHOLD"); maxThreshold != "" {
+		if mt, err := strconv.ParseFloat(maxThreshold, 64); err == nil {
+			config.BlueTeam.TuningMaxThreshold = mt
+		}
+	}
+
+	// Decision metadata configuration
+	if maxKeys := os.Getenv("METADATA_MAX_KEYS"); maxKeys != "" {
+		if mk, err := strconv.Atoi(maxKeys); err == nil {
+			config.Metadata.MaxKeys = mk
+		}
+	}
+	if maxLength := os.Getenv("METADATA_MAX_LENGTH"); maxLength != "" {
+		if ml, err := strconv.Atoi(maxLength); err == nil {
+			config.Metadata.MaxLength = ml
+		}
+	}
+	if redactKeys := os.Getenv("METADATA_REDACT_KEYS"); redactKeys != "" {
+		config.Metadata.RedactKeys = redactKeys
+	}
+
+	// Hypervisor configuration
+	if percentiles := os.Getenv("HYPERVISOR_PERCENTILES"); percentiles != "" {
+		config.Hypervisor.Percentiles = percentiles
+	}
+	if sampleRate := os.Getenv("HYPERVISOR_SAMPLE_RATE"); sampleRate != "" {
+		if sr, err := strconv.ParseFloat(sampleRate, 64); err == nil {
+			config.Hypervisor.SampleRate = sr
+		}
+	}
+	if slowThreshold := os.Getenv("HYPERVISOR_SLOW_THRESHOLD_MS"); slowThreshold != "" {
+		if st, err := strconv.ParseFloat(slowThreshold, 64); err == nil {
+			config.Hypervisor.SlowThresholdMS = st
+		}
+	}
+	if windowDuration := os.Getenv("HYPERVISOR_WINDOW_DURATION"); windowDuration != "" {
+		if d, err := time.ParseDuration(windowDuration); err == nil {
+			config.Hypervisor.WindowDuration = d
+		}
+	}
+	if a4MinDecisions := os.Getenv("HYPERVISOR_A4_MIN_DECISIONS"); a4MinDecisions != "" {
+		if md, err := strconv.Atoi(a4MinDecisions); err == nil {
+			config.Hypervisor.A4MinDecisions = md
+		}
+	}
+	if exportDir := os.Getenv("HYPERVISOR_EXPORT_DIR"); exportDir != "" {
+		config.Hypervisor.ExportDir = exportDir
+	}
+	if exportInterval := os.Getenv("HYPERVISOR_EXPORT_INTERVAL"); exportInterval != "" {
+		if d, err := time.ParseDuration(exportInterval); err == nil {
+			config.Hypervisor.ExportInterval = d
+		}
+	}
+	if exportURL := os.Getenv("HYPERVISOR_EXPORT_URL"); exportURL != "" {
+		config.Hypervisor.ExportURL = exportURL
+	}
+
+	// Load shedding configuration
+	if enabled := os.Getenv("LOAD_SHED_ENABLED"); enabled != "" {
+		config.LoadShed.Enabled = enabled == "true"
+	}
+	if sloMS := os.Getenv("LOAD_SHED_SLO_MS"); sloMS != "" {
+		if slo, err := strconv.ParseFloat(sloMS, 64); err == nil {
+			config.LoadShed.SLOMS = slo
+		}
+	}
+	if startRatio := os.Getenv("LOAD_SHED_START_RATIO"); startRatio != "" {
+		if sr, err := strconv.ParseFloat(startRatio, 64); err == nil {
+			config.LoadShed.StartRatio = sr
+		}
+	}
+	if maxProbability := os.Getenv("LOAD_SHED_MAX_PROBABILITY"); maxProbability != "" {
+		if mp, err := strconv.ParseFloat(maxProbability, 64); err == nil {
+			config.LoadShed.MaxProbability = mp
+		}
+	}
+	if curve := os.Getenv("LOAD_SHED_CURVE"); curve != "" {
+		config.LoadShed.Curve = curve
+	}
 
 	return config, nil
 }
@@ -170,16 +974,55 @@ func DefaultConfig() *Config {
 			ReadTimeout:  10 * time.Second,
 			WriteTimeout: 10 * time.Second,
 			IdleTimeout:  60 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			MaxHeaderBytes:    1 << 20, // 1 MiB
+			TLSEnabled:        false,
+			MaxBatchSize: 1000,
+			AcceptArrays: true,
+			ShutdownTimeout: 30 * time.Second,
+			DrainMode:       "indicate",
+			StrictJSON:      false,
+			DebugEndpoints:  false,
+			StreamMaxDuration: 10 * time.Minute,
+			CorrelationIDs:    true,
+			ResponseBudget:    0,
+			ReadinessTimeout:  2 * time.Second,
+			ReadinessCacheTTL: time.Second,
 		},
 		Detector: DetectorConfig{
 			WindowSize: 500,
 			Threshold:  3.5,
+			MaxSeries:  1000,
+			OrderPolicy:   "flag",
+			ReorderBuffer: 8,
+			WarningMultiplier:  1.0,
+			CriticalMultiplier: 2.0,
+			FallbackEnabled:    false,
+			FallbackMin:        -1e9,
+			FallbackMax:        1e9,
+			ShortWindowSize:    0,
+			LongWindowSize:     1000,
+			DivergencePercent:  5.0,
+			MinStdDev:          0.0,
+			DrainTimeout:       5 * time.Second,
+			AnomalyHistorySize: 100,
+			RecentDecisions:    20,
+			FeedbackWindow:     1000,
+			OutputDecimals:     0,
+			DedupKeyPrefix:     "radm",
+			MinSamples:         2,
+			SeriesFreshness:    5 * time.Minute,
 		},
 		Monetization: MonetizationConfig{
 			BasePrice:            0.001,
 			ComplexityMultiplier: 0.1,
 			OutputFile:           "pov_records.jsonl",
 			Enabled:              true,
+			Currency:             "USD",
+			RoundingDP:           6,
+			DedupWindow:          0,
+			AnomalyRateRecords:   1000,
+			AnomalyRateWindow:    0,
 		},
 		Validation: ValidationConfig{
 			MaxValue:      1e10,
@@ -188,12 +1031,91 @@ func DefaultConfig() *Config {
 			MinTimestamp:  now.Unix() - 86400,
 			AllowedSource: "*",
 			Enabled:       true,
+			SeriesUnits:   "",
 		},
 		RateLimit: RateLimitConfig{
 			RequestsPerSecond: 1000,
 			BurstSize:         100,
 			Enabled:           true,
 		},
+		HTTPClient: HTTPClientConfig{
+			Timeout:             5 * time.Second,
+			DialTimeout:         2 * time.Second,
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		Signing: SigningConfig{
+			Enabled: false,
+			KeyID:   "default",
+		},
+		Audit: AuditConfig{
+			Format:       "json",
+			MaxFileBytes: 100 * 1024 * 1024, // 100 MiB
+			MaxBackups:   10,
+			Async:         false,
+			BufferSize:    4096,
+			FlushInterval: 100 * time.Millisecond,
+			BatchSize:     256,
+			MaskSourceIP:  false,
+			Determinism:   "off",
+			BaseFields:    "",
+		},
+		Syslog: SyslogConfig{
+			Address:  "",
+			Network:  "udp",
+			Facility: 16, // local0
+			Timeout:  5 * time.Second,
+
+			BatchSize:       0,
+			BatchInterval:   time.Second,
+			BatchMaxPending: 10,
+		},
+		RedTeam: RedTeamConfig{
+			MaxFaultDuration: 10 * time.Minute,
+		},
+		Auth: AuthConfig{
+			Keys:   "",
+			Ingest: false,
+		},
+		BlueTeam: BlueTeamConfig{
+			HistoryFile:              "",
+			BreakerFailureThreshold:  5,
+			BreakerFailureWindow:     time.Minute,
+			BreakerOpenTimeout:       30 * time.Second,
+			BreakerHalfOpenSuccesses: 3,
+			HealCooldown:             time.Minute,
+			FailureBackoff:           time.Second,
+			MaxFailureBackoff:        5 * time.Minute,
+			MinSuccessRate:           95.0,
+			MaxHeapBytes:             0,
+			TuningInterval:           5 * time.Minute,
+			TuningMinFeedback:        50,
+			TuningStep:               0.25,
+			TuningMinThreshold:       2.0,
+			TuningMaxThreshold:       6.0,
+		},
+		Metadata: MetadataConfig{
+			MaxKeys:    16,
+			MaxLength:  256,
+			RedactKeys: "password,secret,token,api_key,authorization",
+		},
+		Hypervisor: HypervisorConfig{
+			Percentiles:     "50,90,95,99",
+			SampleRate:      1.0,
+			SlowThresholdMS: 50.0,
+			WindowDuration:  0,
+			A4MinDecisions:  100,
+			ExportDir:       "",
+			ExportInterval:  time.Hour,
+		},
+		LoadShed: LoadShedConfig{
+			Enabled:        false,
+			SLOMS:          50.0,
+			StartRatio:     0.8,
+			MaxProbability: 0.5,
+			Curve:          "linear",
+		},
 	}
 }
 
@@ -220,6 +1142,44 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("server port cannot be empty")
 	}
 
+	if c.Server.ReadTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.IdleTimeout < 0 || c.Server.ReadHeaderTimeout < 0 {
+		return fmt.Errorf("server read, write, idle and read header timeouts must not be negative")
+	}
+
+	if c.Server.MaxHeaderBytes < 0 {
+		return fmt.Errorf("server max header bytes must not be negative")
+	}
+
+	if c.Server.TLSEnabled && (c.Server.TLSCertFile == "" || c.Server.TLSKeyFile == "") {
+		return fmt.Errorf("server TLS cert file and key file are required when TLS is enabled")
+	}
+
+	if c.Server.MaxBatchSize <= 0 {
+		return fmt.Errorf("server max batch size must be positive")
+	}
+
+	if c.Server.ShutdownTimeout <= 0 {
+		return fmt.Errorf("server shutdown timeout must be positive")
+	}
+
+	if c.Server.StreamMaxDuration <= 0 {
+		return fmt.Errorf("server stream max duration must be positive")
+	}
+
+	switch c.Server.DrainMode {
+	case "", "indicate", "reject":
+	default:
+		return fmt.Errorf("server drain mode must be one of indicate, reject")
+	}
+
+	if c.Server.ResponseBudget < 0 {
+		return fmt.Errorf("server response budget must not be negative")
+	}
+
+	if c.Server.ReadinessTimeout < 0 || c.Server.ReadinessCacheTTL < 0 {
+		return fmt.Errorf("server readiness timeout and cache TTL must not be negative")
+	}
+
 	if c.Detector.WindowSize <= 0 {
 		return fmt.Errorf("detector window size must be positive")
 	}
@@ -228,10 +1188,234 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("detector threshold cannot be negative")
 	}
 
+	if c.Detector.MaxSeries < 0 {
+		return fmt.Errorf("detector max series cannot be negative")
+	}
+
+	switch c.Detector.OrderPolicy {
+	case "", "flag", "reject", "reorder":
+	default:
+		return fmt.Errorf("detector order policy must be one of flag, reject, reorder")
+	}
+
+	if c.Detector.ReorderBuffer < 0 || (c.Detector.ReorderBuffer >= c.Detector.WindowSize && c.Detector.OrderPolicy == "reorder") {
+		return fmt.Errorf("detector reorder buffer must be non-negative and smaller than the window size")
+	}
+
+	if c.Detector.WarningMultiplier < 0 || c.Detector.CriticalMultiplier < c.Detector.WarningMultiplier {
+		return fmt.Errorf("detector critical multiplier must be at least the warning multiplier")
+	}
+
+	if c.Detector.FallbackEnabled && c.Detector.FallbackMin >= c.Detector.FallbackMax {
+		return fmt.Errorf("detector fallback min must be less than fallback max")
+	}
+
+	if c.Detector.ShortWindowSize < 0 || (c.Detector.ShortWindowSize > 0 && c.Detector.LongWindowSize <= c.Detector.ShortWindowSize) {
+		return fmt.Errorf("detector long window size must exceed short window size")
+	}
+
+	if c.Detector.MinStdDev < 0 {
+		return fmt.Errorf("detector min std dev cannot be negative")
+	}
+
+	if (c.Detector.RatioNumerator == "") != (c.Detector.RatioDenominator == "") {
+		return fmt.Errorf("detector ratio requires both numerator and denominator series")
+	}
+
+	if c.Detector.RatioNumerator != "" && c.Detector.RatioNumerator == c.Detector.RatioDenominator {
+		return fmt.Errorf("detector ratio numerator and denominator must differ")
+	}
+
+	if c.Detector.DrainTimeout <= 0 {
+		return fmt.Errorf("detector drain timeout must be positive")
+	}
+
+	if c.Detector.AnomalyHistorySize <= 0 {
+		return fmt.Errorf("detector anomaly history size must be positive")
+	}
+
+	if c.Detector.RecentDecisions < 0 {
+		return fmt.Errorf("detector recent decisions cannot be negative")
+	}
+
+	if c.Detector.FeedbackWindow < 0 {
+		return fmt.Errorf("detector feedback window cannot be negative")
+	}
+
+	if c.Detector.OutputDecimals < 0 || c.Detector.OutputDecimals > 15 {
+		return fmt.Errorf("detector output decimals must be between 0 and 15")
+	}
+
+	if c.Detector.MinSamples < 2 || c.Detector.MinSamples > c.Detector.WindowSize {
+		return fmt.Errorf("detector min samples must be between 2 and the window size")
+	}
+
+	if c.Detector.ExpectedSeries != "" && c.Detector.SeriesFreshness <= 0 {
+		return fmt.Errorf("detector series freshness must be positive")
+	}
+
+	mode := c.Detector.Mode
+	if mode == "" {
+		mode = "zscore"
+	}
+	params, exists := detectorModes[mode]
+	if !exists {
+		return fmt.Errorf("detector mode must be one of zscore, percent_change, mad, seasonal")
+	}
+	for name := range c.Detector.ModeParams {
+		if !acceptsModeParam(params, name) {
+			return fmt.Errorf("detector mode %s does not accept parameter %s", mode, name)
+		}
+	}
+	if mode == "percent_change" && c.Detector.ModeParams["percent_threshold"] <= 0 {
+		return fmt.Errorf("detector mode percent_change requires a positive AD_PERCENT_THRESHOLD")
+	}
+	if mode == "seasonal" && c.Detector.ModeParams["season_period"] <= 0 {
+		return fmt.Errorf("detector mode seasonal requires a positive AD_SEASON_PERIOD")
+	}
+
+	if c.Metadata.MaxKeys < 0 || c.Metadata.MaxLength <= 0 {
+		return fmt.Errorf("metadata max keys cannot be negative and max length must be positive")
+	}
+
+	if c.Hypervisor.SampleRate <= 0 || c.Hypervisor.SampleRate > 1 {
+		return fmt.Errorf("hypervisor sample rate must be in (0, 1]")
+	}
+
+	if c.Hypervisor.SlowThresholdMS < 0 {
+		return fmt.Errorf("hypervisor slow threshold cannot be negative")
+	}
+
+	if c.Hypervisor.WindowDuration < 0 {
+		return fmt.Errorf("hypervisor window duration cannot be negative")
+	}
+
+	if c.BlueTeam.BreakerFailureThreshold <= 0 || c.BlueTeam.BreakerFailureWindow <= 0 ||
+		c.BlueTeam.BreakerOpenTimeout <= 0 || c.BlueTeam.BreakerHalfOpenSuccesses <= 0 {
+		return fmt.Errorf("blue team circuit breaker settings must be positive")
+	}
+
+	if c.RedTeam.MaxFaultDuration <= 0 {
+		return fmt.Errorf("red team max fault duration must be positive")
+	}
+
+	if c.Auth.Ingest && strings.TrimSpace(strings.ReplaceAll(c.Auth.Keys, ",", "")) == "" {
+		return fmt.Errorf("auth keys are required when ingest authentication is enabled")
+	}
+
+	if c.BlueTeam.HealCooldown < 0 || c.BlueTeam.FailureBackoff < 0 || c.BlueTeam.MaxFailureBackoff < 0 {
+		return fmt.Errorf("blue team heal cooldown and backoff cannot be negative")
+	}
+
+	if c.BlueTeam.MinSuccessRate < 0 || c.BlueTeam.MinSuccessRate > 100 {
+		return fmt.Errorf("blue team minimum success rate must be between 0 and 100")
+	}
+
+	if c.BlueTeam.MaxHeapBytes < 0 {
+		return fmt.Errorf("blue team maximum heap bytes cannot be negative")
+	}
+
+	if c.BlueTeam.ThresholdTuning {
+		if c.Detector.FeedbackWindow == 0 {
+			return fmt.Errorf("blue team threshold tuning requires a detector feedback window")
+		}
+		if c.BlueTeam.TuningInterval <= 0 || c.BlueTeam.TuningMinFeedback <= 0 || c.BlueTeam.TuningStep <= 0 {
+			return fmt.Errorf("blue team tuning interval, min feedback and step must be positive")
+		}
+		if c.BlueTeam.TuningMinThreshold <= 0 || c.BlueTeam.TuningMinThreshold > c.BlueTeam.TuningMaxThreshold {
+			return fmt.Errorf("blue team tuning thresholds must satisfy 0 < min <= max")
+		}
+	}
+
+	if c.Hypervisor.A4MinDecisions < 0 {
+		return fmt.Errorf("hypervisor A-4 minimum decisions cannot be negative")
+	}
+
+	if c.Hypervisor.ExportDir != "" && c.Hypervisor.ExportInterval <= 0 {
+		return fmt.Errorf("hypervisor export interval must be positive")
+	}
+	if c.Hypervisor.ExportURL != "" {
+		if c.Hypervisor.ExportDir == "" {
+			return fmt.Errorf("hypervisor export URL requires an export directory")
+		}
+		if !strings.HasPrefix(c.Hypervisor.ExportURL, "http://") && !strings.HasPrefix(c.Hypervisor.ExportURL, "https://") {
+			return fmt.Errorf("hypervisor export URL must be an http or https URL")
+		}
+	}
+
+	if c.LoadShed.Enabled {
+		if c.LoadShed.SLOMS <= 0 {
+			return fmt.Errorf("load shed SLO must be positive")
+		}
+		if c.LoadShed.StartRatio <= 0 || c.LoadShed.StartRatio >= 1 {
+			return fmt.Errorf("load shed start ratio must be in (0, 1)")
+		}
+		if c.LoadShed.MaxProbability < 0 || c.LoadShed.MaxProbability > 1 {
+			return fmt.Errorf("load shed max probability must be in [0, 1]")
+		}
+		switch c.LoadShed.Curve {
+		case "", "linear", "quadratic", "step":
+		default:
+			return fmt.Errorf("load shed curve must be one of linear, quadratic, step")
+		}
+	}
+
 	if c.Monetization.BasePrice < 0 {
 		return fmt.Errorf("monetization base price cannot be negative")
 	}
 
+	if c.Monetization.ComplexityMultiplier < 0 {
+		return fmt.Errorf("monetization complexity multiplier cannot be negative")
+	}
+
+	if c.Monetization.Enabled && c.Monetization.OutputFile == "" {
+		return fmt.Errorf("monetization output file cannot be empty when monetization is enabled")
+	}
+
+	if c.Monetization.MaxRecords < 0 {
+		return fmt.Errorf("monetization max records cannot be negative")
+	}
+
+	if c.Monetization.FallbackPrice < 0 || math.IsNaN(c.Monetization.FallbackPrice) || math.IsInf(c.Monetization.FallbackPrice, 0) {
+		return fmt.Errorf("monetization fallback price must be a non-negative finite number")
+	}
+
+	if c.Monetization.WarmupPrice < 0 || math.IsNaN(c.Monetization.WarmupPrice) || math.IsInf(c.Monetization.WarmupPrice, 0) {
+		return fmt.Errorf("monetization warmup price must be a non-negative finite number")
+	}
+
+	if !validCurrencyCode(c.Monetization.Currency) {
+		return fmt.Errorf("monetization currency must be a three-letter ISO 4217 code, got %q", c.Monetization.Currency)
+	}
+
+	if c.Monetization.RoundingDP < 1 || c.Monetization.RoundingDP > 9 {
+		return fmt.Errorf("monetization rounding must be between 1 and 9 decimal places")
+	}
+
+	if c.Monetization.DedupWindow < 0 {
+		return fmt.Errorf("monetization dedup window cannot be negative")
+	}
+
+	if c.Monetization.BillingPeriod < 0 {
+		return fmt.Errorf("monetization billing period cannot be negative")
+	}
+
+	if c.Monetization.AnomalyRateRecords < 0 || c.Monetization.AnomalyRateWindow < 0 {
+		return fmt.Errorf("monetization anomaly rate window cannot be negative")
+	}
+
+	if !(c.Validation.MinValue < c.Validation.MaxValue) {
+		return fmt.Errorf("validation min value %v must be less than max value %v", c.Validation.MinValue, c.Validation.MaxValue)
+	}
+
+	if c.Validation.MinTimestamp >= c.Validation.MaxTimestamp {
+		return fmt.Errorf("validation min timestamp %d must be before max timestamp %d", c.Validation.MinTimestamp, c.Validation.MaxTimestamp)
+	}
+
+	if !validAllowedSource(c.Validation.AllowedSource) {
+		return fmt.Errorf("validation allowed source must be *, a CIDR block, an IPv4 wildcard or an IP address, got %q", c.Validation.AllowedSource)
+	}
+
 	if c.RateLimit.RequestsPerSecond < 0 {
 		return fmt.Errorf("rate limit requests per second cannot be negative")
 	}
@@ -240,5 +1424,76 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("rate limit burst size cannot be negative")
 	}
 
+	if c.RateLimit.Enabled && c.RateLimit.BurstSize < 1 {
+		return fmt.Errorf("rate limit burst size must be at least 1 when rate limiting is enabled")
+	}
+
+	if c.RateLimit.SeriesPerSecond < 0 || c.RateLimit.SeriesBurst < 0 {
+		return fmt.Errorf("per-series rate limit and burst cannot be negative")
+	}
+
+	if c.HTTPClient.Timeout < 0 {
+		return fmt.Errorf("http client timeout cannot be negative")
+	}
+
+	if c.HTTPClient.MaxIdleConns < 0 || c.HTTPClient.MaxIdleConnsPerHost < 0 {
+		return fmt.Errorf("http client idle connection limits cannot be negative")
+	}
+
+	if c.Audit.MaxFileBytes < 0 {
+		return fmt.Errorf("audit max file bytes cannot be negative")
+	}
+
+	if c.Audit.MaxBackups < 0 {
+		return fmt.Errorf("audit max backups cannot be negative")
+	}
+
+	if c.Audit.Async {
+		if c.Audit.BufferSize <= 0 || c.Audit.BatchSize <= 0 {
+			return fmt.Errorf("audit buffer and batch sizes must be positive")
+		}
+		if c.Audit.FlushInterval <= 0 {
+			return fmt.Errorf("audit flush interval must be positive")
+		}
+	}
+
+	switch c.Audit.Determinism {
+	case "violations", "all", "off":
+	default:
+		return fmt.Errorf("audit determinism must be one of violations, all, off")
+	}
+
+	if c.Syslog.Address != "" {
+		switch c.Syslog.Network {
+		case "", "udp", "tcp", "tls":
+		default:
+			return fmt.Errorf("syslog network must be one of udp, tcp, tls")
+		}
+		if c.Syslog.Facility < 0 || c.Syslog.Facility > 23 {
+			return fmt.Errorf("syslog facility must be between 0 and 23")
+		}
+		if c.Syslog.BatchSize < 0 {
+			return fmt.Errorf("syslog batch size must be non-negative")
+		}
+		if c.Syslog.BatchSize > 1 {
+			if c.Syslog.BatchInterval <= 0 {
+				return fmt.Errorf("syslog batch interval must be positive")
+			}
+			if c.Syslog.BatchMaxPending <= 0 {
+				return fmt.Errorf("syslog batch max pending must be positive")
+			}
+		}
+	}
+
+	switch c.Validation.TimestampOrder {
+	case "", "strict", "lenient":
+	default:
+		return fmt.Errorf("validation timestamp order must be one of strict, lenient")
+	}
+
+	if c.Signing.Enabled && c.Signing.Key == "" {
+		return fmt.Errorf("signing key must be set when response signing is enabled")
+	}
+
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate_RejectsInvalidFields(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{
+			name:    "Negative read header timeout",
+			mutate:  func(c *Config) { c.Server.ReadHeaderTimeout = -time.Second },
+			wantErr: "server read, write, idle and read header timeouts",
+		},
+		{
+			name:    "Negative max header bytes",
+			mutate:  func(c *Config) { c.Server.MaxHeaderBytes = -1 },
+			wantErr: "server max header bytes",
+		},
+		{
+			name:    "TLS enabled without a key file",
+			mutate:  func(c *Config) { c.Server.TLSEnabled, c.Server.TLSCertFile = true, "server.crt" },
+			wantErr: "server TLS cert file and key file are required",
+		},
+		{
+			name:    "Hypervisor export URL without a directory",
+			mutate:  func(c *Config) { c.Hypervisor.ExportURL = "https://collector.example/sboh" },
+			wantErr: "hypervisor export URL requires an export directory",
+		},
+		{
+			name:    "Hypervisor export URL not http",
+			mutate:  func(c *Config) { c.Hypervisor.ExportDir, c.Hypervisor.ExportURL = "sboh", "ftp://collector.example/sboh" },
+			wantErr: "hypervisor export URL must be an http or https URL",
+		},
+		{
+			name:    "Unknown drain mode",
+			mutate:  func(c *Config) { c.Server.DrainMode = "pause" },
+			wantErr: "server drain mode",
+		},
+		{
+			name:    "Ingest authentication without keys",
+			mutate:  func(c *Config) { c.Auth.Ingest, c.Auth.Keys = true, " , " },
+			wantErr: "auth keys are required",
+		},
+		{
+			name:    "Min value equal to max value",
+			mutate:  func(c *Config) { c.Validation.MinValue, c.Validation.MaxValue = 5, 5 },
+			wantErr: "validation min value",
+		},
+		{
+			name:    "Min value above max value",
+			mutate:  func(c *Config) { c.Validation.MinValue, c.Validation.MaxValue = 10, -10 },
+			wantErr: "validation min value",
+		},
+		{
+			name:    "NaN min value",
+			mutate:  func(c *Config) { c.Validation.MinValue = math.NaN() },
+			wantErr: "validation min value",
+		},
+		{
+			name:    "Min timestamp equal to max timestamp",
+			mutate:  func(c *Config) { c.Validation.MinTimestamp = c.Validation.MaxTimestamp },
+			wantErr: "validation min timestamp",
+		},
+		{
+			name:    "Min timestamp after max timestamp",
+			mutate:  func(c *Config) { c.Validation.MinTimestamp = c.Validation.MaxTimestamp + 1 },
+			wantErr: "validation min timestamp",
+		},
+		{
+			name:    "Negative complexity multiplier",
+			mutate:  func(c *Config) { c.Monetization.ComplexityMultiplier = -0.1 },
+			wantErr: "monetization complexity multiplier",
+		},
+		{
+			name:    "Empty output file with monetization enabled",
+			mutate:  func(c *Config) { c.Monetization.Enabled, c.Monetization.OutputFile = true, "" },
+			wantErr: "monetization output file",
+		},
+		{
+			name:    "Zero burst size with rate limiting enabled",
+			mutate:  func(c *Config) { c.RateLimit.Enabled, c.RateLimit.BurstSize = true, 0 },
+			wantErr: "rate limit burst size must be at least 1",
+		},
+		{
+			name:    "Malformed CIDR allowed source",
+			mutate:  func(c *Config) { c.Validation.AllowedSource = "10.0.0.0/33" },
+			wantErr: "validation allowed source",
+		},
+		{
+			name:    "Malformed wildcard allowed source",
+			mutate:  func(c *Config) { c.Validation.AllowedSource = "192.168.x.*" },
+			wantErr: "validation allowed source",
+		},
+		{
+			name:    "Out of range wildcard octet",
+			mutate:  func(c *Config) { c.Validation.AllowedSource = "300.*" },
+			wantErr: "validation allowed source",
+		},
+		{
+			name:    "Malformed exact allowed source",
+			mutate:  func(c *Config) { c.Validation.AllowedSource = "localhost" },
+			wantErr: "validation allowed source",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tc.mutate(config)
+
+			err := config.Validate()
+			if err == nil {
+				t.Fatal("Expected a validation error, got none")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected an error mentioning %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidate_AcceptsAllowedSourcePatterns(t *testing.T) {
+	for _, source := range []string{"", "*", "192.168.1.0/24", "2001:db8::/32", "192.168.*", "10.*.*.1", "192.168.1.100", "::1"} {
+		config := DefaultConfig()
+		config.Validation.AllowedSource = source
+		if err := config.Validate(); err != nil {
+			t.Errorf("Expected allowed source %q to be valid, got %v", source, err)
+		}
+	}
+
+	// Without monetization or rate limiting their dependent fields are not required
+	config := DefaultConfig()
+	config.Monetization.Enabled, config.Monetization.OutputFile = false, ""
+	config.RateLimit.Enabled, config.RateLimit.BurstSize = false, 0
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected a disabled monetization and rate limit to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redacted replaces secret values in DumpEnvRedacted output.
+const redacted = "REDACTED"
+
+// DumpEnv renders the configuration as KEY=value lines using the variable
+// names Load reads, so applying them through Load reproduces this Config.
+// Empty string values are emitted but ignored by Load, which keeps the default.
+func (c *Config) DumpEnv() string {
+	return c.dumpEnv(false)
+}
+
+// DumpEnvRedacted is DumpEnv with secrets replaced, for exposing over the API.
+func (c *Config) DumpEnvRedacted() string {
+	return c.dumpEnv(true)
+}
+
+func (c *Config) dumpEnv(redact bool) string {
+	var b strings.Builder
+	set := func(key, value string) {
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(value)
+		b.WriteByte('\n')
+	}
+	secret := func(key, value string) {
+		if redact && value != "" {
+			value = redacted
+		}
+		set(key, value)
+	}
+
+	// Server configuration
+	set("SERVER_PORT", c.Server.Port)
+	set("SERVER_HOST", c.Server.Host)
+	set("SERVER_READ_TIMEOUT", formatDuration(c.Server.ReadTimeout))
+	set("SERVER_WRITE_TIMEOUT", formatDuration(c.Server.WriteTimeout))
+	set("SERVER_IDLE_TIMEOUT", formatDuration(c.Server.IdleTimeout))
+	set("SERVER_READ_HEADER_TIMEOUT", formatDuration(c.Server.ReadHeaderTimeout))
+	set("SERVER_MAX_HEADER_BYTES", strconv.Itoa(c.Server.MaxHeaderBytes))
+	set("SERVER_TLS_ENABLED", strconv.FormatBool(c.Server.TLSEnabled))
+	set("SERVER_TLS_CERT_FILE", c.Server.TLSCertFile)
+	set("SERVER_TLS_KEY_FILE", c.Server.TLSKeyFile)
+	set("SERVER_MAX_BATCH_SIZE", strconv.Itoa(c.Server.MaxBatchSize))
+	set("SERVER_ACCEPT_ARRAYS", strconv.FormatBool(c.Server.AcceptArrays))
+	set("SERVER_SHUTDOWN_TIMEOUT", formatDuration(c.Server.ShutdownTimeout))
+	set("SERVER_DRAIN_MODE", c.Server.DrainMode)
+	set("SERVER_STRICT_JSON", strconv.FormatBool(c.Server.StrictJSON))
+	set("SERVER_DEBUG_ENDPOINTS", strconv.FormatBool(c.Server.DebugEndpoints))
+	secret("SERVER_DEBUG_TOKEN", c.Server.DebugToken)
+	set("SERVER_STREAM_MAX_DURATION", formatDuration(c.Server.StreamMaxDuration))
+	set("SERVER_CORRELATION_IDS", strconv.FormatBool(c.Server.CorrelationIDs))
+	set("SERVER_RESPONSE_BUDGET", formatDuration(c.Server.ResponseBudget))
+	set("SERVER_READINESS_TIMEOUT", formatDuration(c.Server.ReadinessTimeout))
+	set("SERVER_READINESS_CACHE_TTL", formatDuration(c.Server.ReadinessCacheTTL))
+
+	// Detector configuration
+	set("AD_WINDOW_SIZE", strconv.Itoa(c.Detector.WindowSize))
+	set("AD_THRESHOLD", formatFloat(c.Detector.Threshold))
+	set("AD_MAX_SERIES", strconv.Itoa(c.Detector.MaxSeries))
+	set("AD_ORDER_POLICY", c.Detector.OrderPolicy)
+	set("AD_REORDER_BUFFER", strconv.Itoa(c.Detector.ReorderBuffer))
+	set("AD_WARNING_MULTIPLIER", formatFloat(c.Detector.WarningMultiplier))
+	set("AD_CRITICAL_MULTIPLIER", formatFloat(c.Detector.CriticalMultiplier))
+	set("AD_FALLBACK_ENABLED", strconv.FormatBool(c.Detector.FallbackEnabled))
+	set("AD_FALLBACK_MIN", formatFloat(c.Detector.FallbackMin))
+	set("AD_FALLBACK_MAX", formatFloat(c.Detector.FallbackMax))
+	set("AD_SHORT_WINDOW_SIZE", strconv.Itoa(c.Detector.ShortWindowSize))
+	set("AD_LONG_WINDOW_SIZE", strconv.Itoa(c.Detector.LongWindowSize))
+	set("AD_DIVERGENCE_PERCENT", formatFloat(c.Detector.DivergencePercent))
+	set("AD_MIN_STD_DEV", formatFloat(c.Detector.MinStdDev))
+	set("AD_RATIO_NUMERATOR", c.Detector.RatioNumerator)
+	set("AD_RATIO_DENOMINATOR", c.Detector.RatioDenominator)
+	set("AD_DRAIN_TIMEOUT", formatDuration(c.Detector.DrainTimeout))
+	set("AD_MODE", c.Detector.Mode)
+	params := make([]string, 0, len(c.Detector.ModeParams))
+	for name := range c.Detector.ModeParams {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		set(modeParamEnv(name), formatFloat(c.Detector.ModeParams[name]))
+	}
+	set("AD_ANOMALY_HISTORY_SIZE", strconv.Itoa(c.Detector.AnomalyHistorySize))
+	set("AD_RECENT_DECISIONS", strconv.Itoa(c.Detector.RecentDecisions))
+	set("AD_FEEDBACK_WINDOW", strconv.Itoa(c.Detector.FeedbackWindow))
+	set("AD_OUTPUT_DECIMALS", strconv.Itoa(c.Detector.OutputDecimals))
+	set("AD_ANOMALY_STATE_FILE", c.Detector.AnomalyStateFile)
+	set("AD_DEDUP_KEY_PREFIX", c.Detector.DedupKeyPrefix)
+	set("AD_SERIES_MODES", c.Detector.SeriesModes)
+	set("AD_MIN_SAMPLES", strconv.Itoa(c.Detector.MinSamples))
+	set("AD_EXPECTED_SERIES", c.Detector.ExpectedSeries)
+	set("AD_SERIES_FRESHNESS", formatDuration(c.Detector.SeriesFreshness))
+
+	// Monetization configuration
+	set("MONETIZATION_BASE_PRICE", formatFloat(c.Monetization.BasePrice))
+	set("MONETIZATION_COMPLEXITY_MULTIPLIER", formatFloat(c.Monetization.ComplexityMultiplier))
+	set("MONETIZATION_OUTPUT_FILE", c.Monetization.OutputFile)
+	set("MONETIZATION_ENABLED", strconv.FormatBool(c.Monetization.Enabled))
+	set("MONETIZATION_MAX_RECORDS", strconv.Itoa(c.Monetization.MaxRecords))
+	set("MONETIZATION_FALLBACK_PRICE", formatFloat(c.Monetization.FallbackPrice))
+	set("MONETIZATION_WARMUP_PRICING", strconv.FormatBool(c.Monetization.WarmupPricing))
+	set("MONETIZATION_WARMUP_PRICE", formatFloat(c.Monetization.WarmupPrice))
+	set("MONETIZATION_CURRENCY", c.Monetization.Currency)
+	set("MONETIZATION_ROUNDING_DP", strconv.Itoa(c.Monetization.RoundingDP))
+	set("MONETIZATION_DEDUP_WINDOW", strconv.Itoa(c.Monetization.DedupWindow))
+	set("MONETIZATION_TIERS", c.Monetization.Tiers)
+	set("MONETIZATION_BILLING_PERIOD", formatDuration(c.Monetization.BillingPeriod))
+	set("MONETIZATION_ANOMALY_RATE_RECORDS", strconv.Itoa(c.Monetization.AnomalyRateRecords))
+	set("MONETIZATION_ANOMALY_RATE_WINDOW", formatDuration(c.Monetization.AnomalyRateWindow))
+
+	// Validation configuration
+	set("VALIDATION_MAX_VALUE", formatFloat(c.Validation.MaxValue))
+	set("VALIDATION_MIN_VALUE", formatFloat(c.Validation.MinValue))
+	set("VALIDATION_MAX_TIMESTAMP", strconv.FormatInt(c.Validation.MaxTimestamp, 10))
+	set("VALIDATION_MIN_TIMESTAMP", strconv.FormatInt(c.Validation.MinTimestamp, 10))
+	set("VALIDATION_ALLOWED_SOURCE", c.Validation.AllowedSource)
+	set("VALIDATION_ENABLED", strconv.FormatBool(c.Validation.Enabled))
+	set("VALIDATION_SERIES_UNITS", c.Validation.SeriesUnits)
+	set("VALIDATION_TIMESTAMP_ORDER", c.Validation.TimestampOrder)
+
+	// Rate limit configuration
+	set("RATE_LIMIT_REQUESTS_PER_SECOND", strconv.FormatInt(c.RateLimit.RequestsPerSecond, 10))
+	set("RATE_LIMIT_BURST_SIZE", strconv.FormatInt(c.RateLimit.BurstSize, 10))
+	set("RATE_LIMIT_ENABLED", strconv.FormatBool(c.RateLimit.Enabled))
+	set("RATE_LIMIT_SERIES_PER_SECOND", strconv.FormatInt(c.RateLimit.SeriesPerSecond, 10))
+	set("RATE_LIMIT_SERIES_BURST", strconv.FormatInt(c.RateLimit.SeriesBurst, 10))
+
+	// Outbound HTTP client configuration
+	set("HTTP_CLIENT_TIMEOUT", formatDuration(c.HTTPClient.Timeout))
+	set("HTTP_CLIENT_DIAL_TIMEOUT", formatDuration(c.HTTPClient.DialTimeout))
+	set("HTTP_CLIENT_MAX_IDLE_CONNS", strconv.Itoa(c.HTTPClient.MaxIdleConns))
+	set("HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST", strconv.Itoa(c.HTTPClient.MaxIdleConnsPerHost))
+	set("HTTP_CLIENT_IDLE_CONN_TIMEOUT", formatDuration(c.HTTPClient.IdleConnTimeout))
+
+	// Response signing configuration
+	set("SIGNING_ENABLED", strconv.FormatBool(c.Signing.Enabled))
+	set("SIGNING_KEY_ID", c.Signing.KeyID)
+	secret("SIGNING_KEY", c.Signing.Key)
+	secret("SIGNING_PREVIOUS_KEYS", c.Signing.PreviousKeys)
+
+	// Audit configuration
+	set("AUDIT_FORMAT", c.Audit.Format)
+	set("AUDIT_MAX_FILE_BYTES", strconv.FormatInt(c.Audit.MaxFileBytes, 10))
+	set("AUDIT_MAX_BACKUPS", strconv.Itoa(c.Audit.MaxBackups))
+	set("AUDIT_ASYNC", strconv.FormatBool(c.Audit.Async))
+	set("AUDIT_BUFFER_SIZE", strconv.Itoa(c.Audit.BufferSize))
+	set("AUDIT_FLUSH_INTERVAL", formatDuration(c.Audit.FlushInterval))
+	set("AUDIT_BATCH_SIZE", strconv.Itoa(c.Audit.BatchSize))
+	set("AUDIT_MASK_SOURCE_IP", strconv.FormatBool(c.Audit.MaskSourceIP))
+	set("AUDIT_DETERMINISM", c.Audit.Determinism)
+	set("AUDIT_BASE_FIELDS", c.Audit.BaseFields)
+
+	// Syslog configuration
+	set("SYSLOG_ADDRESS", c.Syslog.Address)
+	set("SYSLOG_NETWORK", c.Syslog.Network)
+	set("SYSLOG_FACILITY", strconv.Itoa(c.Syslog.Facility))
+	set("SYSLOG_TIMEOUT", formatDuration(c.Syslog.Timeout))
+	set("SYSLOG_BATCH_SIZE", strconv.Itoa(c.Syslog.BatchSize))
+	set("SYSLOG_BATCH_INTERVAL", formatDuration(c.Syslog.BatchInterval))
+	set("SYSLOG_BATCH_MAX_PENDING", strconv.Itoa(c.Syslog.BatchMaxPending))
+
+	// Red Team configuration
+	set("REDTEAM_MAX_FAULT_DURATION", formatDuration(c.RedTeam.MaxFaultDuration))
+	set("REDTEAM_CAMPAIGN_FILE", c.RedTeam.CampaignFile)
+
+	// Authentication configuration
+	secret("AUTH_KEYS", c.Auth.Keys)
+	set("AUTH_INGEST", strconv.FormatBool(c.Auth.Ingest))
+
+	// Blue Team configuration
+	set("BLUETEAM_HISTORY_FILE", c.BlueTeam.HistoryFile)
+	set("BLUETEAM_BREAKER_FAILURE_THRESHOLD", strconv.Itoa(c.BlueTeam.BreakerFailureThreshold))
+	set("BLUETEAM_BREAKER_FAILURE_WINDOW", formatDuration(c.BlueTeam.BreakerFailureWindow))
+	set("BLUETEAM_BREAKER_OPEN_TIMEOUT", formatDuration(c.BlueTeam.BreakerOpenTimeout))
+	set("BLUETEAM_BREAKER_HALF_OPEN_SUCCESSES", strconv.Itoa(c.BlueTeam.BreakerHalfOpenSuccesses))
+	set("BLUETEAM_HEAL_COOLDOWN", formatDuration(c.BlueTeam.HealCooldown))
+	set("BLUETEAM_FAILURE_BACKOFF", formatDuration(c.BlueTeam.FailureBackoff))
+	set("BLUETEAM_MAX_FAILURE_BACKOFF", formatDuration(c.BlueTeam.MaxFailureBackoff))
+	set("BLUETEAM_MIN_SUCCESS_RATE", formatFloat(c.BlueTeam.MinSuccessRate))
+	set("BLUETEAM_MAX_HEAP_BYTES", strconv.FormatInt(c.BlueTeam.MaxHeapBytes, 10))
+	set("BLUETEAM_DRY_RUN", strconv.FormatBool(c.BlueTeam.DryRun))
+	set("BLUETEAM_THRESHOLD_TUNING", strconv.FormatBool(c.BlueTeam.ThresholdTuning))
+	set("BLUETEAM_TUNING_INTERVAL", formatDuration(c.BlueTeam.TuningInterval))
+	set("BLUETEAM_TUNING_MIN_FEEDBACK", strconv.Itoa(c.BlueTeam.TuningMinFeedback))
+	set("BLUETEAM_TUNING_STEP", formatFloat(c.BlueTeam.TuningStep))
+	set("BLUETEAM_TUNING_MIN_THRESHOLD", formatFloat(c.BlueTeam.TuningMinThreshold))
+	set("BLUETEAM_TUNING_MAX_THRESHOLD", formatFloat(c.BlueTeam.TuningMaxThreshold))
+
+	// Decision metadata configuration
+	set("METADATA_MAX_KEYS", strconv.Itoa(c.Metadata.MaxKeys))
+	set("METADATA_MAX_LENGTH", strconv.Itoa(c.Metadata.MaxLength))
+	set("METADATA_REDACT_KEYS", c.Metadata.RedactKeys)
+
+	// Hypervisor configuration
+	set("HYPERVISOR_PERCENTILES", c.Hypervisor.Percentiles)
+	set("HYPERVISOR_SAMPLE_RATE", formatFloat(c.Hypervisor.SampleRate))
+	set("HYPERVISOR_SLOW_THRESHOLD_MS", formatFloat(c.Hypervisor.SlowThresholdMS))
+	set("HYPERVISOR_WINDOW_DURATION", formatDuration(c.Hypervisor.WindowDuration))
+	set("HYPERVISOR_A4_MIN_DECISIONS", strconv.Itoa(c.Hypervisor.A4MinDecisions))
+	set("HYPERVISOR_EXPORT_DIR", c.Hypervisor.ExportDir)
+	set("HYPERVISOR_EXPORT_INTERVAL", formatDuration(c.Hypervisor.ExportInterval))
+	set("HYPERVISOR_EXPORT_URL", c.Hypervisor.ExportURL)
+
+	// Load shedding configuration
+	set("LOAD_SHED_ENABLED", strconv.FormatBool(c.LoadShed.Enabled))
+	set("LOAD_SHED_SLO_MS", formatFloat(c.LoadShed.SLOMS))
+	set("LOAD_SHED_START_RATIO", formatFloat(c.LoadShed.StartRatio))
+	set("LOAD_SHED_MAX_PROBABILITY", formatFloat(c.LoadShed.MaxProbability))
+	set("LOAD_SHED_CURVE", c.LoadShed.Curve)
+
+	return b.String()
+}
+
+// formatFloat renders f with the shortest representation that parses back exactly.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// formatDuration renders d in time.ParseDuration syntax.
+func formatDuration(d time.Duration) string {
+	return d.String()
+}
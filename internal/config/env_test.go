@@ -0,0 +1,109 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// applyEnv sets each KEY=value line of dump for the duration of the test.
+func applyEnv(t *testing.T, dump string) {
+	t.Helper()
+
+	for _, line := range strings.Split(strings.TrimSpace(dump), "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			t.Fatalf("Malformed env line %q", line)
+		}
+		t.Setenv(key, value)
+	}
+}
+
+func TestDumpEnv_RoundTripsThroughLoad(t *testing.T) {
+	original := DefaultConfig()
+	original.Server.Port = "9090"
+	original.Server.ReadTimeout = 1500 * time.Millisecond
+	original.Server.AcceptArrays = false
+	original.Detector.Threshold = 2.75
+	original.Detector.OrderPolicy = "reorder"
+	original.Detector.FallbackEnabled = true
+	original.Detector.FallbackMin = -0.1
+	original.Detector.RatioNumerator = "errors"
+	original.Detector.RatioDenominator = "requests"
+	original.Detector.Mode = "percent_change"
+	original.Detector.ModeParams = map[string]float64{"percent_threshold": 30}
+	original.Monetization.Enabled = false
+	original.Validation.MaxTimestamp = 1900000000
+	original.RateLimit.RequestsPerSecond = 250
+	original.Signing.Enabled = true
+	original.Signing.KeyID = "k2"
+	original.Signing.Key = "secret-2"
+	original.Signing.PreviousKeys = "k1:secret-1"
+	original.Audit.Format = "cef"
+	original.Metadata.MaxKeys = 4
+	original.Metadata.RedactKeys = "token"
+
+	applyEnv(t, original.DumpEnv())
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Unexpected error loading dumped env: %v", err)
+	}
+	if !reflect.DeepEqual(original, loaded) {
+		t.Errorf("Round-tripped config differs:\n got: %+v\nwant: %+v", loaded, original)
+	}
+}
+
+func TestDumpEnvRedacted_HidesSecrets(t *testing.T) {
+	c := DefaultConfig()
+	c.Signing.KeyID = "k1"
+	c.Signing.Key = "secret-1"
+	c.Signing.PreviousKeys = "k0:secret-0"
+
+	dump := c.DumpEnvRedacted()
+	if strings.Contains(dump, "secret-") {
+		t.Errorf("Expected secrets to be redacted, got:\n%s", dump)
+	}
+	if !strings.Contains(dump, "SIGNING_KEY=REDACTED\n") || !strings.Contains(dump, "SIGNING_KEY_ID=k1\n") {
+		t.Errorf("Expected redacted key with visible key id, got:\n%s", dump)
+	}
+
+	// Unset secrets stay empty so the dump does not imply a key exists
+	if !strings.Contains(DefaultConfig().DumpEnvRedacted(), "SIGNING_KEY=\n") {
+		t.Error("Expected empty signing key to remain empty")
+	}
+}
+
+func TestLoad_DetectorModeFromEnv(t *testing.T) {
+	t.Setenv("AD_MODE", "percent_change")
+	t.Setenv("AD_PERCENT_THRESHOLD", "12.5")
+
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c.Detector.Mode != "percent_change" || c.Detector.ModeParams["percent_threshold"] != 12.5 {
+		t.Errorf("Unexpected detector mode: %q %v", c.Detector.Mode, c.Detector.ModeParams)
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("Expected valid config, got %v", err)
+	}
+
+	c.Detector.ModeParams = nil
+	if err := c.Validate(); err == nil {
+		t.Error("Expected percent_change without a threshold to fail validation")
+	}
+
+	c.Detector.Mode = "zscore"
+	c.Detector.ModeParams = map[string]float64{"percent_threshold": 10}
+	if err := c.Validate(); err == nil {
+		t.Error("Expected zscore with percent_threshold to fail validation")
+	}
+
+	c.Detector.Mode = "ewma"
+	c.Detector.ModeParams = nil
+	if err := c.Validate(); err == nil {
+		t.Error("Expected unknown mode to fail validation")
+	}
+}
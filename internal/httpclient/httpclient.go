@@ -0,0 +1,116 @@
+package httpclient
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Config holds the connection pooling and timeout settings for outbound HTTP.
+type Config struct {
+	Timeout             time.Duration `json:"timeout"`
+	DialTimeout         time.Duration `json:"dial_timeout"`
+	MaxIdleConns        int           `json:"max_idle_conns"`
+	MaxIdleConnsPerHost int           `json:"max_idle_conns_per_host"`
+	IdleConnTimeout     time.Duration `json:"idle_conn_timeout"`
+}
+
+var (
+	mu     sync.RWMutex
+	shared *http.Client
+)
+
+// New creates an HTTP client with its own pooled transport.
+func New(config Config) *http.Client {
+	config = normalize(config)
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   config.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		TLSHandshakeTimeout:   config.DialTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   config.Timeout,
+	}
+}
+
+// normalize replaces the unset settings of config with their defaults.
+func normalize(config Config) Config {
+	defaults := DefaultConfig()
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = defaults.DialTimeout
+	}
+	if config.MaxIdleConns <= 0 {
+		config.MaxIdleConns = defaults.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost <= 0 {
+		config.MaxIdleConnsPerHost = defaults.MaxIdleConnsPerHost
+	}
+	if config.IdleConnTimeout <= 0 {
+		config.IdleConnTimeout = defaults.IdleConnTimeout
+	}
+	return config
+}
+
+// Configure replaces the shared client used by all outbound integrations.
+// Idle connections held by the previous client are released.
+func Configure(config Config) *http.Client {
+	config = normalize(config)
+	client := New(config)
+
+	mu.Lock()
+	previous := shared
+	shared = client
+	mu.Unlock()
+
+	if previous != nil {
+		previous.CloseIdleConnections()
+	}
+
+	log.Printf("HTTPClient: Configured shared client with timeout %v, max idle conns %d (%d per host)",
+		client.Timeout, config.MaxIdleConns, config.MaxIdleConnsPerHost)
+	return client
+}
+
+// Shared returns the process-wide outbound HTTP client, creating it with
+// default settings if Configure has not been called.
+func Shared() *http.Client {
+	mu.RLock()
+	client := shared
+	mu.RUnlock()
+	if client != nil {
+		return client
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if shared == nil {
+		shared = New(DefaultConfig())
+	}
+	return shared
+}
+
+// DefaultConfig returns default outbound HTTP client configuration.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:             5 * time.Second,
+		DialTimeout:         2 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
@@ -0,0 +1,68 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestShared_ReusesClient(t *testing.T) {
+	client := Configure(Config{Timeout: time.Second})
+
+	if Shared() != client {
+		t.Error("Expected Shared to return the configured client")
+	}
+
+	if Shared() != Shared() {
+		t.Error("Expected repeated Shared calls to return the same client")
+	}
+
+	if Shared().Transport != client.Transport {
+		t.Error("Expected shared client to reuse a single pooled transport")
+	}
+}
+
+func TestShared_RespectsConfiguredTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	Configure(Config{Timeout: 50 * time.Millisecond})
+	defer Configure(DefaultConfig())
+
+	start := time.Now()
+	resp, err := Shared().Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("Expected request to time out, but it succeeded")
+	}
+
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Expected request to abort near the 50ms timeout, took %v", elapsed)
+	}
+}
+
+func TestNew_AppliesDefaults(t *testing.T) {
+	client := New(Config{})
+	defaults := DefaultConfig()
+
+	if client.Timeout != defaults.Timeout {
+		t.Errorf("Expected default timeout %v, got %v", defaults.Timeout, client.Timeout)
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", client.Transport)
+	}
+
+	if transport.MaxIdleConns != defaults.MaxIdleConns {
+		t.Errorf("Expected max idle conns %d, got %d", defaults.MaxIdleConns, transport.MaxIdleConns)
+	}
+
+	if transport.MaxIdleConnsPerHost != defaults.MaxIdleConnsPerHost {
+		t.Errorf("Expected max idle conns per host %d, got %d", defaults.MaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	}
+}
@@ -0,0 +1,146 @@
+package hypervisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"internal/httpclient"
+)
+
+// sbohFileTimeFormat names exported report files; it sorts chronologically.
+const sbohFileTimeFormat = "20060102T150405.000000000Z"
+
+// SBOHExporter archives the SBOH report to a timestamped JSON file in a
+// directory on an interval, for compliance review, optionally also pushing
+// each report to a collector.
+type SBOHExporter struct {
+	hypervisor *Hypervisor
+	dir        string
+	pushURL    string // Collector each report is POSTed to; empty disables the push
+	interval   time.Duration
+	stop       chan struct{}
+	done       chan struct{}
+	stopOnce   sync.Once
+	now        func() time.Time
+}
+
+// NewSBOHExporter creates an exporter writing reports of h to dir every
+// interval, creating dir if needed.
+func NewSBOHExporter(h *Hypervisor, dir string, interval time.Duration) (*SBOHExporter, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("SBOH export directory must be set")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("SBOH export interval must be positive")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create SBOH export directory: %w", err)
+	}
+
+	return &SBOHExporter{
+		hypervisor: h,
+		dir:        dir,
+		interval:   interval,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+		now:        time.Now,
+	}, nil
+}
+
+// SetPushURL makes each export also POST the report to url, with the shared
+// outbound HTTP client. It must be called before Start.
+func (e *SBOHExporter) SetPushURL(url string) {
+	e.pushURL = url
+}
+
+// Start exports a report every interval until Stop.
+func (e *SBOHExporter) Start() {
+	go func() {
+		defer close(e.done)
+
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := e.Export(); err != nil {
+					log.Printf("Hypervisor: Failed to export SBOH report: %v", err)
+				}
+			case <-e.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic export and writes a final report, so the archive
+// covers the service up to shutdown.
+func (e *SBOHExporter) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.stop)
+		<-e.done
+		if _, err := e.Export(); err != nil {
+			log.Printf("Hypervisor: Failed to export final SBOH report: %v", err)
+		}
+	})
+}
+
+// Export writes the current SBOH report and returns the file's path. The
+// file is written under a temporary name and renamed, so readers never see a
+// partial report. With a push URL set, the archived report is then pushed;
+// a failed push is returned with the path of the archived file.
+func (e *SBOHExporter) Export() (string, error) {
+	data, err := json.MarshalIndent(e.hypervisor.GenerateSBOHReport(), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode SBOH report: %w", err)
+	}
+
+	path := filepath.Join(e.dir, "sboh-"+e.now().UTC().Format(sbohFileTimeFormat)+".json")
+	tmp, err := os.CreateTemp(e.dir, ".sboh-*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create SBOH report: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write SBOH report: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write SBOH report: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write SBOH report: %w", err)
+	}
+
+	if e.pushURL != "" {
+		if err := e.push(data); err != nil {
+			return path, fmt.Errorf("failed to push SBOH report: %w", err)
+		}
+	}
+	return path, nil
+}
+
+// push POSTs an encoded report to the push URL.
+func (e *SBOHExporter) push(data []byte) error {
+	resp, err := httpclient.Shared().Post(e.pushURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) // Drain so the connection is reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("POST %s: unexpected status %s", e.pushURL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package hypervisor
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSBOHExporter_WritesReportsOnInterval(t *testing.T) {
+	h := NewHypervisor(DefaultConfig())
+	for i := 0; i < 10; i++ {
+		h.RecordDecision(5, true, 0.001)
+	}
+
+	dir := filepath.Join(t.TempDir(), "sboh")
+	exporter, err := NewSBOHExporter(h, dir, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to create exporter: %v", err)
+	}
+	exporter.Start()
+
+	var files []string
+	deadline := time.Now().Add(2 * time.Second)
+	for len(files) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected an SBOH report to be written")
+		}
+		time.Sleep(5 * time.Millisecond)
+		files, _ = filepath.Glob(filepath.Join(dir, "sboh-*.json"))
+	}
+	exporter.Stop()
+
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	var report map[string]interface{}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, data)
+	}
+	if report["total_decisions"] != float64(10) {
+		t.Errorf("Expected 10 total decisions, got %v", report["total_decisions"])
+	}
+	for _, key := range []string{"p95_latency_ms", "decision_success_rate", "axiom_a2_compliant", "axiom_a4_status"} {
+		if _, ok := report[key]; !ok {
+			t.Errorf("Expected %s in the report", key)
+		}
+	}
+
+	// Stop writes a final report and leaves no temporary files behind
+	final, _ := filepath.Glob(filepath.Join(dir, "sboh-*.json"))
+	if len(final) <= len(files) {
+		t.Errorf("Expected a final report on stop, got %d files", len(final))
+	}
+	if tmp, _ := filepath.Glob(filepath.Join(dir, ".sboh-*")); len(tmp) != 0 {
+		t.Errorf("Expected no temporary files, got %v", tmp)
+	}
+}
+
+func TestSBOHExporter_PushesReports(t *testing.T) {
+	h := NewHypervisor(DefaultConfig())
+	h.RecordDecision(5, true, 0.001)
+
+	pushed := make(chan map[string]interface{}, 2)
+	var status atomic.Int32
+	status.Store(http.StatusAccepted)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report map[string]interface{}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &report)
+		pushed <- report
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	exporter, err := NewSBOHExporter(h, t.TempDir(), time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to create exporter: %v", err)
+	}
+	exporter.SetPushURL(server.URL)
+
+	if _, err := exporter.Export(); err != nil {
+		t.Fatalf("Expected the report pushed, got %v", err)
+	}
+	if report := <-pushed; report["total_decisions"] != float64(1) {
+		t.Errorf("Expected the pushed report to match, got %v", report)
+	}
+
+	// A rejected push still leaves the report archived
+	status.Store(http.StatusServiceUnavailable)
+	path, err := exporter.Export()
+	if err == nil {
+		t.Error("Expected a rejected push to be reported")
+	}
+	if _, statErr := os.Stat(path); statErr != nil {
+		t.Errorf("Expected the report archived despite the failed push, got %v", statErr)
+	}
+}
+
+func TestNewSBOHExporter_InvalidConfig(t *testing.T) {
+	h := NewHypervisor(DefaultConfig())
+	if _, err := NewSBOHExporter(h, "", time.Minute); err == nil {
+		t.Error("Expected an empty directory to be rejected")
+	}
+	if _, err := NewSBOHExporter(h, t.TempDir(), 0); err == nil {
+		t.Error("Expected a non-positive interval to be rejected")
+	}
+}
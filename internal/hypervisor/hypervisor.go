@@ -2,7 +2,12 @@ package hypervisor
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,7 +19,11 @@ import (
 // SBOHMetrics represents the Software Bill of Health metrics (Protocol ζ-Hypervisor).
 type SBOHMetrics struct {
 	Timestamp           time.Time `json:"timestamp"`
+	P50LatencyMS        float64   `json:"p50_latency_ms"`
+	P90LatencyMS        float64   `json:"p90_latency_ms"`
 	P95LatencyMS        float64   `json:"p95_latency_ms"`
+	P99LatencyMS        float64   `json:"p99_latency_ms"`
+	Percentiles         map[string]float64 `json:"percentiles,omitempty"` // Configured percentiles keyed by PercentileKey
 	DecisionSuccessRate float64   `json:"decision_success_rate"`
 	MonetizationAccuracy float64  `json:"monetization_accuracy"`
 	TotalDecisions      int64     `json:"total_decisions"`
@@ -28,15 +37,79 @@ type Hypervisor struct {
 	mu                  sync.RWMutex
 	metrics             SBOHMetrics
 	latencySamples      []float64
-	decisionOutcomes    []bool
-	revenueTracking     []float64
+	latencyWeights      []float64 // Decisions each latencySamples entry stands for
+	sortedLatencies     []float64 // latencySamples kept in ascending order for percentiles
+	sortedWeights       []float64 // Weight of each sortedLatencies entry
+	latencyTimes        []time.Time // Recording time of each latencySamples entry
+	sampledLatencies    int         // Entries kept by random sampling, weighted 1/sampleRate
+	decisions           decisionTotals    // Outcomes and revenue of every decision in the window
+	decisionBuckets     []decisionTotals  // Per-interval totals in time-windowed mode, oldest first
 	startTime           time.Time
+	windowDuration      time.Duration
+	now                 func() time.Time
 	maxSamples          int
+	percentiles         []float64
+	sampleRate          float64    // Fraction of ordinary decisions kept as latency samples
+	slowThresholdMS     float64    // Decisions at or above this latency are always sampled
+	rng                 *rand.Rand // Guarded by mu
+	billedDecisions     int64      // Lifetime count of priced decisions; unaffected by eviction and Reset
+	a4MinDecisions      int        // Decisions in the window before Axiom A-4 is evaluated
 }
 
+// decisionBucketsPerWindow is the number of intervals the time window is
+// split into for decision totals, so they expire in steps of a sixtieth of
+// the window rather than per decision.
+const decisionBucketsPerWindow = 60
+
+// decisionTotals aggregates decision outcomes and revenue, so success rate,
+// monetization accuracy and total revenue need no per-decision history.
+type decisionTotals struct {
+	start     time.Time // Start of the interval, for time-windowed buckets
+	count     int64
+	successes int64
+	unpriced  int64 // Decisions recorded with zero revenue, other than warm-up decisions
+	revenue   float64
+}
+
+// add counts one decision. A warm-up decision may be priced at zero on
+// purpose, so it is never counted as unpriced.
+func (t *decisionTotals) add(success bool, revenue float64, warmup bool) {
+	t.count++
+	if success {
+		t.successes++
+	}
+	if revenue == 0 && !warmup {
+		t.unpriced++
+	}
+	t.revenue += revenue
+}
+
+// Axiom A-4 statuses reported by A4Status.
+const (
+	A4Compliant        = "compliant"
+	A4NonCompliant     = "non_compliant"
+	A4InsufficientData = "insufficient_data"
+)
+
 // Config holds hypervisor configuration.
 type Config struct {
-	MaxSamples int `json:"max_samples"`
+	// MaxSamples bounds the latency samples kept for percentiles. Outcomes and
+	// revenue are aggregated, so they do not count against it.
+	MaxSamples  int       `json:"max_samples"`
+	Percentiles []float64 `json:"percentiles"` // Extra latency percentiles to report, each in (0, 100]
+	// SampleRate is the fraction of decisions kept as latency samples, in (0, 1].
+	// Anomalous, failed and slow decisions are always kept; the others are
+	// weighted by 1/SampleRate so the percentiles are not skewed to the tail.
+	SampleRate      float64 `json:"sample_rate"`
+	SlowThresholdMS float64 `json:"slow_threshold_ms"`
+	// WindowDuration, when set, limits metrics to decisions recorded within
+	// that long; MaxSamples still bounds memory. Zero keeps the count-capped
+	// latency window and aggregates outcomes and revenue since the last Reset.
+	WindowDuration time.Duration `json:"window_duration"`
+	// A4MinDecisions is the number of decisions in the window below which
+	// Axiom A-4 reports insufficient data instead of being evaluated, so a
+	// single unusual record early in a deploy cannot trip it. Zero disables the gate.
+	A4MinDecisions int `json:"a4_min_decisions"`
 }
 
 // NewHypervisor creates a new hypervisor instance.
@@ -45,44 +118,221 @@ func NewHypervisor(config Config) *Hypervisor {
 	if maxSamples <= 0 {
 		maxSamples = 10000 // Default to 10k samples
 	}
+	sampleRate := config.SampleRate
+	if sampleRate <= 0 || sampleRate > 1 {
+		sampleRate = 1.0
+	}
 
 	return &Hypervisor{
 		metrics: SBOHMetrics{
 			Timestamp: time.Now(),
 		},
 		latencySamples:   make([]float64, 0, maxSamples),
-		decisionOutcomes: make([]bool, 0, maxSamples),
-		revenueTracking:  make([]float64, 0, maxSamples),
+		sortedLatencies:  make([]float64, 0, maxSamples+1),
 		startTime:        time.Now(),
+		windowDuration:   config.WindowDuration,
+		now:              time.Now,
 		maxSamples:       maxSamples,
+		percentiles:      append([]float64(nil), config.Percentiles...),
+		sampleRate:       sampleRate,
+		slowThresholdMS:  config.SlowThresholdMS,
+		rng:              rand.New(rand.NewSource(time.Now().UnixNano())),
+		a4MinDecisions:   config.A4MinDecisions,
 	}
 }
 
 // RecordDecision records a decision outcome for SBOH tracking.
 func (h *Hypervisor) RecordDecision(latencyMS float64, success bool, revenue float64) {
+	h.RecordDecisionWithAnomaly(latencyMS, success, revenue, false)
+}
+
+// RecordDecisionWithAnomaly records a decision outcome, always keeping the
+// latency sample when the decision was anomalous.
+func (h *Hypervisor) RecordDecisionWithAnomaly(latencyMS float64, success bool, revenue float64, isAnomaly bool) {
+	h.record(latencyMS, success, revenue, isAnomaly, true, false)
+}
+
+// RecordDecisionWithWarmup is RecordDecisionWithAnomaly for a decision that
+// may have been made while the detector was warming up. A warm-up decision
+// billed at a zero warm-up price does not count against monetization
+// accuracy, so Axiom A-4 holds for deployments that do not charge for warm-up.
+func (h *Hypervisor) RecordDecisionWithWarmup(latencyMS float64, success bool, revenue float64, isAnomaly bool, warmup bool) {
+	h.record(latencyMS, success, revenue, isAnomaly, true, warmup)
+}
+
+// record adds a decision outcome to the rolling metrics. billed marks a
+// decision whose revenue was priced by the caller, as opposed to an
+// ObserveExecution measurement; warmup marks a decision made during
+// detector warm-up.
+func (h *Hypervisor) record(latencyMS float64, success bool, revenue float64, isAnomaly bool, billed bool, warmup bool) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	// Add to rolling samples
-	h.latencySamples = append(h.latencySamples, latencyMS)
-	h.decisionOutcomes = append(h.decisionOutcomes, success)
-	h.revenueTracking = append(h.revenueTracking, revenue)
+	now := h.now()
+	if billed {
+		h.billedDecisions++
+	}
+
+	// Add to rolling latency samples, subject to sampling
+	if keep, weight := h.shouldSample(latencyMS, success, isAnomaly); keep {
+		h.latencySamples = append(h.latencySamples, latencyMS)
+		h.latencyWeights = append(h.latencyWeights, weight)
+		h.latencyTimes = append(h.latencyTimes, now)
+		if weight != 1 {
+			h.sampledLatencies++
+		}
+		h.insertSortedLatency(latencyMS, weight)
+		if len(h.latencySamples) > h.maxSamples {
+			h.evictLatencySamples(1)
+		}
+	}
 
-	// Maintain max samples limit
-	if len(h.latencySamples) > h.maxSamples {
-		h.latencySamples = h.latencySamples[1:]
-		h.decisionOutcomes = h.decisionOutcomes[1:]
-		h.revenueTracking = h.revenueTracking[1:]
+	// Outcomes and revenue are aggregated for every decision
+	h.decisions.add(success, revenue, warmup)
+	if h.windowDuration > 0 {
+		h.bucketFor(now).add(success, revenue, warmup)
 	}
 
+	// Drop samples that have aged out of the time window
+	h.expireSamples(now)
+
 	// Update metrics
 	h.updateMetrics()
 }
 
+// Reset discards every recorded sample and restarts the uptime clock,
+// returning the hypervisor to its freshly constructed state.
+func (h *Hypervisor) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.latencySamples = h.latencySamples[:0]
+	h.latencyWeights = nil
+	h.sortedLatencies = h.sortedLatencies[:0]
+	h.sortedWeights = nil
+	h.latencyTimes = nil
+	h.sampledLatencies = 0
+	h.decisions = decisionTotals{}
+	h.decisionBuckets = nil
+	h.startTime = h.now()
+	h.metrics = SBOHMetrics{Timestamp: h.startTime}
+}
+
+// BilledDecisions returns the number of decisions recorded through
+// RecordDecision or RecordDecisionWithAnomaly since the hypervisor was created.
+// Unlike TotalDecisions it is neither windowed nor cleared by Reset, and it
+// excludes ObserveExecution.
+func (h *Hypervisor) BilledDecisions() int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.billedDecisions
+}
+
+// expireSamples drops samples recorded before the time window and reports
+// whether any were dropped. It is a no-op in count-capped mode.
+func (h *Hypervisor) expireSamples(now time.Time) bool {
+	if h.windowDuration <= 0 {
+		return false
+	}
+	cutoff := now.Add(-h.windowDuration)
+
+	latencies := 0
+	for latencies < len(h.latencyTimes) && h.latencyTimes[latencies].Before(cutoff) {
+		latencies++
+	}
+	h.evictLatencySamples(latencies)
+
+	// A bucket expires once every decision in it is older than the cutoff
+	width := h.bucketWidth()
+	buckets := 0
+	for buckets < len(h.decisionBuckets) && !h.decisionBuckets[buckets].start.Add(width).After(cutoff) {
+		buckets++
+	}
+	if buckets > 0 {
+		h.decisionBuckets = h.decisionBuckets[buckets:]
+		h.sumDecisionBuckets()
+	}
+
+	return latencies > 0 || buckets > 0
+}
+
+// bucketWidth returns the interval covered by each decision bucket.
+func (h *Hypervisor) bucketWidth() time.Duration {
+	width := h.windowDuration / decisionBucketsPerWindow
+	if width <= 0 {
+		width = time.Nanosecond
+	}
+	return width
+}
+
+// bucketFor returns the decision bucket covering now, starting a new one
+// when now is past the newest.
+func (h *Hypervisor) bucketFor(now time.Time) *decisionTotals {
+	start := now.Truncate(h.bucketWidth())
+	if n := len(h.decisionBuckets); n > 0 && !h.decisionBuckets[n-1].start.Before(start) {
+		return &h.decisionBuckets[n-1]
+	}
+	h.decisionBuckets = append(h.decisionBuckets, decisionTotals{start: start})
+	return &h.decisionBuckets[len(h.decisionBuckets)-1]
+}
+
+// sumDecisionBuckets recomputes the window totals from the remaining buckets.
+// Summing afresh rather than subtracting expired buckets keeps the revenue
+// free of accumulated rounding error.
+func (h *Hypervisor) sumDecisionBuckets() {
+	h.decisions = decisionTotals{}
+	for _, bucket := range h.decisionBuckets {
+		h.decisions.count += bucket.count
+		h.decisions.successes += bucket.successes
+		h.decisions.unpriced += bucket.unpriced
+		h.decisions.revenue += bucket.revenue
+	}
+}
+
+// evictLatencySamples removes the n oldest latency samples.
+func (h *Hypervisor) evictLatencySamples(n int) {
+	for i, latency := range h.latencySamples[:n] {
+		weight := h.latencyWeights[i]
+		if weight != 1 {
+			h.sampledLatencies--
+		}
+		h.removeSortedLatency(latency, weight)
+	}
+	h.latencySamples = h.latencySamples[n:]
+	h.latencyWeights = h.latencyWeights[n:]
+	h.latencyTimes = h.latencyTimes[n:]
+}
+
+// shouldSample reports whether a decision's latency is kept, and the number
+// of decisions the sample stands for. Slow, failed and anomalous decisions
+// are always kept so tail latency is never sampled away, and weigh 1; the
+// others are kept at sampleRate and weigh 1/sampleRate, so the kept tail does
+// not outweigh the ordinary decisions it was sampled alongside.
+func (h *Hypervisor) shouldSample(latencyMS float64, success, isAnomaly bool) (bool, float64) {
+	if h.sampleRate >= 1 || isAnomaly || !success {
+		return true, 1
+	}
+	if h.slowThresholdMS > 0 && latencyMS >= h.slowThresholdMS {
+		return true, 1
+	}
+	return h.rng.Float64() < h.sampleRate, 1 / h.sampleRate
+}
+
 // updateMetrics recalculates all SBOH metrics.
 func (h *Hypervisor) updateMetrics() {
-	// Calculate P95 latency
+	// Calculate latency percentiles
+	h.metrics.P50LatencyMS = h.calculatePercentile(50)
+	h.metrics.P90LatencyMS = h.calculatePercentile(90)
 	h.metrics.P95LatencyMS = h.calculateP95Latency()
+	h.metrics.P99LatencyMS = h.calculatePercentile(99)
+	if len(h.percentiles) > 0 {
+		percentiles := make(map[string]float64, len(h.percentiles))
+		for _, p := range h.percentiles {
+			percentiles[PercentileKey(p)] = h.calculatePercentile(p)
+		}
+		h.metrics.Percentiles = percentiles
+	}
 
 	// Calculate decision success rate
 	h.metrics.DecisionSuccessRate = h.calculateSuccessRate()
@@ -91,98 +341,166 @@ func (h *Hypervisor) updateMetrics() {
 	h.metrics.MonetizationAccuracy = h.calculateMonetizationAccuracy()
 
 	// Update counters
-	h.metrics.TotalDecisions = int64(len(h.decisionOutcomes))
-	h.metrics.SuccessfulDecisions = int64(h.countSuccessfulDecisions())
-	h.metrics.TotalRevenue = h.sumRevenue()
+	h.metrics.TotalDecisions = h.decisions.count
+	h.metrics.SuccessfulDecisions = h.decisions.successes
+	h.metrics.TotalRevenue = h.decisions.revenue
 	h.metrics.UptimeSeconds = time.Since(h.startTime).Seconds()
 	h.metrics.Timestamp = time.Now()
 }
 
+// insertSortedLatency adds a sample to sortedLatencies, keeping it ordered.
+// Binary search plus a single shift keeps each decision O(n) memmove instead of a full sort.
+func (h *Hypervisor) insertSortedLatency(latencyMS, weight float64) {
+	i := sort.SearchFloat64s(h.sortedLatencies, latencyMS)
+	h.sortedLatencies = append(h.sortedLatencies, 0)
+	copy(h.sortedLatencies[i+1:], h.sortedLatencies[i:])
+	h.sortedLatencies[i] = latencyMS
+	h.sortedWeights = append(h.sortedWeights, 0)
+	copy(h.sortedWeights[i+1:], h.sortedWeights[i:])
+	h.sortedWeights[i] = weight
+}
+
+// removeSortedLatency removes one occurrence of an evicted sample, with its
+// weight, from sortedLatencies.
+func (h *Hypervisor) removeSortedLatency(latencyMS, weight float64) {
+	for i := sort.SearchFloat64s(h.sortedLatencies, latencyMS); i < len(h.sortedLatencies) && h.sortedLatencies[i] == latencyMS; i++ {
+		if h.sortedWeights[i] == weight {
+			h.sortedLatencies = append(h.sortedLatencies[:i], h.sortedLatencies[i+1:]...)
+			h.sortedWeights = append(h.sortedWeights[:i], h.sortedWeights[i+1:]...)
+			return
+		}
+	}
+}
+
 // calculateP95Latency calculates the 95th percentile latency.
 func (h *Hypervisor) calculateP95Latency() float64 {
-	if len(h.latencySamples) == 0 {
+	return h.calculatePercentile(95)
+}
+
+// calculatePercentile returns the latency at percentile p (0-100) using nearest
+// rank, counting each sample by its weight.
+func (h *Hypervisor) calculatePercentile(p float64) float64 {
+	samples := h.sortedLatencies
+	if len(samples) == 0 {
 		return 0.0
 	}
+	if h.sampledLatencies > 0 {
+		return h.weightedPercentile(p)
+	}
 
-	samples := make([]float64, len(h.latencySamples))
-	copy(samples, h.latencySamples)
+	index := int(float64(len(samples)) * p / 100)
+	if index >= len(samples) {
+		index = len(samples) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+
+	return samples[index]
+}
 
-	// Sort samples (simple bubble sort for small arrays)
-	for i := 0; i < len(samples); i++ {
-		for j := 0; j < len(samples)-1-i; j++ {
-			if samples[j] > samples[j+1] {
-				samples[j], samples[j+1] = samples[j+1], samples[j]
-			}
+// weightedPercentile is calculatePercentile over samples of differing weights:
+// the first latency whose cumulative weight exceeds p percent of the total.
+func (h *Hypervisor) weightedPercentile(p float64) float64 {
+	always := len(h.sortedLatencies) - h.sampledLatencies
+	total := float64(always) + float64(h.sampledLatencies)/h.sampleRate
+	rank := total * p / 100
+
+	cumulative := 0.0
+	for i, weight := range h.sortedWeights {
+		cumulative += weight
+		if cumulative > rank {
+			return h.sortedLatencies[i]
 		}
 	}
+	return h.sortedLatencies[len(h.sortedLatencies)-1]
+}
 
-	p95Index := int(float64(len(samples)) * 0.95)
-	if p95Index >= len(samples) {
-		p95Index = len(samples) - 1
-	}
+// PercentileKey names percentile p in reports, e.g. "p99" or "p99.9".
+func PercentileKey(p float64) string {
+	return "p" + strconv.FormatFloat(p, 'f', -1, 64)
+}
 
-	return samples[p95Index]
+// ParsePercentiles parses a comma-separated list of percentiles such as "50,99,99.9".
+func ParsePercentiles(s string) ([]float64, error) {
+	var percentiles []float64
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		p, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid percentile %q: %w", field, err)
+		}
+		if p <= 0 || p > 100 {
+			return nil, fmt.Errorf("percentile %q must be in (0, 100]", field)
+		}
+		percentiles = append(percentiles, p)
+	}
+	return percentiles, nil
 }
 
 // calculateSuccessRate calculates the percentage of successful decisions.
 func (h *Hypervisor) calculateSuccessRate() float64 {
-	if len(h.decisionOutcomes) == 0 {
+	if h.decisions.count == 0 {
 		return 100.0
 	}
 
-	successful := h.countSuccessfulDecisions()
-	return (float64(successful) / float64(len(h.decisionOutcomes))) * 100.0
+	return (float64(h.decisions.successes) / float64(h.decisions.count)) * 100.0
 }
 
 // calculateMonetizationAccuracy calculates monetization logging accuracy.
 func (h *Hypervisor) calculateMonetizationAccuracy() float64 {
 	// In a perfect system, this should always be 100%
 	// Any failure to log revenue would indicate a system fault
-	if len(h.revenueTracking) == 0 {
+	// Any zero revenue entry is a decision that should have been priced
+	if h.decisions.count == 0 || h.decisions.unpriced == 0 {
 		return 100.0
 	}
 
-	// Check for any zero revenue entries that should have been logged
-	zeroCount := 0
-	for _, revenue := range h.revenueTracking {
-		if revenue == 0.0 {
-			zeroCount++
-		}
-	}
+	return (float64(h.decisions.count-h.decisions.unpriced) / float64(h.decisions.count)) * 100.0
+}
 
-	if zeroCount == 0 {
-		return 100.0
+// GetSBOHMetrics returns the current SBOH metrics.
+// In time-windowed mode, samples that have aged out since the last decision are dropped first.
+func (h *Hypervisor) GetSBOHMetrics() SBOHMetrics {
+	if h.windowDuration > 0 {
+		h.mu.Lock()
+		if h.expireSamples(h.now()) {
+			h.updateMetrics()
+		}
+		h.mu.Unlock()
 	}
 
-	return (float64(len(h.revenueTracking)-zeroCount) / float64(len(h.revenueTracking))) * 100.0
-}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 
-// countSuccessfulDecisions counts successful decision outcomes.
-func (h *Hypervisor) countSuccessfulDecisions() int {
-	count := 0
-	for _, success := range h.decisionOutcomes {
-		if success {
-			count++
+	metrics := h.metrics
+	if h.metrics.Percentiles != nil {
+		metrics.Percentiles = make(map[string]float64, len(h.metrics.Percentiles))
+		for k, v := range h.metrics.Percentiles {
+			metrics.Percentiles[k] = v
 		}
 	}
-	return count
+	return metrics
 }
 
-// sumRevenue calculates total revenue from all decisions.
-func (h *Hypervisor) sumRevenue() float64 {
-	total := 0.0
-	for _, revenue := range h.revenueTracking {
-		total += revenue
-	}
-	return total
+// P95LatencyMS returns the current P95 latency without copying the full metrics.
+func (h *Hypervisor) P95LatencyMS() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.metrics.P95LatencyMS
 }
 
-// GetSBOHMetrics returns the current SBOH metrics.
-func (h *Hypervisor) GetSBOHMetrics() SBOHMetrics {
+// DecisionSuccessRate returns the percentage of successful decisions in the
+// window, 100 before any decision is recorded.
+func (h *Hypervisor) DecisionSuccessRate() float64 {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	return h.metrics
+	return h.calculateSuccessRate()
 }
 
 // IsAxiomA2Compliant checks if P95 latency meets Axiom A-2 requirement (≤ 50ms).
@@ -194,20 +512,39 @@ func (h *Hypervisor) IsAxiomA2Compliant() bool {
 }
 
 // IsAxiomA4Compliant checks if monetization accuracy is 100%.
+// It holds while there is insufficient data to evaluate the axiom.
 func (h *Hypervisor) IsAxiomA4Compliant() bool {
+	return h.A4Status() != A4NonCompliant
+}
+
+// A4Status evaluates Axiom A-4, reporting A4InsufficientData until the window
+// holds at least the configured minimum number of decisions.
+func (h *Hypervisor) A4Status() string {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	return h.metrics.MonetizationAccuracy >= 99.999 // Allow for floating point precision
+	if h.decisions.count < int64(h.a4MinDecisions) {
+		return A4InsufficientData
+	}
+	if h.metrics.MonetizationAccuracy >= 99.999 { // Allow for floating point precision
+		return A4Compliant
+	}
+	return A4NonCompliant
 }
 
 // GenerateSBOHReport generates a comprehensive SBOH report.
 func (h *Hypervisor) GenerateSBOHReport() map[string]interface{} {
 	metrics := h.GetSBOHMetrics()
+	h.mu.RLock()
+	sampleCount := len(h.latencySamples)
+	h.mu.RUnlock()
 
 	report := map[string]interface{}{
 		"timestamp":             metrics.Timestamp,
+		"p50_latency_ms":        metrics.P50LatencyMS,
+		"p90_latency_ms":        metrics.P90LatencyMS,
 		"p95_latency_ms":        metrics.P95LatencyMS,
+		"p99_latency_ms":        metrics.P99LatencyMS,
 		"decision_success_rate": metrics.DecisionSuccessRate,
 		"monetization_accuracy": metrics.MonetizationAccuracy,
 		"total_decisions":       metrics.TotalDecisions,
@@ -216,7 +553,21 @@ func (h *Hypervisor) GenerateSBOHReport() map[string]interface{} {
 		"uptime_seconds":        metrics.UptimeSeconds,
 		"axiom_a2_compliant":    h.IsAxiomA2Compliant(),
 		"axiom_a4_compliant":    h.IsAxiomA4Compliant(),
-		"sample_count":          len(h.latencySamples),
+		"axiom_a4_status":       h.A4Status(),
+		"sample_count":          sampleCount,
+		"sample_rate":           h.sampleRate,
+	}
+	if h.windowDuration > 0 {
+		report["window_duration"] = h.windowDuration.String()
+	}
+
+	// Operator-configured percentiles, present even before the first decision
+	if len(h.percentiles) > 0 {
+		percentiles := make(map[string]float64, len(h.percentiles))
+		for _, p := range h.percentiles {
+			percentiles[PercentileKey(p)] = metrics.Percentiles[PercentileKey(p)]
+		}
+		report["percentiles"] = percentiles
 	}
 
 	// Log compliance status
@@ -250,7 +601,7 @@ func (h *Hypervisor) ObserveExecution(fn func() (bool, float64, error)) (bool, f
 	success := err == nil
 	price := 0.001 // Default price, would be calculated based on complexity
 
-	h.RecordDecision(latencyMS, success, price)
+	h.record(latencyMS, success, price, isAnomaly, false, false)
 
 	return isAnomaly, zScore, err
 }
@@ -258,7 +609,12 @@ func (h *Hypervisor) ObserveExecution(fn func() (bool, float64, error)) (bool, f
 // DefaultConfig returns default hypervisor configuration.
 func DefaultConfig() Config {
 	return Config{
-		MaxSamples: 10000,
+		MaxSamples:      10000,
+		Percentiles:     []float64{50, 90, 95, 99},
+		SampleRate:      1.0,
+		SlowThresholdMS: 50.0,
+		WindowDuration:  0,
+		A4MinDecisions:  100,
 	}
 }
 
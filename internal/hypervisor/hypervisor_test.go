@@ -0,0 +1,463 @@
+package hypervisor
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"internal/blueteam"
+)
+
+// bubbleSortP95 is the original full-sort P95 calculation, kept as a reference.
+func bubbleSortP95(latencies []float64) float64 {
+	if len(latencies) == 0 {
+		return 0.0
+	}
+
+	samples := make([]float64, len(latencies))
+	copy(samples, latencies)
+	for i := 0; i < len(samples); i++ {
+		for j := 0; j < len(samples)-1-i; j++ {
+			if samples[j] > samples[j+1] {
+				samples[j], samples[j+1] = samples[j+1], samples[j]
+			}
+		}
+	}
+
+	p95Index := int(float64(len(samples)) * 0.95)
+	if p95Index >= len(samples) {
+		p95Index = len(samples) - 1
+	}
+	return samples[p95Index]
+}
+
+func TestHypervisor_P95MatchesFullSort(t *testing.T) {
+	h := NewHypervisor(Config{MaxSamples: 200})
+	rng := rand.New(rand.NewSource(42))
+
+	// Run past the sample cap so evictions are exercised, including duplicates
+	var window []float64
+	for i := 0; i < 1000; i++ {
+		latency := float64(rng.Intn(50)) + rng.Float64()
+		if i%7 == 0 {
+			latency = 10.0
+		}
+
+		h.RecordDecision(latency, true, 0.001)
+		window = append(window, latency)
+		if len(window) > 200 {
+			window = window[1:]
+		}
+
+		if got, want := h.GetSBOHMetrics().P95LatencyMS, bubbleSortP95(window); got != want {
+			t.Fatalf("Decision %d: expected P95 %.6f, got %.6f", i, want, got)
+		}
+	}
+
+	if len(h.sortedLatencies) != len(h.latencySamples) {
+		t.Errorf("Expected sorted samples to track the window, got %d vs %d",
+			len(h.sortedLatencies), len(h.latencySamples))
+	}
+}
+
+func TestHypervisor_P95FixedDataset(t *testing.T) {
+	h := NewHypervisor(Config{MaxSamples: 100})
+	for i := 100; i >= 1; i-- {
+		h.RecordDecision(float64(i), true, 0.001)
+	}
+
+	// int(100 * 0.95) = 95 -> the 96th smallest value
+	if p95 := h.GetSBOHMetrics().P95LatencyMS; p95 != 96.0 {
+		t.Errorf("Expected P95 96.0, got %.1f", p95)
+	}
+}
+
+func TestHypervisor_ConfiguredPercentiles(t *testing.T) {
+	h := NewHypervisor(Config{MaxSamples: 100, Percentiles: []float64{50, 99.9}})
+	for i := 1; i <= 100; i++ {
+		h.RecordDecision(float64(i), true, 0.001)
+	}
+
+	metrics := h.GetSBOHMetrics()
+	if metrics.P50LatencyMS != 51 || metrics.P90LatencyMS != 91 || metrics.P99LatencyMS != 100 {
+		t.Errorf("Unexpected fixed percentiles: p50=%.1f p90=%.1f p99=%.1f",
+			metrics.P50LatencyMS, metrics.P90LatencyMS, metrics.P99LatencyMS)
+	}
+	if metrics.P95LatencyMS != 96 {
+		t.Errorf("Expected P95 to stay populated, got %.1f", metrics.P95LatencyMS)
+	}
+
+	report := h.GenerateSBOHReport()
+	percentiles, ok := report["percentiles"].(map[string]float64)
+	if !ok {
+		t.Fatalf("Expected percentiles map in report, got %T", report["percentiles"])
+	}
+	if len(percentiles) != 2 || percentiles["p50"] != 51 || percentiles["p99.9"] != 100 {
+		t.Errorf("Unexpected configured percentiles: %v", percentiles)
+	}
+}
+
+func TestParsePercentiles(t *testing.T) {
+	percentiles, err := ParsePercentiles(" 50, 99.9 ,")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(percentiles) != 2 || percentiles[0] != 50 || percentiles[1] != 99.9 {
+		t.Errorf("Unexpected percentiles: %v", percentiles)
+	}
+
+	for _, invalid := range []string{"abc", "0", "101"} {
+		if _, err := ParsePercentiles(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestHypervisor_SampledP95WithinTolerance(t *testing.T) {
+	full := NewHypervisor(Config{MaxSamples: 20000})
+	sampled := NewHypervisor(Config{MaxSamples: 20000, SampleRate: 0.1, SlowThresholdMS: 50})
+	sampled.rng = rand.New(rand.NewSource(7))
+	rng := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 20000; i++ {
+		latency := 5 + rng.ExpFloat64()*4
+		full.RecordDecision(latency, true, 0.001)
+		sampled.RecordDecision(latency, true, 0.001)
+	}
+
+	want := full.GetSBOHMetrics().P95LatencyMS
+	got := sampled.GetSBOHMetrics().P95LatencyMS
+	if math.Abs(got-want)/want > 0.05 {
+		t.Errorf("Sampled P95 %.3f not within 5%% of full P95 %.3f", got, want)
+	}
+
+	if n := len(sampled.latencySamples); n < 1500 || n > 2500 {
+		t.Errorf("Expected roughly 10%% of decisions sampled, got %d", n)
+	}
+	if got := sampled.GetSBOHMetrics().TotalDecisions; got != 20000 {
+		t.Errorf("Expected every decision counted, got %d", got)
+	}
+}
+
+func TestHypervisor_SampledP95WithSlowTail(t *testing.T) {
+	full := NewHypervisor(Config{MaxSamples: 20000})
+	sampled := NewHypervisor(Config{MaxSamples: 20000, SampleRate: 0.1, SlowThresholdMS: 50})
+	sampled.rng = rand.New(rand.NewSource(7))
+
+	// 2% of decisions are slow; every one is kept, but only 10% of the fast ones
+	for i := 0; i < 20000; i++ {
+		latency := 5.0
+		if i%50 == 0 {
+			latency = 60
+		}
+		full.RecordDecision(latency, true, 0.001)
+		sampled.RecordDecision(latency, true, 0.001)
+	}
+
+	want := full.GetSBOHMetrics().P95LatencyMS
+	got := sampled.GetSBOHMetrics()
+	if want != 5 || got.P95LatencyMS != want {
+		t.Errorf("Expected the sampled P95 to match the full P95 of 5ms, got %.1f (full %.1f)", got.P95LatencyMS, want)
+	}
+	if got.P99LatencyMS != 60 {
+		t.Errorf("Expected the slow tail to still set P99, got %.1f", got.P99LatencyMS)
+	}
+	if !sampled.IsAxiomA2Compliant() {
+		t.Error("Expected a 2% slow tail to keep Axiom A-2 compliant")
+	}
+}
+
+func TestHypervisor_SamplingRetainsSlowAndAnomalous(t *testing.T) {
+	h := NewHypervisor(Config{MaxSamples: 10000, SampleRate: 0.01, SlowThresholdMS: 50})
+	h.rng = rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		h.RecordDecision(1.0, true, 0.001)
+	}
+	h.RecordDecision(75.0, true, 0.001)
+	h.RecordDecisionWithAnomaly(2.0, true, 0.001, true)
+	h.RecordDecision(3.0, false, 0.001)
+
+	kept := map[float64]bool{}
+	for _, latency := range h.latencySamples {
+		kept[latency] = true
+	}
+	for _, latency := range []float64{75.0, 2.0, 3.0} {
+		if !kept[latency] {
+			t.Errorf("Expected %.1fms sample to always be retained", latency)
+		}
+	}
+}
+
+func TestHypervisor_TimeWindowDropsStaleSamples(t *testing.T) {
+	h := NewHypervisor(Config{MaxSamples: 1000, WindowDuration: time.Minute})
+	clock := time.Unix(1638360000, 0)
+	h.now = func() time.Time { return clock }
+
+	// An hour-old burst of slow, failing traffic
+	for i := 0; i < 100; i++ {
+		h.RecordDecision(200, false, 0.001)
+	}
+
+	// After a quiet period, only the recent healthy traffic should count
+	clock = clock.Add(time.Hour)
+	for i := 0; i < 10; i++ {
+		h.RecordDecision(5, true, 0.001)
+	}
+
+	metrics := h.GetSBOHMetrics()
+	if metrics.P95LatencyMS != 5 || metrics.TotalDecisions != 10 || metrics.DecisionSuccessRate != 100 {
+		t.Errorf("Expected only recent samples, got p95=%.1f total=%d success=%.1f",
+			metrics.P95LatencyMS, metrics.TotalDecisions, metrics.DecisionSuccessRate)
+	}
+	if len(h.sortedLatencies) != len(h.latencySamples) {
+		t.Errorf("Expected sorted samples to track the window, got %d vs %d",
+			len(h.sortedLatencies), len(h.latencySamples))
+	}
+}
+
+func TestHypervisor_EmptyTimeWindow(t *testing.T) {
+	h := NewHypervisor(Config{MaxSamples: 1000, WindowDuration: time.Second, Percentiles: []float64{99}})
+	clock := time.Unix(1638360000, 0)
+	h.now = func() time.Time { return clock }
+
+	h.RecordDecision(30, true, 0.001)
+	clock = clock.Add(time.Minute)
+
+	metrics := h.GetSBOHMetrics()
+	if metrics.P95LatencyMS != 0 || metrics.P99LatencyMS != 0 || metrics.TotalDecisions != 0 || metrics.TotalRevenue != 0 {
+		t.Errorf("Expected zeroed metrics for an empty window, got %+v", metrics)
+	}
+	if !h.IsAxiomA2Compliant() {
+		t.Error("Expected an empty window to be A-2 compliant")
+	}
+
+	report := h.GenerateSBOHReport()
+	if report["sample_count"] != 0 {
+		t.Errorf("Expected no samples in report, got %v", report["sample_count"])
+	}
+}
+
+// benchmarkSamples is the default sample cap the benchmarks run at.
+const benchmarkSamples = 10000
+
+func BenchmarkHypervisor_RecordDecision(b *testing.B) {
+	h := NewHypervisor(Config{MaxSamples: benchmarkSamples})
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < benchmarkSamples; i++ {
+		h.RecordDecision(rng.Float64()*50, true, 0.001)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.RecordDecision(rng.Float64()*50, true, 0.001)
+	}
+}
+
+// BenchmarkHypervisor_RecordDecisionFullSort measures the previous per-decision bubble sort for comparison.
+func BenchmarkHypervisor_RecordDecisionFullSort(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	samples := make([]float64, benchmarkSamples)
+	for i := range samples {
+		samples[i] = rng.Float64() * 50
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		samples = append(samples[1:], rng.Float64()*50)
+		bubbleSortP95(samples)
+	}
+}
+
+func TestHypervisor_Reset(t *testing.T) {
+	h := NewHypervisor(DefaultConfig())
+	for i := 0; i < 100; i++ {
+		h.RecordDecision(float64(i), i%2 == 0, 0.001)
+	}
+
+	h.Reset()
+	metrics := h.GetSBOHMetrics()
+	if metrics.TotalDecisions != 0 || metrics.P95LatencyMS != 0 || metrics.TotalRevenue != 0 {
+		t.Fatalf("Expected empty metrics after reset, got %+v", metrics)
+	}
+
+	// Metrics after a reset reflect only the new decisions
+	h.RecordDecision(5, true, 0.002)
+	metrics = h.GetSBOHMetrics()
+	if metrics.TotalDecisions != 1 || metrics.P95LatencyMS != 5 || metrics.DecisionSuccessRate != 100 {
+		t.Errorf("Expected metrics for the single post-reset decision, got %+v", metrics)
+	}
+}
+
+func TestHypervisor_BilledDecisionsSurviveEvictionAndReset(t *testing.T) {
+	h := NewHypervisor(Config{MaxSamples: 10})
+	for i := 0; i < 25; i++ {
+		h.RecordDecision(1, true, 0.001)
+	}
+	h.ObserveExecution(func() (bool, float64, error) { return false, 0, nil })
+
+	// MaxSamples bounds latency samples only; every decision is counted
+	if got := h.GetSBOHMetrics().TotalDecisions; got != 26 {
+		t.Errorf("Expected 26 decisions including the observation, got %d", got)
+	}
+	if got := h.BilledDecisions(); got != 25 {
+		t.Errorf("Expected 25 billed decisions excluding the observation, got %d", got)
+	}
+
+	h.Reset()
+	if got := h.BilledDecisions(); got != 25 {
+		t.Errorf("Expected billed decisions unaffected by Reset, got %d", got)
+	}
+}
+
+func TestHypervisor_A4GatedUntilMinDecisions(t *testing.T) {
+	config := DefaultConfig()
+	config.A4MinDecisions = 5
+	h := NewHypervisor(config)
+
+	// A single unpriced record would make accuracy 0%
+	h.RecordDecision(1, true, 0)
+	for i := 0; i < 3; i++ {
+		h.RecordDecision(1, true, 0.001)
+	}
+	if status := h.A4Status(); status != A4InsufficientData {
+		t.Errorf("Expected %s below the gate, got %s", A4InsufficientData, status)
+	}
+	if !h.IsAxiomA4Compliant() {
+		t.Error("Expected insufficient data not to count as an A-4 violation")
+	}
+
+	h.RecordDecision(1, true, 0.001)
+	if status := h.A4Status(); status != A4NonCompliant {
+		t.Errorf("Expected %s once the gate is reached, got %s", A4NonCompliant, status)
+	}
+	if h.IsAxiomA4Compliant() {
+		t.Error("Expected the unpriced record to violate A-4 above the gate")
+	}
+
+	healthy := NewHypervisor(config)
+	for i := 0; i < 5; i++ {
+		healthy.RecordDecision(1, true, 0.001)
+	}
+	if status := healthy.A4Status(); status != A4Compliant {
+		t.Errorf("Expected %s for fully priced decisions, got %s", A4Compliant, status)
+	}
+
+	// Warm-up decisions may be free without counting as unpriced
+	warming := NewHypervisor(config)
+	for i := 0; i < 5; i++ {
+		warming.RecordDecisionWithWarmup(1, true, 0, false, i < 2)
+	}
+	if status := warming.A4Status(); status != A4NonCompliant {
+		t.Errorf("Expected %s for unpriced scored decisions, got %s", A4NonCompliant, status)
+	}
+	warming.Reset()
+	for i := 0; i < 5; i++ {
+		warming.RecordDecisionWithWarmup(1, true, 0.001*float64(i/2), false, i < 2)
+	}
+	if status := warming.A4Status(); status != A4Compliant {
+		t.Errorf("Expected %s with only warm-up decisions free, got %s", A4Compliant, status)
+	}
+}
+
+func TestHypervisor_DrivesBlueTeamHealthCheck(t *testing.T) {
+	config := DefaultConfig()
+	config.A4MinDecisions = 10
+	h := NewHypervisor(config)
+	bt := blueteam.NewBlueTeam(blueteam.DefaultConfig())
+	bt.SetHealthMetrics(h)
+
+	for i := 0; i < 20; i++ {
+		h.RecordDecision(5, true, 0.001)
+	}
+	bt.CheckHealth()
+	if history := bt.GetHealingHistory(0); len(history) != 0 {
+		t.Fatalf("Expected no healing while compliant, got %+v", history)
+	}
+
+	// Slow decisions break Axiom A-2 and trip the breaker
+	for i := 0; i < 20; i++ {
+		h.RecordDecision(200, true, 0.001)
+	}
+	bt.CheckHealth()
+	history := bt.GetHealingHistory(0)
+	if len(history) != 1 || history[0].Type != blueteam.IssueHighLatency {
+		t.Fatalf("Expected a single high-latency heal, got %+v", history)
+	}
+	if bt.CircuitBreaker().State() != blueteam.BreakerOpen {
+		t.Error("Expected the breaker open on high latency")
+	}
+
+	// Back in compliance, the check leaves the service alone
+	h.Reset()
+	for i := 0; i < 20; i++ {
+		h.RecordDecision(5, true, 0.001)
+	}
+	bt.CheckHealth()
+	if got := len(bt.GetHealingHistory(0)); got != 1 {
+		t.Errorf("Expected no heal after recovery, got %d actions", got)
+	}
+
+	// An unpriced decision breaks Axiom A-4
+	h.RecordDecision(5, true, 0)
+	bt.CheckHealth()
+	history = bt.GetHealingHistory(0)
+	if len(history) != 2 || history[1].Type != blueteam.IssueComplianceFailure {
+		t.Errorf("Expected a compliance heal, got %+v", history)
+	}
+}
+
+func TestHypervisor_AggregatesExactBeyondMaxSamples(t *testing.T) {
+	h := NewHypervisor(Config{MaxSamples: 100})
+
+	wantRevenue := 0.0
+	for i := 0; i < 50000; i++ {
+		revenue := 0.001 * float64(i%7+1)
+		h.RecordDecision(float64(i%20), i%4 != 0, revenue)
+		wantRevenue += revenue
+	}
+
+	metrics := h.GetSBOHMetrics()
+	if metrics.TotalDecisions != 50000 || metrics.SuccessfulDecisions != 37500 {
+		t.Errorf("Expected 50000 decisions with 37500 successes, got %d and %d",
+			metrics.TotalDecisions, metrics.SuccessfulDecisions)
+	}
+	if metrics.DecisionSuccessRate != 75 {
+		t.Errorf("Expected a 75%% success rate, got %v", metrics.DecisionSuccessRate)
+	}
+	if metrics.TotalRevenue != wantRevenue {
+		t.Errorf("Expected total revenue %v, got %v", wantRevenue, metrics.TotalRevenue)
+	}
+	if metrics.MonetizationAccuracy != 100 {
+		t.Errorf("Expected 100%% monetization accuracy, got %v", metrics.MonetizationAccuracy)
+	}
+	if len(h.latencySamples) != 100 || len(h.decisionBuckets) != 0 {
+		t.Errorf("Expected only the latency samples retained, got %d samples and %d buckets",
+			len(h.latencySamples), len(h.decisionBuckets))
+	}
+}
+
+func TestHypervisor_TimeWindowBucketsBounded(t *testing.T) {
+	h := NewHypervisor(Config{MaxSamples: 1000, WindowDuration: time.Minute})
+	clock := time.Unix(1638360000, 0)
+	h.now = func() time.Time { return clock }
+
+	// Ten minutes of decisions, one every 100ms
+	for i := 0; i < 6000; i++ {
+		h.RecordDecision(5, i%2 == 0, 0.001)
+		clock = clock.Add(100 * time.Millisecond)
+	}
+
+	// Whole buckets expire, so the window holds between one window and one bucket more
+	metrics := h.GetSBOHMetrics()
+	if metrics.TotalDecisions < 600 || metrics.TotalDecisions > 610 {
+		t.Errorf("Expected about a minute of decisions, got %d", metrics.TotalDecisions)
+	}
+	if metrics.DecisionSuccessRate != 50 {
+		t.Errorf("Expected a 50%% success rate, got %v", metrics.DecisionSuccessRate)
+	}
+	if n := len(h.decisionBuckets); n > decisionBucketsPerWindow+1 {
+		t.Errorf("Expected at most %d buckets, got %d", decisionBucketsPerWindow+1, n)
+	}
+}
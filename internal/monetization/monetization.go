@@ -1,14 +1,32 @@
 package monetization
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"os"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// persistAttempts is how many times a record write is tried before the record
+// is counted as lost.
+const persistAttempts = 3
+
+// persistRetryDelay is the wait before the first retry; it grows linearly.
+var persistRetryDelay = 10 * time.Millisecond
+
+// DefaultCurrency is the ISO 4217 code of prices when Config.Currency is not set.
+const DefaultCurrency = "USD"
+
+// DefaultRoundingDP is the decimal places prices are rounded to when
+// Config.RoundingDP is not set; fine enough for micro-charges.
+const DefaultRoundingDP = 6
+
 // DecisionRecord represents a single decision event for Proof-of-Value (PoV) tracking.
 type DecisionRecord struct {
 	DecisionID    string    `json:"decision_id"`
@@ -17,15 +35,80 @@ type DecisionRecord struct {
 	ZScore        float64   `json:"z_score"`
 	Value         float64   `json:"value"`
 	IsAnomaly     bool      `json:"is_anomaly"`
+	Metadata      map[string]string `json:"metadata,omitempty"` // Client correlation metadata, already redacted
+	CorrelationID string            `json:"correlation_id,omitempty"` // ID of the request that produced the decision
+	Warmup        bool              `json:"warmup,omitempty"`         // Made while the detector was warming up, below MinSamples
+	Currency      string            `json:"currency"`                 // ISO 4217 code of the decision's price
+	Price         float64           `json:"price"`                    // Price billed for the decision, fixed when it is recorded
+}
+
+// PeriodSummary totals the retained decisions of one rollup period.
+type PeriodSummary struct {
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	DecisionCount int       `json:"decision_count"`
+	Revenue       float64   `json:"revenue"`
+	AnomalyCount  int       `json:"anomaly_count"`
 }
 
 // MonetizationTracker handles Proof-of-Value (PoV) logging and financial calculations.
 type MonetizationTracker struct {
 	mu           sync.RWMutex
 	records      []DecisionRecord
+	maxRecords   int
+	totalMinor   int64   // Running sum of the price of every retained record, in minor units
+	anomalies    int     // Retained records flagged as anomalies
 	basePrice    float64
+	currency     string
+	minorUnits   float64 // Minor units per currency unit, 10^RoundingDP
 	complexityMultiplier float64
 	outputFile   string
+	pending      sync.WaitGroup // Records queued or being written
+
+	queueMu     sync.Mutex
+	queue       []DecisionRecord // Records waiting for the flusher
+	closed      bool             // Set by Close; later records are written synchronously
+	wake        chan struct{}    // Signals the flusher that records are queued
+	closing     chan struct{}    // Closed by Close to stop the flusher
+	flusherDone chan struct{}
+	closeOnce   sync.Once
+	closeErr    error // Error of the flusher's final write
+
+	recorded        int64        // Lifetime count of recorded decisions; records evicts
+	persisted       atomic.Int64 // Records written to outputFile
+	persistFailures atomic.Int64 // Records dropped after persistAttempts failed writes
+	persistRetries  atomic.Int64 // Writes that failed and were tried again
+
+	fallbackPrice   float64 // Replaces a non-finite computed price
+	priceFallbacks  int64   // Decisions billed at fallbackPrice
+	warmupPricing   bool    // Bill warm-up decisions at warmupPrice
+	warmupPrice     float64
+	warmupDecisions int64   // Lifetime count of recorded warm-up decisions
+	onPriceFallback func(decisionID string, processingNS int64, zScore float64)
+
+	dedupWindow int                 // Recent decision IDs remembered; 0 disables deduplication
+	seenIDs     map[string]struct{} // The IDs in seenOrder
+	seenOrder   []string            // Ring of recent decision IDs; seenNext is the oldest once full
+	seenNext    int
+	duplicates  int64 // Lifetime count of decisions skipped as already recorded
+
+	tiers           []PricingTier // Volume tiers of the pricing plan, sorted; none uses the price formula
+	billingPeriod   time.Duration // Length of a billing period; 0 is a calendar month
+	periodStart     time.Time     // Start of the billing period periodDecisions counts
+	periodDecisions int64         // Decisions recorded in the billing period
+	now             func() time.Time
+
+	rateRecords int           // Most recent records the windowed anomaly rate covers; 0 is all retained
+	rateWindow  time.Duration // Age of the oldest record the windowed anomaly rate covers; 0 is unbounded
+}
+
+// PersistenceStats counts what happened to the records of every decision
+// since the tracker was created.
+type PersistenceStats struct {
+	Recorded  int64 `json:"recorded"`
+	Persisted int64 `json:"persisted"`
+	Failed    int64 `json:"failed"`
+	Retried   int64 `json:"retried"`
 }
 
 // Config holds monetization configuration.
@@ -33,63 +116,373 @@ type Config struct {
 	BasePrice            float64 `json:"base_price"`
 	ComplexityMultiplier float64 `json:"complexity_multiplier"`
 	OutputFile           string  `json:"output_file"`
+	MaxRecords           int     `json:"max_records"` // Records retained in memory, oldest evicted first; 0 is unbounded
+	FallbackPrice        float64 `json:"fallback_price"` // Price used when the computed price is NaN or infinite; 0 uses BasePrice
+	WarmupPricing        bool    `json:"warmup_pricing"` // Bill warm-up decisions at WarmupPrice instead of the computed price
+	WarmupPrice          float64 `json:"warmup_price"`   // Flat price of a warm-up decision under WarmupPricing; may be 0
+	Currency             string  `json:"currency"`       // ISO 4217 code of prices; empty uses DefaultCurrency
+	RoundingDP           int     `json:"rounding_dp"`    // Decimal places each price is rounded to; 0 uses DefaultRoundingDP
+	DedupWindow          int     `json:"dedup_window"`   // Recent decision IDs remembered so retried decisions are not recorded twice; 0 disables
+	Tiers                []PricingTier `json:"tiers,omitempty"` // Volume pricing plan replacing the price formula; see PricingTier
+	BillingPeriod        time.Duration `json:"billing_period"`  // Period over which Tiers count decisions; 0 is a calendar month (UTC)
+	AnomalyRateRecords   int           `json:"anomaly_rate_records"` // Most recent decisions in the windowed anomaly rate; 0 is all retained
+	AnomalyRateWindow    time.Duration `json:"anomaly_rate_window"`  // Longest a decision counts in the windowed anomaly rate; 0 is unbounded
 }
 
 // NewTracker creates a new MonetizationTracker with the given configuration.
 func NewTracker(config Config) *MonetizationTracker {
-	return &MonetizationTracker{
+	fallbackPrice := config.FallbackPrice
+	if fallbackPrice <= 0 {
+		fallbackPrice = config.BasePrice
+	}
+	currency := config.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	roundingDP := config.RoundingDP
+	if roundingDP <= 0 {
+		roundingDP = DefaultRoundingDP
+	}
+	tiers, err := sortTiers(config.Tiers)
+	if err != nil {
+		log.Printf("Invalid pricing tiers, using the price formula: %v", err)
+		tiers = nil
+	}
+
+	mt := &MonetizationTracker{
 		records:              make([]DecisionRecord, 0),
+		maxRecords:           config.MaxRecords,
 		basePrice:            config.BasePrice,
+		currency:             currency,
+		minorUnits:           math.Pow10(roundingDP),
 		complexityMultiplier: config.ComplexityMultiplier,
 		outputFile:           config.OutputFile,
+		fallbackPrice:        fallbackPrice,
+		warmupPricing:        config.WarmupPricing,
+		warmupPrice:          config.WarmupPrice,
+		dedupWindow:          config.DedupWindow,
+		seenIDs:              make(map[string]struct{}),
+		tiers:                tiers,
+		billingPeriod:        config.BillingPeriod,
+		now:                  time.Now,
+		rateRecords:          config.AnomalyRateRecords,
+		rateWindow:           config.AnomalyRateWindow,
+		wake:                 make(chan struct{}, 1),
+		closing:              make(chan struct{}),
+		flusherDone:          make(chan struct{}),
 	}
+	go mt.runFlusher()
+	return mt
 }
 
-// RecordDecision logs a decision event for PoV tracking and financial calculation.
-func (mt *MonetizationTracker) RecordDecision(decisionID string, value float64, processingNS int64, zScore float64) {
+// SetPriceFallbackHandler registers a callback invoked, with the tracker
+// locked, for each decision billed at the fallback price, e.g. to audit it.
+func (mt *MonetizationTracker) SetPriceFallbackHandler(handler func(decisionID string, processingNS int64, zScore float64)) {
 	mt.mu.Lock()
 	defer mt.mu.Unlock()
 
-	record := DecisionRecord{
-		DecisionID:   decisionID,
-		Timestamp:    time.Now(),
-		ProcessingNS: processingNS,
-		ZScore:       zScore,
-		Value:        value,
-		IsAnomaly:    zScore > 0, // Simplified: any z-score > 0 indicates anomaly
+	mt.onPriceFallback = handler
+}
+
+// RecordDecision logs a decision event for PoV tracking and financial calculation.
+// isAnomaly is the detector's verdict on the decision.
+// With a dedup window, a decision ID recorded recently is skipped.
+func (mt *MonetizationTracker) RecordDecision(decisionID string, value float64, processingNS int64, zScore float64, isAnomaly bool) {
+	mt.RecordDecisionWithMetadata(decisionID, value, processingNS, zScore, isAnomaly, nil)
+}
+
+// RecordDecisionByZScore is RecordDecision flagging every decision with a
+// positive z-score as an anomaly.
+//
+// Deprecated: the z-score alone does not tell whether the detector flagged
+// the decision, so the anomaly rate is overstated. Use RecordDecision with
+// the detector's verdict.
+func (mt *MonetizationTracker) RecordDecisionByZScore(decisionID string, value float64, processingNS int64, zScore float64) {
+	mt.RecordDecision(decisionID, value, processingNS, zScore, zScore > 0)
+}
+
+// RecordDecisionIdempotent is RecordDecision reporting whether the decision
+// was recorded, false when its ID is still in the dedup window, e.g. because
+// a client retried the request that produced it.
+func (mt *MonetizationTracker) RecordDecisionIdempotent(decisionID string, value float64, processingNS int64, zScore float64, isAnomaly bool) (recorded bool) {
+	_, recorded = mt.RecordDecisionWithCorrelation(decisionID, "", value, processingNS, zScore, isAnomaly, nil)
+	return recorded
+}
+
+// RecordDecisionWithMetadata is RecordDecision with client correlation metadata attached to the record.
+func (mt *MonetizationTracker) RecordDecisionWithMetadata(decisionID string, value float64, processingNS int64, zScore float64, isAnomaly bool, metadata map[string]string) {
+	mt.RecordDecisionWithCorrelation(decisionID, "", value, processingNS, zScore, isAnomaly, metadata)
+}
+
+// RecordDecisionWithCorrelation is RecordDecisionWithMetadata also recording
+// correlationID, the ID of the request that produced the decision. It returns
+// the price billed and whether the decision was recorded, as
+// RecordDecisionIdempotent; the price of a duplicate is 0.
+func (mt *MonetizationTracker) RecordDecisionWithCorrelation(decisionID, correlationID string, value float64, processingNS int64, zScore float64, isAnomaly bool, metadata map[string]string) (price float64, recorded bool) {
+	return mt.record(newDecisionRecord(decisionID, correlationID, value, processingNS, zScore, isAnomaly, metadata))
+}
+
+// RecordWarmupDecision is RecordDecisionWithCorrelation for a decision made
+// while the detector was warming up. The record is tagged as warm-up so it
+// can be excluded or discounted, and under the warm-up pricing policy it is
+// billed at the warm-up price. It returns the price billed and whether the
+// decision was recorded, as RecordDecisionWithCorrelation.
+func (mt *MonetizationTracker) RecordWarmupDecision(decisionID, correlationID string, value float64, processingNS int64, zScore float64, isAnomaly bool, metadata map[string]string) (price float64, recorded bool) {
+	record := newDecisionRecord(decisionID, correlationID, value, processingNS, zScore, isAnomaly, metadata)
+	record.Warmup = true
+	return mt.record(record)
+}
+
+// newDecisionRecord builds the record of a decision made now.
+func newDecisionRecord(decisionID, correlationID string, value float64, processingNS int64, zScore float64, isAnomaly bool, metadata map[string]string) DecisionRecord {
+	return DecisionRecord{
+		DecisionID:    decisionID,
+		Timestamp:     time.Now(),
+		ProcessingNS:  processingNS,
+		ZScore:        zScore,
+		Value:         value,
+		IsAnomaly:     isAnomaly,
+		Metadata:      metadata,
+		CorrelationID: correlationID,
 	}
+}
+
+// record retains and persists record, adding its price to the total, and
+// returns the price. It returns false, recording nothing, when the decision
+// ID is a duplicate.
+func (mt *MonetizationTracker) record(record DecisionRecord) (float64, bool) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
 
+	decisionID, processingNS, zScore := record.DecisionID, record.ProcessingNS, record.ZScore
+	if !mt.remember(decisionID) {
+		mt.duplicates++
+		log.Printf("PoV Event: %s already recorded; duplicate skipped", decisionID)
+		return 0, false
+	}
+	now := mt.now()
+	record.Timestamp = now
+	price, fellBack := mt.recordPrice(record, now)
+	mt.countInPeriod(now)
+	record.Currency = mt.currency
+	record.Price = price
+	if record.Warmup {
+		mt.warmupDecisions++
+	}
+	if fellBack {
+		mt.priceFallbacks++
+		log.Printf("Non-finite price for decision %s (latency %d ns, z-score %v); billed at fallback price %.6f %s",
+			decisionID, processingNS, zScore, price, mt.currency)
+		if mt.onPriceFallback != nil {
+			mt.onPriceFallback(decisionID, processingNS, zScore)
+		}
+	}
 	mt.records = append(mt.records, record)
+	mt.recorded++
+	mt.totalMinor += mt.toMinor(price)
+	if record.IsAnomaly {
+		mt.anomalies++
+	}
+
+	// Evict the oldest records beyond the retention limit
+	if mt.maxRecords > 0 && len(mt.records) > mt.maxRecords {
+		evicted := len(mt.records) - mt.maxRecords
+		for _, old := range mt.records[:evicted] {
+			mt.totalMinor -= mt.toMinor(old.Price)
+			if old.IsAnomaly {
+				mt.anomalies--
+			}
+		}
+		mt.records = mt.records[evicted:]
+	}
 
 	// Log for immediate feedback
-	log.Printf("PoV Event: %s | Latency: %d ns | Z-Score: %.3f | Price: $%.6f",
-		decisionID, processingNS, zScore, mt.CalculatePrice(processingNS, zScore))
+	log.Printf("PoV Event: %s | Latency: %d ns | Z-Score: %.3f | Price: %.6f %s",
+		decisionID, processingNS, zScore, price, mt.currency)
 
 	// Persist to file asynchronously for performance
-	go mt.persistRecord(record)
+	mt.enqueue(record)
+	return price, true
+}
+
+// remember adds decisionID to the dedup window, evicting the oldest ID when
+// the window is full. It returns false when the ID is already in the window.
+// Must be called with mt.mu held.
+func (mt *MonetizationTracker) remember(decisionID string) bool {
+	if mt.dedupWindow <= 0 || decisionID == "" {
+		return true
+	}
+	if _, seen := mt.seenIDs[decisionID]; seen {
+		return false
+	}
+
+	if len(mt.seenOrder) < mt.dedupWindow {
+		mt.seenOrder = append(mt.seenOrder, decisionID)
+	} else {
+		delete(mt.seenIDs, mt.seenOrder[mt.seenNext])
+		mt.seenOrder[mt.seenNext] = decisionID
+		mt.seenNext = (mt.seenNext + 1) % mt.dedupWindow
+	}
+	mt.seenIDs[decisionID] = struct{}{}
+	return true
+}
+
+// Flush blocks until all asynchronously persisted records have been written.
+func (mt *MonetizationTracker) Flush() {
+	mt.pending.Wait()
+}
+
+// Close writes the records still queued and stops the background flusher, so
+// no record is lost on shutdown. Records made after Close are written
+// synchronously. It returns the error of the final write, if any.
+func (mt *MonetizationTracker) Close() error {
+	mt.closeOnce.Do(func() {
+		mt.queueMu.Lock()
+		mt.closed = true
+		mt.queueMu.Unlock()
+
+		close(mt.closing)
+		<-mt.flusherDone
+	})
+	mt.pending.Wait()
+	return mt.closeErr
+}
+
+// PersistenceStats returns the lifetime persistence counters. It waits for
+// in-flight writes, including their retries, so they are not mistaken for losses.
+func (mt *MonetizationTracker) PersistenceStats() PersistenceStats {
+	mt.Flush()
+
+	mt.mu.RLock()
+	recorded := mt.recorded
+	mt.mu.RUnlock()
+
+	return PersistenceStats{
+		Recorded:  recorded,
+		Persisted: mt.persisted.Load(),
+		Failed:    mt.persistFailures.Load(),
+		Retried:   mt.persistRetries.Load(),
+	}
 }
 
 // CalculatePrice computes the dynamic price based on processing complexity and latency.
+// A price that would be NaN or infinite is replaced by the fallback price.
 func (mt *MonetizationTracker) CalculatePrice(processingNS int64, zScore float64) float64 {
+	price, _ := mt.calculatePrice(processingNS, zScore)
+	return price
+}
+
+// CalculateWarmupPrice is the price of a decision made during detector
+// warm-up: the warm-up price under the warm-up pricing policy, otherwise
+// CalculatePrice.
+func (mt *MonetizationTracker) CalculateWarmupPrice(processingNS int64, zScore float64) float64 {
+	if mt.warmupPricing {
+		return mt.round(mt.warmupPrice)
+	}
+	return mt.CalculatePrice(processingNS, zScore)
+}
+
+// recordPrice is the price billed for record at now, also reporting whether
+// the fallback price was used: the warm-up price under the warm-up pricing
+// policy, else the tier price under a pricing plan, else the price formula.
+// Must be called with mt.mu held.
+func (mt *MonetizationTracker) recordPrice(record DecisionRecord, now time.Time) (float64, bool) {
+	if record.Warmup && mt.warmupPricing {
+		return mt.round(mt.warmupPrice), false
+	}
+	if len(mt.tiers) > 0 {
+		return mt.round(tierFor(mt.tiers, mt.decisionsInPeriod(now)+1).PricePerDecision), false
+	}
+	return mt.calculatePrice(record.ProcessingNS, record.ZScore)
+}
+
+// calculatePrice is CalculatePrice, also reporting whether the fallback price was used.
+func (mt *MonetizationTracker) calculatePrice(processingNS int64, zScore float64) (float64, bool) {
 	// Base price adjusted by processing time (latency affects pricing)
 	latencyFactor := 1.0 + (float64(processingNS) / 1e9) * mt.complexityMultiplier
 
 	// Anomaly detection complexity factor (higher z-score = more complex analysis)
 	complexityFactor := 1.0 + (zScore / 10.0) * mt.complexityMultiplier
 
-	return mt.basePrice * latencyFactor * complexityFactor
+	price := mt.basePrice * latencyFactor * complexityFactor
+	if math.IsNaN(price) || math.IsInf(price, 0) {
+		return mt.round(mt.fallbackPrice), true
+	}
+	return mt.round(price), false
+}
+
+// round rounds price to the configured decimal places.
+func (mt *MonetizationTracker) round(price float64) float64 {
+	return float64(mt.toMinor(price)) / mt.minorUnits
+}
+
+// toMinor converts price to whole minor units, rounding half away from zero.
+func (mt *MonetizationTracker) toMinor(price float64) int64 {
+	return int64(math.Round(price * mt.minorUnits))
 }
 
-// GetTotalValue calculates the total monetary value of all processed decisions.
+// Currency returns the ISO 4217 code of the tracker's prices.
+func (mt *MonetizationTracker) Currency() string {
+	return mt.currency
+}
+
+// GetTotalValue returns the total monetary value of all retained decisions.
+// The total is maintained as records are added and evicted, so this is O(1).
+// It is kept in integer minor units, so summing many micro-charges does not
+// accumulate floating-point error.
 func (mt *MonetizationTracker) GetTotalValue() float64 {
 	mt.mu.RLock()
 	defer mt.mu.RUnlock()
 
-	total := 0.0
+	return mt.totalValueLocked()
+}
+
+// totalValueLocked is GetTotalValue. Must be called with mt.mu held.
+func (mt *MonetizationTracker) totalValueLocked() float64 {
+	return float64(mt.totalMinor) / mt.minorUnits
+}
+
+// GetRollup totals the retained decisions made at or after since into
+// periods of the given length, aligned to multiples of period since the zero
+// time (whole hours and UTC days). Only periods with decisions are returned,
+// oldest first. Revenue is summed from the price cached on each record.
+func (mt *MonetizationTracker) GetRollup(period time.Duration, since time.Time) []PeriodSummary {
+	if period <= 0 {
+		return nil
+	}
+
+	mt.mu.RLock()
+	defer mt.mu.RUnlock()
+
+	type bucket struct {
+		summary      PeriodSummary
+		revenueMinor int64
+	}
+	buckets := make(map[time.Time]*bucket)
 	for _, record := range mt.records {
-		total += mt.CalculatePrice(record.ProcessingNS, record.ZScore)
+		if record.Timestamp.Before(since) {
+			continue
+		}
+		start := record.Timestamp.Truncate(period)
+		b, exists := buckets[start]
+		if !exists {
+			b = &bucket{summary: PeriodSummary{Start: start, End: start.Add(period)}}
+			buckets[start] = b
+		}
+		b.summary.DecisionCount++
+		b.revenueMinor += mt.toMinor(record.Price)
+		if record.IsAnomaly {
+			b.summary.AnomalyCount++
+		}
+	}
+
+	rollup := make([]PeriodSummary, 0, len(buckets))
+	for _, b := range buckets {
+		b.summary.Revenue = float64(b.revenueMinor) / mt.minorUnits
+		rollup = append(rollup, b.summary)
 	}
-	return total
+	sort.Slice(rollup, func(i, j int) bool { return rollup[i].Start.Before(rollup[j].Start) })
+	return rollup
 }
 
 // GetAverageLatency returns the average processing latency in nanoseconds.
@@ -97,6 +490,11 @@ func (mt *MonetizationTracker) GetAverageLatency() int64 {
 	mt.mu.RLock()
 	defer mt.mu.RUnlock()
 
+	return mt.averageLatencyLocked()
+}
+
+// averageLatencyLocked is GetAverageLatency. Must be called with mt.mu held.
+func (mt *MonetizationTracker) averageLatencyLocked() int64 {
 	if len(mt.records) == 0 {
 		return 0
 	}
@@ -108,51 +506,182 @@ func (mt *MonetizationTracker) GetAverageLatency() int64 {
 	return total / int64(len(mt.records))
 }
 
-// GetAnomalyRate returns the percentage of decisions that were anomalies.
+// GetAnomalyRate returns the percentage of the retained decisions that were
+// anomalies, or 0 when none is retained.
 func (mt *MonetizationTracker) GetAnomalyRate() float64 {
 	mt.mu.RLock()
 	defer mt.mu.RUnlock()
 
+	return mt.anomalyRateLocked()
+}
+
+// anomalyRateLocked is GetAnomalyRate. Must be called with mt.mu held.
+func (mt *MonetizationTracker) anomalyRateLocked() float64 {
 	if len(mt.records) == 0 {
 		return 0.0
 	}
+	return (float64(mt.anomalies) / float64(len(mt.records))) * 100.0
+}
+
+// GetWindowedAnomalyRate returns the percentage of anomalies among the most
+// recent retained decisions: the last AnomalyRateRecords of them, recorded
+// within AnomalyRateWindow. It is 0 when no decision falls in the window, and
+// equals GetAnomalyRate when neither bound is set.
+func (mt *MonetizationTracker) GetWindowedAnomalyRate() float64 {
+	mt.mu.RLock()
+	defer mt.mu.RUnlock()
 
+	return mt.windowedAnomalyRateLocked()
+}
+
+// windowedAnomalyRateLocked is GetWindowedAnomalyRate. Must be called with mt.mu held.
+func (mt *MonetizationTracker) windowedAnomalyRateLocked() float64 {
+	oldest := 0
+	if mt.rateRecords > 0 && len(mt.records) > mt.rateRecords {
+		oldest = len(mt.records) - mt.rateRecords
+	}
+	if mt.rateWindow > 0 {
+		// Records are appended in time order
+		cutoff := mt.now().Add(-mt.rateWindow)
+		oldest += sort.Search(len(mt.records)-oldest, func(i int) bool {
+			return !mt.records[oldest+i].Timestamp.Before(cutoff)
+		})
+	}
+
+	window := mt.records[oldest:]
+	if len(window) == 0 {
+		return 0.0
+	}
 	anomalies := 0
-	for _, record := range mt.records {
+	for _, record := range window {
 		if record.IsAnomaly {
 			anomalies++
 		}
 	}
-	return (float64(anomalies) / float64(len(mt.records))) * 100.0
+	return (float64(anomalies) / float64(len(window))) * 100.0
 }
 
-// GetStats returns comprehensive monetization statistics.
+// GetStats returns comprehensive monetization statistics, all read under one
+// lock acquisition; a nested read lock could deadlock behind a waiting writer.
 func (mt *MonetizationTracker) GetStats() map[string]interface{} {
 	mt.mu.RLock()
 	defer mt.mu.RUnlock()
 
 	return map[string]interface{}{
-		"total_decisions":    len(mt.records),
-		"total_value":        mt.GetTotalValue(),
-		"average_latency_ns": mt.GetAverageLatency(),
-		"anomaly_rate_pct":   mt.GetAnomalyRate(),
-		"base_price":         mt.basePrice,
+		"total_decisions":           len(mt.records),
+		"total_value":               mt.totalValueLocked(),
+		"average_latency_ns":        mt.averageLatencyLocked(),
+		"anomaly_rate_pct":          mt.anomalyRateLocked(),
+		"windowed_anomaly_rate_pct": mt.windowedAnomalyRateLocked(),
+		"base_price":                mt.basePrice,
+		"currency":                  mt.currency,
+		"price_fallbacks":           mt.priceFallbacks,
+		"warmup_decisions":          mt.warmupDecisions,
+		"duplicate_decisions":       mt.duplicates,
+		"pricing_tiers":             len(mt.tiers),
+		"period_decisions":          mt.decisionsInPeriod(mt.now()),
+	}
+}
+
+// enqueue hands record to the background flusher. Once the tracker is
+// closed, record is written synchronously instead.
+func (mt *MonetizationTracker) enqueue(record DecisionRecord) {
+	mt.pending.Add(1)
+
+	mt.queueMu.Lock()
+	if mt.closed {
+		mt.queueMu.Unlock()
+		mt.persistRecords([]DecisionRecord{record})
+		mt.pending.Done()
+		return
+	}
+	mt.queue = append(mt.queue, record)
+	mt.queueMu.Unlock()
+
+	select {
+	case mt.wake <- struct{}{}:
+	default: // The flusher is already signalled
+	}
+}
+
+// runFlusher is the single writer of the output file. It writes the queued
+// records in batches until the tracker is closed, then writes what is left.
+func (mt *MonetizationTracker) runFlusher() {
+	defer close(mt.flusherDone)
+
+	for {
+		select {
+		case <-mt.wake:
+			mt.flushQueue()
+		case <-mt.closing:
+			mt.closeErr = mt.flushQueue()
+			return
+		}
 	}
 }
 
-// persistRecord writes a single record to the output file.
-func (mt *MonetizationTracker) persistRecord(record DecisionRecord) {
+// flushQueue writes and empties the queue.
+func (mt *MonetizationTracker) flushQueue() error {
+	mt.queueMu.Lock()
+	batch := mt.queue
+	mt.queue = nil
+	mt.queueMu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+	err := mt.persistRecords(batch)
+	mt.pending.Add(-len(batch))
+	return err
+}
+
+// persistRecords appends records to the output file in one write, retrying
+// failed writes before counting the records as lost. A record that cannot be
+// encoded is lost immediately, without holding back the others.
+func (mt *MonetizationTracker) persistRecords(records []DecisionRecord) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoded := 0
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			mt.persistFailures.Add(1)
+			log.Printf("Error persisting monetization record %s: failed to encode: %v", record.DecisionID, err)
+			continue
+		}
+		encoded++
+	}
+	if encoded == 0 {
+		return fmt.Errorf("failed to encode %d monetization records", len(records))
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := mt.writeRecords(buf.Bytes())
+		if err == nil {
+			mt.persisted.Add(int64(encoded))
+			return nil
+		}
+		if attempt == persistAttempts {
+			mt.persistFailures.Add(int64(encoded))
+			log.Printf("Error persisting %d monetization records after %d attempts: %v", encoded, attempt, err)
+			return err
+		}
+		mt.persistRetries.Add(1)
+		time.Sleep(time.Duration(attempt) * persistRetryDelay)
+	}
+}
+
+// writeRecords appends encoded records to the output file.
+func (mt *MonetizationTracker) writeRecords(data []byte) error {
 	file, err := os.OpenFile(mt.outputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		log.Printf("Error opening monetization file: %v", err)
-		return
+		return fmt.Errorf("failed to open monetization file: %w", err)
 	}
-	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	if err := encoder.Encode(record); err != nil {
-		log.Printf("Error writing monetization record: %v", err)
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write monetization records: %w", err)
 	}
+	return file.Close()
 }
 
 // DefaultConfig returns a default monetization configuration.
@@ -161,5 +690,7 @@ func DefaultConfig() Config {
 		BasePrice:            0.001, // $0.001 base price per decision
 		ComplexityMultiplier: 0.1,   // 10% price increase per complexity unit
 		OutputFile:           "pov_records.jsonl",
+		Currency:             DefaultCurrency,
+		RoundingDP:           DefaultRoundingDP,
 	}
 }
\ No newline at end of file
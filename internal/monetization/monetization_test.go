@@ -3,8 +3,13 @@ package monetization
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
+	"math"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -32,7 +37,7 @@ func TestMonetizationTracker_RecordDecision(t *testing.T) {
 	processingNS := int64(150000) // 150 microseconds
 	zScore := 3.24
 
-	tracker.RecordDecision(decisionID, value, processingNS, zScore)
+	tracker.RecordDecision(decisionID, value, processingNS, zScore, true)
 
 	// Check stats
 	stats := tracker.GetStats()
@@ -119,10 +124,11 @@ func TestMonetizationTracker_GetAverageLatency(t *testing.T) {
 			float64(i),
 			latency,
 			1.0,
+			false,
 		)
 	}
 
-	expectedAvg := (100000 + 200000 + 300000) / 3
+	expectedAvg := int64(100000+200000+300000) / 3
 	avgLatency = tracker.GetAverageLatency()
 	if avgLatency != expectedAvg {
 		t.Errorf("Expected average latency %d, got %d", expectedAvg, avgLatency)
@@ -145,11 +151,10 @@ func TestMonetizationTracker_GetAnomalyRate(t *testing.T) {
 	}
 
 	// Record decisions with mixed anomaly status
-	// Note: Simplified anomaly detection for testing (zScore > 0)
-	tracker.RecordDecision("normal-1", 1.0, 100000, 0.5)  // Not anomaly
-	tracker.RecordDecision("normal-2", 2.0, 100000, 1.0)  // Not anomaly
-	tracker.RecordDecision("anomaly-1", 3.0, 100000, 2.0) // Anomaly
-	tracker.RecordDecision("anomaly-2", 4.0, 100000, 3.0) // Anomaly
+	tracker.RecordDecision("normal-1", 1.0, 100000, 0.5, false)
+	tracker.RecordDecision("normal-2", 2.0, 100000, 1.0, false)
+	tracker.RecordDecision("anomaly-1", 3.0, 100000, 2.0, true)
+	tracker.RecordDecision("anomaly-2", 4.0, 100000, 3.0, true)
 
 	rate = tracker.GetAnomalyRate()
 	expectedRate := 50.0 // 2 out of 4 decisions are anomalies
@@ -158,6 +163,74 @@ func TestMonetizationTracker_GetAnomalyRate(t *testing.T) {
 	}
 }
 
+func TestMonetizationTracker_WindowedAnomalyRate(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tracker := NewTracker(Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           filepath.Join(t.TempDir(), "pov.jsonl"),
+		MaxRecords:           10,
+		AnomalyRateRecords:   4,
+		AnomalyRateWindow:    time.Minute,
+	})
+	defer tracker.Flush()
+	tracker.now = func() time.Time { return now }
+
+	// An anomalous burst followed by recent normal decisions
+	for i := 0; i < 6; i++ {
+		tracker.RecordDecision(fmt.Sprintf("anomaly-%d", i), 1, 1000, 4.0, true)
+	}
+	for i := 0; i < 4; i++ {
+		tracker.RecordDecision(fmt.Sprintf("normal-%d", i), 1, 1000, 0.5, false)
+	}
+	if rate := tracker.GetAnomalyRate(); rate != 60.0 {
+		t.Errorf("Expected an all-time anomaly rate of 60.00, got %.2f", rate)
+	}
+	if rate := tracker.GetWindowedAnomalyRate(); rate != 0.0 {
+		t.Errorf("Expected a windowed anomaly rate of 0.00 over the last 4 decisions, got %.2f", rate)
+	}
+
+	// Eviction keeps the all-time rate over the retained records
+	for i := 4; i < 8; i++ {
+		tracker.RecordDecision(fmt.Sprintf("normal-%d", i), 1, 1000, 0.5, false)
+	}
+	if rate := tracker.GetAnomalyRate(); rate != 20.0 {
+		t.Errorf("Expected an all-time anomaly rate of 20.00 after eviction, got %.2f", rate)
+	}
+
+	// Only decisions within the window count
+	now = now.Add(30 * time.Second)
+	tracker.RecordDecision("anomaly-late", 1, 1000, 4.0, true)
+	now = now.Add(45 * time.Second)
+	if rate := tracker.GetWindowedAnomalyRate(); rate != 100.0 {
+		t.Errorf("Expected a windowed anomaly rate of 100.00 over the last minute, got %.2f", rate)
+	}
+	now = now.Add(time.Hour)
+	if rate := tracker.GetWindowedAnomalyRate(); rate != 0.0 {
+		t.Errorf("Expected a windowed anomaly rate of 0.00 with no recent decisions, got %.2f", rate)
+	}
+	if stats := tracker.GetStats(); stats["anomaly_rate_pct"] != 20.0 || stats["windowed_anomaly_rate_pct"] != 0.0 {
+		t.Errorf("Expected all-time and windowed anomaly rates of 20.00 and 0.00 in the stats, got %v", stats)
+	}
+}
+
+func TestMonetizationTracker_RecordDecisionByZScore(t *testing.T) {
+	tracker := NewTracker(Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           filepath.Join(t.TempDir(), "pov.jsonl"),
+	})
+	defer tracker.Flush()
+
+	// The deprecated heuristic flags any positive z-score
+	tracker.RecordDecisionByZScore("zero", 1.0, 100000, 0)
+	tracker.RecordDecisionByZScore("positive", 2.0, 100000, 0.5)
+
+	if rate := tracker.GetAnomalyRate(); rate != 50.0 {
+		t.Errorf("Expected anomaly rate 50.00, got %.2f", rate)
+	}
+}
+
 func TestMonetizationTracker_Persistence(t *testing.T) {
 	// Create temporary file for testing
 	tmpFile, err := os.CreateTemp("", "pov_test_*.jsonl")
@@ -180,7 +253,7 @@ func TestMonetizationTracker_Persistence(t *testing.T) {
 	processingNS := int64(150000)
 	zScore := 3.24
 
-	tracker.RecordDecision(decisionID, value, processingNS, zScore)
+	tracker.RecordDecision(decisionID, value, processingNS, zScore, true)
 
 	// Give some time for async persistence
 	time.Sleep(100 * time.Millisecond)
@@ -236,4 +309,321 @@ func TestDefaultConfig(t *testing.T) {
 var (
 	// Use a non-existent file path for testing
 	testOutputFile = "/nonexistent/path/test_pov.jsonl"
-)
\ No newline at end of file
+)
+// recomputeTotal sums the price of every retained record, as GetTotalValue once did.
+func recomputeTotal(tracker *MonetizationTracker) float64 {
+	tracker.mu.RLock()
+	defer tracker.mu.RUnlock()
+
+	total := 0.0
+	for _, record := range tracker.records {
+		total += tracker.CalculatePrice(record.ProcessingNS, record.ZScore)
+	}
+	return total
+}
+
+func TestMonetizationTracker_RunningTotalWithEviction(t *testing.T) {
+	tracker := NewTracker(Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           filepath.Join(t.TempDir(), "pov.jsonl"),
+		MaxRecords:           100,
+	})
+	defer tracker.Flush()
+
+	for i := 0; i < 250; i++ {
+		tracker.RecordDecision(fmt.Sprintf("decision-%d", i), float64(i), int64(1000*(i%17)), float64(i%7), i%7 > 3)
+	}
+
+	if len(tracker.records) != 100 {
+		t.Fatalf("Expected 100 retained records, got %d", len(tracker.records))
+	}
+	if tracker.records[0].DecisionID != "decision-150" {
+		t.Errorf("Expected the oldest records evicted first, oldest is %s", tracker.records[0].DecisionID)
+	}
+
+	expected := recomputeTotal(tracker)
+	if total := tracker.GetTotalValue(); math.Abs(total-expected) > 1e-12 {
+		t.Errorf("Running total %.15f does not match recomputed %.15f", total, expected)
+	}
+}
+
+// newBenchmarkTracker returns a tracker holding n records without logging each one.
+func newBenchmarkTracker(n int) *MonetizationTracker {
+	tracker := NewTracker(DefaultConfig())
+	for i := 0; i < n; i++ {
+		record := DecisionRecord{ProcessingNS: int64(1000 * (i % 17)), ZScore: float64(i % 7)}
+		record.Price = tracker.CalculatePrice(record.ProcessingNS, record.ZScore)
+		tracker.records = append(tracker.records, record)
+		tracker.totalMinor += tracker.toMinor(record.Price)
+	}
+	return tracker
+}
+
+func BenchmarkMonetizationTracker_GetTotalValue(b *testing.B) {
+	tracker := newBenchmarkTracker(100000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tracker.GetTotalValue()
+	}
+}
+
+// BenchmarkMonetizationTracker_RecomputeTotal is the previous O(n) implementation, for comparison.
+func BenchmarkMonetizationTracker_RecomputeTotal(b *testing.B) {
+	tracker := newBenchmarkTracker(100000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		recomputeTotal(tracker)
+	}
+}
+
+func TestMonetizationTracker_NonFinitePriceUsesFallback(t *testing.T) {
+	tracker := NewTracker(Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           filepath.Join(t.TempDir(), "pov.jsonl"),
+		FallbackPrice:        0.005,
+	})
+	var fallbacks []string
+	tracker.SetPriceFallbackHandler(func(decisionID string, processingNS int64, zScore float64) {
+		fallbacks = append(fallbacks, decisionID)
+	})
+
+	if price := tracker.CalculatePrice(1000, math.NaN()); price != 0.005 {
+		t.Errorf("Expected the fallback price for a NaN z-score, got %v", price)
+	}
+	if price := tracker.CalculatePrice(1000, math.Inf(1)); price != 0.005 {
+		t.Errorf("Expected the fallback price for an infinite z-score, got %v", price)
+	}
+
+	tracker.RecordDecision("nan-decision", 1, 1000, math.NaN(), false)
+	tracker.RecordDecision("normal-decision", 1, 1000, 1.0, false)
+	tracker.Flush()
+
+	want := 0.005 + tracker.CalculatePrice(1000, 1.0)
+	if total := tracker.GetTotalValue(); math.IsNaN(total) || math.Abs(total-want) > 1e-12 {
+		t.Errorf("Expected a finite total of %v, got %v", want, total)
+	}
+	if len(fallbacks) != 1 || fallbacks[0] != "nan-decision" {
+		t.Errorf("Expected only the NaN decision to be reported, got %v", fallbacks)
+	}
+	if stats := tracker.GetStats(); stats["price_fallbacks"] != int64(1) {
+		t.Errorf("Expected 1 price fallback, got %v", stats["price_fallbacks"])
+	}
+
+	// Without a configured fallback the base price is billed
+	if price := NewTracker(Config{BasePrice: 0.001, ComplexityMultiplier: 0.1}).CalculatePrice(0, math.NaN()); price != 0.001 {
+		t.Errorf("Expected the base price as the default fallback, got %v", price)
+	}
+}
+
+func TestMonetizationTracker_WarmupDecisions(t *testing.T) {
+	tracker := NewTracker(Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           filepath.Join(t.TempDir(), "pov.jsonl"),
+		MaxRecords:           2,
+		WarmupPricing:        true,
+		WarmupPrice:          0.0002,
+	})
+
+	tracker.RecordWarmupDecision("warmup-1", "", 10, 1000, 0, false, nil)
+	tracker.RecordDecision("scored-1", 10, 1000, 1.0, false)
+	tracker.Flush()
+
+	if !tracker.records[0].Warmup || tracker.records[1].Warmup {
+		t.Errorf("Expected only the warm-up decision tagged, got %+v", tracker.records)
+	}
+	if price := tracker.CalculateWarmupPrice(1000, 0); price != 0.0002 {
+		t.Errorf("Expected the warm-up price, got %v", price)
+	}
+	want := 0.0002 + tracker.CalculatePrice(1000, 1.0)
+	if total := tracker.GetTotalValue(); math.Abs(total-want) > 1e-12 {
+		t.Errorf("Expected a total of %v, got %v", want, total)
+	}
+
+	// Evicting the warm-up record removes its warm-up price from the total
+	tracker.RecordDecision("scored-2", 10, 1000, 1.0, false)
+	want = 2 * tracker.CalculatePrice(1000, 1.0)
+	if total := tracker.GetTotalValue(); math.Abs(total-want) > 1e-12 {
+		t.Errorf("Expected a total of %v after eviction, got %v", want, total)
+	}
+	if stats := tracker.GetStats(); stats["warmup_decisions"] != int64(1) {
+		t.Errorf("Expected 1 warm-up decision, got %v", stats["warmup_decisions"])
+	}
+
+	// Without the policy warm-up decisions are tagged but billed normally
+	standard := NewTracker(Config{BasePrice: 0.001, ComplexityMultiplier: 0.1})
+	if price := standard.CalculateWarmupPrice(1000, 0); price != standard.CalculatePrice(1000, 0) {
+		t.Errorf("Expected the computed price without warm-up pricing, got %v", price)
+	}
+}
+
+func TestMonetizationTracker_CloseFlushesEveryRecord(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "pov.jsonl")
+	tracker := NewTracker(Config{
+		BasePrice:            0.001,
+		ComplexityMultiplier: 0.1,
+		OutputFile:           outputFile,
+	})
+
+	const n = 500
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < n/4; i++ {
+				tracker.RecordDecision(fmt.Sprintf("decision-%d-%d", w, i), 1, 1000, 1.0, false)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if err := tracker.Close(); err != nil {
+		t.Fatalf("Unexpected error closing tracker: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != n {
+		t.Fatalf("Expected %d records written, got %d", n, len(lines))
+	}
+	for _, line := range lines {
+		var record DecisionRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Expected whole JSON lines, got %q: %v", line, err)
+		}
+	}
+
+	// A record made after Close is still written
+	tracker.RecordDecision("late", 1, 1000, 1.0, false)
+	if stats := tracker.PersistenceStats(); stats.Persisted != n+1 {
+		t.Errorf("Expected %d records persisted, got %+v", n+1, stats)
+	}
+}
+
+func TestMonetizationTracker_RoundedTotalDoesNotDrift(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	tracker := NewTracker(Config{
+		BasePrice:  0.00123456789,
+		OutputFile: filepath.Join(t.TempDir(), "pov.jsonl"),
+		Currency:   "EUR",
+		RoundingDP: 6,
+	})
+
+	price := tracker.CalculatePrice(0, 0)
+	if price != 0.001235 {
+		t.Fatalf("Expected the price rounded to 6 decimal places, got %v", price)
+	}
+
+	const n = 100000
+	drifting := 0.0
+	for i := 0; i < n; i++ {
+		tracker.RecordDecision(fmt.Sprintf("decision-%d", i), 1, 0, 0, false)
+		drifting += price
+	}
+	if err := tracker.Close(); err != nil {
+		t.Fatalf("Unexpected error closing tracker: %v", err)
+	}
+
+	// 100k charges of 1235 millionths sum to exactly 123.5
+	if total := tracker.GetTotalValue(); total != 123.5 {
+		t.Errorf("Expected a total of exactly 123.5, got %.12f (a float sum gives %.12f)", total, drifting)
+	}
+	if tracker.records[0].Currency != "EUR" || tracker.GetStats()["currency"] != "EUR" {
+		t.Errorf("Expected the currency recorded, got %q", tracker.records[0].Currency)
+	}
+}
+
+func TestMonetizationTracker_GetRollup(t *testing.T) {
+	tracker := NewTracker(Config{BasePrice: 0.001})
+	hour := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	for _, record := range []DecisionRecord{
+		{Timestamp: hour.Add(-time.Minute), Price: 0.5}, // Before since
+		{Timestamp: hour.Add(5 * time.Minute), Price: 0.001, IsAnomaly: true},
+		{Timestamp: hour.Add(59 * time.Minute), Price: 0.002},
+		{Timestamp: hour.Add(3*time.Hour + time.Second), Price: 0.004, IsAnomaly: true},
+	} {
+		tracker.records = append(tracker.records, record)
+	}
+
+	rollup := tracker.GetRollup(time.Hour, hour)
+	if len(rollup) != 2 {
+		t.Fatalf("Expected 2 hours with decisions, got %+v", rollup)
+	}
+	first := rollup[0]
+	if !first.Start.Equal(hour) || !first.End.Equal(hour.Add(time.Hour)) {
+		t.Errorf("Expected the first period to span 10:00-11:00, got %v-%v", first.Start, first.End)
+	}
+	if first.DecisionCount != 2 || first.AnomalyCount != 1 || first.Revenue != 0.003 {
+		t.Errorf("Expected 2 decisions, 1 anomaly and 0.003 revenue, got %+v", first)
+	}
+	if second := rollup[1]; !second.Start.Equal(hour.Add(3*time.Hour)) || second.DecisionCount != 1 || second.Revenue != 0.004 {
+		t.Errorf("Expected one 0.004 decision at 13:00, got %+v", second)
+	}
+
+	daily := tracker.GetRollup(24*time.Hour, time.Time{})
+	if len(daily) != 1 || daily[0].DecisionCount != 4 || daily[0].Revenue != 0.507 {
+		t.Errorf("Expected one day of 4 decisions and 0.507 revenue, got %+v", daily)
+	}
+	if tracker.GetRollup(0, hour) != nil {
+		t.Error("Expected no rollup for a non-positive period")
+	}
+}
+
+func TestMonetizationTracker_RecordDecisionIdempotent(t *testing.T) {
+	tracker := NewTracker(Config{
+		BasePrice:   0.001,
+		OutputFile:  filepath.Join(t.TempDir(), "pov.jsonl"),
+		DedupWindow: 2,
+	})
+
+	if !tracker.RecordDecisionIdempotent("TS-1", 1, 1000, 1.0, false) {
+		t.Fatal("Expected the first decision to be recorded")
+	}
+	if tracker.RecordDecisionIdempotent("TS-1", 1, 1000, 1.0, false) {
+		t.Error("Expected the retried decision to be skipped")
+	}
+	tracker.RecordDecision("TS-1", 1, 1000, 1.0, false) // RecordDecision is idempotent too
+	if total, want := tracker.GetTotalValue(), tracker.CalculatePrice(1000, 1.0); total != want {
+		t.Errorf("Expected the decision billed once at %v, got %v", want, total)
+	}
+
+	// A full window forgets its oldest ID
+	tracker.RecordDecisionIdempotent("TS-2", 1, 1000, 1.0, false)
+	tracker.RecordDecisionIdempotent("TS-3", 1, 1000, 1.0, false)
+	if !tracker.RecordDecisionIdempotent("TS-1", 1, 1000, 1.0, false) {
+		t.Error("Expected TS-1 to be recorded again once evicted from the window")
+	}
+	if tracker.RecordDecisionIdempotent("TS-3", 1, 1000, 1.0, false) {
+		t.Error("Expected TS-3 to still be in the window")
+	}
+
+	if err := tracker.Close(); err != nil {
+		t.Fatalf("Unexpected error closing tracker: %v", err)
+	}
+	stats := tracker.GetStats()
+	if stats["total_decisions"] != 4 || stats["duplicate_decisions"] != int64(3) {
+		t.Errorf("Expected 4 decisions recorded and 3 duplicates, got %v and %v", stats["total_decisions"], stats["duplicate_decisions"])
+	}
+	if persisted := tracker.PersistenceStats().Persisted; persisted != 4 {
+		t.Errorf("Expected only recorded decisions persisted, got %d", persisted)
+	}
+}
+
+func TestMonetizationTracker_DedupDisabledByDefault(t *testing.T) {
+	tracker := NewTracker(Config{BasePrice: 0.001, OutputFile: filepath.Join(t.TempDir(), "pov.jsonl")})
+	for i := 0; i < 2; i++ {
+		if !tracker.RecordDecisionIdempotent("TS-1", 1, 1000, 1.0, false) {
+			t.Fatal("Expected every decision recorded without a dedup window")
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package monetization
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PricingTier is a volume tier of a pricing plan: decisions up to UpTo in a
+// billing period are billed at PricePerDecision.
+type PricingTier struct {
+	UpTo             int64   `json:"up_to"` // Last decision count of the period in the tier; 0 is unbounded
+	PricePerDecision float64 `json:"price_per_decision"`
+}
+
+// ParseTiers parses a comma-separated list of upTo:price tiers such as
+// "1000:0.001,10000:0.0008,0:0.0005", an upTo of 0 being unbounded. The tiers
+// are returned sorted by UpTo, the unbounded tier last.
+func ParseTiers(s string) ([]PricingTier, error) {
+	var tiers []PricingTier
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		upTo, price, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid pricing tier %q: expected upTo:price", entry)
+		}
+		tier := PricingTier{}
+		var err error
+		if tier.UpTo, err = strconv.ParseInt(strings.TrimSpace(upTo), 10, 64); err != nil || tier.UpTo < 0 {
+			return nil, fmt.Errorf("invalid pricing tier %q: upTo must be a non-negative integer", entry)
+		}
+		if tier.PricePerDecision, err = strconv.ParseFloat(strings.TrimSpace(price), 64); err != nil || tier.PricePerDecision < 0 {
+			return nil, fmt.Errorf("invalid pricing tier %q: price must be a non-negative number", entry)
+		}
+		tiers = append(tiers, tier)
+	}
+	return sortTiers(tiers)
+}
+
+// sortTiers returns a sorted copy of tiers, rejecting duplicate bounds.
+func sortTiers(tiers []PricingTier) ([]PricingTier, error) {
+	sorted := append([]PricingTier(nil), tiers...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].UpTo == 0 || sorted[j].UpTo == 0 {
+			return sorted[j].UpTo == 0 && sorted[i].UpTo != 0
+		}
+		return sorted[i].UpTo < sorted[j].UpTo
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].UpTo == sorted[i-1].UpTo {
+			return nil, fmt.Errorf("duplicate pricing tier bound %d", sorted[i].UpTo)
+		}
+	}
+	return sorted, nil
+}
+
+// tierFor returns the tier of the n-th decision of a billing period: the
+// first tier whose bound is at least n, else the last tier.
+func tierFor(tiers []PricingTier, n int64) PricingTier {
+	for _, tier := range tiers {
+		if tier.UpTo == 0 || n <= tier.UpTo {
+			return tier
+		}
+	}
+	return tiers[len(tiers)-1]
+}
+
+// CalculateTieredPrice returns the price of the next decision under the
+// pricing plan: the price of the tier its count within the current billing
+// period falls in. Without tiers it is CalculatePrice.
+func (mt *MonetizationTracker) CalculateTieredPrice(processingNS int64, zScore float64) float64 {
+	mt.mu.RLock()
+	defer mt.mu.RUnlock()
+
+	if len(mt.tiers) == 0 {
+		return mt.CalculatePrice(processingNS, zScore)
+	}
+	return mt.round(tierFor(mt.tiers, mt.decisionsInPeriod(mt.now())+1).PricePerDecision)
+}
+
+// periodStartOf returns the start of the billing period containing t: the
+// first of its month in UTC, or a multiple of billingPeriod since the zero time.
+func (mt *MonetizationTracker) periodStartOf(t time.Time) time.Time {
+	if mt.billingPeriod <= 0 {
+		t = t.UTC()
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return t.Truncate(mt.billingPeriod)
+}
+
+// decisionsInPeriod returns the decisions recorded so far in the billing
+// period containing now. Must be called with mt.mu held.
+func (mt *MonetizationTracker) decisionsInPeriod(now time.Time) int64 {
+	if !mt.periodStartOf(now).Equal(mt.periodStart) {
+		return 0 // The period rolled over since the last decision
+	}
+	return mt.periodDecisions
+}
+
+// countInPeriod counts a decision recorded at now, starting a new billing
+// period when now is past the current one. Must be called with mt.mu held.
+func (mt *MonetizationTracker) countInPeriod(now time.Time) {
+	if start := mt.periodStartOf(now); !start.Equal(mt.periodStart) {
+		mt.periodStart = start
+		mt.periodDecisions = 0
+	}
+	mt.periodDecisions++
+}
@@ -0,0 +1,99 @@
+package monetization
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTieredTracker returns a tracker billing 3 decisions at 0.01, up to 5 at
+// 0.005 and the rest at 0.001 per day, driven by a manual clock.
+func newTieredTracker(t *testing.T) (*MonetizationTracker, *time.Time) {
+	t.Helper()
+	tiers, err := ParseTiers("0:0.001, 3:0.01, 5:0.005")
+	if err != nil {
+		t.Fatalf("Failed to parse tiers: %v", err)
+	}
+	tracker := NewTracker(Config{
+		BasePrice:     0.1,
+		OutputFile:    filepath.Join(t.TempDir(), "pov.jsonl"),
+		Tiers:         tiers,
+		BillingPeriod: 24 * time.Hour,
+	})
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return clock }
+	return tracker, &clock
+}
+
+func TestMonetizationTracker_TierBoundaries(t *testing.T) {
+	tracker, _ := newTieredTracker(t)
+
+	want := []float64{0.01, 0.01, 0.01, 0.005, 0.005, 0.001, 0.001}
+	for i, expected := range want {
+		if next := tracker.CalculateTieredPrice(1000, 1.0); next != expected {
+			t.Errorf("Decision %d: expected a quoted price of %v, got %v", i+1, expected, next)
+		}
+		price, recorded := tracker.RecordDecisionWithCorrelation(fmt.Sprintf("decision-%d", i), "", 1, 1000, 1.0, false, nil)
+		if !recorded || price != expected {
+			t.Errorf("Decision %d: expected to be billed %v, got %v", i+1, expected, price)
+		}
+	}
+	if total := tracker.GetTotalValue(); total != 0.042 {
+		t.Errorf("Expected a total of 0.042, got %v", total)
+	}
+	if stats := tracker.GetStats(); stats["period_decisions"] != int64(7) {
+		t.Errorf("Expected 7 decisions in the period, got %v", stats["period_decisions"])
+	}
+	tracker.Close()
+}
+
+func TestMonetizationTracker_TierCountResetsAtPeriodRollover(t *testing.T) {
+	tracker, clock := newTieredTracker(t)
+
+	for i := 0; i < 4; i++ {
+		tracker.RecordDecision(fmt.Sprintf("day1-%d", i), 1, 1000, 1.0, false)
+	}
+	if next := tracker.CalculateTieredPrice(1000, 1.0); next != 0.005 {
+		t.Fatalf("Expected the second tier on day 1, got %v", next)
+	}
+
+	// Past midnight the next decision is the first of a new period
+	*clock = clock.Add(12 * time.Hour)
+	if next := tracker.CalculateTieredPrice(1000, 1.0); next != 0.01 {
+		t.Errorf("Expected the first tier once the period rolled over, got %v", next)
+	}
+	price, _ := tracker.RecordDecisionWithCorrelation("day2-0", "", 1, 1000, 1.0, false, nil)
+	if price != 0.01 {
+		t.Errorf("Expected the first decision of day 2 billed at 0.01, got %v", price)
+	}
+	if stats := tracker.GetStats(); stats["period_decisions"] != int64(1) {
+		t.Errorf("Expected 1 decision in the new period, got %v", stats["period_decisions"])
+	}
+	tracker.Close()
+}
+
+func TestMonetizationTracker_NoTiersUsesFormula(t *testing.T) {
+	tracker := NewTracker(Config{BasePrice: 0.001, ComplexityMultiplier: 0.1, OutputFile: filepath.Join(t.TempDir(), "pov.jsonl")})
+	if tiered, formula := tracker.CalculateTieredPrice(1000, 2.0), tracker.CalculatePrice(1000, 2.0); tiered != formula {
+		t.Errorf("Expected the price formula without tiers, got %v instead of %v", tiered, formula)
+	}
+}
+
+func TestParseTiers(t *testing.T) {
+	tiers, err := ParseTiers("10000:0.0008,0:0.0005,1000:0.001")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tiers) != 3 || tiers[0].UpTo != 1000 || tiers[1].UpTo != 10000 || tiers[2].UpTo != 0 {
+		t.Errorf("Expected tiers sorted with the unbounded tier last, got %+v", tiers)
+	}
+	if tiers, err := ParseTiers(""); err != nil || len(tiers) != 0 {
+		t.Errorf("Expected no tiers from an empty plan, got %v, %v", tiers, err)
+	}
+	for _, plan := range []string{"1000", "x:0.001", "1000:-1", "-5:0.001", "1000:0.001,1000:0.002"} {
+		if _, err := ParseTiers(plan); err == nil {
+			t.Errorf("Expected %q to be rejected", plan)
+		}
+	}
+}
@@ -1,7 +1,7 @@
 package ratelimit
 
 import (
-	"fmt"
+	"math"
 	"sync"
 	"time"
 )
@@ -10,7 +10,7 @@ import (
 type TokenBucket struct {
 	mu        sync.Mutex
 	capacity  int64
-	tokens    int64
+	tokens    float64 // Fractional so sub-second refills are not lost to truncation
 	refillRate int64 // tokens per second
 	lastRefill time.Time
 }
@@ -19,7 +19,7 @@ type TokenBucket struct {
 func NewTokenBucket(capacity int64, refillRate int64) *TokenBucket {
 	return &TokenBucket{
 		capacity:   capacity,
-		tokens:     capacity,
+		tokens:     float64(capacity),
 		refillRate: refillRate,
 		lastRefill: time.Now(),
 	}
@@ -31,23 +31,42 @@ func (tb *TokenBucket) Allow() bool {
 	defer tb.mu.Unlock()
 
 	tb.refill()
-	if tb.tokens > 0 {
+	if tb.tokens >= 1 {
 		tb.tokens--
 		return true
 	}
 	return false
 }
 
+// AllowWithRetryAfter is Allow that also reports, when denied, how long until
+// at least one token will be available at the current refill rate.
+// retryAfter is zero when allowed or when the bucket never refills.
+func (tb *TokenBucket) AllowWithRetryAfter() (allowed bool, retryAfter time.Duration) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill()
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return true, 0
+	}
+	if tb.refillRate <= 0 {
+		return false, 0
+	}
+
+	missing := 1 - tb.tokens
+	return false, time.Duration(math.Ceil(missing * float64(time.Second) / float64(tb.refillRate)))
+}
+
 // refill adds tokens to the bucket based on elapsed time.
+// Partial tokens accumulate across calls, so frequent requests still refill.
 func (tb *TokenBucket) refill() {
 	now := time.Now()
 	elapsed := now.Sub(tb.lastRefill)
-	tokensToAdd := int64(elapsed.Seconds()) * tb.refillRate
+	tb.lastRefill = now
 
-	if tokensToAdd > 0 {
-		tb.tokens = min(tb.capacity, tb.tokens+tokensToAdd)
-		tb.lastRefill = now
-	}
+	tokensToAdd := float64(elapsed.Nanoseconds()) * float64(tb.refillRate) / float64(time.Second)
+	tb.tokens = math.Min(float64(tb.capacity), tb.tokens+tokensToAdd)
 }
 
 // GetTokens returns the current number of available tokens.
@@ -55,7 +74,7 @@ func (tb *TokenBucket) GetTokens() int64 {
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
 	tb.refill()
-	return tb.tokens
+	return int64(tb.tokens)
 }
 
 // RateLimiter provides HTTP middleware for rate limiting.
@@ -101,10 +120,10 @@ func (rl *RateLimiter) Allow() bool {
 	return rl.bucket.Allow()
 }
 
-// min returns the minimum of two int64 values.
-func min(a, b int64) int64 {
-	if a < b {
-		return a
+// AllowWithRetryAfter is Allow that also reports how long a denied caller should wait.
+func (rl *RateLimiter) AllowWithRetryAfter() (bool, time.Duration) {
+	if rl == nil || rl.bucket == nil {
+		return true, 0 // Allow if rate limiter is not configured
 	}
-	return b
-}
\ No newline at end of file
+	return rl.bucket.AllowWithRetryAfter()
+}
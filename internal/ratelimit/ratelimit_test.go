@@ -0,0 +1,165 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_FractionalRefill(t *testing.T) {
+	tb := NewTokenBucket(1000, 1000)
+
+	// Drain the bucket
+	for tb.Allow() {
+	}
+	if tokens := tb.GetTokens(); tokens != 0 {
+		t.Fatalf("Expected drained bucket, got %d tokens", tokens)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	// ~100 tokens at 1000/s; allow for scheduler jitter
+	tokens := tb.GetTokens()
+	if tokens < 90 || tokens > 130 {
+		t.Errorf("Expected ~100 tokens after 100ms, got %d", tokens)
+	}
+}
+
+func TestTokenBucket_FrequentCallsStillRefill(t *testing.T) {
+	tb := NewTokenBucket(10, 1000)
+	for tb.Allow() {
+	}
+
+	// Poll every millisecond: each call sees ~1 token of elapsed time,
+	// which integer-second truncation would drop entirely
+	allowed := 0
+	deadline := time.Now().Add(50 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if tb.Allow() {
+			allowed++
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if allowed == 0 {
+		t.Error("Expected requests to be allowed as fractional tokens accumulate")
+	}
+}
+
+func TestTokenBucket_CapsAtCapacity(t *testing.T) {
+	tb := NewTokenBucket(5, 1000)
+	time.Sleep(20 * time.Millisecond)
+
+	if tokens := tb.GetTokens(); tokens != 5 {
+		t.Errorf("Expected tokens capped at capacity 5, got %d", tokens)
+	}
+}
+
+func TestTokenBucket_AllowWithRetryAfter(t *testing.T) {
+	tb := NewTokenBucket(2, 4) // One token every 250ms
+
+	for i := 0; i < 2; i++ {
+		if allowed, retryAfter := tb.AllowWithRetryAfter(); !allowed || retryAfter != 0 {
+			t.Fatalf("Expected request %d allowed with no wait, got %v/%s", i, allowed, retryAfter)
+		}
+	}
+
+	allowed, retryAfter := tb.AllowWithRetryAfter()
+	if allowed {
+		t.Fatal("Expected drained bucket to deny")
+	}
+	if retryAfter <= 200*time.Millisecond || retryAfter > 250*time.Millisecond {
+		t.Errorf("Expected retry after ~250ms, got %s", retryAfter)
+	}
+
+	time.Sleep(retryAfter)
+	if allowed, _ := tb.AllowWithRetryAfter(); !allowed {
+		t.Error("Expected a token to be available after waiting retryAfter")
+	}
+}
+
+func TestRateLimiter_AllowWithRetryAfterUnconfigured(t *testing.T) {
+	var rl *RateLimiter
+	if allowed, retryAfter := rl.AllowWithRetryAfter(); !allowed || retryAfter != 0 {
+		t.Errorf("Expected nil limiter to allow, got %v/%s", allowed, retryAfter)
+	}
+
+	rl = NewRateLimiter(0, 1)
+	rl.AllowWithRetryAfter()
+	if allowed, retryAfter := rl.AllowWithRetryAfter(); allowed || retryAfter != 0 {
+		t.Errorf("Expected non-refilling bucket to deny without a retry hint, got %v/%s", allowed, retryAfter)
+	}
+}
+
+func TestLoadShedder_TracksLatency(t *testing.T) {
+	p95 := 10.0
+	shedder, err := NewLoadShedder(ShedderConfig{
+		SLOMS:          50,
+		StartRatio:     0.6,
+		MaxProbability: 0.9,
+		Curve:          CurveLinear,
+	}, func() float64 { return p95 })
+	if err != nil {
+		t.Fatalf("Failed to create shedder: %v", err)
+	}
+
+	shedRate := func() float64 {
+		shed := 0
+		for i := 0; i < 2000; i++ {
+			if !shedder.Allow() {
+				shed++
+			}
+		}
+		return float64(shed) / 2000
+	}
+
+	// Latency rising towards the SLO sheds progressively more traffic
+	previous := -1.0
+	for _, latency := range []float64{10, 35, 42, 48, 60} {
+		p95 = latency
+		rate := shedRate()
+		if rate < previous {
+			t.Errorf("Expected shedding to increase at P95 %.0fms, got %.3f after %.3f", latency, rate, previous)
+		}
+		previous = rate
+	}
+	if previous < 0.8 {
+		t.Errorf("Expected near-maximum shedding past the SLO, got %.3f", previous)
+	}
+
+	// Recovery below the start point stops shedding entirely
+	p95 = 20
+	if rate := shedRate(); rate != 0 {
+		t.Errorf("Expected no shedding after recovery, got %.3f", rate)
+	}
+}
+
+func TestLoadShedder_Curves(t *testing.T) {
+	config := ShedderConfig{SLOMS: 100, StartRatio: 0.5, MaxProbability: 1}
+	cases := map[string][3]float64{
+		CurveLinear:    {0, 0.5, 1},
+		CurveQuadratic: {0, 0.25, 1},
+		CurveStep:      {0, 0, 1},
+	}
+	for curve, want := range cases {
+		config.Curve = curve
+		shedder, err := NewLoadShedder(config, nil)
+		if err != nil {
+			t.Fatalf("Failed to create %s shedder: %v", curve, err)
+		}
+		for i, p95 := range []float64{50, 75, 120} {
+			if got := shedder.ShedProbability(p95); got != want[i] {
+				t.Errorf("%s curve at %.0fms: expected %.2f, got %.2f", curve, p95, want[i], got)
+			}
+		}
+	}
+
+	config.Curve = "cubic"
+	if _, err := NewLoadShedder(config, nil); err == nil {
+		t.Error("Expected error for unknown curve")
+	}
+
+	var unconfigured *LoadShedder
+	if !unconfigured.Allow() {
+		t.Error("Expected nil shedder to allow requests")
+	}
+}
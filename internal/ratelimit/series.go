@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"container/list"
+	"math"
+	"sync"
+	"time"
+)
+
+// maxTrackedSeries bounds the series a SeriesLimiter keeps state for. Past it,
+// the least recently seen series is forgotten; if it returns it starts over
+// with a full bucket.
+const maxTrackedSeries = 10000
+
+// seriesBucket is the token bucket and shed count of one series.
+type seriesBucket struct {
+	seriesID   string
+	tokens     float64
+	lastRefill time.Time
+	shed       int64 // Points shed while the series has been tracked
+}
+
+// SeriesLimiter caps the processing rate of each series independently, so
+// one hot series is shed while the others proceed. It is separate from the
+// request-level RateLimiter.
+type SeriesLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // Points per second per series
+	burst     float64
+	buckets   map[string]*list.Element
+	lru       *list.List // Front is the most recently seen series
+	maxSeries int
+	shedTotal int64 // Points shed across all series, including forgotten ones
+	now       func() time.Time
+}
+
+// NewSeriesLimiter creates a limiter allowing each series ratePerSecond
+// points, with bursts up to burst. A non-positive burst defaults to the rate.
+func NewSeriesLimiter(ratePerSecond int64, burst int64) *SeriesLimiter {
+	if burst <= 0 {
+		burst = ratePerSecond
+	}
+	return &SeriesLimiter{
+		rate:      float64(ratePerSecond),
+		burst:     float64(burst),
+		buckets:   make(map[string]*list.Element),
+		lru:       list.New(),
+		maxSeries: maxTrackedSeries,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a point of seriesID may be processed now. When it is
+// shed, retryAfter is how long until the series has a token again.
+func (sl *SeriesLimiter) Allow(seriesID string) (allowed bool, retryAfter time.Duration) {
+	if sl == nil {
+		return true, 0 // Allow if the series limit is not configured
+	}
+
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	now := sl.now()
+	bucket := sl.acquire(seriesID, now)
+	sl.refill(bucket, now)
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	bucket.shed++
+	sl.shedTotal++
+	if sl.rate <= 0 {
+		return false, 0
+	}
+	missing := 1 - bucket.tokens
+	return false, time.Duration(math.Ceil(missing * float64(time.Second) / sl.rate))
+}
+
+// acquire returns the bucket of seriesID, creating a full one and forgetting
+// the least recently seen series past maxSeries. Must be called with sl.mu held.
+func (sl *SeriesLimiter) acquire(seriesID string, now time.Time) *seriesBucket {
+	if elem, exists := sl.buckets[seriesID]; exists {
+		sl.lru.MoveToFront(elem)
+		return elem.Value.(*seriesBucket)
+	}
+
+	bucket := &seriesBucket{seriesID: seriesID, tokens: sl.burst, lastRefill: now}
+	sl.buckets[seriesID] = sl.lru.PushFront(bucket)
+	for sl.lru.Len() > sl.maxSeries {
+		oldest := sl.lru.Back()
+		sl.lru.Remove(oldest)
+		delete(sl.buckets, oldest.Value.(*seriesBucket).seriesID)
+	}
+	return bucket
+}
+
+// refill adds the tokens bucket earned since its last refill. Must be called with sl.mu held.
+func (sl *SeriesLimiter) refill(bucket *seriesBucket, now time.Time) {
+	elapsed := now.Sub(bucket.lastRefill)
+	if elapsed <= 0 {
+		return
+	}
+	bucket.lastRefill = now
+	bucket.tokens = math.Min(sl.burst, bucket.tokens+elapsed.Seconds()*sl.rate)
+}
+
+// GetStats returns the per-series limit and the points shed. shed_total covers
+// every series; shed_by_series only the series still tracked.
+func (sl *SeriesLimiter) GetStats() map[string]interface{} {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	shed := make(map[string]int64)
+	for seriesID, elem := range sl.buckets {
+		if count := elem.Value.(*seriesBucket).shed; count > 0 {
+			shed[seriesID] = count
+		}
+	}
+	return map[string]interface{}{
+		"rate_per_series": sl.rate,
+		"burst":           sl.burst,
+		"tracked_series":  len(sl.buckets),
+		"shed_total":      sl.shedTotal,
+		"shed_by_series":  shed,
+	}
+}
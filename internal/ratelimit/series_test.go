@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSeriesLimiter_ShedsOnlyHotSeries(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	sl := NewSeriesLimiter(10, 10)
+	sl.now = func() time.Time { return now }
+
+	// Flood the hot series well past its cap within one instant
+	allowed := 0
+	var retryAfter time.Duration
+	for i := 0; i < 50; i++ {
+		ok, wait := sl.Allow("hot")
+		if ok {
+			allowed++
+		} else {
+			retryAfter = wait
+		}
+	}
+	if allowed != 10 {
+		t.Errorf("Expected the hot series capped at its burst of 10, got %d", allowed)
+	}
+	if retryAfter != 100*time.Millisecond {
+		t.Errorf("Expected a 100ms retry-after at 10/s, got %v", retryAfter)
+	}
+
+	// A series under its cap is unaffected
+	for i := 0; i < 5; i++ {
+		if ok, _ := sl.Allow("cold"); !ok {
+			t.Fatalf("Expected the cold series to be allowed, shed at point %d", i)
+		}
+	}
+
+	// The hot series recovers at its rate
+	now = now.Add(500 * time.Millisecond)
+	allowed = 0
+	for i := 0; i < 10; i++ {
+		if ok, _ := sl.Allow("hot"); ok {
+			allowed++
+		}
+	}
+	if allowed != 5 {
+		t.Errorf("Expected 5 points after 500ms at 10/s, got %d", allowed)
+	}
+
+	stats := sl.GetStats()
+	shed := stats["shed_by_series"].(map[string]int64)
+	if shed["hot"] != 45 || shed["cold"] != 0 || stats["shed_total"] != int64(45) {
+		t.Errorf("Expected 45 points shed from the hot series only, got %v", stats)
+	}
+}
+
+func TestSeriesLimiter_Unconfigured(t *testing.T) {
+	var sl *SeriesLimiter
+	if ok, _ := sl.Allow("cpu"); !ok {
+		t.Error("Expected a nil series limiter to allow every point")
+	}
+}
+
+func TestSeriesLimiter_ForgetsLeastRecentlySeenSeries(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	sl := NewSeriesLimiter(1, 1)
+	sl.now = func() time.Time { return now }
+	sl.maxSeries = 2
+
+	// Shed a point of a and b, then keep a recent
+	for _, seriesID := range []string{"a", "a", "b", "b", "a"} {
+		sl.Allow(seriesID)
+	}
+
+	// A third series evicts b, the least recently seen, with its shed count
+	if ok, _ := sl.Allow("c"); !ok {
+		t.Fatal("Expected a new series to be allowed")
+	}
+	stats := sl.GetStats()
+	shed := stats["shed_by_series"].(map[string]int64)
+	if stats["tracked_series"] != 2 || len(shed) != 1 || shed["a"] != 2 {
+		t.Errorf("Expected a and c tracked with only a's 2 shed points, got %v", stats)
+	}
+	if stats["shed_total"] != int64(3) {
+		t.Errorf("Expected the shed total to keep b's point, got %v", stats["shed_total"])
+	}
+
+	// An evicted series starts over with a full bucket
+	if ok, _ := sl.Allow("b"); !ok {
+		t.Error("Expected the returning series to be allowed")
+	}
+	if _, tracked := sl.GetStats()["shed_by_series"].(map[string]int64)["a"]; tracked {
+		t.Error("Expected a evicted once b returned")
+	}
+}
+
+func TestSeriesLimiter_BoundsUniqueSeries(t *testing.T) {
+	sl := NewSeriesLimiter(0, 1)
+	sl.maxSeries = 100
+
+	// With no refill no bucket ever becomes full, and every series sheds
+	for i := 0; i < 1000; i++ {
+		seriesID := fmt.Sprintf("client-%d", i)
+		sl.Allow(seriesID)
+		sl.Allow(seriesID)
+	}
+
+	stats := sl.GetStats()
+	if stats["tracked_series"] != 100 || len(stats["shed_by_series"].(map[string]int64)) != 100 {
+		t.Errorf("Expected both buckets and shed counts capped at 100 series, got %v tracked", stats["tracked_series"])
+	}
+	if stats["shed_total"] != int64(1000) {
+		t.Errorf("Expected 1000 points shed in total, got %v", stats["shed_total"])
+	}
+}
@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Shedding curves, mapping how far P95 has climbed from the start point
+// towards the SLO onto a shed probability.
+const (
+	CurveLinear    = "linear"    // Probability grows proportionally
+	CurveQuadratic = "quadratic" // Gentle at first, steep near the SLO
+	CurveStep      = "step"      // Full MaxProbability only once the SLO is reached
+)
+
+// ShedderConfig holds adaptive load shedding configuration.
+type ShedderConfig struct {
+	SLOMS          float64 `json:"slo_ms"`          // P95 latency objective
+	StartRatio     float64 `json:"start_ratio"`     // Fraction of the SLO at which shedding begins, in (0, 1)
+	MaxProbability float64 `json:"max_probability"` // Shed probability once P95 reaches the SLO
+	Curve          string  `json:"curve"`
+}
+
+// LoadShedder probabilistically rejects low-priority requests as the current
+// P95 latency approaches the SLO, protecting the latency budget before it is breached.
+type LoadShedder struct {
+	config  ShedderConfig
+	latency func() float64 // Current P95 latency in milliseconds
+
+	mu  sync.Mutex
+	rng *rand.Rand
+
+	shed    atomic.Int64
+	allowed atomic.Int64
+}
+
+// NewLoadShedder creates a load shedder reading the current P95 from latency.
+func NewLoadShedder(config ShedderConfig, latency func() float64) (*LoadShedder, error) {
+	if config.SLOMS <= 0 {
+		return nil, fmt.Errorf("shedder SLO must be positive")
+	}
+	if config.StartRatio <= 0 || config.StartRatio >= 1 {
+		return nil, fmt.Errorf("shedder start ratio must be in (0, 1)")
+	}
+	if config.MaxProbability < 0 || config.MaxProbability > 1 {
+		return nil, fmt.Errorf("shedder max probability must be in [0, 1]")
+	}
+	switch config.Curve {
+	case "":
+		config.Curve = CurveLinear
+	case CurveLinear, CurveQuadratic, CurveStep:
+	default:
+		return nil, fmt.Errorf("unknown shedding curve %q", config.Curve)
+	}
+
+	return &LoadShedder{
+		config:  config,
+		latency: latency,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// ShedProbability returns the probability of shedding a request at the given P95.
+func (s *LoadShedder) ShedProbability(p95MS float64) float64 {
+	start := s.config.SLOMS * s.config.StartRatio
+	if p95MS <= start {
+		return 0
+	}
+
+	progress := math.Min(1, (p95MS-start)/(s.config.SLOMS-start))
+	switch s.config.Curve {
+	case CurveQuadratic:
+		progress *= progress
+	case CurveStep:
+		if progress < 1 {
+			return 0
+		}
+	}
+	return progress * s.config.MaxProbability
+}
+
+// Allow reports whether a low-priority request should be admitted.
+func (s *LoadShedder) Allow() bool {
+	if s == nil || s.latency == nil {
+		return true // Allow if load shedding is not configured
+	}
+
+	probability := s.ShedProbability(s.latency())
+	if probability > 0 {
+		s.mu.Lock()
+		shed := s.rng.Float64() < probability
+		s.mu.Unlock()
+		if shed {
+			s.shed.Add(1)
+			return false
+		}
+	}
+	s.allowed.Add(1)
+	return true
+}
+
+// GetStats returns current load shedder statistics.
+func (s *LoadShedder) GetStats() map[string]interface{} {
+	p95 := s.latency()
+	return map[string]interface{}{
+		"p95_latency_ms":   p95,
+		"shed_probability": s.ShedProbability(p95),
+		"slo_ms":           s.config.SLOMS,
+		"curve":            s.config.Curve,
+		"shed_total":       s.shed.Load(),
+		"allowed_total":    s.allowed.Load(),
+	}
+}
@@ -0,0 +1,183 @@
+package redteam
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"internal/httpclient"
+)
+
+// maxCampaignBytes bounds a campaign fetched over HTTP.
+const maxCampaignBytes = 1 << 20
+
+// CampaignFault is one fault of a campaign. Durations use time.ParseDuration
+// syntax. StartAfter and StopAfter, measured from when the campaign is
+// applied, ramp the fault on and off; empty means at once and never.
+type CampaignFault struct {
+	Type        FaultType              `json:"type"`
+	Probability float64                `json:"probability"`
+	Duration    string                 `json:"duration"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+	StartAfter  string                 `json:"start_after,omitempty"`
+	StopAfter   string                 `json:"stop_after,omitempty"`
+}
+
+// Campaign is a named, scheduled set of faults for a chaos experiment.
+type Campaign struct {
+	Name   string          `json:"name"`
+	Faults []CampaignFault `json:"faults"`
+}
+
+// scheduledFault is a validated CampaignFault.
+type scheduledFault struct {
+	config     FaultConfig
+	startAfter time.Duration
+	stopAfter  time.Duration
+}
+
+// Valid reports whether faultType is one the Red Team can inject.
+func (faultType FaultType) Valid() bool {
+	switch faultType {
+	case FaultLatency, FaultNetworkDelay, FaultValidationFail, FaultProcessingFail, FaultMemoryPressure, FaultCPUStress:
+		return true
+	}
+	return false
+}
+
+// LoadCampaign reads a campaign from a JSON file, or from an http(s) URL
+// fetched with the shared outbound client, and applies it.
+func (rt *RedTeam) LoadCampaign(path string) error {
+	data, err := readCampaign(path)
+	if err != nil {
+		return fmt.Errorf("failed to read campaign: %w", err)
+	}
+
+	var campaign Campaign
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&campaign); err != nil {
+		return fmt.Errorf("failed to decode campaign %s: %w", path, err)
+	}
+	return rt.ApplyCampaign(campaign)
+}
+
+// readCampaign returns the contents of the campaign at path, a file or an
+// http(s) URL.
+func readCampaign(path string) ([]byte, error) {
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		return os.ReadFile(path)
+	}
+
+	resp, err := httpclient.Shared().Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", path, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxCampaignBytes))
+}
+
+// ApplyCampaign validates every fault of campaign and, only if all are valid,
+// schedules them, replacing the schedule of any previous campaign. Faults of
+// the previous campaign already configured stay as they are.
+func (rt *RedTeam) ApplyCampaign(campaign Campaign) error {
+	faults, err := validateCampaign(campaign)
+	if err != nil {
+		return err
+	}
+
+	rt.mu.Lock()
+	for _, timer := range rt.campaignTimers {
+		timer.Stop()
+	}
+	rt.campaignTimers = nil
+	rt.campaign = campaign.Name
+	rt.mu.Unlock()
+
+	for _, fault := range faults {
+		fault := fault
+		if fault.startAfter == 0 {
+			rt.ConfigureFault(fault.config) // Validated; cannot fail
+		} else {
+			rt.schedule(fault.startAfter, func() {
+				log.Printf("RedTeam: Campaign %s starting fault %s", campaign.Name, fault.config.Type)
+				rt.ConfigureFault(fault.config)
+			})
+		}
+		if fault.stopAfter > 0 {
+			rt.schedule(fault.stopAfter, func() {
+				log.Printf("RedTeam: Campaign %s stopping fault %s", campaign.Name, fault.config.Type)
+				rt.DisableFault(fault.config.Type)
+			})
+		}
+	}
+
+	log.Printf("RedTeam: Applied campaign %s with %d faults", campaign.Name, len(faults))
+	return nil
+}
+
+// schedule runs fn after d as part of the current campaign.
+func (rt *RedTeam) schedule(d time.Duration, fn func()) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.campaignTimers = append(rt.campaignTimers, time.AfterFunc(d, fn))
+}
+
+// validateCampaign checks campaign and converts its faults, describing the
+// first invalid one.
+func validateCampaign(campaign Campaign) ([]scheduledFault, error) {
+	if campaign.Name == "" {
+		return nil, fmt.Errorf("campaign name must be set")
+	}
+	if len(campaign.Faults) == 0 {
+		return nil, fmt.Errorf("campaign %s has no faults", campaign.Name)
+	}
+
+	faults := make([]scheduledFault, 0, len(campaign.Faults))
+	for i, f := range campaign.Faults {
+		if !f.Type.Valid() {
+			return nil, fmt.Errorf("campaign fault %d: unsupported fault type %q", i, f.Type)
+		}
+		if f.Probability < 0 || f.Probability > 1 {
+			return nil, fmt.Errorf("campaign fault %d (%s): probability %v must be between 0 and 1", i, f.Type, f.Probability)
+		}
+		duration, err := time.ParseDuration(f.Duration)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("campaign fault %d (%s): duration %q must be a positive duration", i, f.Type, f.Duration)
+		}
+
+		var startAfter, stopAfter time.Duration
+		if f.StartAfter != "" {
+			if startAfter, err = time.ParseDuration(f.StartAfter); err != nil || startAfter < 0 {
+				return nil, fmt.Errorf("campaign fault %d (%s): start_after %q must be a non-negative duration", i, f.Type, f.StartAfter)
+			}
+		}
+		if f.StopAfter != "" {
+			if stopAfter, err = time.ParseDuration(f.StopAfter); err != nil || stopAfter <= startAfter {
+				return nil, fmt.Errorf("campaign fault %d (%s): stop_after %q must be a duration after start_after", i, f.Type, f.StopAfter)
+			}
+		}
+
+		faults = append(faults, scheduledFault{
+			config: FaultConfig{
+				Type:        f.Type,
+				Probability: f.Probability,
+				Duration:    duration,
+				Parameters:  f.Parameters,
+			},
+			startAfter: startAfter,
+			stopAfter:  stopAfter,
+		})
+	}
+	return faults, nil
+}
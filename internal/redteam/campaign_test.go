@@ -0,0 +1,111 @@
+package redteam
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadCampaign_RampsFaultsOnAndOff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "campaign.json")
+	campaign := `{
+		"name": "ramp",
+		"faults": [
+			{"type": "latency", "probability": 0.5, "duration": "1m", "parameters": {"multiplier": 3}},
+			{"type": "processing_fail", "probability": 0.1, "duration": "30s", "start_after": "20ms", "stop_after": "60ms"}
+		]
+	}`
+	if err := os.WriteFile(path, []byte(campaign), 0o644); err != nil {
+		t.Fatalf("Failed to write campaign: %v", err)
+	}
+
+	rt := NewRedTeam()
+	if err := rt.LoadCampaign(path); err != nil {
+		t.Fatalf("Failed to load campaign: %v", err)
+	}
+	if summary, ok := rt.FaultSummary(FaultLatency); !ok || summary["enabled"] != true || summary["duration"] != "1m0s" {
+		t.Errorf("Expected latency applied at once, got %v", summary)
+	}
+	if _, ok := rt.FaultSummary(FaultProcessingFail); ok {
+		t.Error("Expected processing_fail not configured before start_after")
+	}
+	if stats := rt.GetFaultStats(); stats["campaign"] != "ramp" {
+		t.Errorf("Expected the campaign name reported, got %v", stats["campaign"])
+	}
+
+	waitFor(t, func() bool {
+		summary, ok := rt.FaultSummary(FaultProcessingFail)
+		return ok && summary["enabled"] == true
+	}, "processing_fail to start")
+	waitFor(t, func() bool {
+		summary, _ := rt.FaultSummary(FaultProcessingFail)
+		return summary["enabled"] == false
+	}, "processing_fail to stop")
+}
+
+func TestLoadCampaign_FetchesURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/campaign.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"name": "remote", "faults": [{"type": "latency", "probability": 0.5, "duration": "1m"}]}`))
+	}))
+	defer server.Close()
+
+	rt := NewRedTeam()
+	if err := rt.LoadCampaign(server.URL + "/campaign.json"); err != nil {
+		t.Fatalf("Failed to load campaign: %v", err)
+	}
+	if stats := rt.GetFaultStats(); stats["campaign"] != "remote" {
+		t.Errorf("Expected the fetched campaign applied, got %v", stats["campaign"])
+	}
+
+	if err := rt.LoadCampaign(server.URL + "/missing.json"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected a failed fetch to be reported, got %v", err)
+	}
+}
+
+func TestApplyCampaign_RejectsInvalidFaults(t *testing.T) {
+	valid := CampaignFault{Type: FaultLatency, Probability: 0.1, Duration: "1m"}
+	for name, tc := range map[string]struct {
+		fault CampaignFault
+		want  string
+	}{
+		"probability above 1":  {CampaignFault{Type: FaultLatency, Probability: 1.5, Duration: "1m"}, "between 0 and 1"},
+		"negative probability": {CampaignFault{Type: FaultLatency, Probability: -0.1, Duration: "1m"}, "between 0 and 1"},
+		"zero duration":        {CampaignFault{Type: FaultLatency, Probability: 0.1, Duration: "0s"}, "positive duration"},
+		"missing duration":     {CampaignFault{Type: FaultLatency, Probability: 0.1}, "positive duration"},
+		"unknown type":         {CampaignFault{Type: "gremlins", Probability: 0.1, Duration: "1m"}, "unsupported fault type"},
+		"stop before start":    {CampaignFault{Type: FaultLatency, Probability: 0.1, Duration: "1m", StartAfter: "5m", StopAfter: "1m"}, "stop_after"},
+	} {
+		rt := NewRedTeam()
+		err := rt.ApplyCampaign(Campaign{Name: "bad", Faults: []CampaignFault{valid, tc.fault}})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected an error mentioning %q, got %v", name, tc.want, err)
+		}
+		if _, ok := rt.FaultSummary(FaultLatency); ok {
+			t.Errorf("%s: expected nothing applied from an invalid campaign", name)
+		}
+	}
+
+	if err := NewRedTeam().ApplyCampaign(Campaign{Faults: []CampaignFault{valid}}); err == nil {
+		t.Error("Expected a campaign without a name to be rejected")
+	}
+}
+
+// waitFor polls cond until it holds or a second passes.
+func waitFor(t *testing.T, cond func() bool, what string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -1,9 +1,11 @@
 package redteam
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
+	"runtime"
 	"sync"
 	"time"
 )
@@ -20,6 +22,24 @@ const (
 	FaultProcessingFail FaultType = "processing_fail"
 )
 
+// DefaultMaxFaultDuration is the longest a fault may stay active unless
+// changed with SetMaxFaultDuration.
+const DefaultMaxFaultDuration = 10 * time.Minute
+
+// DefaultMemoryPressureMB is the memory held by FaultMemoryPressure when its
+// "megabytes" parameter is not set.
+const DefaultMemoryPressureMB = 64
+
+// DefaultNetworkDelayMS is the delay injected by FaultNetworkDelay when its
+// "delay_ms" parameter is not set.
+const DefaultNetworkDelayMS = 200
+
+// pageSize is the stride used to touch held memory so it is actually resident.
+const pageSize = 4096
+
+// ErrFaultNotConfigured is returned when changing a fault that has no configuration.
+var ErrFaultNotConfigured = errors.New("fault is not configured")
+
 // FaultConfig represents configuration for a specific fault.
 type FaultConfig struct {
 	Type         FaultType     `json:"type"`
@@ -35,27 +55,67 @@ type RedTeam struct {
 	faultConfigs map[FaultType]*FaultConfig
 	activeFaults map[FaultType]time.Time
 	rand         *rand.Rand
+	maxDuration  time.Duration // Upper bound on FaultConfig.Duration
+
+	heldMemory []byte        // Ballast held by FaultMemoryPressure; nil when not applied
+	cpuStop    chan struct{} // Closed to stop the FaultCPUStress goroutines; nil when not applied
+	cpuWorkers int           // Goroutines busy-looping for FaultCPUStress
+
+	campaign       string        // Name of the last applied campaign
+	campaignTimers []*time.Timer // Pending start/stop steps of the campaign
 }
 
-// NewRedTeam creates a new RedTeam instance.
+// NewRedTeam creates a new RedTeam instance seeded from the current time.
 func NewRedTeam() *RedTeam {
+	return NewRedTeamWithSeed(time.Now().UnixNano())
+}
+
+// NewRedTeamWithSeed creates a RedTeam whose fault rolls are fully determined
+// by seed, so a chaos scenario can be reproduced exactly.
+func NewRedTeamWithSeed(seed int64) *RedTeam {
 	return &RedTeam{
 		faultConfigs: make(map[FaultType]*FaultConfig),
 		activeFaults: make(map[FaultType]time.Time),
-		rand:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand:         rand.New(rand.NewSource(seed)),
+		maxDuration:  DefaultMaxFaultDuration,
+	}
+}
+
+// SetMaxFaultDuration sets the longest duration ConfigureFault accepts.
+// Non-positive values restore DefaultMaxFaultDuration.
+func (rt *RedTeam) SetMaxFaultDuration(d time.Duration) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if d <= 0 {
+		d = DefaultMaxFaultDuration
 	}
+	rt.maxDuration = d
 }
 
-// ConfigureFault configures a fault injection pattern.
-func (rt *RedTeam) ConfigureFault(config FaultConfig) {
+// ConfigureFault configures a fault injection pattern. A duration above the
+// maximum is clamped to it with a warning, so a mistaken value cannot leave a
+// fault stuck on; a zero or negative duration is rejected.
+func (rt *RedTeam) ConfigureFault(config FaultConfig) error {
+	if config.Duration <= 0 {
+		return fmt.Errorf("fault %s duration must be positive, got %v", config.Type, config.Duration)
+	}
+
 	rt.mu.Lock()
 	defer rt.mu.Unlock()
 
+	if config.Duration > rt.maxDuration {
+		log.Printf("RedTeam: WARNING: Fault %s duration %v exceeds the maximum %v, clamping",
+			config.Type, config.Duration, rt.maxDuration)
+		config.Duration = rt.maxDuration
+	}
+
 	config.Enabled = true
 	rt.faultConfigs[config.Type] = &config
 
 	log.Printf("RedTeam: Configured fault %s with probability %.2f%% for duration %v",
 		config.Type, config.Probability*100, config.Duration)
+	return nil
 }
 
 // EnableFault enables a specific fault type.
@@ -77,10 +137,43 @@ func (rt *RedTeam) DisableFault(faultType FaultType) {
 	if config, exists := rt.faultConfigs[faultType]; exists {
 		config.Enabled = false
 		delete(rt.activeFaults, faultType)
+		rt.releasePressure(faultType)
 		log.Printf("RedTeam: Disabled fault %s", faultType)
 	}
 }
 
+// ToggleFault flips whether a configured fault is enabled and returns the new
+// state. Unlike EnableFault and DisableFault, an unconfigured fault is an error.
+func (rt *RedTeam) ToggleFault(faultType FaultType) (enabled bool, err error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	config, exists := rt.faultConfigs[faultType]
+	if !exists {
+		return false, fmt.Errorf("%w: %s", ErrFaultNotConfigured, faultType)
+	}
+	config.Enabled = !config.Enabled
+	if !config.Enabled {
+		delete(rt.activeFaults, faultType)
+		rt.releasePressure(faultType)
+	}
+	log.Printf("RedTeam: Toggled fault %s, enabled=%t", faultType, config.Enabled)
+	return config.Enabled, nil
+}
+
+// FaultSummary returns the configuration summary of a fault, in the form
+// GetFaultStats reports under fault_configs, and whether it is configured.
+func (rt *RedTeam) FaultSummary(faultType FaultType) (map[string]interface{}, bool) {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	config, exists := rt.faultConfigs[faultType]
+	if !exists {
+		return nil, false
+	}
+	return summarizeFault(config), true
+}
+
 // ShouldInjectFault determines if a fault should be injected based on probability.
 func (rt *RedTeam) ShouldInjectFault(faultType FaultType) bool {
 	rt.mu.RLock()
@@ -101,12 +194,16 @@ func (rt *RedTeam) ShouldInjectFault(faultType FaultType) bool {
 	}
 	rt.mu.RUnlock()
 
-	// Roll dice for fault injection
-	if rt.rand.Float64() < config.Probability {
-		rt.mu.Lock()
+	// Roll dice for fault injection. rand.Rand is not safe for concurrent use,
+	// so the roll is made under the lock.
+	rt.mu.Lock()
+	inject := rt.rand.Float64() < config.Probability
+	if inject {
 		rt.activeFaults[faultType] = time.Now()
-		rt.mu.Unlock()
+	}
+	rt.mu.Unlock()
 
+	if inject {
 		log.Printf("RedTeam: Injecting fault %s for duration %v", faultType, config.Duration)
 		return true
 	}
@@ -131,6 +228,23 @@ func (rt *RedTeam) InjectLatency(baseLatency time.Duration) time.Duration {
 	return baseLatency
 }
 
+// InjectNetworkDelay sleeps for the fault's "delay_ms" parameter (default
+// DefaultNetworkDelayMS) while FaultNetworkDelay is active, simulating a slow
+// upstream. Unlike InjectLatency the delay does not depend on processing
+// time. It returns the delay injected, zero when the fault is not applied.
+func (rt *RedTeam) InjectNetworkDelay() time.Duration {
+	if !rt.ShouldInjectFault(FaultNetworkDelay) {
+		return 0
+	}
+
+	rt.mu.RLock()
+	delay := time.Duration(intParameter(rt.faultConfigs[FaultNetworkDelay], "delay_ms", DefaultNetworkDelayMS)) * time.Millisecond
+	rt.mu.RUnlock()
+
+	time.Sleep(delay)
+	return delay
+}
+
 // InjectValidationFault simulates validation failures.
 func (rt *RedTeam) InjectValidationFault() error {
 	if rt.ShouldInjectFault(FaultValidationFail) {
@@ -147,6 +261,103 @@ func (rt *RedTeam) InjectProcessingFault() error {
 	return nil
 }
 
+// InjectMemoryPressure allocates and holds the fault's "megabytes" parameter
+// (default DefaultMemoryPressureMB) while FaultMemoryPressure is active. The
+// memory is released by the cleanup routine once the fault expires. It
+// returns the bytes currently held.
+func (rt *RedTeam) InjectMemoryPressure() int {
+	if !rt.ShouldInjectFault(FaultMemoryPressure) {
+		return 0
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.heldMemory == nil {
+		size := intParameter(rt.faultConfigs[FaultMemoryPressure], "megabytes", DefaultMemoryPressureMB) << 20
+		held := make([]byte, size)
+		for i := 0; i < len(held); i += pageSize {
+			held[i] = 1 // Touch every page so the allocation is resident
+		}
+		rt.heldMemory = held
+		log.Printf("RedTeam: Holding %d bytes of memory pressure", size)
+	}
+	return len(rt.heldMemory)
+}
+
+// InjectCPUStress starts the fault's "goroutines" parameter (default
+// runtime.NumCPU()) busy-looping while FaultCPUStress is active. They stop at
+// the fault's duration or when the cleanup routine releases the fault. It
+// returns the goroutines currently spinning.
+func (rt *RedTeam) InjectCPUStress() int {
+	if !rt.ShouldInjectFault(FaultCPUStress) {
+		return 0
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.cpuStop == nil {
+		config := rt.faultConfigs[FaultCPUStress]
+		workers := intParameter(config, "goroutines", runtime.NumCPU())
+		deadline := time.Now().Add(config.Duration)
+		stop := make(chan struct{})
+		for i := 0; i < workers; i++ {
+			go spin(stop, deadline)
+		}
+		rt.cpuStop = stop
+		rt.cpuWorkers = workers
+		log.Printf("RedTeam: Started %d CPU stress goroutines", workers)
+	}
+	return rt.cpuWorkers
+}
+
+// spin busy-loops until stop is closed or deadline passes.
+func spin(stop <-chan struct{}, deadline time.Time) {
+	for time.Now().Before(deadline) {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+}
+
+// releasePressure frees the resources held by a memory or CPU fault. Must be
+// called with rt.mu held.
+func (rt *RedTeam) releasePressure(faultType FaultType) {
+	switch faultType {
+	case FaultMemoryPressure:
+		if rt.heldMemory != nil {
+			log.Printf("RedTeam: Released %d bytes of memory pressure", len(rt.heldMemory))
+			rt.heldMemory = nil
+		}
+	case FaultCPUStress:
+		if rt.cpuStop != nil {
+			close(rt.cpuStop)
+			log.Printf("RedTeam: Stopped %d CPU stress goroutines", rt.cpuWorkers)
+			rt.cpuStop = nil
+			rt.cpuWorkers = 0
+		}
+	}
+}
+
+// intParameter returns a positive integer fault parameter, accepting JSON
+// numbers, or def when it is missing or invalid.
+func intParameter(config *FaultConfig, name string, def int) int {
+	var n int
+	switch v := config.Parameters[name].(type) {
+	case float64:
+		n = int(v)
+	case int:
+		n = v
+	}
+	if n <= 0 {
+		return def
+	}
+	return n
+}
+
 // GetActiveFaults returns currently active faults.
 func (rt *RedTeam) GetActiveFaults() map[FaultType]time.Time {
 	rt.mu.RLock()
@@ -174,6 +385,10 @@ func (rt *RedTeam) GetFaultStats() map[string]interface{} {
 		"configured_faults": len(rt.faultConfigs),
 		"active_faults":    len(rt.GetActiveFaults()),
 		"fault_configs":    rt.getFaultConfigsSummary(),
+
+		"memory_pressure_bytes": len(rt.heldMemory),
+		"cpu_stress_goroutines": rt.cpuWorkers,
+		"campaign":              rt.campaign,
 	}
 
 	return stats
@@ -184,16 +399,21 @@ func (rt *RedTeam) getFaultConfigsSummary() map[string]interface{} {
 	summary := make(map[string]interface{})
 
 	for faultType, config := range rt.faultConfigs {
-		summary[string(faultType)] = map[string]interface{}{
-			"enabled":     config.Enabled,
-			"probability": config.Probability,
-			"duration":    config.Duration.String(),
-		}
+		summary[string(faultType)] = summarizeFault(config)
 	}
 
 	return summary
 }
 
+// summarizeFault returns the reported summary of a fault configuration.
+func summarizeFault(config *FaultConfig) map[string]interface{} {
+	return map[string]interface{}{
+		"enabled":     config.Enabled,
+		"probability": config.Probability,
+		"duration":    config.Duration.String(),
+	}
+}
+
 // SetupDefaultFaults configures a set of default faults for comprehensive testing.
 func (rt *RedTeam) SetupDefaultFaults() {
 	defaultFaults := []FaultConfig{
@@ -218,7 +438,9 @@ func (rt *RedTeam) SetupDefaultFaults() {
 	}
 
 	for _, fault := range defaultFaults {
-		rt.ConfigureFault(fault)
+		if err := rt.ConfigureFault(fault); err != nil {
+			log.Printf("RedTeam: Failed to configure default fault: %v", err)
+		}
 	}
 
 	log.Printf("RedTeam: Configured %d default faults", len(defaultFaults))
@@ -238,6 +460,13 @@ func (rt *RedTeam) CleanupExpiredFaults() {
 			}
 		}
 	}
+
+	// Release held pressure whose fault is no longer active
+	for _, faultType := range []FaultType{FaultMemoryPressure, FaultCPUStress} {
+		if _, active := rt.activeFaults[faultType]; !active {
+			rt.releasePressure(faultType)
+		}
+	}
 }
 
 // StartFaultCleanupRoutine starts a goroutine that periodically cleans up expired faults.
@@ -0,0 +1,175 @@
+package redteam
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestConfigureFault_ClampsExcessiveDuration(t *testing.T) {
+	rt := NewRedTeam()
+	rt.SetMaxFaultDuration(5 * time.Minute)
+
+	if err := rt.ConfigureFault(FaultConfig{Type: FaultLatency, Probability: 0.1, Duration: 24 * time.Hour}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	summary, ok := rt.FaultSummary(FaultLatency)
+	if !ok || summary["duration"] != "5m0s" {
+		t.Errorf("Expected the duration clamped to 5m0s, got %v", summary)
+	}
+
+	if err := rt.ConfigureFault(FaultConfig{Type: FaultValidationFail, Probability: 0.1, Duration: time.Minute}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if summary, _ := rt.FaultSummary(FaultValidationFail); summary["duration"] != "1m0s" {
+		t.Errorf("Expected a duration within the maximum to be kept, got %v", summary)
+	}
+}
+
+func TestConfigureFault_RejectsNonPositiveDuration(t *testing.T) {
+	rt := NewRedTeam()
+	for _, d := range []time.Duration{0, -time.Second} {
+		if err := rt.ConfigureFault(FaultConfig{Type: FaultLatency, Probability: 0.1, Duration: d}); err == nil {
+			t.Errorf("Expected duration %v to be rejected", d)
+		}
+	}
+	if _, ok := rt.FaultSummary(FaultLatency); ok {
+		t.Error("Expected a rejected fault not to be configured")
+	}
+}
+
+func TestInjectMemoryPressure_HeldUntilExpiry(t *testing.T) {
+	rt := NewRedTeam()
+	if err := rt.ConfigureFault(FaultConfig{
+		Type:        FaultMemoryPressure,
+		Probability: 1,
+		Duration:    50 * time.Millisecond,
+		Parameters:  map[string]interface{}{"megabytes": 2.0},
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if held := rt.InjectMemoryPressure(); held != 2<<20 {
+		t.Fatalf("Expected 2MB held, got %d", held)
+	}
+	if held := rt.InjectMemoryPressure(); held != 2<<20 {
+		t.Errorf("Expected a repeated injection to keep the same allocation, got %d", held)
+	}
+	if stats := rt.GetFaultStats(); stats["memory_pressure_bytes"] != 2<<20 {
+		t.Errorf("Expected memory_pressure_bytes reported, got %v", stats["memory_pressure_bytes"])
+	}
+
+	// Cleanup leaves the pressure in place while the fault is active
+	rt.CleanupExpiredFaults()
+	if stats := rt.GetFaultStats(); stats["memory_pressure_bytes"] != 2<<20 {
+		t.Errorf("Expected memory held while active, got %v", stats["memory_pressure_bytes"])
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	rt.CleanupExpiredFaults()
+	if stats := rt.GetFaultStats(); stats["memory_pressure_bytes"] != 0 {
+		t.Errorf("Expected memory released on expiry, got %v", stats["memory_pressure_bytes"])
+	}
+}
+
+func TestInjectCPUStress_StoppedOnDisable(t *testing.T) {
+	rt := NewRedTeam()
+	if err := rt.ConfigureFault(FaultConfig{
+		Type:        FaultCPUStress,
+		Probability: 1,
+		Duration:    time.Minute,
+		Parameters:  map[string]interface{}{"goroutines": 2.0},
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if workers := rt.InjectCPUStress(); workers != 2 {
+		t.Fatalf("Expected 2 stress goroutines, got %d", workers)
+	}
+	if stats := rt.GetFaultStats(); stats["cpu_stress_goroutines"] != 2 {
+		t.Errorf("Expected cpu_stress_goroutines reported, got %v", stats["cpu_stress_goroutines"])
+	}
+
+	rt.DisableFault(FaultCPUStress)
+	if stats := rt.GetFaultStats(); stats["cpu_stress_goroutines"] != 0 {
+		t.Errorf("Expected stress stopped on disable, got %v", stats["cpu_stress_goroutines"])
+	}
+	if workers := rt.InjectCPUStress(); workers != 0 {
+		t.Errorf("Expected no stress while disabled, got %d", workers)
+	}
+}
+
+func TestResourceFaults_NotConfigured(t *testing.T) {
+	rt := NewRedTeam()
+	if rt.InjectMemoryPressure() != 0 || rt.InjectCPUStress() != 0 {
+		t.Error("Expected unconfigured resource faults not to be applied")
+	}
+}
+
+func TestInjectNetworkDelay_SleepsConfiguredDelay(t *testing.T) {
+	rt := NewRedTeam()
+	if delay := rt.InjectNetworkDelay(); delay != 0 {
+		t.Errorf("Expected no delay from an unconfigured fault, got %v", delay)
+	}
+
+	if err := rt.ConfigureFault(FaultConfig{
+		Type:        FaultNetworkDelay,
+		Probability: 1.0,
+		Duration:    time.Minute,
+		Parameters:  map[string]interface{}{"delay_ms": float64(20)},
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	start := time.Now()
+	delay := rt.InjectNetworkDelay()
+	if delay != 20*time.Millisecond {
+		t.Errorf("Expected a 20ms delay, got %v", delay)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Expected the delay to elapse, returned after %v", elapsed)
+	}
+
+	rt.DisableFault(FaultNetworkDelay)
+	if delay := rt.InjectNetworkDelay(); delay != 0 {
+		t.Errorf("Expected no delay once disabled, got %v", delay)
+	}
+}
+
+// faultSequence rolls faultType n times, clearing the active fault between
+// rolls so each call draws from the random source.
+func faultSequence(t *testing.T, rt *RedTeam, faultType FaultType, n int) []bool {
+	t.Helper()
+	if err := rt.ConfigureFault(FaultConfig{Type: faultType, Probability: 0.5, Duration: time.Minute}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sequence := make([]bool, n)
+	for i := range sequence {
+		rt.EnableFault(faultType)
+		sequence[i] = rt.ShouldInjectFault(faultType)
+		rt.DisableFault(faultType)
+	}
+	return sequence
+}
+
+func TestNewRedTeamWithSeed_Reproducible(t *testing.T) {
+	const seed = 42
+	first := faultSequence(t, NewRedTeamWithSeed(seed), FaultProcessingFail, 64)
+	second := faultSequence(t, NewRedTeamWithSeed(seed), FaultProcessingFail, 64)
+
+	// The sequence is exactly the seeded source's rolls
+	source := rand.New(rand.NewSource(seed))
+	for i := range first {
+		if want := source.Float64() < 0.5; first[i] != want || second[i] != want {
+			t.Fatalf("Roll %d: expected %t from the seed, got %t and %t", i, want, first[i], second[i])
+		}
+	}
+
+	other := faultSequence(t, NewRedTeamWithSeed(seed+1), FaultProcessingFail, 64)
+	same := true
+	for i := range first {
+		same = same && first[i] == other[i]
+	}
+	if same {
+		t.Error("Expected a different seed to give a different sequence")
+	}
+}
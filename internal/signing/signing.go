@@ -0,0 +1,121 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// HeaderSignature carries the hex-encoded HMAC-SHA256 of the response body.
+	HeaderSignature = "X-Signature"
+	// HeaderKeyID identifies which key produced the signature.
+	HeaderKeyID = "X-Signature-Key-Id"
+
+	signaturePrefix = "sha256="
+)
+
+// Signer produces and verifies HMAC-SHA256 signatures over response payloads
+// for non-repudiation of detection decisions.
+type Signer struct {
+	keyID string
+	keys  map[string][]byte // keyID -> secret, includes the active key
+}
+
+// Config holds signing configuration.
+type Config struct {
+	KeyID        string            `json:"key_id"`
+	Key          string            `json:"-"`
+	PreviousKeys map[string]string `json:"-"` // Retired keys still accepted for verification
+}
+
+// NewSigner creates a new Signer with the active key and any previous keys.
+func NewSigner(config Config) (*Signer, error) {
+	if config.Key == "" {
+		return nil, fmt.Errorf("signing key cannot be empty")
+	}
+	if config.KeyID == "" {
+		return nil, fmt.Errorf("signing key id cannot be empty")
+	}
+
+	keys := make(map[string][]byte, len(config.PreviousKeys)+1)
+	for id, key := range config.PreviousKeys {
+		if id == "" || key == "" {
+			return nil, fmt.Errorf("previous signing keys must have a non-empty id and key")
+		}
+		keys[id] = []byte(key)
+	}
+	keys[config.KeyID] = []byte(config.Key)
+
+	return &Signer{
+		keyID: config.KeyID,
+		keys:  keys,
+	}, nil
+}
+
+// KeyID returns the identifier of the active signing key.
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// Sign returns the signature of payload using the active key.
+func (s *Signer) Sign(payload []byte) string {
+	return signaturePrefix + hex.EncodeToString(computeMAC(s.keys[s.keyID], payload))
+}
+
+// Verify checks that signature was produced over payload by the key identified by keyID.
+// An empty keyID verifies against the active key.
+func (s *Signer) Verify(payload []byte, signature string, keyID string) bool {
+	if keyID == "" {
+		keyID = s.keyID
+	}
+
+	key, exists := s.keys[keyID]
+	if !exists {
+		return false
+	}
+
+	return VerifySignature(key, payload, signature)
+}
+
+// VerifySignature checks an X-Signature value against payload with the given secret.
+// Clients holding the shared secret can use this to prove what the service returned.
+func VerifySignature(key []byte, payload []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	provided, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(provided, computeMAC(key, payload))
+}
+
+// computeMAC computes the HMAC-SHA256 of payload.
+func computeMAC(key []byte, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// ParsePreviousKeys parses a comma-separated list of "id:key" pairs.
+func ParsePreviousKeys(raw string) (map[string]string, error) {
+	keys := make(map[string]string)
+	if strings.TrimSpace(raw) == "" {
+		return keys, nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid previous signing key entry %q, expected id:key", pair)
+		}
+		keys[parts[0]] = parts[1]
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,96 @@
+package signing
+
+import (
+	"testing"
+)
+
+func TestSigner_VerifiesUnmodifiedPayload(t *testing.T) {
+	signer, err := NewSigner(Config{KeyID: "k1", Key: "secret-1"})
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+
+	body := []byte(`{"is_anomaly":true,"z_score":3.24,"timestamp":1638360000,"value":42.5}`)
+	signature := signer.Sign(body)
+
+	if !signer.Verify(body, signature, "k1") {
+		t.Error("Expected signature to verify for unmodified body")
+	}
+
+	if !VerifySignature([]byte("secret-1"), body, signature) {
+		t.Error("Expected client-side verification to succeed with the shared key")
+	}
+}
+
+func TestSigner_RejectsTamperedPayload(t *testing.T) {
+	signer, err := NewSigner(Config{KeyID: "k1", Key: "secret-1"})
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+
+	body := []byte(`{"is_anomaly":true,"z_score":3.24,"price":0.001}`)
+	signature := signer.Sign(body)
+
+	tampered := []byte(`{"is_anomaly":false,"z_score":3.24,"price":0.001}`)
+	if signer.Verify(tampered, signature, "k1") {
+		t.Error("Expected signature verification to fail for tampered body")
+	}
+
+	if VerifySignature([]byte("wrong-key"), body, signature) {
+		t.Error("Expected verification to fail with the wrong key")
+	}
+
+	if signer.Verify(body, "not-a-signature", "k1") {
+		t.Error("Expected malformed signature to fail verification")
+	}
+}
+
+func TestSigner_KeyRotation(t *testing.T) {
+	oldSigner, err := NewSigner(Config{KeyID: "k1", Key: "secret-1"})
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+
+	body := []byte(`{"is_anomaly":false}`)
+	oldSignature := oldSigner.Sign(body)
+
+	rotated, err := NewSigner(Config{
+		KeyID:        "k2",
+		Key:          "secret-2",
+		PreviousKeys: map[string]string{"k1": "secret-1"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotated signer: %v", err)
+	}
+
+	if rotated.KeyID() != "k2" {
+		t.Errorf("Expected active key k2, got %s", rotated.KeyID())
+	}
+
+	if !rotated.Verify(body, oldSignature, "k1") {
+		t.Error("Expected signature from retired key to verify after rotation")
+	}
+
+	if rotated.Verify(body, oldSignature, "k2") {
+		t.Error("Expected retired signature to fail against the new key")
+	}
+
+	if rotated.Verify(body, oldSignature, "unknown") {
+		t.Error("Expected verification to fail for an unknown key id")
+	}
+}
+
+func TestParsePreviousKeys(t *testing.T) {
+	keys, err := ParsePreviousKeys("k1:secret-1, k2:secret:with:colons")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if keys["k1"] != "secret-1" || keys["k2"] != "secret:with:colons" {
+		t.Errorf("Unexpected parsed keys: %v", keys)
+	}
+
+	if _, err := ParsePreviousKeys("missing-separator"); err == nil {
+		t.Error("Expected error for malformed entry")
+	}
+}
@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RedactedValue replaces the values of redacted metadata keys.
+const RedactedValue = "[REDACTED]"
+
+// ValidateMetadata enforces size caps on client-supplied decision metadata.
+// maxKeys bounds the number of entries and maxLength the length of each key and value.
+func ValidateMetadata(metadata map[string]string, maxKeys int, maxLength int) error {
+	var errors []ValidationError
+
+	if len(metadata) > maxKeys {
+		errors = append(errors, ValidationError{
+			Field:   "metadata",
+			Value:   strconv.Itoa(len(metadata)),
+			Message: fmt.Sprintf("metadata exceeds maximum of %d keys", maxKeys),
+		})
+	}
+
+	for key, value := range metadata {
+		if key == "" || len(key) > maxLength {
+			errors = append(errors, ValidationError{
+				Field:   "metadata",
+				Value:   key,
+				Message: fmt.Sprintf("metadata key must be 1-%d bytes", maxLength),
+			})
+		}
+		if len(value) > maxLength {
+			errors = append(errors, ValidationError{
+				Field:   "metadata." + key,
+				Value:   strconv.Itoa(len(value)),
+				Message: fmt.Sprintf("metadata value exceeds maximum of %d bytes", maxLength),
+			})
+		}
+	}
+
+	if len(errors) > 0 {
+		return NewValidationErrors(errors)
+	}
+
+	return nil
+}
+
+// RedactMetadata returns a copy of metadata with the values of redactKeys
+// (matched case-insensitively) replaced by RedactedValue.
+func RedactMetadata(metadata map[string]string, redactKeys []string) map[string]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	redacted := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		redacted[key] = value
+		for _, secret := range redactKeys {
+			if strings.EqualFold(key, secret) {
+				redacted[key] = RedactedValue
+				break
+			}
+		}
+	}
+	return redacted
+}
+
+// ParseRedactKeys splits a comma-separated list of metadata keys to redact.
+func ParseRedactKeys(s string) []string {
+	var keys []string
+	for _, key := range strings.Split(s, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
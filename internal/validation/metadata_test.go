@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMetadata_Caps(t *testing.T) {
+	if err := ValidateMetadata(map[string]string{"device_id": "dev-42"}, 4, 16); err != nil {
+		t.Errorf("Expected metadata within caps to pass, got %v", err)
+	}
+	if err := ValidateMetadata(nil, 4, 16); err != nil {
+		t.Errorf("Expected empty metadata to pass, got %v", err)
+	}
+
+	tooMany := map[string]string{"a": "1", "b": "2", "c": "3"}
+	if err := ValidateMetadata(tooMany, 2, 16); err == nil {
+		t.Error("Expected error for too many keys")
+	}
+
+	if err := ValidateMetadata(map[string]string{"tag": strings.Repeat("x", 17)}, 4, 16); err == nil {
+		t.Error("Expected error for oversized value")
+	}
+	if err := ValidateMetadata(map[string]string{strings.Repeat("k", 17): "v"}, 4, 16); err == nil {
+		t.Error("Expected error for oversized key")
+	}
+}
+
+func TestRedactMetadata(t *testing.T) {
+	metadata := map[string]string{"device_id": "dev-42", "Api_Token": "s3cret"}
+	redacted := RedactMetadata(metadata, ParseRedactKeys(" api_token , password"))
+
+	if redacted["Api_Token"] != RedactedValue {
+		t.Errorf("Expected token redacted case-insensitively, got %q", redacted["Api_Token"])
+	}
+	if redacted["device_id"] != "dev-42" {
+		t.Errorf("Expected device_id untouched, got %q", redacted["device_id"])
+	}
+	if metadata["Api_Token"] != "s3cret" {
+		t.Error("Expected original metadata to be left unmodified")
+	}
+	if RedactMetadata(nil, []string{"token"}) != nil {
+		t.Error("Expected nil for empty metadata")
+	}
+}
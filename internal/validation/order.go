@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// TimestampOrder is how strictly a series' timestamps must advance.
+type TimestampOrder string
+
+// Supported timestamp orders.
+const (
+	// TimestampStrict requires each timestamp to be greater than the last
+	// accepted one, rejecting duplicates.
+	TimestampStrict TimestampOrder = "strict"
+	// TimestampLenient requires timestamps to be non-decreasing, accepting
+	// duplicates but rejecting older points.
+	TimestampLenient TimestampOrder = "lenient"
+)
+
+// ParseTimestampOrder parses a timestamp order name.
+func ParseTimestampOrder(s string) (TimestampOrder, error) {
+	switch order := TimestampOrder(s); order {
+	case TimestampStrict, TimestampLenient:
+		return order, nil
+	default:
+		return "", fmt.Errorf("unknown timestamp order %q (supported: strict, lenient)", s)
+	}
+}
+
+// TimestampGuard rejects points whose timestamp does not advance past the
+// last accepted timestamp of their series, catching duplicate delivery and
+// producer bugs on strictly time-ordered streams.
+type TimestampGuard struct {
+	mu       sync.Mutex
+	order    TimestampOrder
+	lastSeen map[string]int64 // Last accepted timestamp per series ID
+}
+
+// NewTimestampGuard creates a guard enforcing order.
+func NewTimestampGuard(order TimestampOrder) *TimestampGuard {
+	return &TimestampGuard{order: order, lastSeen: make(map[string]int64)}
+}
+
+// Validate rejects timestamp if it is out of order for seriesID, and
+// otherwise records it as the series' last accepted timestamp.
+func (g *TimestampGuard) Validate(seriesID string, timestamp int64) error {
+	if g == nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	last, seen := g.lastSeen[seriesID]
+	if err := g.check(seriesID, timestamp, last, seen); err != nil {
+		return err
+	}
+	g.lastSeen[seriesID] = timestamp
+	return nil
+}
+
+// Check rejects timestamp if it is out of order for seriesID without
+// recording it. Once the point has been processed, Commit records it, so a
+// point that is shed or fails can be retried.
+func (g *TimestampGuard) Check(seriesID string, timestamp int64) error {
+	if g == nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	last, seen := g.lastSeen[seriesID]
+	return g.check(seriesID, timestamp, last, seen)
+}
+
+// Commit records timestamp as the last accepted timestamp of seriesID,
+// unless a newer one was committed concurrently.
+func (g *TimestampGuard) Commit(seriesID string, timestamp int64) {
+	if g == nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if last, seen := g.lastSeen[seriesID]; !seen || timestamp > last {
+		g.lastSeen[seriesID] = timestamp
+	}
+}
+
+// TimestampBatch checks the points of a batch against the guard and against
+// the earlier points of the same batch, none of which are committed yet.
+type TimestampBatch struct {
+	guard   *TimestampGuard
+	pending map[string]int64 // Last timestamp checked per series ID in this batch
+}
+
+// Batch starts checking a batch of points against g.
+func (g *TimestampGuard) Batch() *TimestampBatch {
+	if g == nil {
+		return nil
+	}
+	return &TimestampBatch{guard: g, pending: make(map[string]int64)}
+}
+
+// Check is TimestampGuard.Check, also rejecting timestamp if it is out of
+// order after the batch's earlier points of seriesID.
+func (b *TimestampBatch) Check(seriesID string, timestamp int64) error {
+	if b == nil {
+		return nil
+	}
+
+	b.guard.mu.Lock()
+	defer b.guard.mu.Unlock()
+
+	last, seen := b.guard.lastSeen[seriesID]
+	if pending, checked := b.pending[seriesID]; checked {
+		last, seen = pending, true
+	}
+	if err := b.guard.check(seriesID, timestamp, last, seen); err != nil {
+		return err
+	}
+	b.pending[seriesID] = timestamp
+	return nil
+}
+
+// check rejects timestamp if it does not advance past last, the series' last
+// accepted timestamp when seen. Must be called with g.mu held.
+func (g *TimestampGuard) check(seriesID string, timestamp int64, last int64, seen bool) error {
+	if !seen || timestamp > last || (timestamp == last && g.order != TimestampStrict) {
+		return nil
+	}
+
+	field := "timestamp"
+	if seriesID != "" {
+		field = seriesID + ".timestamp"
+	}
+	message := fmt.Sprintf("timestamp must not be older than the last accepted timestamp %d", last)
+	if g.order == TimestampStrict {
+		message = fmt.Sprintf("timestamp must be newer than the last accepted timestamp %d", last)
+	}
+	return ValidationError{
+		Field:   field,
+		Value:   strconv.FormatInt(timestamp, 10),
+		Message: message,
+	}
+}
@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTimestampGuard_StrictAndLenient(t *testing.T) {
+	strict := NewTimestampGuard(TimestampStrict)
+	lenient := NewTimestampGuard(TimestampLenient)
+
+	for _, guard := range []*TimestampGuard{strict, lenient} {
+		if err := guard.Validate("cpu", 100); err != nil {
+			t.Fatalf("%s: unexpected error on the first point: %v", guard.order, err)
+		}
+	}
+
+	// A duplicate timestamp is rejected only in strict mode
+	err := strict.Validate("cpu", 100)
+	if err == nil || !strings.Contains(err.Error(), "cpu.timestamp' with value '100'") {
+		t.Errorf("Expected strict mode to reject a duplicate timestamp, got %v", err)
+	}
+	if err := lenient.Validate("cpu", 100); err != nil {
+		t.Errorf("Expected lenient mode to accept a duplicate timestamp, got %v", err)
+	}
+
+	// An older timestamp is rejected in both modes
+	for _, guard := range []*TimestampGuard{strict, lenient} {
+		if err := guard.Validate("cpu", 99); err == nil {
+			t.Errorf("%s: expected an out-of-order timestamp to be rejected", guard.order)
+		}
+		if err := guard.Validate("cpu", 101); err != nil {
+			t.Errorf("%s: expected a newer timestamp to be accepted, got %v", guard.order, err)
+		}
+		// Series are tracked independently
+		if err := guard.Validate("mem", 50); err != nil {
+			t.Errorf("%s: expected another series to be unaffected, got %v", guard.order, err)
+		}
+	}
+}
+
+func TestTimestampGuard_CheckDoesNotCommit(t *testing.T) {
+	guard := NewTimestampGuard(TimestampStrict)
+	guard.Commit("cpu", 100)
+
+	// A checked but uncommitted point, e.g. one that was shed, can be retried
+	if err := guard.Check("cpu", 101); err != nil {
+		t.Fatalf("Expected a newer timestamp to pass, got %v", err)
+	}
+	if err := guard.Check("cpu", 101); err != nil {
+		t.Errorf("Expected an uncommitted timestamp to pass again, got %v", err)
+	}
+
+	guard.Commit("cpu", 101)
+	if err := guard.Check("cpu", 101); err == nil {
+		t.Error("Expected a committed timestamp to be rejected as a duplicate")
+	}
+	// A late commit of an older point does not move the guard back
+	guard.Commit("cpu", 50)
+	if err := guard.Check("cpu", 60); err == nil {
+		t.Error("Expected the guard to keep the newest committed timestamp")
+	}
+
+	// A batch is checked against its own earlier points too
+	batch := guard.Batch()
+	if err := batch.Check("cpu", 102); err != nil {
+		t.Fatalf("Expected the first batch point to pass, got %v", err)
+	}
+	if err := batch.Check("cpu", 102); err == nil {
+		t.Error("Expected a duplicate within the batch to be rejected")
+	}
+	if err := guard.Check("cpu", 102); err != nil {
+		t.Errorf("Expected the batch to leave the guard uncommitted, got %v", err)
+	}
+
+	var disabled *TimestampGuard
+	if err := disabled.Batch().Check("cpu", 1); err != nil || disabled.Check("cpu", 1) != nil {
+		t.Error("Expected a nil guard to accept every point")
+	}
+}
+
+func TestParseTimestampOrder(t *testing.T) {
+	if order, err := ParseTimestampOrder("strict"); err != nil || order != TimestampStrict {
+		t.Errorf("Expected strict, got %q, %v", order, err)
+	}
+	if _, err := ParseTimestampOrder("sorted"); err == nil {
+		t.Error("Expected an unknown order to be rejected")
+	}
+
+	var guard *TimestampGuard
+	if err := guard.Validate("cpu", 1); err != nil {
+		t.Errorf("Expected a nil guard to accept every point, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Unit names a physical quantity whose values have hard bounds.
+type Unit string
+
+// Supported units.
+const (
+	UnitPercent    Unit = "percent"
+	UnitRatio      Unit = "ratio"
+	UnitKelvin     Unit = "kelvin"
+	UnitCelsius    Unit = "celsius"
+	UnitFahrenheit Unit = "fahrenheit"
+	UnitCount      Unit = "count"
+)
+
+// AnySeries is the registry key whose unit applies to every series without
+// its own entry, including unkeyed data points.
+const AnySeries = "*"
+
+// Constraint is the inclusive range of physically possible values for a unit.
+// An infinite bound is unconstrained.
+type Constraint struct {
+	Unit Unit
+	Min  float64
+	Max  float64
+}
+
+// unitConstraints lists the bounds of each supported unit.
+var unitConstraints = map[Unit]Constraint{
+	UnitPercent:    {Unit: UnitPercent, Min: 0, Max: 100},
+	UnitRatio:      {Unit: UnitRatio, Min: 0, Max: 1},
+	UnitKelvin:     {Unit: UnitKelvin, Min: 0, Max: math.Inf(1)},
+	UnitCelsius:    {Unit: UnitCelsius, Min: -273.15, Max: math.Inf(1)},
+	UnitFahrenheit: {Unit: UnitFahrenheit, Min: -459.67, Max: math.Inf(1)},
+	UnitCount:      {Unit: UnitCount, Min: 0, Max: math.Inf(1)},
+}
+
+// ConstraintFor returns the bounds of unit.
+func ConstraintFor(unit Unit) (Constraint, bool) {
+	c, ok := unitConstraints[unit]
+	return c, ok
+}
+
+// Check returns a ValidationError describing why value is impossible, or nil.
+func (c Constraint) Check(field string, value float64) error {
+	if !math.IsNaN(value) && value >= c.Min && value <= c.Max {
+		return nil
+	}
+
+	message := fmt.Sprintf("%s value must be between %s and %s", c.Unit, formatFloat(c.Min), formatFloat(c.Max))
+	if math.IsInf(c.Max, 1) {
+		message = fmt.Sprintf("%s value must be at least %s", c.Unit, formatFloat(c.Min))
+	}
+	return ValidationError{
+		Field:   field,
+		Value:   formatFloat(value),
+		Message: message,
+	}
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// UnitRegistry maps series IDs to the unit their values are measured in,
+// so physically impossible values are rejected before detection.
+type UnitRegistry struct {
+	series map[string]Constraint
+}
+
+// NewUnitRegistry builds a registry from series ID to unit name.
+// The AnySeries key sets the unit of series without their own entry.
+func NewUnitRegistry(units map[string]Unit) (*UnitRegistry, error) {
+	registry := &UnitRegistry{series: make(map[string]Constraint, len(units))}
+	for seriesID, unit := range units {
+		c, ok := ConstraintFor(unit)
+		if !ok {
+			return nil, fmt.Errorf("unknown unit %q for series %q (supported: %s)", unit, seriesID, supportedUnits())
+		}
+		registry.series[seriesID] = c
+	}
+	return registry, nil
+}
+
+// Validate rejects a value that is impossible for the unit of seriesID.
+// Series without a unit are not constrained.
+func (r *UnitRegistry) Validate(seriesID string, value float64) error {
+	if r == nil {
+		return nil
+	}
+	c, ok := r.series[seriesID]
+	if !ok {
+		if c, ok = r.series[AnySeries]; !ok {
+			return nil
+		}
+	}
+
+	field := "value"
+	if seriesID != "" {
+		field = seriesID + ".value"
+	}
+	return c.Check(field, value)
+}
+
+// ParseSeriesUnits parses a comma-separated list of series:unit pairs such as
+// "cpu:percent,reactor_temp:kelvin".
+func ParseSeriesUnits(s string) (map[string]Unit, error) {
+	units := make(map[string]Unit)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		seriesID, unit, ok := strings.Cut(pair, ":")
+		seriesID, unit = strings.TrimSpace(seriesID), strings.TrimSpace(unit)
+		if !ok || seriesID == "" || unit == "" {
+			return nil, fmt.Errorf("invalid series unit %q: expected series:unit", pair)
+		}
+		units[seriesID] = Unit(strings.ToLower(unit))
+	}
+	return units, nil
+}
+
+// supportedUnits lists the unit names in sorted order.
+func supportedUnits() string {
+	names := make([]string, 0, len(unitConstraints))
+	for unit := range unitConstraints {
+		names = append(names, string(unit))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
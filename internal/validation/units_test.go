@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnitRegistry_RejectsImpossibleValues(t *testing.T) {
+	units, err := ParseSeriesUnits("cpu:percent, reactor_temp:Kelvin")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	registry, err := NewUnitRegistry(units)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rejected := []struct {
+		series  string
+		value   float64
+		message string
+	}{
+		{"cpu", 100.5, "cpu.value' with value '100.5': percent value must be between 0 and 100"},
+		{"cpu", -1, "percent value must be between 0 and 100"},
+		{"reactor_temp", -3, "reactor_temp.value' with value '-3': kelvin value must be at least 0"},
+	}
+	for _, tc := range rejected {
+		err := registry.Validate(tc.series, tc.value)
+		if err == nil {
+			t.Errorf("Expected %s=%v to be rejected", tc.series, tc.value)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("Expected %q in %q", tc.message, err.Error())
+		}
+	}
+
+	for _, tc := range []struct {
+		series string
+		value  float64
+	}{{"cpu", 0}, {"cpu", 100}, {"reactor_temp", 0}, {"reactor_temp", 5800}, {"unconstrained", -1e6}, {"", -40}} {
+		if err := registry.Validate(tc.series, tc.value); err != nil {
+			t.Errorf("Expected %s=%v to pass, got %v", tc.series, tc.value, err)
+		}
+	}
+}
+
+func TestUnitRegistry_AnySeriesFallback(t *testing.T) {
+	registry, err := NewUnitRegistry(map[string]Unit{AnySeries: UnitCount, "delta": UnitCelsius})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := registry.Validate("", -1); err == nil {
+		t.Error("Expected unkeyed negative count to be rejected")
+	}
+	if err := registry.Validate("delta", -10); err != nil {
+		t.Errorf("Expected the series' own unit to take precedence, got %v", err)
+	}
+}
+
+func TestParseSeriesUnits_Invalid(t *testing.T) {
+	for _, invalid := range []string{"cpu", "cpu:", ":percent"} {
+		if _, err := ParseSeriesUnits(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+	if _, err := NewUnitRegistry(map[string]Unit{"cpu": "furlongs"}); err == nil || !strings.Contains(err.Error(), "percent") {
+		t.Errorf("Expected unknown unit error listing supported units, got %v", err)
+	}
+}
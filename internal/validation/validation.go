@@ -1,7 +1,6 @@
 package validation
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -231,7 +230,11 @@ func ValidateDataPointFromAnomaly(dp anomaly.DataPoint, sourceIP string) error {
 		Value:     dp.Value,
 		SourceIP:  sourceIP,
 	}
-	return ValidateDataPoint(req)
+	if err := validate.Struct(req); err != nil {
+		log.Printf("[α-IngressGuard] Validation Failure: %v", err)
+		return err
+	}
+	return nil
 }
 
 // DefaultConfig returns a default validation configuration.